@@ -0,0 +1,60 @@
+//go:build gentf
+
+// Command gentf connects to a PingOne environment and prints example HCL for its propagation
+// plans, stores and rules, with sensitive values redacted. The output is a starting point for
+// acceptance-test fixtures and documentation examples.
+//
+// The command is excluded from normal builds. Run it with the gentf build tag:
+//
+//	go run -tags gentf ./cmd/gentf -environment-id <environment_id> -out fixtures.tf
+//
+// Credentials are read from PINGONE_CLIENT_ID, PINGONE_CLIENT_SECRET, PINGONE_ENVIRONMENT_ID
+// and PINGONE_REGION, the same environment variables the provider reads.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/provider"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "gentf: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	environmentID := flag.String("environment-id", "", "The environment to read. Defaults to PINGONE_ENVIRONMENT_ID.")
+	out := flag.String("out", "", "The file to write. Defaults to standard output.")
+	flag.Parse()
+
+	opts := provider.FixtureOptions{
+		ClientID:            os.Getenv("PINGONE_CLIENT_ID"),
+		ClientSecret:        os.Getenv("PINGONE_CLIENT_SECRET"),
+		EnvironmentID:       os.Getenv("PINGONE_ENVIRONMENT_ID"),
+		Region:              os.Getenv("PINGONE_REGION"),
+		TargetEnvironmentID: *environmentID,
+	}
+	if opts.ClientID == "" || opts.ClientSecret == "" || opts.EnvironmentID == "" {
+		return errors.New("PINGONE_CLIENT_ID, PINGONE_CLIENT_SECRET and PINGONE_ENVIRONMENT_ID must be set")
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return provider.GenerateFixtures(context.Background(), w, opts)
+}
@@ -0,0 +1,42 @@
+// Command schemajson prints the schemas of the provider, its resources and its data sources as
+// JSON, in the format of `terraform providers schema -json`. Policy engines such as OPA or
+// Sentinel can generate rules from it, for example to find every sensitive attribute, without
+// running Terraform or configuring credentials.
+//
+//	go run ./cmd/schemajson -out schema.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/provider"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "schemajson: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	address := flag.String("address", "registry.opentofu.org/easytofu/pingoneprovisioning", "The provider address to key the schemas by.")
+	out := flag.String("out", "", "The file to write. Defaults to standard output.")
+	flag.Parse()
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return provider.WriteSchemaJSON(context.Background(), w, *address, "dev")
+}
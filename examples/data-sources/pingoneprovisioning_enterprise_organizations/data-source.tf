@@ -0,0 +1,21 @@
+data "pingoneprovisioning_enterprise_organizations" "all" {
+  enterprise = "example-enterprise"
+}
+
+variable "platform_organizations" {
+  type    = list(string)
+  default = ["example-org", "example-tools"]
+}
+
+resource "pingoneprovisioning_enterprise_team_organizations" "platform" {
+  enterprise    = "example-enterprise"
+  team_slug     = "platform"
+  organizations = var.platform_organizations
+
+  lifecycle {
+    precondition {
+      condition     = alltrue([for org in var.platform_organizations : contains(data.pingoneprovisioning_enterprise_organizations.all.slugs, org)])
+      error_message = "Every platform organization must belong to the enterprise."
+    }
+  }
+}
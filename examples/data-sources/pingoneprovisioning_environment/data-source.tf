@@ -0,0 +1,7 @@
+data "pingoneprovisioning_environment" "workforce" {
+  name = "Workforce"
+}
+
+output "workforce_environment_id" {
+  value = data.pingoneprovisioning_environment.workforce.id
+}
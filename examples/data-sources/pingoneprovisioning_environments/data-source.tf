@@ -0,0 +1,23 @@
+data "pingoneprovisioning_environments" "workforce" {
+  solution_type = "WORKFORCE"
+  type          = "PRODUCTION"
+}
+
+resource "pingoneprovisioning_propagation_store" "scim" {
+  for_each = toset(data.pingoneprovisioning_environments.workforce.ids)
+
+  environment_id = each.value
+  name           = "Example SCIM Store"
+  type           = "SCIM"
+
+  configuration_scim {
+    authentication_method  = "OAuth 2.0"
+    authorization_type     = "Bearer"
+    scim_url               = "https://example.com/scim/v2"
+    scim_version           = "2.0"
+    unique_user_identifier = "userName"
+    user_filter            = "active eq true"
+    users_resource         = "Users"
+    groups_resource        = "Groups"
+  }
+}
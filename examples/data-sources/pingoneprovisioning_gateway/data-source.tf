@@ -0,0 +1,9 @@
+data "pingoneprovisioning_gateway" "ldap" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  name           = "Corporate LDAP Gateway"
+  type           = "LDAP"
+}
+
+output "ldap_gateway_id" {
+  value = data.pingoneprovisioning_gateway.ldap.id
+}
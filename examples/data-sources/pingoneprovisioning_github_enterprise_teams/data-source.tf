@@ -0,0 +1,8 @@
+data "pingoneprovisioning_github_enterprise_teams" "platform" {
+  enterprise  = "example-enterprise"
+  name_prefix = "platform-"
+}
+
+output "platform_team_slugs" {
+  value = [for team in data.pingoneprovisioning_github_enterprise_teams.platform.teams : team.slug]
+}
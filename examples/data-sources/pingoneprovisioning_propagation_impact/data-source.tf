@@ -0,0 +1,29 @@
+check "provisioning_impact" {
+  data "pingoneprovisioning_propagation_impact" "this" {
+    environment_id = "00000000-0000-0000-0000-000000000000"
+
+    rules = [
+      {
+        key         = "pingoneprovisioning_propagation_rule.scim"
+        id          = pingoneprovisioning_propagation_rule.scim.id
+        active      = pingoneprovisioning_propagation_rule.scim.active
+        deprovision = pingoneprovisioning_propagation_rule.scim.deprovision
+      },
+    ]
+
+    stores = [
+      {
+        key = "pingoneprovisioning_propagation_store.scim"
+        id  = pingoneprovisioning_propagation_store.scim.id
+        secrets = {
+          oauth_access_token = var.scim_token
+        }
+      },
+    ]
+  }
+
+  assert {
+    condition     = length(data.pingoneprovisioning_propagation_impact.this.deprovision_enabled_rules) == 0
+    error_message = "This plan turns on deprovisioning for: ${join(", ", data.pingoneprovisioning_propagation_impact.this.deprovision_enabled_rules)}"
+  }
+}
@@ -0,0 +1,11 @@
+check "salesforce_mappings" {
+  data "pingoneprovisioning_propagation_mapping_coverage" "salesforce" {
+    environment_id = "00000000-0000-0000-0000-000000000000"
+    rule_id        = pingoneprovisioning_propagation_rule.salesforce.id
+  }
+
+  assert {
+    condition     = data.pingoneprovisioning_propagation_mapping_coverage.salesforce.complete
+    error_message = "The Salesforce rule does not map ${join(", ", data.pingoneprovisioning_propagation_mapping_coverage.salesforce.missing_attributes)}."
+  }
+}
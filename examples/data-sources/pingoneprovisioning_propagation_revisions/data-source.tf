@@ -0,0 +1,11 @@
+data "pingoneprovisioning_propagation_revisions" "recent" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  limit          = 5
+}
+
+output "recent_revisions" {
+  value = [
+    for r in data.pingoneprovisioning_propagation_revisions.recent.revisions :
+    "${r.created_at} ${r.id} ${coalesce(r.author, "unknown")}: ${coalesce(r.summary, "no summary")}"
+  ]
+}
@@ -0,0 +1,9 @@
+data "pingoneprovisioning_propagation_rule_mapping" "username" {
+  environment_id   = "00000000-0000-0000-0000-000000000000"
+  rule_id          = pingoneprovisioning_propagation_rule.scim.id
+  target_attribute = "userName"
+}
+
+output "username_source" {
+  value = data.pingoneprovisioning_propagation_rule_mapping.username.source_attribute
+}
@@ -0,0 +1,9 @@
+data "pingoneprovisioning_propagation_rule_preview" "scim" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  rule_id        = "11111111-1111-1111-1111-111111111111"
+  sample_size    = 5
+}
+
+output "matched_user_count" {
+  value = data.pingoneprovisioning_propagation_rule_preview.scim.preview.matched_user_count
+}
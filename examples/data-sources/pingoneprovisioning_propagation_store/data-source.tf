@@ -2,8 +2,18 @@ data "pingoneprovisioning_propagation_store" "example" {
   environment_id = "00000000-0000-0000-0000-000000000000"
   name           = "Example SCIM Store"
   type           = "SCIM"
+
+  # Sensitive values in the configuration blocks are returned as "CHANGE_ME".
+  secrets_placeholder = "CHANGE_ME"
 }
 
 output "propagation_store_id" {
   value = data.pingoneprovisioning_propagation_store.example.id
 }
+
+# Stores are often named differently in each environment; the URL they connect to is not.
+data "pingoneprovisioning_propagation_store" "by_endpoint" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  endpoint_url   = "https://scim.example.com/scim/v2"
+  type           = "SCIM"
+}
@@ -0,0 +1,10 @@
+data "pingoneprovisioning_propagation_store_ready" "scim" {
+  environment_id  = "00000000-0000-0000-0000-000000000000"
+  store_id        = "11111111-1111-1111-1111-111111111111"
+  status          = "ACTIVE"
+  timeout_seconds = 300
+}
+
+output "scim_store_status" {
+  value = data.pingoneprovisioning_propagation_store_ready.scim.current_status
+}
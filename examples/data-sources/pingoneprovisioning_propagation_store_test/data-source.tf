@@ -0,0 +1,13 @@
+data "pingoneprovisioning_propagation_store_test" "scim" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  store_id       = "11111111-1111-1111-1111-111111111111"
+  fail_on_error  = true
+
+  configuration = {
+    BEARER_TOKEN = var.scim_bearer_token
+  }
+}
+
+output "scim_connection_ok" {
+  value = data.pingoneprovisioning_propagation_store_test.scim.success
+}
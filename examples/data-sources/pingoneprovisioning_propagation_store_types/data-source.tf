@@ -0,0 +1,7 @@
+data "pingoneprovisioning_propagation_store_types" "available" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+}
+
+output "workday_available" {
+  value = contains(data.pingoneprovisioning_propagation_store_types.available.store_types, "Workday")
+}
@@ -0,0 +1,5 @@
+data "pingoneprovisioning_provider_config" "current" {}
+
+output "pingone_api_hostname" {
+  value = data.pingoneprovisioning_provider_config.current.api_hostname
+}
@@ -0,0 +1,15 @@
+data "pingoneprovisioning_role_preflight" "this" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+
+  resource_types = [
+    "pingoneprovisioning_propagation_rule",
+    "pingoneprovisioning_group_memberships",
+  ]
+  data_source_types = [
+    "pingoneprovisioning_user",
+  ]
+}
+
+output "missing_roles" {
+  value = data.pingoneprovisioning_role_preflight.this.missing_roles
+}
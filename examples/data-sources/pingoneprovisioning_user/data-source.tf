@@ -0,0 +1,17 @@
+data "pingoneprovisioning_user" "jdoe" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  username       = "jdoe"
+}
+
+resource "pingoneprovisioning_user_custom_attributes" "jdoe" {
+  environment_id = data.pingoneprovisioning_user.jdoe.environment_id
+  user_id        = data.pingoneprovisioning_user.jdoe.id
+
+  attributes = {
+    costCenter = "cc-100"
+  }
+}
+
+output "jdoe_custom_attributes" {
+  value = data.pingoneprovisioning_user.jdoe.custom_attributes
+}
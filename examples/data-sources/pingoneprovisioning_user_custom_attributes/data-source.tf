@@ -0,0 +1,12 @@
+data "pingoneprovisioning_user_custom_attributes" "jdoe" {
+  environment_id  = "00000000-0000-0000-0000-000000000000"
+  user_id         = "11111111-1111-1111-1111-111111111111"
+  attribute_names = ["costCenter"]
+}
+
+check "jdoe_cost_center" {
+  assert {
+    condition     = try(data.pingoneprovisioning_user_custom_attributes.jdoe.attributes.costCenter, null) == "cc-100"
+    error_message = "jdoe's costCenter has not propagated."
+  }
+}
@@ -0,0 +1,9 @@
+list "pingoneprovisioning_enterprise_team_organizations" "acme" {
+  provider         = pingoneprovisioning
+  include_resource = true
+
+  config {
+    enterprise  = "acme"
+    name_prefix = "platform-"
+  }
+}
@@ -0,0 +1 @@
+terraform import pingoneprovisioning_enterprise_team_external_group.platform example-enterprise/platform-engineering
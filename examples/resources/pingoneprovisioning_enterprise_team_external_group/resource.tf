@@ -0,0 +1,11 @@
+# The SCIM group a propagation rule provisions to the GitHub EMU store.
+data "pingoneprovisioning_github_scim_group" "platform" {
+  enterprise   = "example-enterprise"
+  display_name = "Platform Engineering"
+}
+
+resource "pingoneprovisioning_enterprise_team_external_group" "platform" {
+  enterprise = "example-enterprise"
+  team_slug  = "platform-engineering"
+  group_id   = data.pingoneprovisioning_github_scim_group.platform.id
+}
@@ -0,0 +1 @@
+terraform import pingoneprovisioning_enterprise_team_organizations.platform example-enterprise/platform-engineering
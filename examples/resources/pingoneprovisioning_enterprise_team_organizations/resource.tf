@@ -0,0 +1,8 @@
+resource "pingoneprovisioning_enterprise_team_organizations" "platform" {
+  enterprise    = "example-enterprise"
+  team_slug     = "platform-engineering"
+  organizations = ["example-org", "example-tools"]
+
+  # Another team also assigns organizations to this enterprise team; only ensure ours are present.
+  authoritative = false
+}
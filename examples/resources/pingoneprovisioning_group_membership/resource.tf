@@ -0,0 +1,5 @@
+resource "pingoneprovisioning_group_membership" "example" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  user_id        = "11111111-1111-1111-1111-111111111111"
+  group_id       = "22222222-2222-2222-2222-222222222222"
+}
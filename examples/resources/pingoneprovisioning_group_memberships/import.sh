@@ -0,0 +1 @@
+terraform import pingoneprovisioning_group_memberships.example 00000000-0000-0000-0000-000000000000/22222222-2222-2222-2222-222222222222
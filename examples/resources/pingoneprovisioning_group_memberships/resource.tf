@@ -0,0 +1,9 @@
+resource "pingoneprovisioning_group_memberships" "example" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  group_id       = "22222222-2222-2222-2222-222222222222"
+
+  user_ids = [
+    "11111111-1111-1111-1111-111111111111",
+    "33333333-3333-3333-3333-333333333333",
+  ]
+}
@@ -0,0 +1 @@
+terraform import pingoneprovisioning_propagation_default_plan.example 00000000-0000-0000-0000-000000000000
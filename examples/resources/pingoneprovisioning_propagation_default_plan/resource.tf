@@ -0,0 +1,4 @@
+resource "pingoneprovisioning_propagation_default_plan" "example" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  name           = "Default Plan"
+}
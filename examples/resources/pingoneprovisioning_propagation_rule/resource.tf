@@ -2,6 +2,7 @@ resource "pingoneprovisioning_propagation_rule" "example" {
   environment_id  = "00000000-0000-0000-0000-000000000000"
   plan_id         = "22222222-2222-2222-2222-222222222222"
   name            = "Users to SCIM"
+  description     = "Provisions active users to the SCIM directory."
   source_store_id = "33333333-3333-3333-3333-333333333333"
   target_store_id = "44444444-4444-4444-4444-444444444444"
 
@@ -0,0 +1,25 @@
+resource "pingoneprovisioning_propagation_rule_set" "saas" {
+  environment_id  = "00000000-0000-0000-0000-000000000000"
+  plan_id         = "11111111-1111-1111-1111-111111111111"
+  name            = "Users to SaaS"
+  source_store_id = "22222222-2222-2222-2222-222222222222"
+
+  target_store_ids = [
+    "33333333-3333-3333-3333-333333333333",
+    "44444444-4444-4444-4444-444444444444",
+  ]
+
+  active = true
+  filter = "active eq true"
+
+  mappings = [
+    {
+      source_attribute = "userName"
+      target_attribute = "userName"
+    },
+  ]
+}
+
+output "rule_status" {
+  value = { for target, rule in pingoneprovisioning_propagation_rule_set.saas.rules : target => rule.status }
+}
@@ -0,0 +1,22 @@
+provider "pingoneprovisioning" {
+  alias  = "dr"
+  region = "EU"
+}
+
+data "pingoneprovisioning_propagation_store" "primary" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  name           = "Corporate SCIM"
+  type           = "SCIM"
+}
+
+resource "pingoneprovisioning_propagation_store_mirror" "dr" {
+  provider = pingoneprovisioning.dr
+
+  environment_id         = "11111111-1111-1111-1111-111111111111"
+  portable_configuration = data.pingoneprovisioning_propagation_store.primary.portable_configuration
+
+  secrets = {
+    OAUTH_CLIENT_ID     = var.dr_scim_client_id
+    OAUTH_CLIENT_SECRET = var.dr_scim_client_secret
+  }
+}
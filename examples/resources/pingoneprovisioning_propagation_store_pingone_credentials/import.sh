@@ -0,0 +1 @@
+terraform import pingoneprovisioning_propagation_store_pingone_credentials.example 11111111-1111-1111-1111-111111111111/22222222-2222-2222-2222-222222222222
@@ -0,0 +1,18 @@
+resource "pingoneprovisioning_propagation_store_pingone_credentials" "target" {
+  environment_id = "11111111-1111-1111-1111-111111111111"
+}
+
+resource "pingoneprovisioning_propagation_store" "target" {
+  environment_id = "00000000-0000-0000-0000-000000000000"
+  name           = "Target PingOne environment"
+  type           = "PingOne"
+
+  configuration_ping_one {
+    authentication_method = pingoneprovisioning_propagation_store_pingone_credentials.target.authentication_method
+    base_url              = pingoneprovisioning_propagation_store_pingone_credentials.target.base_url
+    scim_url              = pingoneprovisioning_propagation_store_pingone_credentials.target.scim_url
+    oauth_client_id       = pingoneprovisioning_propagation_store_pingone_credentials.target.oauth_client_id
+    oauth_client_secret   = pingoneprovisioning_propagation_store_pingone_credentials.target.oauth_client_secret
+    oauth_token_url       = pingoneprovisioning_propagation_store_pingone_credentials.target.oauth_token_url
+  }
+}
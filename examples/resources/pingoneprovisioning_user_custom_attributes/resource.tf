@@ -5,4 +5,9 @@ resource "pingoneprovisioning_user_custom_attributes" "example" {
   attributes = {
     customRoles = ["example_role"]
   }
+
+  # Keep roles that other systems assign to the user.
+  merge_strategies = {
+    customRoles = "append"
+  }
 }
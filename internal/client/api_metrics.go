@@ -0,0 +1,230 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// API endpoint families that calls are counted under.
+const (
+	APIFamilyStores   = "stores"
+	APIFamilyRules    = "rules"
+	APIFamilyMappings = "mappings"
+	APIFamilyPlans    = "plans"
+	APIFamilyToken    = "token"
+	APIFamilyOther    = "other"
+	APIFamilyGitHub   = "github"
+)
+
+// apiFamilyOrder is the order families are reported in. Families not listed follow in name
+// order.
+var apiFamilyOrder = []string{APIFamilyStores, APIFamilyRules, APIFamilyMappings, APIFamilyPlans, APIFamilyOther, APIFamilyToken, APIFamilyGitHub}
+
+// APIFamilyMetrics are the counters of one endpoint family.
+type APIFamilyMetrics struct {
+	// Calls counts every request sent, including retries.
+	Calls int64
+	// Retries counts requests sent again after a retryable response.
+	Retries int64
+	// Throttled counts 429 Too Many Requests responses.
+	Throttled int64
+	// Wait is the time spent waiting before retries.
+	Wait time.Duration
+}
+
+// APIMetrics counts API calls, retries and throttling per endpoint family. It is safe for
+// concurrent use, and a nil *APIMetrics counts nothing.
+type APIMetrics struct {
+	mu       sync.Mutex
+	families map[string]*APIFamilyMetrics
+
+	// reportedSlowdowns is the number of retries and throttled responses when NewSlowdowns last
+	// returned true.
+	reportedSlowdowns int64
+}
+
+func NewAPIMetrics() *APIMetrics {
+	return &APIMetrics{families: make(map[string]*APIFamilyMetrics)}
+}
+
+// Transport returns a RoundTripper that counts each request sent through rt under the family
+// that classify returns for it. Retry loops should wrap the returned transport so that every
+// attempt is counted.
+func (m *APIMetrics) Transport(rt http.RoundTripper, classify func(*http.Request) string) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &metricsTransport{rt: rt, metrics: m, classify: classify}
+}
+
+// RecordRetry records that a request of family is retried after waiting wait.
+func (m *APIMetrics) RecordRetry(family string, wait time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f := m.family(family)
+	f.Retries++
+	f.Wait += wait
+}
+
+// Snapshot returns a copy of the counters, keyed by family.
+func (m *APIMetrics) Snapshot() map[string]APIFamilyMetrics {
+	out := make(map[string]APIFamilyMetrics)
+	if m == nil {
+		return out
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, f := range m.families {
+		out[name] = *f
+	}
+	return out
+}
+
+// NewSlowdowns reports whether requests were retried or throttled since it last returned true.
+func (m *APIMetrics) NewSlowdowns() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var total int64
+	for _, f := range m.families {
+		total += f.Retries + f.Throttled
+	}
+	if total <= m.reportedSlowdowns {
+		return false
+	}
+	m.reportedSlowdowns = total
+	return true
+}
+
+// Summary describes the counters in one line, for example
+// `rules: 40 calls, 3 retries, 2 throttled, 4.2s waiting; github: 12 calls`. It reports whether
+// any request was retried or throttled. An empty summary means no calls were made.
+func (m *APIMetrics) Summary() (string, bool) {
+	snapshot := m.Snapshot()
+
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	rank := func(name string) int {
+		for i, ordered := range apiFamilyOrder {
+			if ordered == name {
+				return i
+			}
+		}
+		return len(apiFamilyOrder)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if rank(names[i]) != rank(names[j]) {
+			return rank(names[i]) < rank(names[j])
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, 0, len(names))
+	slowed := false
+	for _, name := range names {
+		f := snapshot[name]
+		part := fmt.Sprintf("%s: %d calls", name, f.Calls)
+		if f.Retries > 0 {
+			part += fmt.Sprintf(", %d retries", f.Retries)
+		}
+		if f.Throttled > 0 {
+			part += fmt.Sprintf(", %d throttled", f.Throttled)
+		}
+		if f.Wait > 0 {
+			part += fmt.Sprintf(", %s waiting", f.Wait.Round(100*time.Millisecond))
+		}
+		if f.Retries > 0 || f.Throttled > 0 {
+			slowed = true
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; "), slowed
+}
+
+func (m *APIMetrics) record(family string, resp *http.Response) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f := m.family(family)
+	f.Calls++
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		f.Throttled++
+	}
+}
+
+// family returns the counters of name, creating them. m.mu must be held.
+func (m *APIMetrics) family(name string) *APIFamilyMetrics {
+	if m.families == nil {
+		m.families = make(map[string]*APIFamilyMetrics)
+	}
+	f, ok := m.families[name]
+	if !ok {
+		f = &APIFamilyMetrics{}
+		m.families[name] = f
+	}
+	return f
+}
+
+type metricsTransport struct {
+	rt       http.RoundTripper
+	metrics  *APIMetrics
+	classify func(*http.Request) string
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.rt.RoundTrip(req)
+	t.metrics.record(t.family(req), resp)
+	return resp, err
+}
+
+func (t *metricsTransport) family(req *http.Request) string {
+	if t.classify == nil {
+		return APIFamilyOther
+	}
+	return t.classify(req)
+}
+
+// PingOneAPIFamily classifies a PingOne request by its path.
+func PingOneAPIFamily(req *http.Request) string {
+	if req == nil || req.URL == nil {
+		return APIFamilyOther
+	}
+
+	p := strings.ToLower(req.URL.Path)
+	switch {
+	case strings.HasSuffix(p, "/as/token"):
+		return APIFamilyToken
+	case strings.Contains(p, "/mappings"):
+		return APIFamilyMappings
+	case strings.Contains(p, "/propagation/rules"):
+		return APIFamilyRules
+	case strings.Contains(p, "/propagation/stores"):
+		return APIFamilyStores
+	case strings.Contains(p, "/propagation/plans"):
+		return APIFamilyPlans
+	default:
+		return APIFamilyOther
+	}
+}
+
+// GitHubAPIFamily classifies every request as a GitHub request.
+func GitHubAPIFamily(*http.Request) string {
+	return APIFamilyGitHub
+}
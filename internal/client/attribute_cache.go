@@ -0,0 +1,43 @@
+package client
+
+import (
+	"strings"
+	"sync"
+)
+
+// AttributeCache caches attribute name lists per environment or store so plan-time validation
+// does not re-read PingOne for every resource instance.
+type AttributeCache struct {
+	mu      sync.Mutex
+	entries map[string][]string
+}
+
+// NewAttributeCache returns an empty AttributeCache.
+func NewAttributeCache() *AttributeCache {
+	return &AttributeCache{entries: make(map[string][]string)}
+}
+
+// Get returns the cached attribute names for the key, if present.
+func (c *AttributeCache) Get(key string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names, ok := c.entries[strings.ToLower(key)]
+	return names, ok
+}
+
+// Set stores the attribute names for the key.
+func (c *AttributeCache) Set(key string, names []string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[strings.ToLower(key)] = names
+}
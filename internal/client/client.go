@@ -1,9 +1,118 @@
 package client
 
-import "github.com/patrickcping/pingone-go-sdk-v2/management"
+import (
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
 
 // Client holds the PingOne SDK clients shared by provider resources and datasources.
 type Client struct {
 	API    *management.APIClient
 	GitHub *GitHubClient
+
+	// Region is the PingOne region the provider is configured for, as the SDK's long name (for
+	// example `NorthAmerica`).
+	Region string
+
+	// TokenURL is the OAuth token URL the management API client authenticates against.
+	TokenURL string
+
+	// ClientID is the client ID of the worker application, which is also its application ID.
+	ClientID string
+
+	// AuthEnvironmentID is the environment the worker application is defined in.
+	AuthEnvironmentID string
+
+	// PageSize is the `limit` sent on PingOne list requests. Zero uses the API default.
+	PageSize int32
+
+	// ReadOnly blocks Create, Update and Delete on every resource when enabled.
+	ReadOnly ReadOnlyMode
+
+	// UserSchemaAttributes caches PingOne user schema attribute names per environment.
+	UserSchemaAttributes *AttributeCache
+
+	// ValidateTargetAttributes checks rule mapping target attributes against the target
+	// store's attribute catalog at plan time.
+	ValidateTargetAttributes bool
+
+	// TargetStoreAttributes caches target store attribute catalogs per store.
+	TargetStoreAttributes *AttributeCache
+
+	// StoreTypes caches the API type of propagation stores per store for plan-time checks.
+	StoreTypes *StoreTypeCache
+
+	// SkipMappingRefresh makes propagation rule refreshes keep the mappings recorded in state
+	// instead of listing them. Creates and updates still read mappings.
+	SkipMappingRefresh bool
+
+	// UseSDKRuleCreate creates propagation rules with the SDK instead of the raw plan-scoped
+	// request.
+	UseSDKRuleCreate bool
+
+	// SkipPropagationRevisions stops resources from creating a propagation revision after they
+	// change propagation plans, stores or rules.
+	SkipPropagationRevisions bool
+
+	// RevisionFailureBehavior controls how a failure to create a propagation revision is
+	// reported.
+	RevisionFailureBehavior RevisionFailureBehavior
+
+	// RevisionRetryTimeout bounds the retries of a failed propagation revision when
+	// RevisionFailureBehavior is RevisionFailureRetry.
+	RevisionRetryTimeout time.Duration
+
+	// Secrets are the provider's named secrets, which store configuration blocks reference
+	// instead of setting a value.
+	Secrets map[string]string
+
+	// Policy holds the guardrails rule resources enforce at plan time.
+	Policy Policy
+
+	// Endpoints records the PingOne endpoints that requests through this client have found to
+	// work.
+	Endpoints *Endpoints
+
+	// Metrics counts the calls, retries and throttling of the requests sent through API and
+	// GitHub.
+	Metrics *APIMetrics
 }
+
+// Policy holds guardrails that platform teams set on the provider so that every propagation
+// rule planned through it follows them, whatever the module that declares the rule sets.
+type Policy struct {
+	// ForbidActiveWithoutFilter fails plans for active rules that select every user, because
+	// they have neither a filter nor population IDs.
+	ForbidActiveWithoutFilter bool
+
+	// ForbidDeprovision fails plans for rules that deprovision users.
+	ForbidDeprovision bool
+}
+
+// ReadOnlyMode controls how the provider treats write operations.
+type ReadOnlyMode int
+
+const (
+	// ReadOnlyDisabled allows writes.
+	ReadOnlyDisabled ReadOnlyMode = iota
+	// ReadOnlyError fails any plan that would create, update or delete a resource.
+	ReadOnlyError
+	// ReadOnlySimulate lets plans complete, reporting each write as a warning, and fails apply
+	// before any request is sent.
+	ReadOnlySimulate
+)
+
+// RevisionFailureBehavior controls how resources report a propagation revision they could not
+// create after changing a propagation plan, store or rule.
+type RevisionFailureBehavior int
+
+const (
+	// RevisionFailureWarn reports the failure as a warning; the apply succeeds.
+	RevisionFailureWarn RevisionFailureBehavior = iota
+	// RevisionFailureError reports the failure as an error, failing the apply.
+	RevisionFailureError
+	// RevisionFailureRetry retries the revision with backoff until RevisionRetryTimeout, then
+	// reports the failure as an error.
+	RevisionFailureRetry
+)
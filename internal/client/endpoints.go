@@ -0,0 +1,126 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// Endpoints records what requests through a provider instance learn about where PingOne serves
+// its API, so that later requests go there directly instead of probing again. A nil *Endpoints
+// records nothing.
+type Endpoints struct {
+	mu                  sync.Mutex
+	hostnameFallbacks   map[*management.APIClient]*management.APIClient
+	mappingDeleteRoutes map[*management.APIClient]MappingDeleteRoute
+	basePaths           map[basePathKey]string
+}
+
+type basePathKey struct {
+	client *management.APIClient
+	family string
+}
+
+// MappingDeleteRoute is the request path that deletes propagation mappings through an API client.
+type MappingDeleteRoute int
+
+const (
+	// MappingDeleteRouteUnknown means no mapping delete has succeeded through the client yet.
+	MappingDeleteRouteUnknown MappingDeleteRoute = iota
+	// MappingDeleteRouteSDK is the path the SDK sends DELETE to.
+	MappingDeleteRouteSDK
+	// MappingDeleteRouteManual is the hand-built `/propagation/mappings/{id}` path.
+	MappingDeleteRouteManual
+)
+
+// NewEndpoints returns an empty Endpoints.
+func NewEndpoints() *Endpoints {
+	return &Endpoints{
+		hostnameFallbacks:   make(map[*management.APIClient]*management.APIClient),
+		mappingDeleteRoutes: make(map[*management.APIClient]MappingDeleteRoute),
+		basePaths:           make(map[basePathKey]string),
+	}
+}
+
+// HostnameFallback returns the client for the fallback hostname that last served a request
+// configured could not, if any.
+func (e *Endpoints) HostnameFallback(configured *management.APIClient) (*management.APIClient, bool) {
+	if e == nil {
+		return nil, false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	altClient, ok := e.hostnameFallbacks[configured]
+	return altClient, ok
+}
+
+// SetHostnameFallback records altClient as the client to start requests for configured with. A
+// nil altClient clears the record.
+func (e *Endpoints) SetHostnameFallback(configured *management.APIClient, altClient *management.APIClient) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if altClient == nil {
+		delete(e.hostnameFallbacks, configured)
+		return
+	}
+	e.hostnameFallbacks[configured] = altClient
+}
+
+// MappingDeleteRoute returns the route that last deleted a propagation mapping through apiClient,
+// or MappingDeleteRouteUnknown.
+func (e *Endpoints) MappingDeleteRoute(apiClient *management.APIClient) MappingDeleteRoute {
+	if e == nil {
+		return MappingDeleteRouteUnknown
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.mappingDeleteRoutes[apiClient]
+}
+
+// SetMappingDeleteRoute records the route that deleted a propagation mapping through apiClient.
+func (e *Endpoints) SetMappingDeleteRoute(apiClient *management.APIClient, route MappingDeleteRoute) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mappingDeleteRoutes[apiClient] = route
+}
+
+// BasePath returns the base path resolved for the family of hand-built requests sent through
+// apiClient, if any. Clients are not reconfigured after they are built; a client for another
+// hostname is a new client and gets its own entries.
+func (e *Endpoints) BasePath(apiClient *management.APIClient, family string) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	basePath, ok := e.basePaths[basePathKey{client: apiClient, family: family}]
+	return basePath, ok
+}
+
+// SetBasePath records the base path resolved for family on apiClient.
+func (e *Endpoints) SetBasePath(apiClient *management.APIClient, family string, basePath string) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.basePaths[basePathKey{client: apiClient, family: family}] = basePath
+}
@@ -34,6 +34,9 @@ const (
 	defaultAPIVersion = "2022-11-28"
 	defaultAccept     = "application/vnd.github+json"
 	scimAccept        = "application/scim+json"
+
+	// GraphQLPath is the path of the GitHub GraphQL API, relative to the configured base URL.
+	GraphQLPath = "/graphql"
 )
 
 type GitHubClient struct {
@@ -42,6 +45,17 @@ type GitHubClient struct {
 	Token      string
 	APIVersion string
 	UserAgent  string
+
+	// Metrics records retries and the time spent waiting for them. Calls are counted by the
+	// HTTP client's transport.
+	Metrics *APIMetrics
+
+	// PageSize is the `per_page` value requested on list endpoints. Zero requests
+	// GitHubPageSize.
+	PageSize int
+
+	// ETags, when set, turns repeated GET requests into conditional requests.
+	ETags *GitHubETagCache
 }
 
 func NewGitHubClient(token string, baseURL string, apiVersion string, userAgent string, httpClient *http.Client) (*GitHubClient, error) {
@@ -113,6 +127,12 @@ func (c *GitHubClient) Do(ctx context.Context, method string, path string, query
 		contentType = scimAccept
 	}
 
+	// Only plain reads are sent as conditional requests.
+	etagKey := ""
+	if c.ETags != nil && method == http.MethodGet && payload == nil {
+		etagKey = acceptHeader + " " + endpoint
+	}
+
 	// Retry loop with exponential backoff
 	deadline := time.Now().Add(maxRetryTimeout)
 	attempt := 0
@@ -139,6 +159,11 @@ func (c *GitHubClient) Do(ctx context.Context, method string, path string, query
 		if payload != nil {
 			req.Header.Set("Content-Type", contentType)
 		}
+		if etagKey != "" {
+			if etag := c.ETags.etag(etagKey); etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+		}
 
 		if githubDebugEnabled() {
 			tflog.Debug(ctx, "github enterprise api request", map[string]interface{}{
@@ -173,6 +198,9 @@ func (c *GitHubClient) Do(ctx context.Context, method string, path string, query
 
 		// Check if we should retry
 		if !shouldRetryStatus(resp.StatusCode) {
+			if etagKey != "" {
+				resp = c.ETags.resolve(etagKey, resp)
+			}
 			return resp, nil
 		}
 
@@ -200,12 +228,11 @@ func (c *GitHubClient) Do(ctx context.Context, method string, path string, query
 
 		log.Printf("pingoneprovisioning: github received %d for %s %s, retrying in %s (attempt %d)",
 			resp.StatusCode, method, endpoint, sleepDuration.Round(time.Millisecond), attempt+1)
+		c.Metrics.RecordRetry(APIFamilyGitHub, sleepDuration)
 
 		// Sleep before retry
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(sleepDuration):
+		if err := utils.SleepContext(ctx, sleepDuration); err != nil {
+			return nil, err
 		}
 
 		// Increase backoff for next iteration
@@ -275,6 +302,10 @@ func (c *GitHubClient) buildURL(path string, query url.Values) (string, error) {
 
 	cleanPath := "/" + strings.TrimLeft(path, "/")
 	basePath := strings.TrimRight(base.Path, "/")
+	// GitHub Enterprise Server serves GraphQL at /api/graphql, beside the REST /api/v3 prefix.
+	if cleanPath == GraphQLPath && strings.HasSuffix(basePath, "/api/v3") {
+		basePath = strings.TrimSuffix(basePath, "/v3")
+	}
 	if basePath == "" {
 		base.Path = cleanPath
 	} else {
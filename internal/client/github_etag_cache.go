@@ -0,0 +1,79 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+)
+
+// githubETagCacheMaxEntries bounds the cache; responses are no longer cached once it is full.
+const githubETagCacheMaxEntries = 1000
+
+// GitHubETagCache remembers the ETag and body of GitHub GET responses so that repeated reads
+// are sent as conditional requests. GitHub answers an unchanged resource with 304 Not Modified,
+// which does not count against the rate limit, and the cached response is returned instead.
+type GitHubETagCache struct {
+	mu      sync.Mutex
+	entries map[string]githubETagEntry
+}
+
+type githubETagEntry struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+func NewGitHubETagCache() *GitHubETagCache {
+	return &GitHubETagCache{entries: make(map[string]githubETagEntry)}
+}
+
+// etag returns the ETag cached for key, or "" when there is none.
+func (c *GitHubETagCache) etag(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key].etag
+}
+
+// resolve returns the response to hand to the caller for a response to a request for key. A
+// 304 is replaced by the cached response; a 200 with an ETag is cached. The body of resp stays
+// readable.
+func (c *GitHubETagCache) resolve(key string, resp *http.Response) *http.Response {
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		c.mu.Unlock()
+		if !ok {
+			return resp
+		}
+		if resp.Body != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       resp.Request,
+		}
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := utils.ReadAndRestoreResponseBody(resp)
+		if err != nil {
+			return resp
+		}
+		c.mu.Lock()
+		if _, ok := c.entries[key]; ok || len(c.entries) < githubETagCacheMaxEntries {
+			c.entries[key] = githubETagEntry{etag: resp.Header.Get("ETag"), header: resp.Header.Clone(), body: body}
+		}
+		c.mu.Unlock()
+	}
+	return resp
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// githubMaxPages bounds Link-header following so a misbehaving API cannot loop forever.
+const githubMaxPages = 1000
+
+// GitHubPageSize is the default `per_page` value requested on GitHub list endpoints (the API
+// maximum).
+const GitHubPageSize = 100
+
+// ListPageSize returns the page size to request on list endpoints: PageSize when it is between
+// 1 and GitHubPageSize, otherwise GitHubPageSize.
+func (c *GitHubClient) ListPageSize() int {
+	if c == nil || c.PageSize < 1 || c.PageSize > GitHubPageSize {
+		return GitHubPageSize
+	}
+	return c.PageSize
+}
+
+// ListAll issues GET requests against a GitHub list endpoint and calls handle with each page's
+// response, following the `rel="next"` Link header until the last page. The response body is
+// readable inside handle; it is closed after handle returns.
+func (c *GitHubClient) ListAll(ctx context.Context, path string, query url.Values, handle func(*http.Response) error) error {
+	if c == nil {
+		return fmt.Errorf("nil github client")
+	}
+
+	pageQuery := url.Values{}
+	for k, v := range query {
+		pageQuery[k] = append([]string(nil), v...)
+	}
+	if pageQuery.Get("per_page") == "" {
+		pageQuery.Set("per_page", fmt.Sprintf("%d", c.ListPageSize()))
+	}
+
+	pagePath := path
+	seen := make(map[string]bool)
+
+	for page := 0; ; page++ {
+		if page >= githubMaxPages {
+			return fmt.Errorf("stopped after %d pages while listing %s", githubMaxPages, path)
+		}
+
+		key := pagePath + "?" + pageQuery.Encode()
+		if seen[key] {
+			return fmt.Errorf("pagination loop detected while listing %s", path)
+		}
+		seen[key] = true
+
+		resp, err := c.Do(ctx, http.MethodGet, pagePath, pageQuery, nil)
+		if err != nil {
+			return err
+		}
+
+		handleErr := handle(resp)
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+		if handleErr != nil {
+			return handleErr
+		}
+
+		next, ok := nextLinkURL(resp.Header.Get("Link"))
+		if !ok {
+			return nil
+		}
+
+		pagePath, pageQuery, err = c.relativePath(next)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// relativePath converts an absolute URL returned by the API into a path relative to BaseURL
+// and its query, suitable for Do.
+func (c *GitHubClient) relativePath(raw string) (string, url.Values, error) {
+	next, err := url.Parse(raw)
+	if err != nil {
+		return "", nil, err
+	}
+
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	nextPath := next.Path
+	if basePath := strings.TrimRight(base.Path, "/"); basePath != "" {
+		nextPath = strings.TrimPrefix(nextPath, basePath)
+	}
+
+	return nextPath, next.Query(), nil
+}
+
+// nextLinkURL returns the URL of the `rel="next"` entry in a Link header.
+func nextLinkURL(header string) (string, bool) {
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(strings.TrimSpace(part), ";")
+		if len(segments) < 2 {
+			continue
+		}
+
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		for _, param := range segments[1:] {
+			param = strings.TrimSpace(param)
+			if strings.EqualFold(param, `rel="next"`) || strings.EqualFold(param, "rel=next") {
+				return strings.Trim(target, "<>"), true
+			}
+		}
+	}
+
+	return "", false
+}
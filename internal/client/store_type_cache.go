@@ -0,0 +1,44 @@
+package client
+
+import (
+	"strings"
+	"sync"
+)
+
+// StoreTypeCache caches the API type of propagation stores, such as `scim`, per store so that
+// plan-time checks read each store once. A store's type cannot change, so entries are kept for
+// the life of the client.
+type StoreTypeCache struct {
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+// NewStoreTypeCache returns an empty StoreTypeCache.
+func NewStoreTypeCache() *StoreTypeCache {
+	return &StoreTypeCache{entries: make(map[string]string)}
+}
+
+// Get returns the cached store type for the key, if present.
+func (c *StoreTypeCache) Get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	storeType, ok := c.entries[strings.ToLower(key)]
+	return storeType, ok
+}
+
+// Set stores the store type for the key.
+func (c *StoreTypeCache) Set(key string, storeType string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[strings.ToLower(key)] = storeType
+}
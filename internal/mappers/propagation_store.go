@@ -602,6 +602,9 @@ func SalesforceContactsToMap(c *customtypes.ConfigurationSalesforceContacts) map
 	if !c.UpdateUsers.IsNull() {
 		m["UPDATE_USERS"] = c.UpdateUsers.ValueBool()
 	}
+	if !c.RecordType.IsNull() {
+		m["RECORD_TYPE"] = c.RecordType.ValueString()
+	}
 
 	return m
 }
@@ -628,6 +631,7 @@ func SalesforceContactsFromMap(c *customtypes.ConfigurationSalesforceContacts, c
 	c.GroupNameSource = utils.FromMapString(config, "GROUP_NAME_SOURCE")
 	c.RemoveAction = utils.FromMapString(config, "REMOVE_ACTION")
 	c.UpdateUsers = utils.FromMapBool(config, "UPDATE_USERS")
+	c.RecordType = utils.FromMapString(config, "RECORD_TYPE")
 }
 
 // ScimToMap maps SCIM configuration model to API configuration map.
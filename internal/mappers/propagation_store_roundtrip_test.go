@@ -0,0 +1,208 @@
+package mappers
+
+import (
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/schemas"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// connectorMapper describes the ToMap and FromMap functions of one connector type, so that the
+// round-trip fuzz test can fill in and compare any configuration struct through reflection.
+type connectorMapper struct {
+	storeType string
+	block     schema.Block
+	newConfig func() any
+	toMap     func(any) map[string]interface{}
+	fromMap   func(any, map[string]interface{})
+
+	// emptyIsNull lists attributes that PingOne reports as "" when they are not set, which
+	// FromMap reads back as null.
+	emptyIsNull []string
+	// excluded lists attributes that are not sent to PingOne, with the reason.
+	excluded map[string]string
+}
+
+func mapperFor[T any](storeType string, block schema.Block, toMap func(*T) map[string]interface{}, fromMap func(*T, map[string]interface{})) connectorMapper {
+	return connectorMapper{
+		storeType: storeType,
+		block:     block,
+		newConfig: func() any { return new(T) },
+		toMap:     func(c any) map[string]interface{} { return toMap(c.(*T)) },
+		fromMap:   func(c any, m map[string]interface{}) { fromMap(c.(*T), m) },
+		excluded:  map[string]string{},
+	}
+}
+
+// bearerTokenRefReason is why `bearer_token_ref` is never round-tripped: the provider resolves it
+// into `bearer_token` before the configuration is mapped.
+const bearerTokenRefReason = "resolved into bearer_token by the provider"
+
+func connectorMappers() []connectorMapper {
+	mappers := []connectorMapper{
+		mapperFor("Aquera", schemas.AqueraConfigSchema(false), AqueraToMap, AqueraFromMap),
+		mapperFor("AzureADSAMLV2", schemas.AzureAdSamlV2ConfigSchema(false), AzureAdSamlV2ToMap, AzureAdSamlV2FromMap),
+		mapperFor("GithubEMU", schemas.GithubEmuConfigSchema(false), GithubEMUToMap, GithubEMUFromMap),
+		mapperFor("GoogleApps", schemas.GoogleAppsConfigSchema(false), GoogleAppsToMap, GoogleAppsFromMap),
+		mapperFor("LDAPGateway", schemas.LdapGatewayConfigSchema(false), LdapGatewayToMap, LdapGatewayFromMap),
+		mapperFor("PingOne", schemas.PingOneConfigSchema(false), PingOneToMap, PingOneFromMap),
+		mapperFor("Salesforce", schemas.SalesforceConfigSchema(false), SalesforceToMap, SalesforceFromMap),
+		mapperFor("SalesforceContacts", schemas.SalesforceContactsConfigSchema(false), SalesforceContactsToMap, SalesforceContactsFromMap),
+		mapperFor("SCIM", schemas.ScimConfigSchema(false), ScimToMap, ScimFromMap),
+		mapperFor("ServiceNow", schemas.ServiceNowConfigSchema(false), ServiceNowToMap, ServiceNowFromMap),
+		mapperFor("Slack", schemas.SlackConfigSchema(false), SlackToMap, SlackFromMap),
+		mapperFor("Workday", schemas.WorkdayConfigSchema(false), WorkdayToMap, WorkdayFromMap),
+		mapperFor("Zoom", schemas.ZoomConfigSchema(false), ZoomToMap, ZoomFromMap),
+	}
+
+	for i := range mappers {
+		mappers[i].excluded["bearer_token_ref"] = bearerTokenRefReason
+		switch mappers[i].storeType {
+		case "PingOne":
+			mappers[i].emptyIsNull = []string{"authentication_method", "base_url", "scim_url"}
+		case "Salesforce":
+			mappers[i].excluded["record_type"] = "PingOne only has a record type for Salesforce Contacts stores"
+		}
+	}
+	return mappers
+}
+
+// configFields returns the configuration struct fields keyed by their tfsdk name.
+func configFields(config any) map[string]reflect.Value {
+	v := reflect.ValueOf(config).Elem()
+	fields := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fields[v.Type().Field(i).Tag.Get("tfsdk")] = v.Field(i)
+	}
+	return fields
+}
+
+// setNull sets every field of config to null.
+func setNull(config any) {
+	for _, field := range configFields(config) {
+		switch field.Interface().(type) {
+		case types.String:
+			field.Set(reflect.ValueOf(types.StringNull()))
+		case types.Bool:
+			field.Set(reflect.ValueOf(types.BoolNull()))
+		}
+	}
+}
+
+// alwaysSent returns the attributes that ToMap sends even when they are null. They are required
+// when the block is set, so the fuzz test never leaves them null.
+func (c connectorMapper) alwaysSent() map[string]bool {
+	config := c.newConfig()
+	setNull(config)
+	out := c.newConfig()
+	setNull(out)
+	c.fromMap(out, c.toMap(config))
+
+	sent := make(map[string]bool)
+	for name, field := range configFields(out) {
+		if !field.Interface().(attr.Value).IsNull() {
+			sent[name] = true
+		}
+	}
+	return sent
+}
+
+// fuzzConfig fills config with null, empty and fuzzed values chosen by rng. Fields are visited
+// in name order so that a seed always produces the same configuration.
+func fuzzConfig(config any, rng *rand.Rand, value string, required map[string]bool) {
+	fields := configFields(config)
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		field := fields[name]
+		switch field.Interface().(type) {
+		case types.String:
+			candidates := []types.String{types.StringValue(""), types.StringValue(value), types.StringValue(name + "-" + value)}
+			if !required[name] {
+				candidates = append(candidates, types.StringNull())
+			}
+			field.Set(reflect.ValueOf(candidates[rng.IntN(len(candidates))]))
+		case types.Bool:
+			candidates := []types.Bool{types.BoolValue(true), types.BoolValue(false)}
+			if !required[name] {
+				candidates = append(candidates, types.BoolNull())
+			}
+			field.Set(reflect.ValueOf(candidates[rng.IntN(len(candidates))]))
+		}
+	}
+}
+
+// FuzzPropagationStoreConfigurationRoundTrip checks that mapping a configuration to the API map
+// and back returns the same configuration, for every connector type. Secrets are sent but not
+// compared, since PingOne never returns them and the provider keeps them from state instead.
+func FuzzPropagationStoreConfigurationRoundTrip(f *testing.F) {
+	for seed := range uint64(32) {
+		f.Add(seed, "")
+		f.Add(seed, "https://api.example/scim/v2")
+	}
+	f.Add(uint64(7), "  spaced  ")
+	f.Add(uint64(11), "ünïcødé \"quoted\"")
+
+	mappers := connectorMappers()
+
+	f.Fuzz(func(t *testing.T, seed uint64, value string) {
+		rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+
+		for _, c := range mappers {
+			sensitive := map[string]bool{}
+			attributes := c.block.(schema.SingleNestedBlock).Attributes
+			for name, a := range attributes {
+				sensitive[name] = a.IsSensitive()
+			}
+
+			in := c.newConfig()
+			fuzzConfig(in, rng, value, c.alwaysSent())
+			out := c.newConfig()
+			c.fromMap(out, c.toMap(in))
+
+			got := configFields(out)
+			for name, want := range configFields(in) {
+				if _, ok := attributes[name]; !ok {
+					t.Fatalf("%s: field %q has no schema attribute", c.storeType, name)
+				}
+				if _, ok := c.excluded[name]; ok || sensitive[name] {
+					continue
+				}
+
+				wantValue := want.Interface().(attr.Value)
+				if s, ok := wantValue.(types.String); ok && s.ValueString() == "" && slices.Contains(c.emptyIsNull, name) {
+					wantValue = types.StringNull()
+				}
+				if gotValue := got[name].Interface().(attr.Value); !gotValue.Equal(wantValue) {
+					t.Errorf("%s: %s = %s after a round trip, want %s", c.storeType, name, gotValue, wantValue)
+				}
+			}
+		}
+	})
+}
+
+func TestConnectorMappers_CoverEveryConfigurationBlock(t *testing.T) {
+	t.Parallel()
+
+	covered := map[string]bool{}
+	for _, c := range connectorMappers() {
+		covered[reflect.TypeOf(c.newConfig()).Elem().Name()] = true
+	}
+
+	model := reflect.TypeOf(customtypes.PropagationStoreModel{})
+	for i := 0; i < model.NumField(); i++ {
+		field := model.Field(i)
+		if !strings.HasPrefix(field.Type.String(), "*types.Configuration") {
+			continue
+		}
+		if name := field.Type.Elem().Name(); !covered[name] {
+			t.Errorf("%s (%s) has no round-trip mapper", name, field.Tag.Get("tfsdk"))
+		}
+	}
+}
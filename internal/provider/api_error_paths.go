@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// propagationRuleAPIErrorPaths maps the API fields PingOne names in error `details[].target` to
+// the propagation rule attributes they come from. Every path also exists on the propagation rule
+// set, which creates its rules through the same functions. `populationExpression` is reported
+// on `filter`, the part of the expression that is written by hand.
+var propagationRuleAPIErrorPaths = map[string]path.Path{
+	"name":                  path.Root("name"),
+	"active":                path.Root("active"),
+	"populationExpression":  path.Root("filter"),
+	"groups":                path.Root("group_ids"),
+	"deprovision":           path.Root("deprovision"),
+	"configuration":         path.Root("configuration"),
+	"correlationAttributes": path.Root("correlation_attributes"),
+	"sourceStore":           path.Root("source_store_id"),
+	"plan":                  path.Root("plan_id"),
+}
+
+// apiErrorDetail is one entry of the `details` array in a PingOne error response.
+type apiErrorDetail struct {
+	Code    string
+	Target  string
+	Message string
+}
+
+// apiErrorDetails returns the `details` of a PingOne error response, or nil when the body has
+// none. The response body is left readable.
+func apiErrorDetails(httpResp *http.Response) []apiErrorDetail {
+	if httpResp == nil {
+		return nil
+	}
+
+	decoded, err := utils.DecodeResponseJSON(httpResp)
+	if err != nil {
+		return nil
+	}
+	root, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	rawDetails, ok := utils.NestedValue(root, "details")
+	if !ok {
+		return nil
+	}
+	list, ok := rawDetails.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	details := make([]apiErrorDetail, 0, len(list))
+	for _, raw := range list {
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var d apiErrorDetail
+		d.Code, _ = utils.NestedString(obj, "code")
+		d.Target, _ = utils.NestedString(obj, "target")
+		d.Message, _ = utils.NestedString(obj, "message")
+		details = append(details, d)
+	}
+	return details
+}
+
+// apiErrorPath returns the attribute path for an error detail target. Targets that name a
+// nested field, such as `sourceStore.id` or `groups[0].id`, match on their top-level field.
+func apiErrorPath(paths map[string]path.Path, target string) (path.Path, bool) {
+	target = strings.TrimSpace(target)
+	if p, ok := paths[target]; ok {
+		return p, true
+	}
+	if i := strings.IndexAny(target, ".["); i > 0 {
+		p, ok := paths[target[:i]]
+		return p, ok
+	}
+	return path.Empty(), false
+}
+
+// addAPIErrorDiagnostics reports a failed request. Error details whose target is in paths are
+// added as attribute errors; if none are, a single error is added with detail. Details that
+// cannot be placed on an attribute are listed in that error, so no message is lost.
+func addAPIErrorDiagnostics(diags *diag.Diagnostics, paths map[string]path.Path, summary string, detail string, httpResp *http.Response) {
+	var unmapped []string
+	mapped := 0
+
+	for _, d := range apiErrorDetails(httpResp) {
+		if d.Message == "" {
+			continue
+		}
+		p, ok := apiErrorPath(paths, d.Target)
+		if !ok {
+			unmapped = append(unmapped, d.Message)
+			continue
+		}
+		diags.AddAttributeError(p, summary, fmt.Sprintf("PingOne rejected the value: %s", d.Message))
+		mapped++
+	}
+
+	if mapped == 0 {
+		diags.AddError(summary, detail)
+		return
+	}
+	if len(unmapped) > 0 {
+		diags.AddError(summary, strings.Join(unmapped, "\n"))
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAddAPIErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	response := func(body string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	t.Run("maps_targets_to_attributes", func(t *testing.T) {
+		t.Parallel()
+
+		var diags diag.Diagnostics
+		addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths, "Error Updating Propagation Rule", "generic",
+			response(`{"code":"INVALID_DATA","details":[
+				{"code":"INVALID_VALUE","target":"populationExpression","message":"Invalid SCIM filter"},
+				{"code":"INVALID_VALUE","target":"groups[0].id","message":"Group not found"}
+			]}`))
+
+		if len(diags) != 2 {
+			t.Fatalf("diags = %v", diags)
+		}
+		wantPaths := []path.Path{path.Root("filter"), path.Root("group_ids")}
+		for i, d := range diags {
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(wantPaths[i]) {
+				t.Fatalf("diag %d = %v, want path %s", i, d, wantPaths[i])
+			}
+		}
+	})
+
+	t.Run("keeps_unmapped_details", func(t *testing.T) {
+		t.Parallel()
+
+		var diags diag.Diagnostics
+		addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths, "Error Updating Propagation Rule", "generic",
+			response(`{"details":[
+				{"target":"name","message":"Name is too long"},
+				{"target":"somethingElse","message":"Other problem"}
+			]}`))
+
+		if len(diags) != 2 {
+			t.Fatalf("diags = %v", diags)
+		}
+		if _, ok := diags[1].(diag.DiagnosticWithPath); ok || diags[1].Detail() != "Other problem" {
+			t.Fatalf("unmapped diag = %v", diags[1])
+		}
+	})
+
+	t.Run("falls_back_without_details", func(t *testing.T) {
+		t.Parallel()
+
+		var diags diag.Diagnostics
+		addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths, "Error Creating Propagation Rule", "generic", response(`{"message":"boom"}`))
+		if len(diags) != 1 || diags[0].Detail() != "generic" {
+			t.Fatalf("diags = %v", diags)
+		}
+
+		diags = nil
+		addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths, "Error Creating Propagation Rule", "generic", nil)
+		if len(diags) != 1 || diags[0].Detail() != "generic" {
+			t.Fatalf("diags = %v", diags)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// metricsOf returns the API metrics of c, or nil when the provider is not configured.
+func metricsOf(c *client.Client) *client.APIMetrics {
+	if c == nil {
+		return nil
+	}
+	return c.Metrics
+}
+
+// reportAPIMetrics reports the API calls made so far when a resource finishes a create, update
+// or delete. Terraform has no hook for the end of an apply, so each one logs the running totals
+// and the last one covers the whole apply. When requests were retried or throttled since the
+// last warning, it also adds a warning with the totals, so that an apply slowed down by rate
+// limits says so in its output, usually because `-parallelism` is higher than they allow.
+func reportAPIMetrics(ctx context.Context, metrics *client.APIMetrics, diags *diag.Diagnostics) {
+	summary, _ := metrics.Summary()
+	if summary == "" {
+		return
+	}
+	tflog.Info(ctx, "API calls so far in this run", map[string]interface{}{
+		"summary": summary,
+	})
+	if !metrics.NewSlowdowns() {
+		return
+	}
+	diags.AddWarning(
+		"API Requests Retried or Throttled",
+		fmt.Sprintf("Requests to the PingOne or GitHub API were retried or throttled, which usually means `-parallelism` is higher than the API rate limits allow. API calls so far in this run: %s", summary),
+	)
+}
+
+// LogAPIMetricsSummary logs the API calls, retries and throttle waits per endpoint family that
+// were made through the client Configure built, including those of plan-time reads. It is a
+// single log line written when the provider stops serving; creates, updates and deletes report
+// the running totals themselves with reportAPIMetrics. The summary is a warning when requests
+// were retried or throttled.
+func (p *PingOneProvisioningProvider) LogAPIMetricsSummary() {
+	summary, slowed := p.metrics.Summary()
+	if summary == "" {
+		return
+	}
+	if slowed {
+		log.Printf("[WARN] pingoneprovisioning: API calls were retried or throttled; consider a lower -parallelism. %s", summary)
+		return
+	}
+	log.Printf("[INFO] pingoneprovisioning: API calls: %s", summary)
+}
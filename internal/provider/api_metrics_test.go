@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestRetryTransport_RecordsAPIMetrics(t *testing.T) {
+	t.Parallel()
+
+	var ruleCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/propagation/rules") && ruleCalls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := client.NewAPIMetrics()
+	httpClient := &http.Client{Transport: newRetryTransport(
+		metrics.Transport(http.DefaultTransport, client.PingOneAPIFamily),
+		WithInitialBackoff(time.Millisecond),
+		WithMetrics(metrics),
+	)}
+
+	for _, p := range []string{
+		"/v1/environments/env-id/propagation/rules/rule-id",
+		"/v1/environments/env-id/propagation/rules/rule-id/mappings",
+		"/v1/environments/env-id/propagation/stores",
+	} {
+		resp, err := httpClient.Get(server.URL + p)
+		if err != nil {
+			t.Fatalf("GET %s: %v", p, err)
+		}
+		resp.Body.Close()
+	}
+
+	got := metrics.Snapshot()
+	if rules := got[client.APIFamilyRules]; rules.Calls != 2 || rules.Retries != 1 || rules.Throttled != 1 {
+		t.Fatalf("rules = %+v, want 2 calls, 1 retry, 1 throttled", rules)
+	}
+	if got[client.APIFamilyMappings].Calls != 1 || got[client.APIFamilyStores].Calls != 1 {
+		t.Fatalf("metrics = %+v", got)
+	}
+
+	summary, slowed := metrics.Summary()
+	if !slowed || !strings.HasPrefix(summary, "stores: 1 calls; rules: 2 calls, 1 retries, 1 throttled; mappings: 1 calls") {
+		t.Fatalf("summary = %q, slowed = %v", summary, slowed)
+	}
+}
+
+func TestNewManagementClient_CountsInItsMetrics(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/as/token") {
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"env-id"}`))
+	}))
+	defer server.Close()
+
+	metrics := client.NewAPIMetrics()
+	apiClient, err := newManagementClient(context.Background(), "", "client-id", "client-secret", "env-id", "NorthAmerica", server.URL+"/as/token", server.URL+"/v1", defaultRequestTimeout, defaultRequestTimeout, metrics)
+	if err != nil {
+		t.Fatalf("newManagementClient: %v", err)
+	}
+	other, err := newManagementClient(context.Background(), "", "client-id", "client-secret", "env-id", "NorthAmerica", server.URL+"/as/token", server.URL+"/v1", defaultRequestTimeout, defaultRequestTimeout, client.NewAPIMetrics())
+	if err != nil {
+		t.Fatalf("newManagementClient: %v", err)
+	}
+
+	if _, _, err := apiClient.EnvironmentsApi.ReadOneEnvironment(context.Background(), "env-id").Execute(); err != nil {
+		t.Fatalf("ReadOneEnvironment: %v", err)
+	}
+	if _, _, err := other.EnvironmentsApi.ReadOneEnvironment(context.Background(), "env-id").Execute(); err != nil {
+		t.Fatalf("ReadOneEnvironment: %v", err)
+	}
+
+	got := metrics.Snapshot()
+	if got[client.APIFamilyToken].Calls != 1 || got[client.APIFamilyOther].Calls != 1 {
+		t.Fatalf("metrics = %+v, want the token and environment requests of one client", got)
+	}
+}
+
+func TestReportAPIMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	metrics := client.NewAPIMetrics()
+
+	var diags diag.Diagnostics
+	reportAPIMetrics(ctx, metrics, &diags)
+	if len(diags) != 0 {
+		t.Fatalf("diags = %v, want none before any call", diags)
+	}
+
+	metrics.RecordRetry(client.APIFamilyRules, time.Second)
+	reportAPIMetrics(ctx, metrics, &diags)
+	if diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "rules: 0 calls, 1 retries, 1s waiting") {
+		t.Fatalf("diags = %v, want one warning with the totals", diags)
+	}
+
+	// Later resources only warn again about new retries.
+	reportAPIMetrics(ctx, metrics, &diags)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("warnings = %d, want 1 without new retries", diags.WarningsCount())
+	}
+	metrics.RecordRetry(client.APIFamilyStores, time.Second)
+	reportAPIMetrics(ctx, metrics, &diags)
+	if diags.WarningsCount() != 2 {
+		t.Fatalf("warnings = %d, want 2 after a new retry", diags.WarningsCount())
+	}
+
+	reportAPIMetrics(ctx, nil, &diags)
+	if diags.WarningsCount() != 2 {
+		t.Fatalf("warnings = %d, want nil metrics to report nothing", diags.WarningsCount())
+	}
+}
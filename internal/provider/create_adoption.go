@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// createAttempts is how many times createOrAdopt sends a create whose outcome is unknown.
+const createAttempts = 3
+
+// createRetryBackoff is the wait before the first resend; it doubles on each attempt.
+var createRetryBackoff = 2 * time.Second
+
+type createRequestKey struct{}
+
+// withCreateRequest marks ctx as carrying a create. The retry transport does not resend such
+// requests on a gateway error, because PingOne may already have created the object;
+// createOrAdopt looks for it before sending the request again.
+func withCreateRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, createRequestKey{}, true)
+}
+
+func isCreateRequest(ctx context.Context) bool {
+	marked, _ := ctx.Value(createRequestKey{}).(bool)
+	return marked
+}
+
+// isAmbiguousCreateFailure reports whether a failed create may still have been applied: no
+// response was received, or a gateway answered in place of PingOne.
+func isAmbiguousCreateFailure(ctx context.Context, httpResp *http.Response, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if httpResp == nil {
+		return true
+	}
+	switch httpResp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// createOrAdopt runs create. When the create fails without a clear answer, find is called to
+// look for an object with the same name: a match is adopted instead of creating a duplicate,
+// otherwise the create is sent again, up to createAttempts times. If find fails, the create
+// error is returned rather than risking a duplicate.
+func createOrAdopt[T any](
+	ctx context.Context,
+	kind string,
+	name string,
+	create func(context.Context) (T, *http.Response, error),
+	find func(context.Context) (T, *http.Response, bool, error),
+) (T, *http.Response, error) {
+	backoff := createRetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		result, httpResp, err := create(withCreateRequest(ctx))
+		if !isAmbiguousCreateFailure(ctx, httpResp, err) {
+			return result, httpResp, err
+		}
+
+		existing, existingResp, found, findErr := find(ctx)
+		if findErr != nil {
+			tflog.Warn(ctx, "Could not check for an existing object after a failed create", map[string]interface{}{
+				"kind":  kind,
+				"name":  name,
+				"error": findErr.Error(),
+			})
+			return result, httpResp, err
+		}
+		if found {
+			tflog.Warn(ctx, "Adopting object created by a request that did not complete", map[string]interface{}{
+				"kind":    kind,
+				"name":    name,
+				"attempt": attempt,
+			})
+			return existing, existingResp, nil
+		}
+
+		if attempt >= createAttempts {
+			return result, httpResp, err
+		}
+
+		tflog.Debug(ctx, "Create did not complete and no object was found; sending it again", map[string]interface{}{
+			"kind":    kind,
+			"name":    name,
+			"attempt": attempt,
+			"backoff": backoff.String(),
+		})
+		if utils.SleepContext(ctx, backoff) != nil {
+			return result, httpResp, err
+		}
+		backoff *= 2
+	}
+}
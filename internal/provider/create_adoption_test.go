@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateOrAdopt(t *testing.T) {
+	prior := createRetryBackoff
+	createRetryBackoff = time.Millisecond
+	t.Cleanup(func() { createRetryBackoff = prior })
+
+	gatewayTimeout := &http.Response{StatusCode: http.StatusGatewayTimeout}
+	badRequest := &http.Response{StatusCode: http.StatusBadRequest}
+
+	t.Run("adopts_object_after_timeout", func(t *testing.T) {
+		creates := 0
+		got, _, err := createOrAdopt(context.Background(), "store", "SCIM",
+			func(ctx context.Context) (string, *http.Response, error) {
+				if !isCreateRequest(ctx) {
+					t.Errorf("create context is not marked")
+				}
+				creates++
+				return "", gatewayTimeout, fmt.Errorf("504 Gateway Timeout")
+			},
+			func(context.Context) (string, *http.Response, bool, error) {
+				return "store-1", nil, true, nil
+			},
+		)
+		if err != nil || got != "store-1" || creates != 1 {
+			t.Fatalf("got %q, err %v, creates %d; want store-1, nil, 1", got, err, creates)
+		}
+	})
+
+	t.Run("resends_when_not_found", func(t *testing.T) {
+		creates := 0
+		got, _, err := createOrAdopt(context.Background(), "store", "SCIM",
+			func(context.Context) (string, *http.Response, error) {
+				creates++
+				if creates == 1 {
+					return "", nil, fmt.Errorf("connection reset")
+				}
+				return "store-2", &http.Response{StatusCode: http.StatusCreated}, nil
+			},
+			func(context.Context) (string, *http.Response, bool, error) {
+				return "", nil, false, nil
+			},
+		)
+		if err != nil || got != "store-2" || creates != 2 {
+			t.Fatalf("got %q, err %v, creates %d; want store-2, nil, 2", got, err, creates)
+		}
+	})
+
+	t.Run("gives_up_after_attempts", func(t *testing.T) {
+		creates := 0
+		_, _, err := createOrAdopt(context.Background(), "store", "SCIM",
+			func(context.Context) (string, *http.Response, error) {
+				creates++
+				return "", gatewayTimeout, fmt.Errorf("504 Gateway Timeout")
+			},
+			func(context.Context) (string, *http.Response, bool, error) {
+				return "", nil, false, nil
+			},
+		)
+		if err == nil || creates != createAttempts {
+			t.Fatalf("err %v, creates %d; want error after %d creates", err, creates, createAttempts)
+		}
+	})
+
+	t.Run("does_not_resend_when_lookup_fails", func(t *testing.T) {
+		creates := 0
+		_, _, err := createOrAdopt(context.Background(), "store", "SCIM",
+			func(context.Context) (string, *http.Response, error) {
+				creates++
+				return "", gatewayTimeout, fmt.Errorf("504 Gateway Timeout")
+			},
+			func(context.Context) (string, *http.Response, bool, error) {
+				return "", nil, false, fmt.Errorf("found 2 propagation stores named \"SCIM\"")
+			},
+		)
+		if err == nil || creates != 1 {
+			t.Fatalf("err %v, creates %d; want the create error after 1 create", err, creates)
+		}
+	})
+
+	t.Run("rejected_create_is_not_looked_up", func(t *testing.T) {
+		_, _, err := createOrAdopt(context.Background(), "store", "SCIM",
+			func(context.Context) (string, *http.Response, error) {
+				return "", badRequest, fmt.Errorf("400 Bad Request")
+			},
+			func(context.Context) (string, *http.Response, bool, error) {
+				t.Errorf("find called for a rejected create")
+				return "", nil, false, nil
+			},
+		)
+		if err == nil {
+			t.Fatalf("expected the create error")
+		}
+	})
+}
+
+func TestRetryTransport_DoesNotResendCreateOnGatewayError(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: newRetryTransport(http.DefaultTransport, WithInitialBackoff(time.Millisecond), WithMaxRetryTimeout(50*time.Millisecond))}
+
+	req, err := http.NewRequestWithContext(withCreateRequest(context.Background()), http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusGatewayTimeout || requests != 1 {
+		t.Fatalf("status %d after %d requests; want 504 after 1", resp.StatusCode, requests)
+	}
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &enterpriseOrganizationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &enterpriseOrganizationsDataSource{}
+)
+
+// enterpriseOrganizationsQuery lists one page of an enterprise's organizations. The REST API has
+// no endpoint for this, so the GraphQL API is used.
+const enterpriseOrganizationsQuery = `query($slug: String!, $first: Int!, $cursor: String) {
+  enterprise(slug: $slug) {
+    organizations(first: $first, after: $cursor) {
+      nodes { databaseId login name }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+type enterpriseOrganizationsDataSource struct {
+	client *client.GitHubClient
+}
+
+type enterpriseOrganizationsDataSourceModel struct {
+	Enterprise    types.String                    `tfsdk:"enterprise"`
+	Organizations []enterpriseOrganizationSummary `tfsdk:"organizations"`
+	Slugs         []types.String                  `tfsdk:"slugs"`
+}
+
+type enterpriseOrganizationSummary struct {
+	Id   types.Int64  `tfsdk:"id"`
+	Slug types.String `tfsdk:"slug"`
+	Name types.String `tfsdk:"name"`
+}
+
+type enterpriseOrganizationResponse struct {
+	DatabaseId int64  `json:"databaseId"`
+	Login      string `json:"login"`
+	Name       string `json:"name"`
+}
+
+type enterpriseOrganizationsGraphQLResponse struct {
+	Data struct {
+		Enterprise *struct {
+			Organizations struct {
+				Nodes    []enterpriseOrganizationResponse `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"organizations"`
+		} `json:"enterprise"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func NewEnterpriseOrganizationsDataSource() datasource.DataSource {
+	return &enterpriseOrganizationsDataSource{}
+}
+
+func (d *enterpriseOrganizationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_enterprise_organizations"
+}
+
+func (d *enterpriseOrganizationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the organizations in a GitHub enterprise.",
+		Attributes: map[string]schema.Attribute{
+			"enterprise": schema.StringAttribute{
+				Description: "The enterprise slug.",
+				Required:    true,
+			},
+			"organizations": schema.ListNestedAttribute{
+				Description: "The organizations in the enterprise, sorted by slug.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The organization ID.",
+							Computed:    true,
+						},
+						"slug": schema.StringAttribute{
+							Description: "The organization slug (login).",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The organization display name, if set.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"slugs": schema.ListAttribute{
+				Description: "The organization slugs, in the same order as `organizations`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *enterpriseOrganizationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData.GitHub
+}
+
+func (d *enterpriseOrganizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config enterpriseOrganizationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, d.client) {
+		return
+	}
+
+	enterprise := strings.TrimSpace(config.Enterprise.ValueString())
+	if enterprise == "" {
+		resp.Diagnostics.AddError(
+			"Missing Enterprise",
+			"enterprise must be provided to list GitHub enterprise organizations.",
+		)
+		return
+	}
+
+	organizations, err := listGitHubEnterpriseOrganizations(ctx, d.client, enterprise)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Enterprise Organizations",
+			err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Listed GitHub enterprise organizations", map[string]interface{}{
+		"enterprise": enterprise,
+		"count":      len(organizations),
+	})
+
+	state := config
+	state.Organizations = make([]enterpriseOrganizationSummary, 0, len(organizations))
+	state.Slugs = make([]types.String, 0, len(organizations))
+	for _, org := range organizations {
+		state.Organizations = append(state.Organizations, enterpriseOrganizationSummary{
+			Id:   types.Int64Value(org.DatabaseId),
+			Slug: types.StringValue(org.Login),
+			Name: stringValueOrNull(org.Name, ""),
+		})
+		state.Slugs = append(state.Slugs, types.StringValue(org.Login))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// listGitHubEnterpriseOrganizations returns every organization in the enterprise, sorted by slug,
+// following GraphQL cursor pagination.
+func listGitHubEnterpriseOrganizations(ctx context.Context, c *client.GitHubClient, enterprise string) ([]enterpriseOrganizationResponse, error) {
+	var organizations []enterpriseOrganizationResponse
+
+	var cursor *string
+	for {
+		payload := map[string]interface{}{
+			"query": enterpriseOrganizationsQuery,
+			"variables": map[string]interface{}{
+				"slug":   enterprise,
+				"first":  c.ListPageSize(),
+				"cursor": cursor,
+			},
+		}
+
+		httpResp, err := c.Do(ctx, http.MethodPost, client.GraphQLPath, nil, payload)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %s", err)
+		}
+		if httpResp.StatusCode >= 300 {
+			return nil, fmt.Errorf("API error: %s", githubResponseErrorWithHint(httpResp, c))
+		}
+
+		var page enterpriseOrganizationsGraphQLResponse
+		if err := utils.DecodeResponseJSONInto(httpResp, &page); err != nil {
+			return nil, fmt.Errorf("could not parse response: %s", err)
+		}
+
+		// GraphQL reports errors, such as an unknown enterprise or a missing scope, with a 200 status.
+		if len(page.Errors) > 0 {
+			messages := make([]string, 0, len(page.Errors))
+			for _, e := range page.Errors {
+				messages = append(messages, e.Message)
+			}
+			return nil, fmt.Errorf("API error: %s", strings.Join(messages, "; "))
+		}
+		if page.Data.Enterprise == nil {
+			return nil, fmt.Errorf("enterprise %q not found", enterprise)
+		}
+
+		orgs := page.Data.Enterprise.Organizations
+		organizations = append(organizations, orgs.Nodes...)
+		if !orgs.PageInfo.HasNextPage || orgs.PageInfo.EndCursor == "" {
+			break
+		}
+		next := orgs.PageInfo.EndCursor
+		cursor = &next
+	}
+
+	sort.SliceStable(organizations, func(i, j int) bool {
+		return strings.ToLower(organizations[i].Login) < strings.ToLower(organizations[j].Login)
+	})
+
+	return organizations, nil
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+)
+
+func TestListGitHubEnterpriseOrganizations_FollowsCursor(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			t.Errorf("request = %s %s, want POST /graphql", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body.Variables["slug"] != "acme" {
+			t.Errorf("slug = %v, want acme", body.Variables["slug"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.Variables["cursor"] == "page-2" {
+			_, _ = w.Write([]byte(`{"data":{"enterprise":{"organizations":{"nodes":[{"databaseId":2,"login":"acme-api","name":""}],"pageInfo":{"hasNextPage":false,"endCursor":"page-3"}}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"enterprise":{"organizations":{"nodes":[{"databaseId":3,"login":"acme-web","name":"Acme Web"},{"databaseId":1,"login":"Acme-Admin","name":"Acme Admin"}],"pageInfo":{"hasNextPage":true,"endCursor":"page-2"}}}}}`))
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+
+	orgs, err := listGitHubEnterpriseOrganizations(context.Background(), gh, "acme")
+	if err != nil {
+		t.Fatalf("listGitHubEnterpriseOrganizations error: %v", err)
+	}
+
+	var logins []string
+	for _, org := range orgs {
+		logins = append(logins, org.Login)
+	}
+	if got := strings.Join(logins, ","); got != "Acme-Admin,acme-api,acme-web" {
+		t.Fatalf("organizations = %s, want Acme-Admin,acme-api,acme-web", got)
+	}
+}
+
+func TestListGitHubEnterpriseOrganizations_GraphQLErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// GitHub Enterprise Server serves GraphQL beside the REST prefix.
+		if r.URL.Path != "/api/graphql" {
+			t.Errorf("path = %s, want /api/graphql", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"enterprise":null},"errors":[{"message":"Could not resolve to an Enterprise with the slug of 'missing'."}]}`))
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL+"/api/v3", "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+
+	_, err = listGitHubEnterpriseOrganizations(context.Background(), gh, "missing")
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve to an Enterprise") {
+		t.Fatalf("error = %v, want GraphQL error message", err)
+	}
+}
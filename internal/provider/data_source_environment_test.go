@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestReadEnvironment_MapsFields(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		body := `{"id":"env-id","name":"Workforce","type":"PRODUCTION","region":"EU","license":{"id":"lic-id"},"billOfMaterials":{"solutionType":"WORKFORCE"}}`
+		if r.URL.Path != "/v1/environments/env-id" {
+			status = http.StatusNotFound
+			body = `{"code":"NOT_FOUND","message":"not found"}`
+		}
+		return testResponse(r, status, body), nil
+	})
+
+	env, _, err := readEnvironment(context.Background(), apiClient, "env-id")
+	if err != nil {
+		t.Fatalf("readEnvironment error: %v", err)
+	}
+	if env.Id.ValueString() != "env-id" || env.Name.ValueString() != "Workforce" || env.Region.ValueString() != "EU" {
+		t.Fatalf("environment = %+v", env)
+	}
+	if env.LicenseId.ValueString() != "lic-id" || env.SolutionType.ValueString() != "WORKFORCE" || !env.Description.IsNull() {
+		t.Fatalf("environment = %+v", env)
+	}
+
+	_, httpResp, err := readEnvironment(context.Background(), apiClient, "missing")
+	if err == nil || httpResp == nil || httpResp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 error, got resp=%v err=%v", httpResp, err)
+	}
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &environmentsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &environmentsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &environmentsDataSource{}
+)
+
+type environmentsDataSource struct {
+	client *client.Client
+}
+
+type environmentsDataSourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	NamePrefix   types.String `tfsdk:"name_prefix"`
+	LicenseId    types.String `tfsdk:"license_id"`
+	Type         types.String `tfsdk:"type"`
+	SolutionType types.String `tfsdk:"solution_type"`
+	Environments types.List   `tfsdk:"environments"`
+	Ids          types.List   `tfsdk:"ids"`
+}
+
+// environmentFilter holds the data source's filters. Empty fields match every environment.
+type environmentFilter struct {
+	Name         string
+	NamePrefix   string
+	LicenseID    string
+	Type         string
+	SolutionType string
+}
+
+func (f environmentFilter) matches(env customtypes.EnvironmentModel) bool {
+	name := env.Name.ValueString()
+	if f.Name != "" && !strings.EqualFold(name, f.Name) {
+		return false
+	}
+	if f.NamePrefix != "" && !strings.HasPrefix(strings.ToLower(name), strings.ToLower(f.NamePrefix)) {
+		return false
+	}
+	if f.LicenseID != "" && !strings.EqualFold(env.LicenseId.ValueString(), f.LicenseID) {
+		return false
+	}
+	if f.Type != "" && !strings.EqualFold(env.Type.ValueString(), f.Type) {
+		return false
+	}
+	if f.SolutionType != "" && !strings.EqualFold(env.SolutionType.ValueString(), f.SolutionType) {
+		return false
+	}
+	return true
+}
+
+func NewEnvironmentsDataSource() datasource.DataSource {
+	return &environmentsDataSource{}
+}
+
+func (d *environmentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environments"
+}
+
+func (d *environmentsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the PingOne environments the worker application can read, so modules can loop over environments without hard-coding their IDs.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Optional filter by exact environment name. Compared case-insensitively.",
+				Optional:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Optional filter by environment name prefix. Compared case-insensitively.",
+				Optional:    true,
+			},
+			"license_id": schema.StringAttribute{
+				Description: "Optional filter by the ID of the license the environment uses.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Optional filter by environment type. Options are `PRODUCTION`, `SANDBOX`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("PRODUCTION", "SANDBOX"),
+				},
+			},
+			"solution_type": schema.StringAttribute{
+				Description: "Optional filter by the solution in the environment's bill of materials. Options are `WORKFORCE`, `CUSTOMER`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("WORKFORCE", "CUSTOMER"),
+				},
+			},
+			"environments": schema.ListAttribute{
+				Description: "List of environments that match the filters, sorted by name.",
+				Computed:    true,
+				ElementType: customtypes.EnvironmentModelType(),
+			},
+			"ids": schema.ListAttribute{
+				Description: "List of environment IDs found, in the same order as `environments`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *environmentsDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("name"),
+			path.MatchRoot("name_prefix"),
+		),
+	}
+}
+
+func (d *environmentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state environmentsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := environmentFilter{
+		Name:         strings.TrimSpace(state.Name.ValueString()),
+		NamePrefix:   strings.TrimSpace(state.NamePrefix.ValueString()),
+		LicenseID:    strings.TrimSpace(state.LicenseId.ValueString()),
+		Type:         strings.TrimSpace(state.Type.ValueString()),
+		SolutionType: strings.TrimSpace(state.SolutionType.ValueString()),
+	}
+
+	tflog.Info(ctx, "Starting read of PingOne environments", map[string]interface{}{
+		"name":          filter.Name,
+		"name_prefix":   filter.NamePrefix,
+		"license_id":    filter.LicenseID,
+		"type":          filter.Type,
+		"solution_type": filter.SolutionType,
+	})
+
+	environments, err := listEnvironments(ctx, d.client.Endpoints, d.client.API, filter, d.client.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environments",
+			fmt.Sprintf("Could not list environments: %s", err),
+		)
+		return
+	}
+
+	ids := make([]string, 0, len(environments))
+	for _, env := range environments {
+		ids = append(ids, env.Id.ValueString())
+	}
+
+	tflog.Info(ctx, "Finished reading PingOne environments", map[string]interface{}{
+		"total_found": len(environments),
+	})
+
+	environmentsList, diags := types.ListValueFrom(ctx, customtypes.EnvironmentModelType(), environments)
+	resp.Diagnostics.Append(diags...)
+	state.Environments = environmentsList
+
+	idsList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	state.Ids = idsList
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listEnvironments lists the organization's environments and keeps those matching filter,
+// sorted by name then ID. Filtering is done locally because the environments endpoint cannot
+// filter on every field the data source offers.
+func listEnvironments(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, filter environmentFilter, pageSize int32) ([]customtypes.EnvironmentModel, error) {
+	items, err := listPingOneCollection(ctx, endpoints, apiClient, endpointFamilyEnvironments, "/environments", pageSize, "environments")
+	if err != nil {
+		return nil, err
+	}
+
+	environments := make([]customtypes.EnvironmentModel, 0, len(items))
+	for _, item := range items {
+		env := environmentModelFromMap(item)
+		if env.Id.ValueString() == "" || !filter.matches(env) {
+			continue
+		}
+		environments = append(environments, env)
+	}
+
+	sort.Slice(environments, func(i, j int) bool {
+		if environments[i].Name.ValueString() != environments[j].Name.ValueString() {
+			return environments[i].Name.ValueString() < environments[j].Name.ValueString()
+		}
+		return environments[i].Id.ValueString() < environments[j].Id.ValueString()
+	})
+
+	return environments, nil
+}
+
+func environmentModelFromMap(item map[string]interface{}) customtypes.EnvironmentModel {
+	optional := func(keys ...string) types.String {
+		if v, ok := utils.NestedString(item, keys...); ok && v != "" {
+			return types.StringValue(v)
+		}
+		return types.StringNull()
+	}
+
+	id, _ := utils.NestedString(item, "id")
+	name, _ := utils.NestedString(item, "name")
+
+	return customtypes.EnvironmentModel{
+		Id:           types.StringValue(strings.TrimSpace(id)),
+		Name:         types.StringValue(name),
+		Description:  optional("description"),
+		Type:         optional("type"),
+		Region:       optional("region"),
+		LicenseId:    optional("license", "id"),
+		SolutionType: optional("billOfMaterials", "solutionType"),
+	}
+}
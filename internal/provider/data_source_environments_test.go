@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListEnvironments_FiltersAndSorts(t *testing.T) {
+	t.Parallel()
+
+	body := `{"_embedded":{"environments":[
+		{"id":"env-3","name":"Workforce Prod","type":"PRODUCTION","region":"NA","license":{"id":"lic-1"},"billOfMaterials":{"solutionType":"WORKFORCE"}},
+		{"id":"env-1","name":"Customer Prod","type":"PRODUCTION","region":"NA","license":{"id":"lic-2"},"billOfMaterials":{"solutionType":"CUSTOMER"}},
+		{"id":"env-2","name":"Workforce Dev","type":"SANDBOX","region":"NA","license":{"id":"lic-1"}}
+	]}}`
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/v1/environments" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	tests := []struct {
+		name   string
+		filter environmentFilter
+		want   []string
+	}{
+		{name: "all", want: []string{"env-1", "env-2", "env-3"}},
+		{name: "name_prefix", filter: environmentFilter{NamePrefix: "workforce"}, want: []string{"env-2", "env-3"}},
+		{name: "name", filter: environmentFilter{Name: "customer prod"}, want: []string{"env-1"}},
+		{name: "license_and_type", filter: environmentFilter{LicenseID: "lic-1", Type: "production"}, want: []string{"env-3"}},
+		{name: "solution_type", filter: environmentFilter{SolutionType: "CUSTOMER"}, want: []string{"env-1"}},
+	}
+
+	for _, tt := range tests {
+		got, err := listEnvironments(context.Background(), nil, apiClient, tt.filter, 0)
+		if err != nil {
+			t.Fatalf("%s: listEnvironments error: %v", tt.name, err)
+		}
+		ids := make([]string, 0, len(got))
+		for _, env := range got {
+			ids = append(ids, env.Id.ValueString())
+		}
+		if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+			t.Fatalf("%s: ids = %v, want %v", tt.name, ids, tt.want)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ datasource.DataSource                     = &gatewayDataSource{}
+	_ datasource.DataSourceWithConfigure        = &gatewayDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &gatewayDataSource{}
+)
+
+type gatewayDataSource struct {
+	client *client.Client
+}
+
+func NewGatewayDataSource() datasource.DataSource {
+	return &gatewayDataSource{}
+}
+
+func (d *gatewayDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway"
+}
+
+func (d *gatewayDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches a PingOne gateway (for example an LDAP gateway) so store configuration can reference it by name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique ID of the gateway.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the gateway. Conflicts with `id`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the gateway (for example `LDAP`). When set with `name`, only gateways of this type are matched. When set alone, the environment must have exactly one gateway of this type.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of the gateway.",
+				Computed:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Indicates whether the gateway is enabled.",
+				Computed:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "The region of the environment that owns the gateway.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *gatewayDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("type"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("id"),
+			path.MatchRoot("type"),
+		),
+	}
+}
+
+func (d *gatewayDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *gatewayDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state customtypes.GatewayModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := state.EnvironmentId.ValueString()
+	apiClient := d.client.API
+
+	gateway, lookupDiags := findGateway(ctx, apiClient, environmentID, state.Id, state.Name, state.Type)
+	resp.Diagnostics.Append(lookupDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gatewayToModel(gateway, environmentID, &state)
+
+	region, err := environmentRegion(ctx, apiClient, environmentID)
+	if err != nil {
+		tflog.Warn(ctx, "Could not determine environment region for gateway", map[string]interface{}{
+			"environment_id": environmentID,
+			"error":          err.Error(),
+		})
+	}
+	if region != "" {
+		state.Region = types.StringValue(region)
+	} else {
+		state.Region = types.StringNull()
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// findGateway reads the gateway with ID id, or else finds the one gateway with the name and type
+// that are set. No match, or more than one, is an error.
+func findGateway(ctx context.Context, apiClient *management.APIClient, environmentID string, id, name, gatewayType types.String) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if gatewayID := id.ValueString(); gatewayID != "" {
+		tflog.Info(ctx, "Reading gateway by ID", map[string]interface{}{
+			"environment_id": environmentID,
+			"id":             gatewayID,
+		})
+
+		_, httpResp, err := apiClient.GatewaysApi.ReadOneGateway(ctx, environmentID, gatewayID).Execute()
+		// The typed oneOf decode can fail for gateway types the SDK does not model; the raw
+		// body is still usable in that case.
+		if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				diags.AddError(
+					"Gateway Not Found",
+					fmt.Sprintf("No gateway found with ID '%s' in environment '%s'.", gatewayID, environmentID),
+				)
+				return nil, diags
+			}
+			diags.AddError(
+				"Error Reading Gateway",
+				fmt.Sprintf("Could not read gateway: %s", utils.HandleSDKError(err, httpResp)),
+			)
+			return nil, diags
+		}
+
+		gateway, err := utils.DecodeResponseJSONObject(httpResp, "gateway")
+		if err != nil {
+			diags.AddError(
+				"Error Parsing Gateway",
+				fmt.Sprintf("Could not parse gateway response: %s", err),
+			)
+			return nil, diags
+		}
+		return gateway, diags
+	}
+
+	targetName := name.ValueString()
+	targetType := strings.TrimSpace(gatewayType.ValueString())
+	if targetName == "" && targetType == "" {
+		diags.AddError(
+			"Missing Required Arguments",
+			"Configure `id`, `name` or `type` to look up a gateway.",
+		)
+		return nil, diags
+	}
+
+	tflog.Info(ctx, "Reading gateway by name and type", map[string]interface{}{
+		"environment_id": environmentID,
+		"name":           targetName,
+		"type":           targetType,
+	})
+
+	gateways, err := listGatewaysRaw(ctx, apiClient, environmentID)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Gateways",
+			fmt.Sprintf("Could not list gateways: %s", err),
+		)
+		return nil, diags
+	}
+
+	var described string
+	switch {
+	case targetName == "":
+		described = fmt.Sprintf("of type '%s'", targetType)
+	case targetType == "":
+		described = fmt.Sprintf("with name '%s'", targetName)
+	default:
+		described = fmt.Sprintf("with name '%s' and type '%s'", targetName, targetType)
+	}
+
+	matches := filterGatewaysByNameType(gateways, targetName, targetType)
+	switch {
+	case len(matches) == 0:
+		diags.AddError(
+			"Gateway Not Found",
+			fmt.Sprintf("No gateway found %s in environment '%s'.", described, environmentID),
+		)
+		return nil, diags
+	case len(matches) > 1:
+		diags.AddError(
+			"Multiple Gateways Found",
+			fmt.Sprintf("Found %d gateways %s in environment '%s'. Set `name` and `type`, or use the `id` argument, to select a specific gateway.", len(matches), described, environmentID),
+		)
+		return nil, diags
+	}
+	return matches[0], diags
+}
+
+// listGatewaysRaw returns every gateway in the environment as raw JSON objects.
+//
+// Gateways are decoded from the raw response because the SDK models gateways as a
+// oneOf that drops types it does not know about.
+func listGatewaysRaw(ctx context.Context, apiClient *management.APIClient, environmentID string) ([]map[string]interface{}, error) {
+	var gateways []map[string]interface{}
+
+	iterator := apiClient.GatewaysApi.ReadAllGateways(ctx, environmentID).Execute()
+	for cursor, iterErr := range iterator {
+		if iterErr != nil {
+			return nil, iterErr
+		}
+		if cursor.HTTPResponse == nil {
+			continue
+		}
+
+		decoded, err := utils.DecodeResponseJSON(cursor.HTTPResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := collectionPageItems(decoded, "gateways")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			if obj, ok := item.(map[string]interface{}); ok {
+				gateways = append(gateways, obj)
+			}
+		}
+	}
+
+	return gateways, nil
+}
+
+// filterGatewaysByNameType returns the gateways with name and gatewayType. An empty name or type
+// matches any.
+func filterGatewaysByNameType(gateways []map[string]interface{}, name, gatewayType string) []map[string]interface{} {
+	var matches []map[string]interface{}
+	for _, gateway := range gateways {
+		if name != "" {
+			gatewayName, _ := utils.NestedString(gateway, "name")
+			if gatewayName != name {
+				continue
+			}
+		}
+		if gatewayType != "" {
+			rawType, _ := utils.NestedString(gateway, "type")
+			if !strings.EqualFold(rawType, gatewayType) {
+				continue
+			}
+		}
+		matches = append(matches, gateway)
+	}
+	return matches
+}
+
+func gatewayToModel(gateway map[string]interface{}, environmentID string, state *customtypes.GatewayModel) {
+	state.EnvironmentId = types.StringValue(environmentID)
+
+	if v, ok := utils.NestedString(gateway, "id"); ok && v != "" {
+		state.Id = types.StringValue(v)
+	} else {
+		state.Id = types.StringNull()
+	}
+	if v, ok := utils.NestedString(gateway, "name"); ok && v != "" {
+		state.Name = types.StringValue(v)
+	} else {
+		state.Name = types.StringNull()
+	}
+	if v, ok := utils.NestedString(gateway, "type"); ok && v != "" {
+		state.Type = types.StringValue(v)
+	} else {
+		state.Type = types.StringNull()
+	}
+	if v, ok := utils.NestedString(gateway, "description"); ok && v != "" {
+		state.Description = types.StringValue(v)
+	} else {
+		state.Description = types.StringNull()
+	}
+	if v, ok := utils.NestedBool(gateway, "enabled"); ok {
+		state.Enabled = types.BoolValue(v)
+	} else {
+		state.Enabled = types.BoolNull()
+	}
+}
+
+// environmentRegion returns the region code reported by PingOne for the environment.
+func environmentRegion(ctx context.Context, apiClient *management.APIClient, environmentID string) (string, error) {
+	_, httpResp, err := apiClient.EnvironmentsApi.ReadOneEnvironment(ctx, environmentID).Execute()
+	if err != nil {
+		if httpResp == nil || httpResp.StatusCode >= 300 {
+			return "", errors.New(utils.HandleSDKError(err, httpResp))
+		}
+	}
+
+	decoded, err := utils.DecodeResponseJSON(httpResp)
+	if err != nil {
+		return "", err
+	}
+
+	obj, ok := decoded.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+
+	region, _ := utils.NestedString(obj, "region")
+	return region, nil
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+func newGatewayTestClient(t *testing.T) *management.APIClient {
+	t.Helper()
+
+	gateways := map[string]string{
+		"gw-ldap-1":   `{"id":"gw-ldap-1","name":"Corporate LDAP","type":"LDAP","enabled":true}`,
+		"gw-ldap-2":   `{"id":"gw-ldap-2","name":"Branch LDAP","type":"LDAP","enabled":true}`,
+		"gw-radius":   `{"id":"gw-radius","name":"RADIUS","type":"RADIUS","enabled":false}`,
+		"gw-ldap-dup": `{"id":"gw-ldap-dup","name":"Corporate LDAP","type":"PING_INTELLIGENCE","enabled":true}`,
+	}
+
+	return newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, ""
+		switch id := strings.TrimPrefix(r.URL.Path, "/v1/environments/env-id/gateways"); {
+		case r.URL.Path == "/v1/environments/empty-env-id/gateways":
+			// PingOne omits `_embedded` from an empty collection.
+			body = `{"_links":{"self":{"href":"https://api.example/v1/environments/empty-env-id/gateways"}},"count":0,"size":0}`
+		case id == "":
+			items := make([]string, 0, len(gateways))
+			for _, gateway := range gateways {
+				items = append(items, gateway)
+			}
+			body = `{"_embedded":{"gateways":[` + strings.Join(items, ",") + `]},"_links":{}}`
+		case gateways[strings.TrimPrefix(id, "/")] != "":
+			body = gateways[strings.TrimPrefix(id, "/")]
+		default:
+			status, body = http.StatusNotFound, `{"code":"NOT_FOUND","message":"not found"}`
+		}
+		return testResponse(r, status, body), nil
+	})
+}
+
+func TestFindGateway(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newGatewayTestClient(t)
+
+	tests := []struct {
+		name        string
+		environment string
+		id          string
+		gatewayName string
+		gatewayType string
+		wantID      string
+		wantError   string
+	}{
+		{name: "by_id", id: "gw-radius", wantID: "gw-radius"},
+		{name: "by_id_not_found", id: "gw-missing", wantError: "Gateway Not Found"},
+		{name: "by_name_and_type", gatewayName: "Corporate LDAP", gatewayType: "ldap", wantID: "gw-ldap-1"},
+		{name: "by_name_ambiguous", gatewayName: "Corporate LDAP", wantError: "Multiple Gateways Found"},
+		{name: "by_name_not_found", gatewayName: "Missing", wantError: "Gateway Not Found"},
+		{name: "by_type", gatewayType: "RADIUS", wantID: "gw-radius"},
+		{name: "by_type_ambiguous", gatewayType: "LDAP", wantError: "Multiple Gateways Found"},
+		{name: "by_type_not_found", gatewayType: "API_GATEWAY_INTEGRATION", wantError: "Gateway Not Found"},
+		{name: "no_gateways", environment: "empty-env-id", gatewayType: "LDAP", wantError: "Gateway Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			optional := func(v string) types.String {
+				if v == "" {
+					return types.StringNull()
+				}
+				return types.StringValue(v)
+			}
+
+			environmentID := tt.environment
+			if environmentID == "" {
+				environmentID = "env-id"
+			}
+
+			gateway, diags := findGateway(context.Background(), apiClient, environmentID, optional(tt.id), optional(tt.gatewayName), optional(tt.gatewayType))
+			if tt.wantError != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantError {
+					t.Fatalf("diags = %v, want %q", diags, tt.wantError)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("findGateway: %v", diags)
+			}
+			if gateway["id"] != tt.wantID {
+				t.Fatalf("gateway = %v, want %s", gateway, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestGatewayDataSource_ConfigValidators(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &gatewayDataSource{}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	config := func(values map[string]string) tfsdk.Config {
+		attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+		for name, attrType := range objType.AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+		for name, value := range values {
+			attrs[name] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, attrs)}
+	}
+
+	tests := []struct {
+		name      string
+		values    map[string]string
+		wantError bool
+	}{
+		{name: "id", values: map[string]string{"id": "gw-id"}},
+		{name: "name", values: map[string]string{"name": "Corporate LDAP"}},
+		{name: "type", values: map[string]string{"type": "LDAP"}},
+		{name: "name_and_type", values: map[string]string{"name": "Corporate LDAP", "type": "LDAP"}},
+		{name: "none", values: map[string]string{}, wantError: true},
+		{name: "id_and_name", values: map[string]string{"id": "gw-id", "name": "Corporate LDAP"}, wantError: true},
+		{name: "id_and_type", values: map[string]string{"id": "gw-id", "type": "LDAP"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			values := map[string]string{"environment_id": "env-id"}
+			for name, value := range tt.values {
+				values[name] = value
+			}
+			// As in the framework, each validator gets its own response.
+			req := datasource.ValidateConfigRequest{Config: config(values)}
+			var diags diag.Diagnostics
+			for _, validator := range d.ConfigValidators(ctx) {
+				resp := &datasource.ValidateConfigResponse{}
+				validator.ValidateDataSource(ctx, req, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("diags = %v, want error %v", diags, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &githubEnterpriseTeamsDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubEnterpriseTeamsDataSource{}
+)
+
+type githubEnterpriseTeamsDataSource struct {
+	client *client.GitHubClient
+}
+
+type githubEnterpriseTeamsDataSourceModel struct {
+	Enterprise types.String                  `tfsdk:"enterprise"`
+	NamePrefix types.String                  `tfsdk:"name_prefix"`
+	NameRegex  types.String                  `tfsdk:"name_regex"`
+	Teams      []githubEnterpriseTeamSummary `tfsdk:"teams"`
+}
+
+type githubEnterpriseTeamSummary struct {
+	Id                        types.Int64  `tfsdk:"id"`
+	Name                      types.String `tfsdk:"name"`
+	Slug                      types.String `tfsdk:"slug"`
+	GroupId                   types.String `tfsdk:"group_id"`
+	GroupName                 types.String `tfsdk:"group_name"`
+	OrganizationSelectionType types.String `tfsdk:"organization_selection_type"`
+}
+
+type githubEnterpriseTeamResponse struct {
+	Id                        int64  `json:"id"`
+	Name                      string `json:"name"`
+	Slug                      string `json:"slug"`
+	Description               string `json:"description"`
+	GroupId                   string `json:"group_id"`
+	OrganizationSelectionType string `json:"organization_selection_type"`
+}
+
+func NewGithubEnterpriseTeamsDataSource() datasource.DataSource {
+	return &githubEnterpriseTeamsDataSource{}
+}
+
+func (d *githubEnterpriseTeamsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_enterprise_teams"
+}
+
+func (d *githubEnterpriseTeamsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the teams in a GitHub enterprise, optionally filtered by name.",
+		Attributes: map[string]schema.Attribute{
+			"enterprise": schema.StringAttribute{
+				Description: "The enterprise slug.",
+				Required:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Only return teams whose name starts with this prefix (case-insensitive).",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only return teams whose name matches this regular expression (Go RE2 syntax).",
+				Optional:    true,
+			},
+			"teams": schema.ListNestedAttribute{
+				Description: "The matching enterprise teams, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The team ID.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The team name.",
+							Computed:    true,
+						},
+						"slug": schema.StringAttribute{
+							Description: "The team slug.",
+							Computed:    true,
+						},
+						"group_id": schema.StringAttribute{
+							Description: "The ID of the identity provider group linked to the team, if any.",
+							Computed:    true,
+						},
+						"group_name": schema.StringAttribute{
+							Description: "The display name of the linked identity provider group, read from the enterprise's SCIM groups. Null when the team has no linked group or the group no longer exists.",
+							Computed:    true,
+						},
+						"organization_selection_type": schema.StringAttribute{
+							Description: "How organizations are assigned to the team: `disabled`, `selected`, or `all`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *githubEnterpriseTeamsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData.GitHub
+}
+
+func (d *githubEnterpriseTeamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config githubEnterpriseTeamsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, d.client) {
+		return
+	}
+
+	enterprise := strings.TrimSpace(config.Enterprise.ValueString())
+	if enterprise == "" {
+		resp.Diagnostics.AddError(
+			"Missing Enterprise",
+			"enterprise must be provided to list GitHub enterprise teams.",
+		)
+		return
+	}
+
+	prefix := ""
+	if !config.NamePrefix.IsNull() && !config.NamePrefix.IsUnknown() {
+		prefix = config.NamePrefix.ValueString()
+	}
+
+	var nameRegex *regexp.Regexp
+	if !config.NameRegex.IsNull() && !config.NameRegex.IsUnknown() {
+		compiled, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				fmt.Sprintf("Could not compile name_regex: %s", err),
+			)
+			return
+		}
+		nameRegex = compiled
+	}
+
+	teams, err := listGitHubEnterpriseTeams(ctx, d.client, enterprise)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Enterprise Teams",
+			err.Error(),
+		)
+		return
+	}
+
+	teams = filterGitHubEnterpriseTeams(teams, prefix, nameRegex)
+
+	tflog.Debug(ctx, "Listed GitHub enterprise teams", map[string]interface{}{
+		"enterprise": enterprise,
+		"matched":    len(teams),
+	})
+
+	groupNames, err := githubScimGroupDisplayNames(ctx, d.client, enterprise, teams)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading SCIM Group",
+			err.Error(),
+		)
+		return
+	}
+
+	state := config
+	state.Teams = make([]githubEnterpriseTeamSummary, 0, len(teams))
+	for _, team := range teams {
+		state.Teams = append(state.Teams, githubEnterpriseTeamSummary{
+			Id:                        types.Int64Value(team.Id),
+			Name:                      stringValueOrNull(team.Name, ""),
+			Slug:                      stringValueOrNull(team.Slug, ""),
+			GroupId:                   stringValueOrNull(team.GroupId, ""),
+			GroupName:                 stringValueOrNull(groupNames[team.GroupId], ""),
+			OrganizationSelectionType: stringValueOrNull(team.OrganizationSelectionType, ""),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// listGitHubEnterpriseTeams returns every team in the enterprise, following Link-header
+// pagination.
+func listGitHubEnterpriseTeams(ctx context.Context, c *client.GitHubClient, enterprise string) ([]githubEnterpriseTeamResponse, error) {
+	var teams []githubEnterpriseTeamResponse
+
+	err := c.ListAll(ctx, enterpriseTeamsPath(enterprise), nil, func(httpResp *http.Response) error {
+		if httpResp.StatusCode >= 300 {
+			return fmt.Errorf("API error: %s", githubResponseErrorWithHint(httpResp, c))
+		}
+
+		var page []githubEnterpriseTeamResponse
+		if err := utils.DecodeResponseJSONInto(httpResp, &page); err != nil {
+			return fmt.Errorf("could not parse response: %s", err)
+		}
+
+		teams = append(teams, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// githubScimGroupDisplayNames returns the display names of the SCIM groups linked to teams,
+// keyed by group ID. Each group is read once, however many teams share it; a group that no
+// longer exists is left out.
+func githubScimGroupDisplayNames(ctx context.Context, c *client.GitHubClient, enterprise string, teams []githubEnterpriseTeamResponse) (map[string]string, error) {
+	names := make(map[string]string)
+	read := make(map[string]bool)
+
+	query := url.Values{}
+	query.Set("excludedAttributes", "members")
+	for _, team := range teams {
+		groupID := strings.TrimSpace(team.GroupId)
+		if groupID == "" || read[groupID] {
+			continue
+		}
+		read[groupID] = true
+
+		httpResp, err := c.Do(ctx, http.MethodGet, enterpriseScimGroupPath(enterprise, groupID), query, nil)
+		if err != nil {
+			return nil, fmt.Errorf("request for SCIM group %q failed: %s", groupID, err)
+		}
+		if httpResp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if httpResp.StatusCode >= 300 {
+			return nil, fmt.Errorf("API error reading SCIM group %q: %s", groupID, githubResponseErrorWithHint(httpResp, c))
+		}
+
+		var group githubScimGroupResponse
+		if err := utils.DecodeResponseJSONInto(httpResp, &group); err != nil {
+			return nil, fmt.Errorf("could not parse SCIM group %q: %s", groupID, err)
+		}
+		names[groupID] = group.DisplayName
+	}
+
+	return names, nil
+}
+
+// filterGitHubEnterpriseTeams keeps teams whose name starts with prefix (case-insensitive) and
+// matches nameRegex, and sorts the result by name.
+func filterGitHubEnterpriseTeams(teams []githubEnterpriseTeamResponse, prefix string, nameRegex *regexp.Regexp) []githubEnterpriseTeamResponse {
+	prefix = strings.ToLower(prefix)
+
+	filtered := make([]githubEnterpriseTeamResponse, 0, len(teams))
+	for _, team := range teams {
+		if prefix != "" && !strings.HasPrefix(strings.ToLower(team.Name), prefix) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(team.Name) {
+			continue
+		}
+		filtered = append(filtered, team)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return strings.ToLower(filtered[i].Name) < strings.ToLower(filtered[j].Name)
+	})
+
+	return filtered
+}
+
+func enterpriseTeamsPath(enterprise string) string {
+	return fmt.Sprintf("/enterprises/%s/teams", url.PathEscape(strings.TrimSpace(enterprise)))
+}
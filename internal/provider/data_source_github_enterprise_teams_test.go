@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+)
+
+func TestListGitHubEnterpriseTeams_FollowsLinkHeader(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/enterprises/acme/teams" {
+			t.Errorf("path = %s, want /enterprises/acme/teams", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "100" {
+			t.Errorf("per_page = %q, want 100", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"id":2,"name":"platform-web","slug":"platform-web","organization_selection_type":"all"}]`))
+			return
+		}
+
+		w.Header().Set("Link", fmt.Sprintf(`<%s/enterprises/acme/teams?per_page=100&page=2>; rel="next", <%s/enterprises/acme/teams?per_page=100&page=2>; rel="last"`, server.URL, server.URL))
+		_, _ = w.Write([]byte(`[{"id":1,"name":"platform-api","slug":"platform-api","group_id":"g-1","organization_selection_type":"selected"},{"id":3,"name":"security","slug":"security"}]`))
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+
+	teams, err := listGitHubEnterpriseTeams(context.Background(), gh, "acme")
+	if err != nil {
+		t.Fatalf("listGitHubEnterpriseTeams error: %v", err)
+	}
+	if len(teams) != 3 {
+		t.Fatalf("teams = %d, want 3", len(teams))
+	}
+
+	filtered := filterGitHubEnterpriseTeams(teams, "Platform-", nil)
+	if len(filtered) != 2 || filtered[0].Slug != "platform-api" || filtered[1].Slug != "platform-web" {
+		t.Fatalf("prefix filter = %+v", filtered)
+	}
+
+	filtered = filterGitHubEnterpriseTeams(teams, "", regexp.MustCompile(`-web$`))
+	if len(filtered) != 1 || filtered[0].Id != 2 {
+		t.Fatalf("regex filter = %+v", filtered)
+	}
+}
+
+func TestGitHubScimGroupDisplayNames(t *testing.T) {
+	t.Parallel()
+
+	requests := map[string]int{}
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		if got := r.URL.Query().Get("excludedAttributes"); got != "members" {
+			t.Errorf("excludedAttributes = %q, want members", got)
+		}
+
+		w.Header().Set("Content-Type", "application/scim+json")
+		switch r.URL.Path {
+		case "/scim/v2/enterprises/acme/Groups/g-1":
+			_, _ = w.Write([]byte(`{"id":"g-1","displayName":"Platform Engineers"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"detail":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+
+	teams := []githubEnterpriseTeamResponse{
+		{Id: 1, GroupId: "g-1"},
+		{Id: 2, GroupId: "g-1"},
+		{Id: 3, GroupId: "g-deleted"},
+		{Id: 4},
+	}
+	names, err := githubScimGroupDisplayNames(context.Background(), gh, "acme", teams)
+	if err != nil {
+		t.Fatalf("githubScimGroupDisplayNames error: %v", err)
+	}
+	if len(names) != 1 || names["g-1"] != "Platform Engineers" {
+		t.Fatalf("names = %v, want only g-1", names)
+	}
+	if requests["/scim/v2/enterprises/acme/Groups/g-1"] != 1 || len(requests) != 2 {
+		t.Fatalf("requests = %v, want one per linked group", requests)
+	}
+}
+
+func TestListGitHubEnterpriseTeams_ConditionalRequests(t *testing.T) {
+	t.Parallel()
+
+	var notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got != "25" {
+			t.Errorf("per_page = %q, want 25", got)
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`[{"id":1,"name":"platform-api","slug":"platform-api"}]`))
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+	gh.PageSize = 25
+	gh.ETags = client.NewGitHubETagCache()
+
+	for i := 0; i < 2; i++ {
+		teams, err := listGitHubEnterpriseTeams(context.Background(), gh, "acme")
+		if err != nil {
+			t.Fatalf("listGitHubEnterpriseTeams (read %d) error: %v", i+1, err)
+		}
+		if len(teams) != 1 || teams[0].Slug != "platform-api" {
+			t.Fatalf("read %d: teams = %+v", i+1, teams)
+		}
+	}
+	if got := notModified.Load(); got != 1 {
+		t.Fatalf("304 responses = %d, want 1", got)
+	}
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -177,17 +176,8 @@ func (d *githubScimGroupDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	bodyBytes, err := utils.ReadAndRestoreResponseBody(httpResp)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading SCIM Group",
-			fmt.Sprintf("Could not read response: %s", err),
-		)
-		return
-	}
-
 	var payload githubScimGroupListResponse
-	if err := json.Unmarshal(bodyBytes, &payload); err != nil {
+	if err := utils.DecodeResponseJSONInto(httpResp, &payload); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Parsing SCIM Group",
 			fmt.Sprintf("Could not parse response: %s", err),
@@ -234,6 +224,10 @@ func enterpriseScimGroupsPath(enterprise string) string {
 	return fmt.Sprintf("/scim/v2/enterprises/%s/Groups", url.PathEscape(strings.TrimSpace(enterprise)))
 }
 
+func enterpriseScimGroupPath(enterprise string, groupID string) string {
+	return fmt.Sprintf("%s/%s", enterpriseScimGroupsPath(enterprise), url.PathEscape(strings.TrimSpace(groupID)))
+}
+
 func escapeScimFilterValue(value string) string {
 	return strings.ReplaceAll(value, "\"", "\\\"")
 }
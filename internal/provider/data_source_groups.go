@@ -5,13 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"sort"
 	"strings"
 
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/mappers"
 	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -136,18 +136,8 @@ func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			continue
 		}
 
-		bodyBytes, readErr := io.ReadAll(cursor.HTTPResponse.Body)
-		_ = cursor.HTTPResponse.Body.Close()
-		if readErr != nil {
-			resp.Diagnostics.AddError(
-				"Error Reading Groups",
-				fmt.Sprintf("Could not read groups response: %s", readErr),
-			)
-			return
-		}
-
 		var rawResponse map[string]interface{}
-		if err := json.Unmarshal(bodyBytes, &rawResponse); err != nil {
+		if err := utils.DecodeResponseJSONInto(cursor.HTTPResponse, &rawResponse); err != nil {
 			resp.Diagnostics.AddError(
 				"Error Parsing Groups",
 				fmt.Sprintf("Could not parse groups response: %s", err),
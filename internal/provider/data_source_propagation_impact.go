@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ datasource.DataSource              = &propagationImpactDataSource{}
+	_ datasource.DataSourceWithConfigure = &propagationImpactDataSource{}
+)
+
+type propagationImpactDataSource struct {
+	client *client.Client
+}
+
+type propagationImpactDataSourceModel struct {
+	EnvironmentId           types.String                  `tfsdk:"environment_id"`
+	Rules                   []propagationImpactRuleModel  `tfsdk:"rules"`
+	Stores                  []propagationImpactStoreModel `tfsdk:"stores"`
+	ActivatedRules          types.List                    `tfsdk:"activated_rules"`
+	DeprovisionEnabledRules types.List                    `tfsdk:"deprovision_enabled_rules"`
+	CredentialChangedStores types.List                    `tfsdk:"credential_changed_stores"`
+	UnverifiedStores        types.List                    `tfsdk:"unverified_stores"`
+	HasRiskyChanges         types.Bool                    `tfsdk:"has_risky_changes"`
+	SummaryJSON             types.String                  `tfsdk:"summary_json"`
+}
+
+type propagationImpactRuleModel struct {
+	Key         types.String `tfsdk:"key"`
+	Id          types.String `tfsdk:"id"`
+	Active      types.Bool   `tfsdk:"active"`
+	Deprovision types.Bool   `tfsdk:"deprovision"`
+}
+
+type propagationImpactStoreModel struct {
+	Key     types.String `tfsdk:"key"`
+	Id      types.String `tfsdk:"id"`
+	Secrets types.Map    `tfsdk:"secrets"`
+}
+
+// propagationImpact is the provisioning impact of the planned rules and stores compared with
+// PingOne. Each list holds the `key` of the rules or stores concerned, sorted.
+type propagationImpact struct {
+	ActivatedRules          []string `json:"activated_rules"`
+	DeprovisionEnabledRules []string `json:"deprovision_enabled_rules"`
+	CredentialChangedStores []string `json:"credential_changed_stores"`
+	UnverifiedStores        []string `json:"unverified_stores"`
+	HasRiskyChanges         bool     `json:"has_risky_changes"`
+}
+
+func NewPropagationImpactDataSource() datasource.DataSource {
+	return &propagationImpactDataSource{}
+}
+
+func (d *propagationImpactDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_impact"
+}
+
+func (d *propagationImpactDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Summarizes the provisioning impact of planned rule and store values compared with PingOne: rules being activated, rules starting to deprovision, and stores whose credentials change. Read it in a `check` block to gate risky changes.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "The planned values of the rules to compare, usually taken from `pingoneprovisioning_propagation_rule` resources.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "A label for the rule in the results, for example its resource address.",
+							Required:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "The ID of the rule. Null, or an ID PingOne does not know, for a rule that does not exist yet.",
+							Optional:    true,
+						},
+						"active": schema.BoolAttribute{
+							Description: "Whether the rule is planned to be active.",
+							Optional:    true,
+						},
+						"deprovision": schema.BoolAttribute{
+							Description: "Whether the rule is planned to deprovision users.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"stores": schema.ListNestedAttribute{
+				Description: "The planned credentials of the stores to compare.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "A label for the store in the results, for example its resource address.",
+							Required:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "The ID of the store. Null for a store that does not exist yet.",
+							Optional:    true,
+						},
+						"secrets": schema.MapAttribute{
+							Description: "The planned credential values, keyed by PingOne configuration key or by configuration block attribute, for example `OAUTH_ACCESS_TOKEN` or `oauth_access_token`.",
+							Optional:    true,
+							Sensitive:   true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"activated_rules": schema.ListAttribute{
+				Description: "The keys of rules planned to be active that are inactive in PingOne or do not exist yet.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"deprovision_enabled_rules": schema.ListAttribute{
+				Description: "The keys of rules planned to deprovision users that do not deprovision in PingOne or do not exist yet.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"credential_changed_stores": schema.ListAttribute{
+				Description: "The keys of stores with a planned secret that differs from the value PingOne returns, and of stores that do not exist yet and have secrets.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"unverified_stores": schema.ListAttribute{
+				Description: "The keys of stores with a planned secret PingOne does not return, so it cannot be compared.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"has_risky_changes": schema.BoolAttribute{
+				Description: "Whether `activated_rules`, `deprovision_enabled_rules` or `credential_changed_stores` is not empty.",
+				Computed:    true,
+			},
+			"summary_json": schema.StringAttribute{
+				Description: "The results as a JSON object with the same keys, for run tasks and policies that read the plan JSON.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *propagationImpactDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationImpactDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationImpactDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+
+	tflog.Info(ctx, "Summarizing propagation impact", map[string]interface{}{
+		"environment_id": environmentID,
+		"rules":          len(state.Rules),
+		"stores":         len(state.Stores),
+	})
+
+	impact, err := summarizePropagationImpact(ctx, d.client.API, environmentID, state.Rules, state.Stores)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Summarizing Propagation Impact",
+			err.Error(),
+		)
+		return
+	}
+
+	summary, err := json.Marshal(impact)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Summarizing Propagation Impact",
+			fmt.Sprintf("Could not encode the summary: %s", err),
+		)
+		return
+	}
+
+	state.ActivatedRules, diags = types.ListValueFrom(ctx, types.StringType, impact.ActivatedRules)
+	resp.Diagnostics.Append(diags...)
+	state.DeprovisionEnabledRules, diags = types.ListValueFrom(ctx, types.StringType, impact.DeprovisionEnabledRules)
+	resp.Diagnostics.Append(diags...)
+	state.CredentialChangedStores, diags = types.ListValueFrom(ctx, types.StringType, impact.CredentialChangedStores)
+	resp.Diagnostics.Append(diags...)
+	state.UnverifiedStores, diags = types.ListValueFrom(ctx, types.StringType, impact.UnverifiedStores)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.HasRiskyChanges = types.BoolValue(impact.HasRiskyChanges)
+	state.SummaryJSON = types.StringValue(string(summary))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// summarizePropagationImpact compares the planned rules and stores with PingOne. Rules and
+// stores that PingOne does not know are treated as new.
+func summarizePropagationImpact(ctx context.Context, apiClient *management.APIClient, environmentID string, rules []propagationImpactRuleModel, stores []propagationImpactStoreModel) (propagationImpact, error) {
+	impact := propagationImpact{
+		ActivatedRules:          []string{},
+		DeprovisionEnabledRules: []string{},
+		CredentialChangedStores: []string{},
+		UnverifiedStores:        []string{},
+	}
+
+	for _, rule := range rules {
+		key := rule.Key.ValueString()
+		plannedActive := !rule.Active.IsNull() && !rule.Active.IsUnknown() && rule.Active.ValueBool()
+		plannedDeprovision := !rule.Deprovision.IsNull() && !rule.Deprovision.IsUnknown() && rule.Deprovision.ValueBool()
+		if !plannedActive && !plannedDeprovision {
+			continue
+		}
+
+		var ruleObj map[string]interface{}
+		if ruleID := rule.Id.ValueString(); ruleID != "" {
+			obj, httpResp, err := readPropagationRule(ctx, apiClient, environmentID, ruleID)
+			if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+				return impact, fmt.Errorf("could not read propagation rule %q (%s): %s", ruleID, key, err)
+			}
+			ruleObj = obj
+		}
+
+		liveActive, _ := utils.NestedBool(ruleObj, "active")
+		liveDeprovision, _ := utils.NestedBool(ruleObj, "deprovision")
+		if plannedActive && !liveActive {
+			impact.ActivatedRules = append(impact.ActivatedRules, key)
+		}
+		if plannedDeprovision && !liveDeprovision {
+			impact.DeprovisionEnabledRules = append(impact.DeprovisionEnabledRules, key)
+		}
+	}
+
+	for _, store := range stores {
+		key := store.Key.ValueString()
+		secrets := make(map[string]string)
+		for secretKey, value := range store.Secrets.Elements() {
+			if s, ok := value.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+				secrets[strings.ToUpper(secretKey)] = s.ValueString()
+			}
+		}
+		if len(secrets) == 0 {
+			continue
+		}
+
+		storeID := store.Id.ValueString()
+		if storeID == "" {
+			impact.CredentialChangedStores = append(impact.CredentialChangedStores, key)
+			continue
+		}
+
+		result, httpResp, err := apiClient.PropagationStoresApi.ReadOnePropagationStore(ctx, environmentID, storeID).Execute()
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				impact.CredentialChangedStores = append(impact.CredentialChangedStores, key)
+				continue
+			}
+			return impact, fmt.Errorf("could not read propagation store %q (%s): %s", storeID, key, utils.HandleSDKError(err, httpResp))
+		}
+
+		live := make(map[string]interface{})
+		for configKey, value := range result.GetConfiguration() {
+			live[strings.ToUpper(configKey)] = value
+		}
+
+		changed, unverified := false, false
+		for secretKey, planned := range secrets {
+			current, ok := live[secretKey].(string)
+			switch {
+			case !ok || current == "":
+				unverified = true
+			case current != planned:
+				changed = true
+			}
+		}
+		if changed {
+			impact.CredentialChangedStores = append(impact.CredentialChangedStores, key)
+		} else if unverified {
+			impact.UnverifiedStores = append(impact.UnverifiedStores, key)
+		}
+	}
+
+	sort.Strings(impact.ActivatedRules)
+	sort.Strings(impact.DeprovisionEnabledRules)
+	sort.Strings(impact.CredentialChangedStores)
+	sort.Strings(impact.UnverifiedStores)
+	impact.HasRiskyChanges = len(impact.ActivatedRules) > 0 || len(impact.DeprovisionEnabledRules) > 0 || len(impact.CredentialChangedStores) > 0
+
+	return impact, nil
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSummarizePropagationImpact(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, ""
+		switch r.URL.Path {
+		case "/v1/environments/env-id/propagation/rules/rule-inactive":
+			body = `{"id":"rule-inactive","active":false,"deprovision":false}`
+		case "/v1/environments/env-id/propagation/rules/rule-active":
+			body = `{"id":"rule-active","active":true,"deprovision":false}`
+		case "/v1/environments/env-id/propagation/stores/store-scim":
+			body = `{"id":"store-scim","type":"SCIM","configuration":{"SCIM_URL":"https://scim.example","OAUTH_ACCESS_TOKEN":"old-token"}}`
+		case "/v1/environments/env-id/propagation/stores/store-same":
+			body = `{"id":"store-same","type":"SCIM","configuration":{"OAUTH_ACCESS_TOKEN":"token"}}`
+		case "/v1/environments/env-id/propagation/stores/store-hidden":
+			body = `{"id":"store-hidden","type":"SCIM","configuration":{"SCIM_URL":"https://scim.example"}}`
+		default:
+			status, body = http.StatusNotFound, `{"code":"NOT_FOUND"}`
+		}
+
+		return testResponse(r, status, body), nil
+	})
+
+	secrets := func(key string, value string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{key: types.StringValue(value)})
+	}
+
+	rules := []propagationImpactRuleModel{
+		{Key: types.StringValue("rule.activated"), Id: types.StringValue("rule-inactive"), Active: types.BoolValue(true), Deprovision: types.BoolValue(false)},
+		{Key: types.StringValue("rule.deprovisioning"), Id: types.StringValue("rule-active"), Active: types.BoolValue(true), Deprovision: types.BoolValue(true)},
+		{Key: types.StringValue("rule.new"), Id: types.StringNull(), Active: types.BoolValue(true), Deprovision: types.BoolNull()},
+		{Key: types.StringValue("rule.deleted"), Id: types.StringValue("rule-gone"), Active: types.BoolValue(false), Deprovision: types.BoolValue(true)},
+	}
+	stores := []propagationImpactStoreModel{
+		{Key: types.StringValue("store.rotated"), Id: types.StringValue("store-scim"), Secrets: secrets("oauth_access_token", "new-token")},
+		{Key: types.StringValue("store.unchanged"), Id: types.StringValue("store-same"), Secrets: secrets("OAUTH_ACCESS_TOKEN", "token")},
+		{Key: types.StringValue("store.hidden"), Id: types.StringValue("store-hidden"), Secrets: secrets("OAUTH_ACCESS_TOKEN", "token")},
+		{Key: types.StringValue("store.new"), Id: types.StringNull(), Secrets: secrets("OAUTH_ACCESS_TOKEN", "token")},
+		{Key: types.StringValue("store.no_secrets"), Id: types.StringValue("store-scim"), Secrets: types.MapNull(types.StringType)},
+	}
+
+	got, err := summarizePropagationImpact(context.Background(), apiClient, "env-id", rules, stores)
+	if err != nil {
+		t.Fatalf("summarizePropagationImpact error: %v", err)
+	}
+
+	want := propagationImpact{
+		ActivatedRules:          []string{"rule.activated", "rule.new"},
+		DeprovisionEnabledRules: []string{"rule.deleted", "rule.deprovisioning"},
+		CredentialChangedStores: []string{"store.new", "store.rotated"},
+		UnverifiedStores:        []string{"store.hidden"},
+		HasRiskyChanges:         true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("impact = %+v, want %+v", got, want)
+	}
+}
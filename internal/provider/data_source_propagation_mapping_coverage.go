@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ datasource.DataSource              = &propagationMappingCoverageDataSource{}
+	_ datasource.DataSourceWithConfigure = &propagationMappingCoverageDataSource{}
+)
+
+// requiredTargetAttributes lists the attributes each target store type needs on every
+// provisioned user, keyed by the lower-cased store type. Store types that are not listed have
+// no built-in requirements.
+var requiredTargetAttributes = map[string][]string{
+	"githubemu": {"userName"},
+	"salesforce": {
+		"Alias", "Email", "EmailEncodingKey", "LanguageLocaleKey", "LastName", "LocaleSidKey",
+		"ProfileId", "TimeZoneSidKey", "Username",
+	},
+	"salesforcecontacts": {"LastName"},
+	"scim":               {"userName"},
+	"slack":              {"userName"},
+}
+
+type propagationMappingCoverageDataSource struct {
+	client *client.Client
+}
+
+type propagationMappingCoverageDataSourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	EnvironmentId      types.String `tfsdk:"environment_id"`
+	RuleId             types.String `tfsdk:"rule_id"`
+	RequiredAttributes types.List   `tfsdk:"required_attributes"`
+	TargetStoreId      types.String `tfsdk:"target_store_id"`
+	TargetStoreType    types.String `tfsdk:"target_store_type"`
+	MappedAttributes   types.List   `tfsdk:"mapped_attributes"`
+	MissingAttributes  types.List   `tfsdk:"missing_attributes"`
+	Complete           types.Bool   `tfsdk:"complete"`
+}
+
+func NewPropagationMappingCoverageDataSource() datasource.DataSource {
+	return &propagationMappingCoverageDataSource{}
+}
+
+func (d *propagationMappingCoverageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_mapping_coverage"
+}
+
+func (d *propagationMappingCoverageDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports which attributes a rule's target store requires that none of the rule's enabled mappings set. Read it in a `check` block to fail CI when a rule misses a required mapping.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the propagation rule.",
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"rule_id": schema.StringAttribute{
+				Description: "The ID of the propagation rule to check.",
+				Required:    true,
+			},
+			"required_attributes": schema.ListAttribute{
+				Description: "The target attributes the rule must map. Defaults to the attributes the target store's type requires, for the types that have built-in requirements: `GithubEMU`, `Salesforce`, `SalesforceContacts`, `SCIM` and `Slack`.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"target_store_id": schema.StringAttribute{
+				Description: "The ID of the rule's target store.",
+				Computed:    true,
+			},
+			"target_store_type": schema.StringAttribute{
+				Description: "The type of the rule's target store.",
+				Computed:    true,
+			},
+			"mapped_attributes": schema.ListAttribute{
+				Description: "The target attributes set by the rule's enabled mappings, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"missing_attributes": schema.ListAttribute{
+				Description: "The required attributes that no enabled mapping sets, in the order of `required_attributes`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"complete": schema.BoolAttribute{
+				Description: "Whether every required attribute is mapped.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *propagationMappingCoverageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationMappingCoverageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationMappingCoverageDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	ruleID := strings.TrimSpace(state.RuleId.ValueString())
+	apiClient := d.client.API
+
+	ruleObj, _, err := readPropagationRule(ctx, apiClient, environmentID, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Rule",
+			fmt.Sprintf("Could not read propagation rule '%s': %s", ruleID, err),
+		)
+		return
+	}
+	targetStoreID, _ := utils.NestedString(ruleObj, "targetStore", "id")
+
+	storeType, err := readPropagationStoreType(ctx, apiClient, environmentID, targetStoreID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Store",
+			fmt.Sprintf("Could not read target store '%s' of propagation rule '%s': %s", targetStoreID, ruleID, err),
+		)
+		return
+	}
+
+	required := []string{}
+	if !state.RequiredAttributes.IsNull() && !state.RequiredAttributes.IsUnknown() {
+		resp.Diagnostics.Append(state.RequiredAttributes.ElementsAs(ctx, &required, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if builtIn, ok := requiredTargetAttributes[strings.ToLower(storeType)]; ok {
+		required = builtIn
+	} else {
+		resp.Diagnostics.AddWarning(
+			"No Required Attributes Known",
+			fmt.Sprintf("The provider has no built-in required attributes for %s stores, so every rule targeting them is reported complete. Set `required_attributes` to check the rule's mappings.", storeType),
+		)
+	}
+
+	mappings, err := listPropagationRuleMappings(ctx, d.client.Endpoints, apiClient, environmentID, ruleID, d.client.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Mappings",
+			fmt.Sprintf("Could not list the mappings of propagation rule '%s': %s", ruleID, err),
+		)
+		return
+	}
+	mapped, missing := propagationMappingCoverage(required, mappings)
+
+	state.Id = types.StringValue(ruleID)
+	state.TargetStoreId = types.StringValue(targetStoreID)
+	state.TargetStoreType = types.StringValue(storeType)
+	state.RequiredAttributes, diags = types.ListValueFrom(ctx, types.StringType, required)
+	resp.Diagnostics.Append(diags...)
+	state.MappedAttributes, diags = types.ListValueFrom(ctx, types.StringType, mapped)
+	resp.Diagnostics.Append(diags...)
+	state.MissingAttributes, diags = types.ListValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Complete = types.BoolValue(len(missing) == 0)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// propagationMappingCoverage returns the target attributes set by enabled mappings, sorted, and
+// the required attributes none of them sets. Attribute names are compared case-insensitively.
+func propagationMappingCoverage(required []string, mappings []map[string]interface{}) ([]string, []string) {
+	seen := make(map[string]bool)
+	mapped := []string{}
+	for _, m := range mappings {
+		if !mappingEnabledFromAPI(m) {
+			continue
+		}
+		target, _ := utils.NestedString(m, "targetAttribute")
+		target = strings.TrimSpace(target)
+		if target == "" || seen[strings.ToLower(target)] {
+			continue
+		}
+		seen[strings.ToLower(target)] = true
+		mapped = append(mapped, target)
+	}
+	sort.Strings(mapped)
+
+	missing := []string{}
+	for _, name := range required {
+		if !seen[strings.ToLower(strings.TrimSpace(name))] {
+			missing = append(missing, name)
+		}
+	}
+	return mapped, missing
+}
+
+// readPropagationStoreType returns the type of a store as PingOne reports it. The type is read
+// from the raw response, since the SDK can fail to decode stores with newer types.
+func readPropagationStoreType(ctx context.Context, apiClient *management.APIClient, environmentID string, storeID string) (string, error) {
+	_, httpResp, err := apiClient.PropagationStoresApi.
+		ReadOnePropagationStore(ctx, environmentID, storeID).
+		Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		return "", fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
+	}
+
+	storeObj, err := utils.DecodeResponseJSONObject(httpResp, "store")
+	if err != nil {
+		return "", err
+	}
+
+	storeType, _ := utils.NestedString(storeObj, "type")
+	return storeType, nil
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPropagationMappingCoverage(t *testing.T) {
+	t.Parallel()
+
+	mappings := []map[string]interface{}{
+		{"sourceAttribute": "username", "targetAttribute": "Username"},
+		{"sourceAttribute": "email", "targetAttribute": "email"},
+		{"sourceAttribute": "email", "targetAttribute": "Email"},
+		{"sourceAttribute": "name.family", "targetAttribute": "LastName", "enabled": false},
+		{"expression": `"00e000000000001"`, "targetAttribute": " ProfileId "},
+	}
+
+	mapped, missing := propagationMappingCoverage(requiredTargetAttributes["salesforce"], mappings)
+	if want := []string{"ProfileId", "Username", "email"}; !reflect.DeepEqual(mapped, want) {
+		t.Fatalf("mapped = %v, want %v", mapped, want)
+	}
+	want := []string{"Alias", "EmailEncodingKey", "LanguageLocaleKey", "LastName", "LocaleSidKey", "TimeZoneSidKey"}
+	if !reflect.DeepEqual(missing, want) {
+		t.Fatalf("missing = %v, want %v", missing, want)
+	}
+
+	if _, missing := propagationMappingCoverage(nil, nil); missing == nil || len(missing) != 0 {
+		t.Fatalf("missing without requirements = %#v, want an empty list", missing)
+	}
+}
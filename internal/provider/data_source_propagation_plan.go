@@ -53,6 +53,14 @@ func (d *propagationPlanDataSource) Schema(_ context.Context, req datasource.Sch
 				Description: "Status of the propagation plan.",
 				Computed:    true,
 			},
+			"rule_count": schema.Int64Attribute{
+				Description: "Number of propagation rules in the plan. Null when the rules could not be counted.",
+				Computed:    true,
+			},
+			"lifecycle_status": schema.StringAttribute{
+				Description: "A summary of the plan's readiness from `status` and `rule_count`: `INACTIVE`, `CONFIGURED_NO_RULES` when the plan has no rules, or `ACTIVE`.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -183,49 +191,33 @@ func (d *propagationPlanDataSource) Read(ctx context.Context, req datasource.Rea
 			"name":           targetName,
 		})
 
-		iterator := apiClient.IdentityPropagationPlansApi.ReadAllPlans(ctx, environmentID).Execute()
+		plans, err := listPropagationPlans(ctx, d.client.Endpoints, apiClient, environmentID, d.client.PageSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Plans",
+				fmt.Sprintf("Could not list propagation plans: %s", err),
+			)
+			return
+		}
 
 		var matches []customtypes.PropagationPlanModel
 
-		for cursor, iterErr := range iterator {
-			if iterErr != nil {
-				resp.Diagnostics.AddError(
-					"Error Reading Propagation Plans",
-					fmt.Sprintf("Could not iterate propagation plans: %s", iterErr),
-				)
-				return
-			}
-			if cursor.EntityArray == nil {
+		for _, p := range plans {
+			if p.GetName() != targetName {
 				continue
 			}
 
-			embedded, ok := cursor.EntityArray.GetEmbeddedOk()
-			if !ok || embedded == nil {
-				continue
+			model := customtypes.PropagationPlanModel{
+				Id:            types.StringValue(p.GetId()),
+				EnvironmentId: types.StringValue(environmentID),
+				Name:          types.StringValue(p.GetName()),
+				Status:        types.StringNull(),
 			}
-
-			plans, ok := embedded.GetPlansOk()
-			if !ok {
-				continue
+			if v, ok := p.GetStatusOk(); ok && v != nil {
+				model.Status = types.StringValue(string(*v))
 			}
 
-			for _, p := range plans {
-				if p.GetName() != targetName {
-					continue
-				}
-
-				model := customtypes.PropagationPlanModel{
-					Id:            types.StringValue(p.GetId()),
-					EnvironmentId: types.StringValue(environmentID),
-					Name:          types.StringValue(p.GetName()),
-					Status:        types.StringNull(),
-				}
-				if v, ok := p.GetStatusOk(); ok && v != nil {
-					model.Status = types.StringValue(string(*v))
-				}
-
-				matches = append(matches, model)
-			}
+			matches = append(matches, model)
 		}
 
 		if len(matches) == 0 {
@@ -249,6 +241,9 @@ func (d *propagationPlanDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
+	state.RuleCount = propagationPlanRuleCount(ctx, d.client.Endpoints, apiClient, environmentID, state.Id.ValueString())
+	state.LifecycleStatus = propagationPlanLifecycleStatus(&state)
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ datasource.DataSource              = &propagationRevisionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &propagationRevisionsDataSource{}
+)
+
+// defaultPropagationRevisionsLimit is how many revisions the data source returns when `limit`
+// is not set.
+const defaultPropagationRevisionsLimit = 10
+
+type propagationRevisionsDataSource struct {
+	client *client.Client
+}
+
+type propagationRevisionsDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	Limit         types.Int64  `tfsdk:"limit"`
+	Revisions     types.List   `tfsdk:"revisions"`
+}
+
+type propagationRevisionModel struct {
+	Id                 types.String `tfsdk:"id"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	Author             types.String `tfsdk:"author"`
+	PreviousRevisionId types.String `tfsdk:"previous_revision_id"`
+	Summary            types.String `tfsdk:"summary"`
+}
+
+var propagationRevisionAttrTypes = map[string]attr.Type{
+	"id":                   types.StringType,
+	"created_at":           types.StringType,
+	"author":               types.StringType,
+	"previous_revision_id": types.StringType,
+	"summary":              types.StringType,
+}
+
+func NewPropagationRevisionsDataSource() datasource.DataSource {
+	return &propagationRevisionsDataSource{}
+}
+
+func (d *propagationRevisionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_revisions"
+}
+
+func (d *propagationRevisionsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists an environment's most recent propagation revisions, newest first, for audit reports.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of revisions to return, from 1 to 100. Defaults to %d.", defaultPropagationRevisionsLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"revisions": schema.ListNestedAttribute{
+				Description: "The revisions, newest first. Empty when the environment has none.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The revision ID.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "When the revision was created, as PingOne reports it.",
+							Computed:    true,
+						},
+						"author": schema.StringAttribute{
+							Description: "Who created the revision, as PingOne reports it. Null when PingOne does not report it.",
+							Computed:    true,
+						},
+						"previous_revision_id": schema.StringAttribute{
+							Description: "The ID of the revision before this one. Null for the first revision.",
+							Computed:    true,
+						},
+						"summary": schema.StringAttribute{
+							Description: "PingOne's summary of what changed in the revision. Null when PingOne does not provide one.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *propagationRevisionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationRevisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationRevisionsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	limit := defaultPropagationRevisionsLimit
+	if !state.Limit.IsNull() && !state.Limit.IsUnknown() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	revisions, err := listPropagationRevisions(ctx, d.client.API, environmentID, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Revisions",
+			fmt.Sprintf("Could not read the propagation revisions of environment '%s': %s", environmentID, err),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(environmentID)
+	state.Revisions, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: propagationRevisionAttrTypes}, revisions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listPropagationRevisions returns up to limit revisions, newest first. PingOne has no operation
+// that lists revisions, so the latest revision is read and each revision's previous revision
+// after it.
+func listPropagationRevisions(ctx context.Context, apiClient *management.APIClient, environmentID string, limit int) ([]propagationRevisionModel, error) {
+	revisions := []propagationRevisionModel{}
+
+	httpResp, err := apiClient.PropagationRevisionsApi.
+		EnvironmentsEnvironmentIDPropagationRevisionsIdlatestGet(ctx, environmentID).
+		Execute()
+	for len(revisions) < limit {
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				// No revisions yet, or the previous revision no longer exists.
+				return revisions, nil
+			}
+			return nil, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
+		}
+
+		revisionObj, err := utils.DecodeResponseJSONObject(httpResp, "revision")
+		if err != nil {
+			return nil, err
+		}
+		revision := propagationRevisionFromAPI(revisionObj)
+		for _, seen := range revisions {
+			if seen.Id.Equal(revision.Id) {
+				return nil, fmt.Errorf("revision '%s' is its own ancestor", revision.Id.ValueString())
+			}
+		}
+		revisions = append(revisions, revision)
+
+		if revision.PreviousRevisionId.IsNull() {
+			break
+		}
+		httpResp, err = apiClient.PropagationRevisionsApi.
+			EnvironmentsEnvironmentIDPropagationRevisionsPreviousRevisionIDGet(ctx, environmentID, revision.PreviousRevisionId.ValueString()).
+			Execute()
+	}
+
+	return revisions, nil
+}
+
+func propagationRevisionFromAPI(revisionObj map[string]interface{}) propagationRevisionModel {
+	id, _ := utils.NestedString(revisionObj, "id")
+	createdAt, _ := utils.NestedString(revisionObj, "createdAt")
+
+	return propagationRevisionModel{
+		Id:                 types.StringValue(id),
+		CreatedAt:          types.StringValue(createdAt),
+		Author:             firstNestedString(revisionObj, []string{"author"}, []string{"createdBy", "username"}, []string{"createdBy", "id"}),
+		PreviousRevisionId: firstNestedString(revisionObj, []string{"previousRevision", "id"}, []string{"previousRevisionId"}),
+		Summary:            firstNestedString(revisionObj, []string{"summary"}, []string{"description"}),
+	}
+}
+
+// firstNestedString returns the first non-empty string at one of paths, or null when none is
+// set.
+func firstNestedString(obj map[string]interface{}, paths ...[]string) types.String {
+	for _, p := range paths {
+		if v, ok := utils.NestedString(obj, p...); ok && v != "" {
+			return types.StringValue(v)
+		}
+	}
+	return types.StringNull()
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+func TestListPropagationRevisions(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(t *testing.T, revisions map[string]string) *management.APIClient {
+		t.Helper()
+
+		return newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+			id := strings.TrimPrefix(r.URL.Path, "/v1/environments/env-id/propagation/revisions/")
+			status, body := http.StatusOK, revisions[id]
+			if body == "" {
+				status, body = http.StatusNotFound, `{"code":"NOT_FOUND","message":"not found"}`
+			}
+			return testResponse(r, status, body), nil
+		})
+	}
+
+	apiClient := newClient(t, map[string]string{
+		"id:latest": `{"id":"rev-3","createdAt":"2026-10-03T00:00:00Z","createdBy":{"id":"user-1"},"previousRevision":{"id":"rev-2"},"summary":"Updated 1 rule"}`,
+		"rev-2":     `{"id":"rev-2","createdAt":"2026-10-02T00:00:00Z","previousRevision":{"id":"rev-1"}}`,
+		"rev-1":     `{"id":"rev-1","createdAt":"2026-10-01T00:00:00Z"}`,
+	})
+
+	got, err := listPropagationRevisions(context.Background(), apiClient, "env-id", 10)
+	if err != nil {
+		t.Fatalf("listPropagationRevisions: %v", err)
+	}
+	if len(got) != 3 || got[0].Id.ValueString() != "rev-3" || got[2].Id.ValueString() != "rev-1" {
+		t.Fatalf("revisions = %+v, want rev-3, rev-2, rev-1", got)
+	}
+	if got[0].Author.ValueString() != "user-1" || got[0].Summary.ValueString() != "Updated 1 rule" {
+		t.Fatalf("latest revision = %+v", got[0])
+	}
+	if !got[1].Author.IsNull() || !got[1].Summary.IsNull() || !got[2].PreviousRevisionId.IsNull() {
+		t.Fatalf("unreported fields should be null: %+v", got)
+	}
+
+	if got, err := listPropagationRevisions(context.Background(), apiClient, "env-id", 2); err != nil || len(got) != 2 {
+		t.Fatalf("with limit 2: %d revisions, err %v; want 2", len(got), err)
+	}
+
+	if got, err := listPropagationRevisions(context.Background(), newClient(t, nil), "env-id", 10); err != nil || len(got) != 0 {
+		t.Fatalf("without revisions: %v, err %v; want none", got, err)
+	}
+}
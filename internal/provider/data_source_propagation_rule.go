@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"strings"
 
@@ -59,6 +60,10 @@ func (d *propagationRuleDataSource) Schema(_ context.Context, req datasource.Sch
 				Optional:    true,
 				Computed:    true,
 			},
+			"description": schema.StringAttribute{
+				Description: "The description of the propagation rule.",
+				Computed:    true,
+			},
 			"source_store_id": schema.StringAttribute{
 				Description: "The source store ID for the propagation rule.",
 				Computed:    true,
@@ -79,21 +84,50 @@ func (d *propagationRuleDataSource) Schema(_ context.Context, req datasource.Sch
 				Description: "Whether to deprovision users in the target store when they are removed from the source.",
 				Computed:    true,
 			},
-			"population_ids": schema.ListAttribute{
-				Description: "List of population IDs in scope for this rule.",
+			"population_ids": schema.SetAttribute{
+				Description: "Set of population IDs in scope for this rule.",
 				Computed:    true,
 				ElementType: types.StringType,
 			},
-			"group_ids": schema.ListAttribute{
-				Description: "List of group IDs in scope for group provisioning.",
+			"population_match": schema.StringAttribute{
+				Description: "How the rule's population expression combines `population_ids`: `any` when they are joined with `or`, `all` when they are joined with `and`. Null when the rule has no populations.",
+				Computed:    true,
+			},
+			"group_ids": schema.SetAttribute{
+				Description: "Set of group IDs in scope for group provisioning.",
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"links": schema.MapAttribute{
+				Description: linksAttributeDescription,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"mapping_count": schema.Int64Attribute{
+				Description: "Number of attribute mappings on the rule.",
+				Computed:    true,
+			},
 			"configuration": schema.MapAttribute{
 				Description: "Rule configuration map (for example, `MFA_USER_DEVICE_MANAGEMENT`).",
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"correlation_attributes": schema.ListNestedAttribute{
+				Description: "Attribute pairs PingOne uses to match a user to a user that already exists in the target store. Null when the rule uses PingOne's default matching.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_attribute": schema.StringAttribute{
+							Description: "The PingOne user attribute to match on.",
+							Computed:    true,
+						},
+						"target_attribute": schema.StringAttribute{
+							Description: "The target store attribute compared to `source_attribute`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"mappings": schema.ListNestedAttribute{
 				Description: "List of attribute mappings for this rule.",
 				Computed:    true,
@@ -115,6 +149,16 @@ func (d *propagationRuleDataSource) Schema(_ context.Context, req datasource.Sch
 							Description: "Expression used to compute the target attribute value.",
 							Computed:    true,
 						},
+						"sensitive_expression": schema.StringAttribute{
+							Description: "Always null. The data source cannot tell which expressions hold secrets, so every expression is reported in `expression`.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"expression_builder": mappingExpressionBuilderDataSourceSchema(),
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the mapping is applied. Disabled mappings stay on the rule without being applied.",
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -246,7 +290,7 @@ func (d *propagationRuleDataSource) Read(ctx context.Context, req datasource.Rea
 			"name":           targetName,
 		})
 
-		rules, err := listPropagationRules(ctx, apiClient, environmentID, targetPlanID)
+		rules, err := listPropagationRules(ctx, d.client.Endpoints, apiClient, environmentID, targetPlanID, d.client.PageSize)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Reading Propagation Rules",
@@ -255,18 +299,7 @@ func (d *propagationRuleDataSource) Read(ctx context.Context, req datasource.Rea
 			return
 		}
 
-		var matches []string
-		for _, rule := range rules {
-			name, _ := utils.NestedString(rule, "name")
-			if name != targetName {
-				continue
-			}
-			id, _ := utils.NestedString(rule, "id")
-			if id != "" {
-				matches = append(matches, id)
-			}
-		}
-
+		matches := propagationRuleIDsByName(rules, targetName)
 		if len(matches) == 0 {
 			resp.Diagnostics.AddError(
 				"Propagation Rule Not Found",
@@ -287,7 +320,7 @@ func (d *propagationRuleDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	ruleObj, httpResp, err := readPropagationRuleDataSource(ctx, apiClient, environmentID, ruleID)
+	ruleObj, httpResp, err := readPropagationRule(ctx, apiClient, environmentID, ruleID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Propagation Rule",
@@ -307,8 +340,13 @@ func (d *propagationRuleDataSource) Read(ctx context.Context, req datasource.Rea
 	state.Id = types.StringValue(ruleID)
 
 	applyRuleAPIToStateDataSource(ruleObj, &state)
+	state.CorrelationAttributes, diags = correlationAttributesFromAPI(ctx, ruleObj)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	mappings, err := readPropagationRuleMappings(ctx, apiClient, environmentID, ruleID)
+	mappings, err := readPropagationRuleMappings(ctx, propagationServiceFor(d.client, apiClient), environmentID, ruleID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Propagation Rule Mappings",
@@ -317,74 +355,42 @@ func (d *propagationRuleDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 	state.Mappings = mappings
+	state.MappingCount = types.Int64Value(int64(len(mappings)))
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
-func listPropagationRules(ctx context.Context, apiClient *management.APIClient, environmentID string, planID string) ([]map[string]interface{}, error) {
-	var httpResp *http.Response
-	var err error
-
+func listPropagationRules(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, planID string, pageSize int32) ([]map[string]interface{}, error) {
 	if planID != "" {
-		httpResp, err = apiClient.PropagationRulesApi.
-			EnvironmentsEnvironmentIDPropagationPlansPlanIDRulesGet(ctx, environmentID, planID).
-			Execute()
-	} else {
-		httpResp, err = apiClient.PropagationRulesApi.
-			EnvironmentsEnvironmentIDPropagationRulesGet(ctx, environmentID).
-			Execute()
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
-	}
-
-	decoded, err := utils.DecodeResponseJSON(httpResp)
-	if err != nil {
-		return nil, err
+		return listPropagationRulesForPlan(ctx, endpoints, apiClient, environmentID, planID, pageSize)
 	}
 
-	list, err := utils.ExtractEmbeddedArray(decoded, "rules", "items")
-	if err != nil {
-		if arr, ok := decoded.([]interface{}); ok {
-			list = arr
-		} else {
-			return nil, err
-		}
-	}
+	return listPingOneCollection(
+		ctx,
+		endpoints,
+		apiClient,
+		endpointFamilyPropagationRules,
+		fmt.Sprintf("/environments/%s/propagation/rules", url.PathEscape(environmentID)),
+		pageSize,
+		"rules", "items",
+	)
+}
 
-	var rules []map[string]interface{}
-	for _, v := range list {
-		m, ok := v.(map[string]interface{})
-		if !ok {
+// propagationRuleIDsByName returns the IDs of the listed rules named name exactly.
+func propagationRuleIDsByName(rules []map[string]interface{}, name string) []string {
+	var matches []string
+	for _, rule := range rules {
+		ruleName, _ := utils.NestedString(rule, "name")
+		if ruleName != name {
 			continue
 		}
-		rules = append(rules, m)
-	}
-
-	return rules, nil
-}
-
-func readPropagationRuleDataSource(ctx context.Context, apiClient *management.APIClient, environmentID string, ruleID string) (map[string]interface{}, *http.Response, error) {
-	httpResp, err := apiClient.PropagationRulesApi.
-		EnvironmentsEnvironmentIDPropagationRulesRuleIDGet(ctx, environmentID, ruleID).
-		Execute()
-	if err != nil {
-		return nil, httpResp, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
-	}
-
-	decoded, err := utils.DecodeResponseJSON(httpResp)
-	if err != nil {
-		return nil, httpResp, err
-	}
-
-	ruleObj, ok := decoded.(map[string]interface{})
-	if !ok {
-		return nil, httpResp, fmt.Errorf("unexpected rule response shape")
+		id, _ := utils.NestedString(rule, "id")
+		if id != "" {
+			matches = append(matches, id)
+		}
 	}
-
-	return ruleObj, httpResp, nil
+	return matches
 }
 
 func applyRuleAPIToStateDataSource(apiObj map[string]interface{}, state *customtypes.PropagationRuleModel) {
@@ -394,12 +400,16 @@ func applyRuleAPIToStateDataSource(apiObj map[string]interface{}, state *customt
 	if name, ok := utils.NestedString(apiObj, "name"); ok && name != "" {
 		state.Name = types.StringValue(name)
 	}
+	if description, ok := utils.NestedString(apiObj, "description"); ok && description != "" {
+		state.Description = types.StringValue(description)
+	}
 	if srcID, ok := utils.NestedString(apiObj, "sourceStore", "id"); ok && srcID != "" {
 		state.SourceStoreId = types.StringValue(srcID)
 	}
 	if tgtID, ok := utils.NestedString(apiObj, "targetStore", "id"); ok && tgtID != "" {
 		state.TargetStoreId = types.StringValue(tgtID)
 	}
+	state.Links = linksFromJSON(apiObj)
 
 	if v, ok := apiObj["populationExpression"]; ok {
 		if s, ok := v.(string); ok {
@@ -449,10 +459,12 @@ func applyRuleAPIToStateDataSource(apiObj map[string]interface{}, state *customt
 	}
 	sort.Strings(populationIDs)
 	if len(populationIDs) > 0 {
-		listVal, _ := types.ListValueFrom(context.Background(), types.StringType, populationIDs)
-		state.PopulationIds = listVal
+		setVal, _ := types.SetValueFrom(context.Background(), types.StringType, populationIDs)
+		state.PopulationIds = setVal
+		state.PopulationMatch = types.StringValue(populationMatchFromExpression(state.Filter.ValueString()))
 	} else {
-		state.PopulationIds = types.ListNull(types.StringType)
+		state.PopulationIds = types.SetNull(types.StringType)
+		state.PopulationMatch = types.StringNull()
 	}
 
 	var groupIDs []string
@@ -469,81 +481,41 @@ func applyRuleAPIToStateDataSource(apiObj map[string]interface{}, state *customt
 	}
 	sort.Strings(groupIDs)
 	if len(groupIDs) > 0 {
-		listVal, _ := types.ListValueFrom(context.Background(), types.StringType, groupIDs)
-		state.GroupIds = listVal
+		setVal, _ := types.SetValueFrom(context.Background(), types.StringType, groupIDs)
+		state.GroupIds = setVal
 	} else {
-		state.GroupIds = types.ListNull(types.StringType)
+		state.GroupIds = types.SetNull(types.StringType)
 	}
 }
 
-func readPropagationRuleMappings(ctx context.Context, apiClient *management.APIClient, environmentID string, ruleID string) ([]customtypes.PropagationRuleMappingModel, error) {
-	httpResp, err := apiClient.PropagationMappingsApi.
-		EnvironmentsEnvironmentIDPropagationRulesRuleIDMappingsGet(ctx, environmentID, ruleID).
-		Execute()
-	if err != nil {
-		return nil, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
-	}
-
-	decoded, err := utils.DecodeResponseJSON(httpResp)
+func readPropagationRuleMappings(ctx context.Context, service propagationService, environmentID string, ruleID string) ([]customtypes.PropagationRuleMappingModel, error) {
+	list, err := service.MappingList(ctx, environmentID, ruleID)
 	if err != nil {
 		return nil, err
 	}
 
-	list, err := utils.ExtractEmbeddedArray(decoded, "mappings", "items")
-	if err != nil {
-		if arr, ok := decoded.([]interface{}); ok {
-			list = arr
-		} else {
-			return nil, err
-		}
-	}
-
 	type rawMapping struct {
 		id         string
 		source     string
 		target     string
 		expression string
+		enabled    bool
 		key        string
 	}
 
 	var raw []rawMapping
-	for _, v := range list {
-		m, ok := v.(map[string]interface{})
-		if !ok {
-			continue
-		}
+	for _, m := range list {
 		id, _ := utils.NestedString(m, "id")
 		source, _ := utils.NestedString(m, "sourceAttribute")
-		if source == "" {
-			if v, ok := m["source_attribute"].(string); ok {
-				source = v
-			}
-		}
 		target, _ := utils.NestedString(m, "targetAttribute")
-		if target == "" {
-			if v, ok := m["target_attribute"].(string); ok {
-				target = v
-			}
-		}
 		expression, _ := utils.NestedString(m, "expression")
 
 		source = strings.TrimSpace(source)
 		target = strings.TrimSpace(target)
 		expression = strings.TrimSpace(expression)
 
-		if target == "" {
-			continue
-		}
-
-		key := ""
-		switch {
-		case expression != "" && source == "":
-			key = "expr:" + target + "->" + expression
-		case source != "" && expression == "":
-			key = "src:" + source + "->" + target
-		case source != "" && expression != "":
-			key = "src_expr:" + source + "->" + target + "->" + expression
-		default:
+		key := mappingKey(source, target, expression)
+		if key == "" {
 			continue
 		}
 
@@ -552,6 +524,7 @@ func readPropagationRuleMappings(ctx context.Context, apiClient *management.APIC
 			source:     source,
 			target:     target,
 			expression: expression,
+			enabled:    mappingEnabledFromAPI(m),
 			key:        key,
 		})
 	}
@@ -563,8 +536,10 @@ func readPropagationRuleMappings(ctx context.Context, apiClient *management.APIC
 	var mappings []customtypes.PropagationRuleMappingModel
 	for _, m := range raw {
 		model := customtypes.PropagationRuleMappingModel{
-			Id:              types.StringValue(m.id),
-			TargetAttribute: types.StringValue(m.target),
+			Id:                  types.StringValue(m.id),
+			TargetAttribute:     types.StringValue(m.target),
+			SensitiveExpression: types.StringNull(),
+			Enabled:             types.BoolValue(m.enabled),
 		}
 		if m.source != "" {
 			model.SourceAttribute = types.StringValue(m.source)
@@ -573,6 +548,7 @@ func readPropagationRuleMappings(ctx context.Context, apiClient *management.APIC
 		}
 		if m.expression != "" {
 			model.Expression = types.StringValue(m.expression)
+			model.ExpressionBuilder = decompileMappingExpression(m.expression)
 		} else {
 			model.Expression = types.StringNull()
 		}
@@ -582,3 +558,12 @@ func readPropagationRuleMappings(ctx context.Context, apiClient *management.APIC
 
 	return mappings, nil
 }
+
+// populationMatchFromExpression reports whether a population expression joins its population
+// clauses with `and` rather than `or`.
+func populationMatchFromExpression(expression string) string {
+	if strings.Contains(expression, `" and population.id eq "`) {
+		return populationMatchAll
+	}
+	return populationMatchAny
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &propagationRuleMappingDataSource{}
+	_ datasource.DataSourceWithConfigure = &propagationRuleMappingDataSource{}
+)
+
+type propagationRuleMappingDataSource struct {
+	client *client.Client
+}
+
+type propagationRuleMappingDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	EnvironmentId   types.String `tfsdk:"environment_id"`
+	RuleId          types.String `tfsdk:"rule_id"`
+	TargetAttribute types.String `tfsdk:"target_attribute"`
+	SourceAttribute types.String `tfsdk:"source_attribute"`
+	Expression      types.String `tfsdk:"expression"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+}
+
+func NewPropagationRuleMappingDataSource() datasource.DataSource {
+	return &propagationRuleMappingDataSource{}
+}
+
+func (d *propagationRuleMappingDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_rule_mapping"
+}
+
+func (d *propagationRuleMappingDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up the mapping of a propagation rule that sets a given target attribute. The read fails unless exactly one mapping sets it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The mapping ID.",
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"rule_id": schema.StringAttribute{
+				Description: "The ID of the propagation rule.",
+				Required:    true,
+			},
+			"target_attribute": schema.StringAttribute{
+				Description: "The target attribute the mapping sets. Compared case-insensitively.",
+				Required:    true,
+			},
+			"source_attribute": schema.StringAttribute{
+				Description: "Source attribute expression. Null when the mapping uses an expression.",
+				Computed:    true,
+			},
+			"expression": schema.StringAttribute{
+				Description: "Expression used to compute the target attribute value. Null when the mapping copies a source attribute.",
+				Computed:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the mapping is applied. Disabled mappings stay on the rule without being applied.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *propagationRuleMappingDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationRuleMappingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationRuleMappingDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	ruleID := strings.TrimSpace(state.RuleId.ValueString())
+	targetAttribute := state.TargetAttribute.ValueString()
+
+	mappings, err := listPropagationRuleMappings(ctx, d.client.Endpoints, d.client.API, environmentID, ruleID, d.client.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Mappings",
+			fmt.Sprintf("Could not list the mappings of propagation rule '%s': %s", ruleID, err),
+		)
+		return
+	}
+
+	matches := propagationRuleMappingsByTarget(mappings, targetAttribute)
+	if len(matches) == 0 {
+		resp.Diagnostics.AddError(
+			"Propagation Mapping Not Found",
+			fmt.Sprintf("No mapping of propagation rule %q sets target attribute %q.", ruleID, targetAttribute),
+		)
+		return
+	}
+	if len(matches) > 1 {
+		resp.Diagnostics.AddError(
+			"Multiple Propagation Mappings Found",
+			fmt.Sprintf("Found %d mappings of propagation rule %q that set target attribute %q.", len(matches), ruleID, targetAttribute),
+		)
+		return
+	}
+	mapping := matches[0]
+
+	id, _ := utils.NestedString(mapping, "id")
+	state.Id = types.StringValue(id)
+	state.SourceAttribute = types.StringNull()
+	if source, ok := utils.NestedString(mapping, "sourceAttribute"); ok && source != "" {
+		state.SourceAttribute = types.StringValue(source)
+	}
+	state.Expression = types.StringNull()
+	if expression, ok := utils.NestedString(mapping, "expression"); ok && expression != "" {
+		state.Expression = types.StringValue(expression)
+	}
+	state.Enabled = types.BoolValue(mappingEnabledFromAPI(mapping))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// propagationRuleMappingsByTarget returns the mappings that set target, compared
+// case-insensitively.
+func propagationRuleMappingsByTarget(mappings []map[string]interface{}, target string) []map[string]interface{} {
+	target = strings.TrimSpace(target)
+	var matches []map[string]interface{}
+	for _, m := range mappings {
+		mappingTarget, _ := utils.NestedString(m, "targetAttribute")
+		if strings.EqualFold(strings.TrimSpace(mappingTarget), target) {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestPropagationRuleMappingsByTarget(t *testing.T) {
+	t.Parallel()
+
+	mappings := []map[string]interface{}{
+		{"id": "map-1", "sourceAttribute": "email", "targetAttribute": "userName"},
+		{"id": "map-2", "sourceAttribute": "name.family", "targetAttribute": "name.familyName"},
+		{"id": "map-3", "expression": `"EMEA"`, "targetAttribute": " Region "},
+		{"id": "map-4", "sourceAttribute": "name.given", "targetAttribute": "region"},
+	}
+
+	matches := propagationRuleMappingsByTarget(mappings, "USERNAME")
+	if len(matches) != 1 || matches[0]["id"] != "map-1" {
+		t.Fatalf("matches for USERNAME = %v, want map-1", matches)
+	}
+
+	if matches := propagationRuleMappingsByTarget(mappings, "region"); len(matches) != 2 {
+		t.Fatalf("matches for region = %v, want map-3 and map-4", matches)
+	}
+
+	if matches := propagationRuleMappingsByTarget(mappings, "title"); len(matches) != 0 {
+		t.Fatalf("matches for title = %v, want none", matches)
+	}
+}
@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultPropagationRulePreviewSampleSize = 10
+
+var (
+	_ datasource.DataSource                     = &propagationRulePreviewDataSource{}
+	_ datasource.DataSourceWithConfigure        = &propagationRulePreviewDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &propagationRulePreviewDataSource{}
+)
+
+type propagationRulePreviewDataSource struct {
+	client *client.Client
+}
+
+type propagationRulePreviewDataSourceModel struct {
+	EnvironmentId        types.String `tfsdk:"environment_id"`
+	RuleId               types.String `tfsdk:"rule_id"`
+	PopulationExpression types.String `tfsdk:"population_expression"`
+	SampleSize           types.Int64  `tfsdk:"sample_size"`
+	Preview              types.Object `tfsdk:"preview"`
+}
+
+var propagationRulePreviewUserAttrTypes = map[string]attr.Type{
+	"id":       types.StringType,
+	"username": types.StringType,
+	"email":    types.StringType,
+}
+
+var propagationRulePreviewAttrTypes = map[string]attr.Type{
+	"matched_user_count": types.Int64Type,
+	"sample_users":       types.ListType{ElemType: types.ObjectType{AttrTypes: propagationRulePreviewUserAttrTypes}},
+}
+
+func NewPropagationRulePreviewDataSource() datasource.DataSource {
+	return &propagationRulePreviewDataSource{}
+}
+
+func (d *propagationRulePreviewDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_rule_preview"
+}
+
+func (d *propagationRulePreviewDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Previews which PingOne users a propagation rule's population expression matches, so scoping can be validated before the rule is activated.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"rule_id": schema.StringAttribute{
+				Description: "The ID of an existing propagation rule to preview. Conflicts with `population_expression`.",
+				Optional:    true,
+			},
+			"population_expression": schema.StringAttribute{
+				Description: "A population expression (SCIM filter) to preview. When `rule_id` is set this is computed from the rule.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"sample_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of matched users to return in `preview.sample_users`. Defaults to `%d`.", defaultPropagationRulePreviewSampleSize),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"preview": schema.SingleNestedAttribute{
+				Description: "The users matched by the population expression.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"matched_user_count": schema.Int64Attribute{
+						Description: "The number of users matched by the population expression.",
+						Computed:    true,
+					},
+					"sample_users": schema.ListNestedAttribute{
+						Description: "A sample of the matched users.",
+						Computed:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Description: "The ID of the user.",
+									Computed:    true,
+								},
+								"username": schema.StringAttribute{
+									Description: "The username of the user.",
+									Computed:    true,
+								},
+								"email": schema.StringAttribute{
+									Description: "The email address of the user.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *propagationRulePreviewDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("rule_id"),
+			path.MatchRoot("population_expression"),
+		),
+	}
+}
+
+func (d *propagationRulePreviewDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationRulePreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationRulePreviewDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	apiClient := d.client.API
+
+	expression := ""
+	if !state.RuleId.IsNull() && !state.RuleId.IsUnknown() {
+		ruleID := state.RuleId.ValueString()
+		ruleObj, _, err := readPropagationRule(ctx, apiClient, environmentID, ruleID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Rule",
+				fmt.Sprintf("Could not read propagation rule '%s': %s", ruleID, err),
+			)
+			return
+		}
+		expression, _ = utils.NestedString(ruleObj, "populationExpression")
+	} else {
+		expression = state.PopulationExpression.ValueString()
+	}
+
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		expression = "population.id pr"
+	}
+
+	sampleSize := int32(defaultPropagationRulePreviewSampleSize)
+	if !state.SampleSize.IsNull() && !state.SampleSize.IsUnknown() {
+		sampleSize = int32(state.SampleSize.ValueInt64())
+	}
+
+	tflog.Info(ctx, "Previewing propagation rule population expression", map[string]interface{}{
+		"environment_id":        environmentID,
+		"population_expression": expression,
+	})
+
+	page, httpResp, err := apiClient.UsersApi.ReadAllUsers(ctx, environmentID).
+		Filter(expression).
+		Limit(sampleSize).
+		ExecuteInitialPage()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		resp.Diagnostics.AddError(
+			"Error Previewing Propagation Rule",
+			fmt.Sprintf("PingOne rejected the population expression %q: %s", expression, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	decoded, err := utils.DecodeResponseJSON(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Users",
+			fmt.Sprintf("Could not parse users response: %s", err),
+		)
+		return
+	}
+
+	users, err := utils.ExtractEmbeddedArray(decoded, "users")
+	if err != nil {
+		users = nil
+	}
+
+	sample := make([]attr.Value, 0, len(users))
+	for _, item := range users {
+		userMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		attrs := map[string]attr.Value{
+			"id":       types.StringNull(),
+			"username": types.StringNull(),
+			"email":    types.StringNull(),
+		}
+		if v, ok := utils.NestedString(userMap, "id"); ok {
+			attrs["id"] = types.StringValue(v)
+		}
+		if v, ok := utils.NestedString(userMap, "username"); ok {
+			attrs["username"] = types.StringValue(v)
+		}
+		if v, ok := utils.NestedString(userMap, "email"); ok {
+			attrs["email"] = types.StringValue(v)
+		}
+		sample = append(sample, types.ObjectValueMust(propagationRulePreviewUserAttrTypes, attrs))
+	}
+
+	matched := int64(len(sample))
+	if page != nil {
+		if count, ok := page.GetCountOk(); ok && count != nil {
+			matched = int64(*count)
+		}
+	} else if root, ok := decoded.(map[string]interface{}); ok {
+		if count, ok := root["count"].(float64); ok {
+			matched = int64(count)
+		}
+	}
+
+	sampleList, diags := types.ListValue(types.ObjectType{AttrTypes: propagationRulePreviewUserAttrTypes}, sample)
+	resp.Diagnostics.Append(diags...)
+
+	preview, diags := types.ObjectValue(propagationRulePreviewAttrTypes, map[string]attr.Value{
+		"matched_user_count": types.Int64Value(matched),
+		"sample_users":       sampleList,
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.EnvironmentId = types.StringValue(environmentID)
+	state.PopulationExpression = types.StringValue(expression)
+	state.Preview = preview
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
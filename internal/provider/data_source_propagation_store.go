@@ -5,24 +5,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"strings"
-	"time"
 
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
-	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/mappers"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/schemas"
 	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/patrickcping/pingone-go-sdk-v2/management"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -31,6 +31,10 @@ var (
 	_ datasource.DataSourceWithConfigure = &propagationStoreDataSource{}
 )
 
+// defaultSecretsPlaceholder replaces sensitive configuration values when redact_secrets is not
+// set to false.
+const defaultSecretsPlaceholder = "REDACTED"
+
 // propagationStoreDataSource is the data source implementation.
 type propagationStoreDataSource struct {
 	client *client.Client
@@ -47,7 +51,7 @@ func (d *propagationStoreDataSource) Metadata(_ context.Context, req datasource.
 
 func (d *propagationStoreDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetches a PingOne provisioning propagation store.",
+		Description: "Fetches a PingOne provisioning propagation store by ID, by name and type, or by endpoint URL.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique ID of the propagation store.",
@@ -64,14 +68,22 @@ func (d *propagationStoreDataSource) Schema(_ context.Context, req datasource.Sc
 				Computed:    true,
 			},
 			"type": schema.StringAttribute{
-				Description: "The type of the identity store.",
+				Description: "The type of the identity store. When looking a store up by `endpoint_url`, only stores of this type are matched.",
 				Optional:    true,
 				Computed:    true,
 			},
+			"endpoint_url": schema.StringAttribute{
+				Description: "Looks the store up by the URL it connects to: the store whose `SCIM_URL` or `BASE_URL` configuration value is this URL. The scheme and host are compared case-insensitively, and a trailing `/` is ignored. Cannot be combined with `name`.",
+				Optional:    true,
+			},
 			"description": schema.StringAttribute{
 				Description: "A description of the identity store.",
 				Computed:    true,
 			},
+			"provisioning_direction": schema.StringAttribute{
+				Description: "The direction the store provisions in, which follows from its type: `inbound` for stores that provision identities into PingOne (Workday), `bidirectional` for stores that can be a rule's source or target (LDAP Gateway), and `outbound` for every other store.",
+				Computed:    true,
+			},
 			"image_id": schema.StringAttribute{
 				Description: "The image ID for the identity store resource.",
 				Computed:    true,
@@ -88,14 +100,37 @@ func (d *propagationStoreDataSource) Schema(_ context.Context, req datasource.Sc
 				Description: "The status of the propagation store.",
 				Computed:    true,
 			},
+			"links": schema.MapAttribute{
+				Description: linksAttributeDescription,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"sync_status": schema.ObjectAttribute{
-				Description: "Sync status for the propagation store.",
+				Description:    "Sync status for the propagation store.",
+				Computed:       true,
+				AttributeTypes: customtypes.SyncStatusAttrTypes,
+			},
+			"redact_secrets": schema.BoolAttribute{
+				Description: "Whether sensitive configuration values, such as tokens and passwords, are replaced with `secrets_placeholder`. Defaults to `true`. Set to `false` to read the values PingOne returns.",
+				Optional:    true,
 				Computed:    true,
-				AttributeTypes: map[string]attr.Type{
-					"last_sync_time": types.StringType,
-					"next_sync_time": types.StringType,
-					"status":         types.StringType,
-					"details":        types.StringType,
+			},
+			"configuration_raw": schema.MapAttribute{
+				Description: "The store's configuration as PingOne returns it, keyed by PingOne configuration key (for example `SCIM_URL`), for any store type including those without a `configuration_*` block. Values that are not strings are JSON-encoded. Secrets, such as tokens and passwords, are left out regardless of `redact_secrets`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"portable_configuration": schema.StringAttribute{
+				Description: "The store's configuration as JSON, without environment-specific IDs, for `pingoneprovisioning_propagation_store_mirror` to create the same store in another environment. Values redacted by `redact_secrets` are listed in its `redacted_keys` and must be supplied to the mirror as secrets.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"secrets_placeholder": schema.StringAttribute{
+				Description: "The value that replaces sensitive configuration values when `redact_secrets` is `true`. Defaults to `REDACTED`.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 		},
@@ -109,7 +144,7 @@ func (d *propagationStoreDataSource) Schema(_ context.Context, req datasource.Sc
 			"configuration_salesforce":          schemas.SalesforceConfigSchema(true),
 			"configuration_salesforce_contacts": schemas.SalesforceContactsConfigSchema(true),
 			"configuration_scim":                schemas.ScimConfigSchema(true),
-			"scim_configuration":                schemas.ScimConfigSchema(true),
+			"scim_configuration":                schemas.ScimConfigDeprecatedAliasSchema(true),
 			"configuration_service_now":         schemas.ServiceNowConfigSchema(true),
 			"configuration_slack":               schemas.SlackConfigSchema(true),
 			"configuration_workday":             schemas.WorkdayConfigSchema(true),
@@ -135,7 +170,7 @@ func (v propagationStoreLookupValidator) MarkdownDescription(_ context.Context)
 }
 
 func (v propagationStoreLookupValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
-	var config customtypes.PropagationStoreModel
+	var config customtypes.PropagationStoreDataSourceModel
 
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -143,7 +178,7 @@ func (v propagationStoreLookupValidator) ValidateDataSource(ctx context.Context,
 		return
 	}
 
-	_, validationDiags := propagationStoreLookupModeFromValues(config.Id, config.Name, config.Type)
+	_, validationDiags := propagationStoreLookupModeFromValues(config.Id, config.Name, config.Type, config.EndpointUrl)
 	resp.Diagnostics.Append(validationDiags...)
 }
 
@@ -166,7 +201,7 @@ func (d *propagationStoreDataSource) Configure(_ context.Context, req datasource
 
 // Read refreshes the Terraform state with the latest data.
 func (d *propagationStoreDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state customtypes.PropagationStoreModel
+	var state customtypes.PropagationStoreDataSourceModel
 
 	diags := req.Config.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -177,11 +212,9 @@ func (d *propagationStoreDataSource) Read(ctx context.Context, req datasource.Re
 	environmentID := state.EnvironmentId.ValueString()
 	apiClient := d.client.API
 
-	var apiResult *management.PropagationStore
-	var apiTypeRaw string
-	var apiStatusRaw string
+	var storeObj map[string]interface{}
 
-	lookupMode, validationDiags := propagationStoreLookupModeFromValues(state.Id, state.Name, state.Type)
+	lookupMode, validationDiags := propagationStoreLookupModeFromValues(state.Id, state.Name, state.Type, state.EndpointUrl)
 	resp.Diagnostics.Append(validationDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -199,114 +232,82 @@ func (d *propagationStoreDataSource) Read(ctx context.Context, req datasource.Re
 			"type":           targetType,
 		})
 
-		iterator := apiClient.PropagationStoresApi.ReadAllStores(ctx, environmentID).Execute()
-
-		type foundStore struct {
-			obj       *management.PropagationStore
-			rawType   string
-			rawStatus string
+		stores, err := listPropagationStores(ctx, d.client.Endpoints, apiClient, environmentID, d.client.PageSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Stores",
+				fmt.Sprintf("Could not list propagation stores: %s", err),
+			)
+			return
 		}
-		var foundStores []foundStore
 
-		for cursor, iterErr := range iterator {
-			if iterErr != nil {
-				resp.Diagnostics.AddError(
-					"Error Reading Propagation Stores",
-					fmt.Sprintf("Could not iterate propagation stores: %s", iterErr),
-				)
-				return
-			}
-
-			if cursor.HTTPResponse == nil {
-				continue
-			}
-
-			bodyBytes, readErr := io.ReadAll(cursor.HTTPResponse.Body)
-			_ = cursor.HTTPResponse.Body.Close()
-			if readErr != nil {
-				resp.Diagnostics.AddError(
-					"Error Reading Propagation Stores",
-					fmt.Sprintf("Could not read propagation stores response: %s", readErr),
-				)
-				return
+		var foundStores []map[string]interface{}
+		for _, storeMap := range stores {
+			storeName, _ := utils.NestedString(storeMap, "name")
+			storeTypeRaw, _ := utils.NestedString(storeMap, "type")
+			if storeName == targetName && strings.EqualFold(storeTypeRaw, targetTypeAPI) {
+				foundStores = append(foundStores, storeMap)
 			}
+		}
 
-			var raw map[string]interface{}
-			if err := json.Unmarshal(bodyBytes, &raw); err != nil {
-				resp.Diagnostics.AddError(
-					"Error Parsing Propagation Stores",
-					fmt.Sprintf("Could not parse propagation stores response: %s", err),
-				)
-				return
-			}
+		if len(foundStores) == 0 {
+			resp.Diagnostics.AddError(
+				"Propagation Store Not Found",
+				fmt.Sprintf("No propagation store found with name '%s' and type '%s' in environment '%s'.", targetName, targetType, environmentID),
+			)
+			return
+		} else if len(foundStores) > 1 {
+			resp.Diagnostics.AddError(
+				"Multiple Propagation Stores Found",
+				fmt.Sprintf("Found %d stores with name '%s' and type '%s' in environment '%s'. Use the 'id' argument to select a specific store.", len(foundStores), targetName, targetType, environmentID),
+			)
+			return
+		}
 
-			embedded, ok := raw["_embedded"].(map[string]interface{})
-			if !ok {
-				continue
-			}
+		storeObj = foundStores[0]
+	case propagationStoreLookupModeEndpoint:
+		endpointURL := state.EndpointUrl.ValueString()
+		targetTypeAPI := ""
+		if !state.Type.IsNull() && !state.Type.IsUnknown() {
+			targetTypeAPI = utils.NormalizePropagationStoreTypeForAPI(state.Type.ValueString())
+		}
 
-			stores, ok := embedded["stores"].([]interface{})
-			if !ok {
-				continue
-			}
+		tflog.Info(ctx, "Reading propagation store by endpoint URL", map[string]interface{}{
+			"environment_id": environmentID,
+			"endpoint_url":   endpointURL,
+			"type":           state.Type.ValueString(),
+		})
 
-			for _, s := range stores {
-				storeMap, ok := s.(map[string]interface{})
-				if !ok {
-					continue
-				}
-
-				storeName, _ := storeMap["name"].(string)
-				storeTypeRaw, _ := storeMap["type"].(string)
-				storeStatusRaw, _ := storeMap["status"].(string)
-
-				if storeName != targetName || !strings.EqualFold(storeTypeRaw, targetTypeAPI) {
-					continue
-				}
-
-				storeJSON, err := json.Marshal(storeMap)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error Parsing Propagation Store",
-						fmt.Sprintf("Could not marshal propagation store response: %s", err),
-					)
-					return
-				}
-
-				var storeObj management.PropagationStore
-				if err := json.Unmarshal(storeJSON, &storeObj); err != nil {
-					resp.Diagnostics.AddError(
-						"Error Parsing Propagation Store",
-						fmt.Sprintf("Could not unmarshal propagation store response: %s", err),
-					)
-					return
-				}
-
-				foundStores = append(foundStores, foundStore{
-					obj:       &storeObj,
-					rawType:   storeTypeRaw,
-					rawStatus: storeStatusRaw,
-				})
-			}
+		stores, err := listPropagationStores(ctx, d.client.Endpoints, apiClient, environmentID, d.client.PageSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Stores",
+				fmt.Sprintf("Could not list propagation stores: %s", err),
+			)
+			return
 		}
 
+		foundStores := propagationStoresWithEndpoint(stores, endpointURL, targetTypeAPI)
 		if len(foundStores) == 0 {
 			resp.Diagnostics.AddError(
 				"Propagation Store Not Found",
-				fmt.Sprintf("No propagation store found with name '%s' and type '%s' in environment '%s'.", targetName, targetType, environmentID),
+				fmt.Sprintf("No propagation store with SCIM_URL or BASE_URL '%s' found in environment '%s'.", endpointURL, environmentID),
 			)
 			return
 		} else if len(foundStores) > 1 {
+			names := make([]string, 0, len(foundStores))
+			for _, store := range foundStores {
+				name, _ := utils.NestedString(store, "name")
+				names = append(names, fmt.Sprintf("'%s'", name))
+			}
 			resp.Diagnostics.AddError(
 				"Multiple Propagation Stores Found",
-				fmt.Sprintf("Found %d stores with name '%s' and type '%s' in environment '%s'. Use the 'id' argument to select a specific store.", len(foundStores), targetName, targetType, environmentID),
+				fmt.Sprintf("Found %d stores with SCIM_URL or BASE_URL '%s' in environment '%s': %s. Set `type` or use the 'id' argument to select a specific store.", len(foundStores), endpointURL, environmentID, strings.Join(names, ", ")),
 			)
 			return
 		}
 
-		apiResult = foundStores[0].obj
-		apiTypeRaw = foundStores[0].rawType
-		apiStatusRaw = foundStores[0].rawStatus
+		storeObj = foundStores[0]
 	case propagationStoreLookupModeId:
 		// =========================================================================================
 		// Scenario B: Lookup by ID if provided
@@ -318,10 +319,10 @@ func (d *propagationStoreDataSource) Read(ctx context.Context, req datasource.Re
 			"id":             storeID,
 		})
 
-		result, httpResp, err := apiClient.PropagationStoresApi.
+		_, httpResp, err := apiClient.PropagationStoresApi.
 			ReadOnePropagationStore(ctx, environmentID, storeID).
 			Execute()
-		if err != nil {
+		if err = ignoreStoreDecodeError(httpResp, err); err != nil {
 			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
 				resp.Diagnostics.AddError(
 					"Propagation Store Not Found",
@@ -336,27 +337,202 @@ func (d *propagationStoreDataSource) Read(ctx context.Context, req datasource.Re
 			return
 		}
 
-		apiResult = result
-		rawType, rawStatus, parseErr := utils.ExtractPropagationStoreTypeStatus(httpResp)
-		if parseErr != nil {
+		storeObj, err = utils.DecodeResponseJSONObject(httpResp, "store")
+		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Reading Propagation Store",
-				fmt.Sprintf("Could not parse propagation store response: %s", parseErr),
+				fmt.Sprintf("Could not parse propagation store response: %s", err),
 			)
 			return
 		}
-		apiTypeRaw = rawType
-		apiStatusRaw = rawStatus
 	default:
 		resp.Diagnostics.AddError("Invalid Lookup Configuration", "Unable to determine lookup configuration for propagation store.")
 		return
 	}
 
 	// Map API response to state
-	d.apiToModel(apiResult, apiTypeRaw, apiStatusRaw, environmentID, &state)
+	preferredType := ""
+	if !state.Type.IsNull() && !state.Type.IsUnknown() {
+		preferredType = state.Type.ValueString()
+	}
+	state.PropagationStoreModel = propagationStoreFromJSON(storeObj, environmentID, preferredType, nil)
+	config, _ := storeObj["configuration"].(map[string]interface{})
+	state.ConfigurationRaw = rawPropagationStoreConfiguration(config, sensitivePropagationStoreConfigurationKeys(req.Config))
+
+	if state.RedactSecrets.IsNull() {
+		state.RedactSecrets = types.BoolValue(true)
+	}
+	if state.SecretsPlaceholder.IsNull() {
+		state.SecretsPlaceholder = types.StringValue(defaultSecretsPlaceholder)
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.RedactSecrets.ValueBool() {
+		resp.Diagnostics.Append(setPortablePropagationStore(ctx, &resp.State, nil)...)
+		return
+	}
+
+	resp.Diagnostics.Append(redactPropagationStoreSecrets(ctx, &resp.State, state.SecretsPlaceholder.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setPortablePropagationStore(ctx, &resp.State, &state.PropagationStoreModel)...)
+}
+
+// setPortablePropagationStore sets portable_configuration from the store in state. original is
+// the store before its secrets were redacted, or nil when they were not.
+func setPortablePropagationStore(ctx context.Context, state *tfsdk.State, original *customtypes.PropagationStoreModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var exported customtypes.PropagationStoreDataSourceModel
+	diags.Append(state.Get(ctx, &exported)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	portable, err := exportPortablePropagationStore(&exported.PropagationStoreModel, original)
+	if err != nil {
+		diags.AddWarning(
+			"Portable Configuration Not Available",
+			fmt.Sprintf("Could not export the configuration of propagation store '%s': %s", exported.Id.ValueString(), err),
+		)
+		return diags
+	}
+
+	diags.Append(state.SetAttribute(ctx, path.Root("portable_configuration"), portable)...)
+	return diags
+}
+
+// propagationStoreSecretKeyFragments mark a configuration key as secret when the store type has
+// no configuration block whose schema says so, for example a connector added to PingOne after
+// this provider version.
+var propagationStoreSecretKeyFragments = []string{"PASSWORD", "SECRET", "TOKEN", "API_KEY", "PRIVATE_KEY", "CREDENTIAL"}
+
+// sensitivePropagationStoreConfigurationKeys returns the PingOne configuration keys of the
+// sensitive attributes in the configuration blocks of config's schema. Block attributes are the
+// lower-case form of the PingOne key, for example `oauth_access_token` for `OAUTH_ACCESS_TOKEN`.
+func sensitivePropagationStoreConfigurationKeys(config tfsdk.Config) map[string]bool {
+	keys := make(map[string]bool)
+	for _, block := range config.Schema.GetBlocks() {
+		for attrName, attribute := range block.GetNestedObject().GetAttributes() {
+			if attribute.IsSensitive() {
+				keys[strings.ToUpper(attrName)] = true
+			}
+		}
+	}
+	return keys
+}
+
+// rawPropagationStoreConfiguration converts the configuration PingOne returns for a store into
+// configuration_raw, leaving out null values and secrets. A key is a secret when it is in
+// sensitiveKeys or contains one of propagationStoreSecretKeyFragments.
+func rawPropagationStoreConfiguration(config map[string]interface{}, sensitiveKeys map[string]bool) types.Map {
+	values := make(map[string]attr.Value, len(config))
+	for key, value := range config {
+		if value == nil || isPropagationStoreSecretKey(key, sensitiveKeys) {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			values[key] = types.StringValue(v)
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			values[key] = types.StringValue(string(encoded))
+		}
+	}
+
+	return types.MapValueMust(types.StringType, values)
+}
+
+func isPropagationStoreSecretKey(key string, sensitiveKeys map[string]bool) bool {
+	upper := strings.ToUpper(key)
+	if sensitiveKeys[upper] {
+		return true
+	}
+	for _, fragment := range propagationStoreSecretKeyFragments {
+		if strings.Contains(upper, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactPropagationStoreSecrets replaces every sensitive value in the configuration blocks of
+// state with placeholder, so that the configuration can be written out as HCL without leaking
+// credentials. Sensitive values PingOne does not return stay null.
+func redactPropagationStoreSecrets(ctx context.Context, state *tfsdk.State, placeholder string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for blockName, block := range state.Schema.GetBlocks() {
+		for attrName, attribute := range block.GetNestedObject().GetAttributes() {
+			if !attribute.IsSensitive() {
+				continue
+			}
+
+			attrPath := path.Root(blockName).AtName(attrName)
+			var value types.String
+			diags.Append(state.GetAttribute(ctx, attrPath, &value)...)
+			if diags.HasError() {
+				return diags
+			}
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			diags.Append(state.SetAttribute(ctx, attrPath, placeholder)...)
+		}
+	}
+
+	return diags
+}
+
+// propagationStoreEndpointKeys are the configuration keys an `endpoint_url` lookup compares.
+var propagationStoreEndpointKeys = []string{"SCIM_URL", "BASE_URL"}
+
+// propagationStoresWithEndpoint returns the stores whose SCIM_URL or BASE_URL is endpointURL, of
+// type storeTypeAPI when it is not empty.
+func propagationStoresWithEndpoint(stores []map[string]interface{}, endpointURL string, storeTypeAPI string) []map[string]interface{} {
+	want := normalizePropagationStoreEndpoint(endpointURL)
+
+	var found []map[string]interface{}
+	for _, store := range stores {
+		if storeTypeAPI != "" {
+			if storeType, _ := utils.NestedString(store, "type"); !strings.EqualFold(storeType, storeTypeAPI) {
+				continue
+			}
+		}
+		for _, key := range propagationStoreEndpointKeys {
+			if value, ok := utils.NestedString(store, "configuration", key); ok && value != "" && normalizePropagationStoreEndpoint(value) == want {
+				found = append(found, store)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// normalizePropagationStoreEndpoint lower-cases the scheme and host of a URL and drops a trailing
+// `/`, so that URLs PingOne stores in a different form still match. A value that does not parse
+// as an absolute URL is only trimmed.
+func normalizePropagationStoreEndpoint(endpoint string) string {
+	endpoint = strings.TrimSpace(endpoint)
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return strings.TrimRight(endpoint, "/")
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+	return parsed.String()
 }
 
 type propagationStoreLookupMode int
@@ -365,14 +541,29 @@ const (
 	propagationStoreLookupModeInvalid propagationStoreLookupMode = iota
 	propagationStoreLookupModeNameType
 	propagationStoreLookupModeId
+	propagationStoreLookupModeEndpoint
 )
 
-func propagationStoreLookupModeFromValues(id, name, storeType types.String) (propagationStoreLookupMode, diag.Diagnostics) {
+func propagationStoreLookupModeFromValues(id, name, storeType, endpointURL types.String) (propagationStoreLookupMode, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	idSet := !id.IsNull() && !id.IsUnknown() && id.ValueString() != ""
 	nameSet := !name.IsNull() && !name.IsUnknown() && name.ValueString() != ""
 	typeSet := !storeType.IsNull() && !storeType.IsUnknown() && storeType.ValueString() != ""
+	endpointSet := !endpointURL.IsNull() && !endpointURL.IsUnknown() && endpointURL.ValueString() != ""
+
+	// `type` narrows an endpoint lookup, while `name` would make it a second lookup.
+	if endpointSet {
+		if nameSet {
+			diags.AddAttributeError(
+				path.Root("name"),
+				"Conflicting Lookup Arguments",
+				"`name` cannot be combined with `endpoint_url`. Use `type` to narrow a lookup by `endpoint_url`.",
+			)
+			return propagationStoreLookupModeInvalid, diags
+		}
+		return propagationStoreLookupModeEndpoint, diags
+	}
 
 	// If either name or type is configured, require both. We intentionally do
 	// not treat `id` as conflicting since Optional+Computed attributes can carry
@@ -404,104 +595,7 @@ func propagationStoreLookupModeFromValues(id, name, storeType types.String) (pro
 
 	diags.AddError(
 		"Missing Required Arguments",
-		"Configure either `id` or both `name` and `type` to lookup a propagation store.",
+		"Configure `id`, both `name` and `type`, or `endpoint_url` to lookup a propagation store.",
 	)
 	return propagationStoreLookupModeInvalid, diags
 }
-
-func (d *propagationStoreDataSource) apiToModel(apiObj *management.PropagationStore, rawType string, rawStatus string, environmentId string, state *customtypes.PropagationStoreModel) {
-	state.Id = types.StringValue(apiObj.GetId())
-	state.EnvironmentId = types.StringValue(environmentId)
-	state.Name = types.StringValue(apiObj.GetName())
-
-	preferredType := ""
-	if !state.Type.IsNull() && !state.Type.IsUnknown() {
-		preferredType = state.Type.ValueString()
-	}
-	apiType := rawType
-	if apiType == "" || apiType == "UNKNOWN" {
-		apiType = string(apiObj.GetType())
-	}
-	if apiType == "" || apiType == "UNKNOWN" {
-		apiType = preferredType
-	}
-	tfType := utils.NormalizePropagationStoreTypeForTerraform(apiType, preferredType)
-	state.Type = types.StringValue(tfType)
-
-	if v, ok := apiObj.GetDescriptionOk(); ok {
-		state.Description = types.StringValue(*v)
-	} else {
-		state.Description = types.StringNull()
-	}
-
-	if image, ok := apiObj.GetImageOk(); ok && image != nil {
-		if v, ok := image.GetIdOk(); ok && v != nil {
-			state.ImageId = types.StringValue(*v)
-		} else {
-			state.ImageId = types.StringNull()
-		}
-
-		if v, ok := image.GetHrefOk(); ok && v != nil {
-			state.ImageHref = types.StringValue(*v)
-		} else {
-			state.ImageHref = types.StringNull()
-		}
-	} else {
-		state.ImageId = types.StringNull()
-		state.ImageHref = types.StringNull()
-	}
-
-	if v, ok := apiObj.GetManagedOk(); ok {
-		state.Managed = types.BoolValue(*v)
-	} else {
-		state.Managed = types.BoolNull()
-	}
-
-	status := rawStatus
-	if status == "" {
-		if v, ok := apiObj.GetStatusOk(); ok && v != nil && string(*v) != "" && string(*v) != "UNKNOWN" {
-			status = string(*v)
-		}
-	}
-	if status != "" {
-		state.Status = types.StringValue(status)
-	} else {
-		state.Status = types.StringNull()
-	}
-
-	if v, ok := apiObj.GetSyncStatusOk(); ok && v != nil {
-		attrs := map[string]attr.Value{
-			"last_sync_time": types.StringNull(),
-			"next_sync_time": types.StringNull(),
-			"status":         types.StringNull(),
-			"details":        types.StringNull(),
-		}
-
-		if vv, ok := v.GetLastSyncAtOk(); ok && vv != nil {
-			attrs["last_sync_time"] = types.StringValue(vv.Format(time.RFC3339))
-		}
-		if vv, ok := v.GetSyncStateOk(); ok && vv != nil {
-			attrs["status"] = types.StringValue(string(*vv))
-		}
-		if vv, ok := v.GetDetailsOk(); ok && vv != nil {
-			attrs["details"] = types.StringValue(*vv)
-		}
-
-		state.SyncStatus = types.ObjectValueMust(map[string]attr.Type{
-			"last_sync_time": types.StringType,
-			"next_sync_time": types.StringType,
-			"status":         types.StringType,
-			"details":        types.StringType,
-		}, attrs)
-	} else {
-		state.SyncStatus = types.ObjectNull(map[string]attr.Type{
-			"last_sync_time": types.StringType,
-			"next_sync_time": types.StringType,
-			"status":         types.StringType,
-			"details":        types.StringType,
-		})
-	}
-
-	config := apiObj.GetConfiguration()
-	mappers.ApplyPropagationStoreConfigurationFromMap(state, tfType, config, nil)
-}
@@ -1,11 +1,18 @@
 package provider
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestPropagationStoreLookupModeFromValues(t *testing.T) {
@@ -16,6 +23,7 @@ func TestPropagationStoreLookupModeFromValues(t *testing.T) {
 		id            types.String
 		storeName     types.String
 		storeType     types.String
+		endpointURL   types.String
 		wantMode      propagationStoreLookupMode
 		wantErrCount  int
 		wantErrPath   path.Path
@@ -73,6 +81,35 @@ func TestPropagationStoreLookupModeFromValues(t *testing.T) {
 			wantMode:     propagationStoreLookupModeNameType,
 			wantErrCount: 0,
 		},
+		{
+			name:         "endpoint_only",
+			id:           types.StringNull(),
+			storeName:    types.StringNull(),
+			storeType:    types.StringNull(),
+			endpointURL:  types.StringValue("https://scim.example/v2"),
+			wantMode:     propagationStoreLookupModeEndpoint,
+			wantErrCount: 0,
+		},
+		{
+			name:         "endpoint_and_type",
+			id:           types.StringNull(),
+			storeName:    types.StringNull(),
+			storeType:    types.StringValue("SCIM"),
+			endpointURL:  types.StringValue("https://scim.example/v2"),
+			wantMode:     propagationStoreLookupModeEndpoint,
+			wantErrCount: 0,
+		},
+		{
+			name:          "endpoint_and_name_errors",
+			id:            types.StringNull(),
+			storeName:     types.StringValue("SCIM Store"),
+			storeType:     types.StringValue("SCIM"),
+			endpointURL:   types.StringValue("https://scim.example/v2"),
+			wantMode:      propagationStoreLookupModeInvalid,
+			wantErrCount:  1,
+			wantErrPath:   path.Root("name"),
+			wantErrIsPath: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -81,7 +118,7 @@ func TestPropagationStoreLookupModeFromValues(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			gotMode, gotDiags := propagationStoreLookupModeFromValues(tt.id, tt.storeName, tt.storeType)
+			gotMode, gotDiags := propagationStoreLookupModeFromValues(tt.id, tt.storeName, tt.storeType, tt.endpointURL)
 
 			if gotMode != tt.wantMode {
 				t.Fatalf("mode mismatch: got %v want %v", gotMode, tt.wantMode)
@@ -113,3 +150,126 @@ func TestPropagationStoreLookupModeFromValues(t *testing.T) {
 		})
 	}
 }
+
+func TestPropagationStoresWithEndpoint(t *testing.T) {
+	t.Parallel()
+
+	stores := []map[string]interface{}{
+		{"id": "scim", "type": "scim", "configuration": map[string]interface{}{"SCIM_URL": "https://SCIM.example.com/v2/"}},
+		{"id": "pingone", "type": "PingOne", "configuration": map[string]interface{}{"BASE_URL": "https://api.example", "SCIM_URL": "https://scim.example.com/v2"}},
+		{"id": "github", "type": "GithubEMU", "configuration": map[string]interface{}{"BASE_URL": "https://api.github.com/scim/v2/enterprises/acme"}},
+		{"id": "unset", "type": "scim", "configuration": map[string]interface{}{"SCIM_URL": ""}},
+	}
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		storeType string
+		want      []string
+	}{
+		{name: "scim_url_normalized", endpoint: "https://scim.example.com/v2", want: []string{"scim", "pingone"}},
+		{name: "narrowed_by_type", endpoint: "https://scim.example.com/v2", storeType: "PingOne", want: []string{"pingone"}},
+		{name: "base_url", endpoint: "https://api.github.com/scim/v2/enterprises/acme/", want: []string{"github"}},
+		{name: "path_is_case_sensitive", endpoint: "https://api.github.com/scim/v2/enterprises/ACME", want: nil},
+		{name: "empty_value_never_matches", endpoint: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, store := range propagationStoresWithEndpoint(stores, tt.endpoint, tt.storeType) {
+				got = append(got, store["id"].(string))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("stores = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactPropagationStoreSecrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	(&propagationStoreDataSource{}).Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	model := customtypes.PropagationStoreDataSourceModel{
+		PropagationStoreModel: customtypes.PropagationStoreModel{
+			Type:       types.StringValue("SCIM"),
+			SyncStatus: types.ObjectNull(customtypes.SyncStatusAttrTypes),
+			Links:      types.MapNull(types.StringType),
+			ConfigurationScim: &customtypes.ConfigurationScim{
+				ScimUrl:           types.StringValue("https://scim.example"),
+				OauthClientSecret: types.StringValue("s3cret"),
+			},
+		},
+		RedactSecrets:      types.BoolValue(true),
+		SecretsPlaceholder: types.StringValue("CHANGE_ME"),
+		ConfigurationRaw:   types.MapNull(types.StringType),
+	}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+
+	if diags := redactPropagationStoreSecrets(ctx, &state, "CHANGE_ME"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got customtypes.PropagationStoreDataSourceModel
+	if diags := state.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	scim := got.ConfigurationScim
+	if scim == nil {
+		t.Fatal("configuration_scim should still be set")
+	}
+	if scim.OauthClientSecret.ValueString() != "CHANGE_ME" {
+		t.Fatalf("oauth_client_secret = %s, want the placeholder", scim.OauthClientSecret)
+	}
+	if !scim.BasicAuthPassword.IsNull() {
+		t.Fatalf("basic_auth_password = %s, want null", scim.BasicAuthPassword)
+	}
+	if scim.ScimUrl.ValueString() != "https://scim.example" {
+		t.Fatalf("scim_url = %s, want it unchanged", scim.ScimUrl)
+	}
+	if got.ConfigurationSlack != nil {
+		t.Fatal("configuration_slack should stay null")
+	}
+}
+
+func TestRawPropagationStoreConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	(&propagationStoreDataSource{}).Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	sensitiveKeys := sensitivePropagationStoreConfigurationKeys(tfsdk.Config{Schema: schemaResp.Schema})
+
+	got := rawPropagationStoreConfiguration(map[string]interface{}{
+		"BASE_URL":        "https://connector.example",
+		"CREATE_USERS":    true,
+		"PAGE_SIZE":       float64(100),
+		"SCOPES":          []interface{}{"read", "write"},
+		"DOMAIN":          nil,
+		"OAUTH_CLIENT_ID": "client-id",
+		"CLIENT_SECRET":   "s3cret",
+		"SIGNING_TOKEN":   "t0ken",
+	}, sensitiveKeys)
+
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"BASE_URL":     types.StringValue("https://connector.example"),
+		"CREATE_USERS": types.StringValue("true"),
+		"PAGE_SIZE":    types.StringValue("100"),
+		"SCOPES":       types.StringValue(`["read","write"]`),
+	})
+	if !got.Equal(want) {
+		t.Fatalf("configuration_raw = %s, want %s", got, want)
+	}
+}
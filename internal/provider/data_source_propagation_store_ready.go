@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+const (
+	defaultPropagationStoreReadyTimeoutSeconds      = 600
+	defaultPropagationStoreReadyPollIntervalSeconds = 10
+)
+
+var (
+	_ datasource.DataSource                     = &propagationStoreReadyDataSource{}
+	_ datasource.DataSourceWithConfigure        = &propagationStoreReadyDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &propagationStoreReadyDataSource{}
+)
+
+type propagationStoreReadyDataSource struct {
+	client *client.Client
+}
+
+type propagationStoreReadyDataSourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	EnvironmentId       types.String `tfsdk:"environment_id"`
+	StoreId             types.String `tfsdk:"store_id"`
+	Status              types.String `tfsdk:"status"`
+	SyncStatus          types.String `tfsdk:"sync_status"`
+	TimeoutSeconds      types.Int64  `tfsdk:"timeout_seconds"`
+	PollIntervalSeconds types.Int64  `tfsdk:"poll_interval_seconds"`
+	CurrentStatus       types.String `tfsdk:"current_status"`
+	CurrentSyncStatus   types.String `tfsdk:"current_sync_status"`
+}
+
+// propagationStoreReadiness holds the store fields the ready data source waits on. Empty
+// fields in a target are not checked.
+type propagationStoreReadiness struct {
+	Status     string
+	SyncStatus string
+}
+
+func (r propagationStoreReadiness) satisfies(want propagationStoreReadiness) bool {
+	if want.Status != "" && !strings.EqualFold(r.Status, want.Status) {
+		return false
+	}
+	if want.SyncStatus != "" && !strings.EqualFold(r.SyncStatus, want.SyncStatus) {
+		return false
+	}
+	return true
+}
+
+func NewPropagationStoreReadyDataSource() datasource.DataSource {
+	return &propagationStoreReadyDataSource{}
+}
+
+func (d *propagationStoreReadyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_store_ready"
+}
+
+func (d *propagationStoreReadyDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Waits until a PingOne provisioning propagation store reaches a requested status or sync status. Rules can `depends_on` this data source so they are not activated before their stores are ready.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the propagation store.",
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"store_id": schema.StringAttribute{
+				Description: "The ID of the propagation store to wait for.",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "The store status to wait for, for example `ACTIVE`. Compared case-insensitively.",
+				Optional:    true,
+			},
+			"sync_status": schema.StringAttribute{
+				Description: "The sync state to wait for, as reported in `sync_status.status` on the store. Compared case-insensitively.",
+				Optional:    true,
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: fmt.Sprintf("How long to wait before failing. Defaults to `%d`.", defaultPropagationStoreReadyTimeoutSeconds),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3600),
+				},
+			},
+			"poll_interval_seconds": schema.Int64Attribute{
+				Description: fmt.Sprintf("How long to wait between reads of the store. Defaults to `%d`.", defaultPropagationStoreReadyPollIntervalSeconds),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 300),
+				},
+			},
+			"current_status": schema.StringAttribute{
+				Description: "The store status when the wait finished.",
+				Computed:    true,
+			},
+			"current_sync_status": schema.StringAttribute{
+				Description: "The store sync state when the wait finished.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *propagationStoreReadyDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("status"),
+			path.MatchRoot("sync_status"),
+		),
+	}
+}
+
+func (d *propagationStoreReadyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationStoreReadyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationStoreReadyDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	storeID := strings.TrimSpace(state.StoreId.ValueString())
+	want := propagationStoreReadiness{
+		Status:     strings.TrimSpace(state.Status.ValueString()),
+		SyncStatus: strings.TrimSpace(state.SyncStatus.ValueString()),
+	}
+
+	timeout := time.Duration(defaultPropagationStoreReadyTimeoutSeconds) * time.Second
+	if !state.TimeoutSeconds.IsNull() && !state.TimeoutSeconds.IsUnknown() {
+		timeout = time.Duration(state.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+	interval := time.Duration(defaultPropagationStoreReadyPollIntervalSeconds) * time.Second
+	if !state.PollIntervalSeconds.IsNull() && !state.PollIntervalSeconds.IsUnknown() {
+		interval = time.Duration(state.PollIntervalSeconds.ValueInt64()) * time.Second
+	}
+
+	tflog.Info(ctx, "Waiting for propagation store to become ready", map[string]interface{}{
+		"environment_id": environmentID,
+		"store_id":       storeID,
+		"status":         want.Status,
+		"sync_status":    want.SyncStatus,
+		"timeout":        timeout.String(),
+	})
+
+	current, err := waitForPropagationStoreReady(ctx, d.client.API, environmentID, storeID, want, interval, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Propagation Store Not Ready",
+			fmt.Sprintf("Propagation store '%s' in environment '%s' did not become ready: %s", storeID, environmentID, err),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(storeID)
+	state.CurrentStatus = types.StringValue(current.Status)
+	state.CurrentSyncStatus = types.StringValue(current.SyncStatus)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// waitForPropagationStoreReady reads the store every interval until it satisfies want or the
+// timeout elapses. A 404 is treated as not ready yet, since a store created in the same apply
+// may not be readable immediately.
+func waitForPropagationStoreReady(ctx context.Context, apiClient *management.APIClient, environmentID string, storeID string, want propagationStoreReadiness, interval time.Duration, timeout time.Duration) (propagationStoreReadiness, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var current propagationStoreReadiness
+	for {
+		readiness, httpResp, err := readPropagationStoreReadiness(ctx, apiClient, environmentID, storeID)
+		switch {
+		case err == nil:
+			current = readiness
+			if current.satisfies(want) {
+				return current, nil
+			}
+		case httpResp != nil && httpResp.StatusCode == http.StatusNotFound:
+			tflog.Debug(ctx, "Propagation store not found yet", map[string]interface{}{"store_id": storeID})
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			// The read was cut short by the timeout; report the timeout below.
+		default:
+			return current, err
+		}
+
+		tflog.Debug(ctx, "Propagation store not ready", map[string]interface{}{
+			"store_id":    storeID,
+			"status":      current.Status,
+			"sync_status": current.SyncStatus,
+		})
+
+		if err := utils.SleepContext(ctx, interval); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return current, fmt.Errorf("timed out after %s (status=%q sync_status=%q)", timeout, current.Status, current.SyncStatus)
+			}
+			return current, err
+		}
+	}
+}
+
+func readPropagationStoreReadiness(ctx context.Context, apiClient *management.APIClient, environmentID string, storeID string) (propagationStoreReadiness, *http.Response, error) {
+	// The SDK can fail to decode stores with newer enum values, so the fields are read from the
+	// raw response whenever the request itself succeeded.
+	_, httpResp, err := apiClient.PropagationStoresApi.
+		ReadOnePropagationStore(ctx, environmentID, storeID).
+		Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		return propagationStoreReadiness{}, httpResp, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
+	}
+
+	storeObj, err := utils.DecodeResponseJSONObject(httpResp, "store")
+	if err != nil {
+		return propagationStoreReadiness{}, httpResp, err
+	}
+
+	var readiness propagationStoreReadiness
+	readiness.Status, _ = utils.NestedString(storeObj, "status")
+	readiness.SyncStatus, _ = utils.NestedString(storeObj, "syncStatus", "syncState")
+	return readiness, httpResp, nil
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForPropagationStoreReady_PollsUntilStatusMatches(t *testing.T) {
+	t.Parallel()
+
+	responses := []struct {
+		status int
+		body   string
+	}{
+		{status: http.StatusNotFound, body: `{"code":"NOT_FOUND","message":"not found"}`},
+		{status: http.StatusOK, body: `{"id":"store-id","name":"SCIM","type":"scim","status":"INACTIVE","syncStatus":{"syncState":"SYNCING"}}`},
+		{status: http.StatusOK, body: `{"id":"store-id","name":"SCIM","type":"scim","status":"ACTIVE","syncStatus":{"syncState":"SYNCING"}}`},
+	}
+
+	calls := 0
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/v1/environments/env-id/propagation/stores/store-id" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+
+		resp := responses[len(responses)-1]
+		if calls < len(responses) {
+			resp = responses[calls]
+		}
+		calls++
+
+		return testResponse(r, resp.status, resp.body), nil
+	})
+
+	got, err := waitForPropagationStoreReady(context.Background(), apiClient, "env-id", "store-id", propagationStoreReadiness{Status: "active"}, time.Millisecond, 5*time.Second)
+	if err != nil {
+		t.Fatalf("waitForPropagationStoreReady error: %v", err)
+	}
+	if got.Status != "ACTIVE" || got.SyncStatus != "SYNCING" {
+		t.Fatalf("readiness = %+v", got)
+	}
+	if calls != 3 {
+		t.Fatalf("calls = %d, want 3", calls)
+	}
+
+	_, err = waitForPropagationStoreReady(context.Background(), apiClient, "env-id", "store-id", propagationStoreReadiness{SyncStatus: "FAILED"}, time.Millisecond, 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// propagationStoreConnectionCheckContentType is the media type PingOne expects on connection
+// test requests.
+const propagationStoreConnectionCheckContentType = "application/vnd.pingidentity.connection.check+json"
+
+var (
+	_ datasource.DataSource              = &propagationStoreTestDataSource{}
+	_ datasource.DataSourceWithConfigure = &propagationStoreTestDataSource{}
+)
+
+type propagationStoreTestDataSource struct {
+	client *client.Client
+}
+
+type propagationStoreTestDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	StoreId       types.String `tfsdk:"store_id"`
+	Configuration types.Map    `tfsdk:"configuration"`
+	FailOnError   types.Bool   `tfsdk:"fail_on_error"`
+	Success       types.Bool   `tfsdk:"success"`
+	StatusCode    types.Int64  `tfsdk:"status_code"`
+	Message       types.String `tfsdk:"message"`
+}
+
+// propagationStoreConnectionResult is the outcome of a connection test.
+type propagationStoreConnectionResult struct {
+	Success    bool
+	StatusCode int
+	Message    string
+}
+
+func NewPropagationStoreTestDataSource() datasource.DataSource {
+	return &propagationStoreTestDataSource{}
+}
+
+func (d *propagationStoreTestDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_store_test"
+}
+
+func (d *propagationStoreTestDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs PingOne's connection test for a propagation store and reports whether the store's credentials are accepted by the target system. Useful in CI right after rotating a bearer token or OAuth secret.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the propagation store.",
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"store_id": schema.StringAttribute{
+				Description: "The ID of the propagation store to test.",
+				Required:    true,
+			},
+			"configuration": schema.MapAttribute{
+				Description: "Configuration values (API field names, for example `OAUTH_ACCESS_TOKEN`) that override the store's stored configuration for the test. PingOne does not return secrets when a store is read, so secrets must be supplied here for the test to use them.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Description: "When `true`, a failed connection test fails the plan or apply instead of only setting `success` to `false`. Defaults to `false`.",
+				Optional:    true,
+			},
+			"success": schema.BoolAttribute{
+				Description: "Whether PingOne could connect to the target system with the store's configuration.",
+				Computed:    true,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP status code PingOne returned for the connection test.",
+				Computed:    true,
+			},
+			"message": schema.StringAttribute{
+				Description: "Details reported by PingOne when the test fails. Empty when it succeeds.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *propagationStoreTestDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationStoreTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationStoreTestDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	storeID := strings.TrimSpace(state.StoreId.ValueString())
+
+	overrides := map[string]string{}
+	if !state.Configuration.IsNull() && !state.Configuration.IsUnknown() {
+		diags = state.Configuration.ElementsAs(ctx, &overrides, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Testing propagation store connection", map[string]interface{}{
+		"environment_id": environmentID,
+		"store_id":       storeID,
+	})
+
+	result, err := testPropagationStoreConnection(ctx, d.client.API, environmentID, storeID, overrides)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Testing Propagation Store Connection",
+			fmt.Sprintf("Could not test the connection of propagation store '%s' in environment '%s': %s", storeID, environmentID, err),
+		)
+		return
+	}
+
+	if !result.Success && state.FailOnError.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Propagation Store Connection Failed",
+			fmt.Sprintf("PingOne could not connect propagation store '%s' to its target system (HTTP %d): %s", storeID, result.StatusCode, result.Message),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(storeID)
+	state.Success = types.BoolValue(result.Success)
+	state.StatusCode = types.Int64Value(int64(result.StatusCode))
+	state.Message = types.StringValue(result.Message)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// testPropagationStoreConnection reads the store, applies the configuration overrides and posts
+// the result to the connection test endpoint. A rejected test is reported in the result rather
+// than as an error; errors are limited to failures reading the store or reaching PingOne.
+func testPropagationStoreConnection(ctx context.Context, apiClient *management.APIClient, environmentID string, storeID string, overrides map[string]string) (propagationStoreConnectionResult, error) {
+	if apiClient == nil {
+		return propagationStoreConnectionResult{}, fmt.Errorf("nil api client")
+	}
+
+	// The SDK can fail to decode stores with newer enum values, so the store is read from the raw
+	// response whenever the request itself succeeded.
+	_, httpResp, err := apiClient.PropagationStoresApi.
+		ReadOnePropagationStore(ctx, environmentID, storeID).
+		Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		return propagationStoreConnectionResult{}, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
+	}
+
+	storeObj, err := utils.DecodeResponseJSONObject(httpResp, "store")
+	if err != nil {
+		return propagationStoreConnectionResult{}, err
+	}
+
+	configuration, _ := storeObj["configuration"].(map[string]interface{})
+	if configuration == nil {
+		configuration = map[string]interface{}{}
+	}
+	for k, v := range overrides {
+		configuration[k] = v
+	}
+
+	payload := map[string]interface{}{
+		"type":          storeObj["type"],
+		"configuration": configuration,
+	}
+	if name, ok := storeObj["name"]; ok {
+		payload["name"] = name
+	}
+
+	return postPropagationStoreConnectionCheck(ctx, apiClient, environmentID, payload)
+}
+
+func postPropagationStoreConnectionCheck(ctx context.Context, apiClient *management.APIClient, environmentID string, payload map[string]interface{}) (propagationStoreConnectionResult, error) {
+	cfg := apiClient.GetConfig()
+	if cfg == nil {
+		return propagationStoreConnectionResult{}, fmt.Errorf("api client has nil config")
+	}
+	if cfg.HTTPClient == nil {
+		return propagationStoreConnectionResult{}, fmt.Errorf("api client has nil http client")
+	}
+
+	basePath, err := cfg.ServerURLWithContext(ctx, "PropagationStoresApiService.TestConnectionConfiguration")
+	if err != nil {
+		return propagationStoreConnectionResult{}, err
+	}
+	basePath = strings.TrimRight(strings.TrimSpace(basePath), "/")
+
+	endpoint := fmt.Sprintf(
+		"%s/environments/%s/propagation/stores/connection/status",
+		basePath,
+		url.PathEscape(environmentID),
+	)
+
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return propagationStoreConnectionResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return propagationStoreConnectionResult{}, err
+	}
+	req.Header.Set("Content-Type", propagationStoreConnectionCheckContentType)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return propagationStoreConnectionResult{}, fmt.Errorf("%s", utils.DescribeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return propagationStoreConnectionResult{}, err
+	}
+
+	result := propagationStoreConnectionResult{
+		Success:    resp.StatusCode < 300,
+		StatusCode: resp.StatusCode,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// These describe the provider's own credentials, not the store's.
+		return result, fmt.Errorf("PingOne rejected the request (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	case http.StatusNotFound:
+		return result, fmt.Errorf("environment not found (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if !result.Success {
+		result.Message = propagationStoreConnectionMessage(respBody)
+	}
+
+	return result, nil
+}
+
+// propagationStoreConnectionMessage returns the error details from a failed connection test,
+// preferring the nested details PingOne reports for the target system.
+func propagationStoreConnectionMessage(body []byte) string {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+
+	parts := []string{}
+	if message, ok := utils.NestedString(decoded, "message"); ok && message != "" {
+		parts = append(parts, strings.TrimSuffix(message, "."))
+	}
+	if details, ok := decoded["details"].([]interface{}); ok {
+		for _, raw := range details {
+			detail, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if message, ok := utils.NestedString(detail, "message"); ok && message != "" {
+				parts = append(parts, message)
+			}
+		}
+	}
+
+	if len(parts) == 0 {
+		return strings.TrimSpace(string(body))
+	}
+	return strings.Join(parts, ": ")
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestTestPropagationStoreConnection_SendsStoreConfigurationWithOverrides(t *testing.T) {
+	t.Parallel()
+
+	var sent map[string]interface{}
+	checkStatus := http.StatusOK
+	checkBody := `{}`
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		body := `{"id":"store-id","name":"SCIM","type":"scim","configuration":{"SCIM_URL":"https://scim.example","AUTHENTICATION_METHOD":"Bearer"}}`
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/environments/env-id/propagation/stores/store-id":
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/environments/env-id/propagation/stores/connection/status":
+			if got := r.Header.Get("Content-Type"); got != propagationStoreConnectionCheckContentType {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			status, body = checkStatus, checkBody
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			status = http.StatusNotFound
+		}
+
+		return testResponse(r, status, body), nil
+	})
+
+	got, err := testPropagationStoreConnection(context.Background(), apiClient, "env-id", "store-id", map[string]string{"BEARER_TOKEN": "rotated"})
+	if err != nil {
+		t.Fatalf("testPropagationStoreConnection error: %v", err)
+	}
+	if !got.Success || got.StatusCode != http.StatusOK || got.Message != "" {
+		t.Fatalf("result = %+v", got)
+	}
+	if sent["type"] != "scim" {
+		t.Fatalf("type = %v", sent["type"])
+	}
+	configuration, _ := sent["configuration"].(map[string]interface{})
+	if configuration["BEARER_TOKEN"] != "rotated" || configuration["SCIM_URL"] != "https://scim.example" {
+		t.Fatalf("configuration = %v", configuration)
+	}
+
+	checkStatus = http.StatusBadRequest
+	checkBody = `{"code":"INVALID_DATA","message":"The request could not be completed.","details":[{"code":"INVALID_VALUE","message":"Unable to connect: 401 Unauthorized"}]}`
+	got, err = testPropagationStoreConnection(context.Background(), apiClient, "env-id", "store-id", nil)
+	if err != nil {
+		t.Fatalf("testPropagationStoreConnection error: %v", err)
+	}
+	if got.Success || got.StatusCode != http.StatusBadRequest {
+		t.Fatalf("result = %+v", got)
+	}
+	if got.Message != "The request could not be completed: Unable to connect: 401 Unauthorized" {
+		t.Fatalf("message = %q", got.Message)
+	}
+}
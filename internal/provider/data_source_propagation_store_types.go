@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &propagationStoreTypesDataSource{}
+	_ datasource.DataSourceWithConfigure = &propagationStoreTypesDataSource{}
+)
+
+type propagationStoreTypesDataSource struct {
+	client *client.Client
+}
+
+type propagationStoreTypesDataSourceModel struct {
+	EnvironmentId              types.String `tfsdk:"environment_id"`
+	RequiredTypes              types.List   `tfsdk:"required_types"`
+	LicenseId                  types.String `tfsdk:"license_id"`
+	ProvisioningEnabled        types.Bool   `tfsdk:"provisioning_enabled"`
+	InboundProvisioningEnabled types.Bool   `tfsdk:"inbound_provisioning_enabled"`
+	LdapGatewayEnabled         types.Bool   `tfsdk:"ldap_gateway_enabled"`
+	StoreTypes                 types.List   `tfsdk:"store_types"`
+	UnavailableStoreTypes      types.List   `tfsdk:"unavailable_store_types"`
+}
+
+// propagationStoreEntitlements holds the license flags that gate propagation store types.
+// A nil flag means the license did not report it, which is treated as not restricted.
+type propagationStoreEntitlements struct {
+	Provisioning        *bool
+	InboundProvisioning *bool
+	LdapGateway         *bool
+}
+
+func NewPropagationStoreTypesDataSource() datasource.DataSource {
+	return &propagationStoreTypesDataSource{}
+}
+
+func (d *propagationStoreTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_store_types"
+}
+
+func (d *propagationStoreTypesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the propagation store types the environment's license allows, so modules can conditionally create stores.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"required_types": schema.ListAttribute{
+				Description: "Optional store types that must be available. The read fails with an error naming the missing types when any are not licensed.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"license_id": schema.StringAttribute{
+				Description: "The ID of the license assigned to the environment.",
+				Computed:    true,
+			},
+			"provisioning_enabled": schema.BoolAttribute{
+				Description: "Indicates whether the license allows outbound provisioning.",
+				Computed:    true,
+			},
+			"inbound_provisioning_enabled": schema.BoolAttribute{
+				Description: "Indicates whether the license allows inbound provisioning (for example from Workday).",
+				Computed:    true,
+			},
+			"ldap_gateway_enabled": schema.BoolAttribute{
+				Description: "Indicates whether the license allows LDAP gateways.",
+				Computed:    true,
+			},
+			"store_types": schema.ListAttribute{
+				Description: "The store types available in the environment, using the values accepted by `pingoneprovisioning_propagation_store.type`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"unavailable_store_types": schema.ListAttribute{
+				Description: "The store types supported by the provider that the environment's license does not allow.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *propagationStoreTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *propagationStoreTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state propagationStoreTypesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	apiClient := d.client.API
+
+	tflog.Info(ctx, "Reading propagation store types for environment", map[string]interface{}{
+		"environment_id": environmentID,
+	})
+
+	environment, httpResp, err := apiClient.EnvironmentsApi.ReadOneEnvironment(ctx, environmentID).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment",
+			fmt.Sprintf("Could not read environment: %s", utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	organizationID := ""
+	if org, ok := environment.GetOrganizationOk(); ok && org != nil {
+		organizationID = org.GetId()
+	}
+	licenseID := environment.GetLicense().Id
+
+	if organizationID == "" || licenseID == "" {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment License",
+			fmt.Sprintf("Environment '%s' did not report an organization and license.", environmentID),
+		)
+		return
+	}
+
+	license, httpResp, err := apiClient.LicensesApi.ReadOneLicense(ctx, organizationID, licenseID).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment License",
+			fmt.Sprintf("Could not read license '%s': %s", licenseID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	var entitlements propagationStoreEntitlements
+	if users, ok := license.GetUsersOk(); ok && users != nil {
+		entitlements.Provisioning = users.AllowProvisioning
+		entitlements.InboundProvisioning = users.AllowInboundProvisioning
+	}
+	if gateways, ok := license.GetGatewaysOk(); ok && gateways != nil {
+		entitlements.LdapGateway = gateways.AllowLdapGateway
+	}
+
+	available, unavailable := propagationStoreTypeAvailability(entitlements)
+
+	if !state.RequiredTypes.IsNull() && !state.RequiredTypes.IsUnknown() {
+		var required []string
+		resp.Diagnostics.Append(state.RequiredTypes.ElementsAs(ctx, &required, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if missing := missingPropagationStoreTypes(required, available); len(missing) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("required_types"),
+				"Propagation Store Types Not Available",
+				fmt.Sprintf("The license assigned to environment '%s' does not allow the following propagation store types: %s.", environmentID, strings.Join(missing, ", ")),
+			)
+			return
+		}
+	}
+
+	state.EnvironmentId = types.StringValue(environmentID)
+	state.LicenseId = types.StringValue(licenseID)
+	state.ProvisioningEnabled = types.BoolValue(entitlementAllowed(entitlements.Provisioning))
+	state.InboundProvisioningEnabled = types.BoolValue(entitlementAllowed(entitlements.InboundProvisioning))
+	state.LdapGatewayEnabled = types.BoolValue(entitlementAllowed(entitlements.LdapGateway))
+
+	availableList, diags := types.ListValueFrom(ctx, types.StringType, available)
+	resp.Diagnostics.Append(diags...)
+	state.StoreTypes = availableList
+
+	unavailableList, diags := types.ListValueFrom(ctx, types.StringType, unavailable)
+	resp.Diagnostics.Append(diags...)
+	state.UnavailableStoreTypes = unavailableList
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func entitlementAllowed(flag *bool) bool {
+	return flag == nil || *flag
+}
+
+// propagationStoreTypeAvailability splits the provider's supported store types by whether
+// the license entitlements allow them.
+func propagationStoreTypeAvailability(entitlements propagationStoreEntitlements) ([]string, []string) {
+	available := make([]string, 0, len(utils.PropagationStoreTerraformTypes))
+	unavailable := make([]string, 0)
+
+	for _, storeType := range utils.PropagationStoreTerraformTypes {
+		allowed := entitlementAllowed(entitlements.Provisioning)
+		switch storeType {
+		case "Workday":
+			// Workday is an inbound source rather than an outbound target.
+			allowed = entitlementAllowed(entitlements.InboundProvisioning)
+		case "LDAPGateway":
+			allowed = allowed && entitlementAllowed(entitlements.LdapGateway)
+		}
+
+		if allowed {
+			available = append(available, storeType)
+		} else {
+			unavailable = append(unavailable, storeType)
+		}
+	}
+
+	return available, unavailable
+}
+
+// missingPropagationStoreTypes returns the required types that are not in the available set,
+// accepting any spelling the propagation store resource accepts.
+func missingPropagationStoreTypes(required, available []string) []string {
+	availableAPI := make(map[string]bool, len(available))
+	for _, storeType := range available {
+		availableAPI[strings.ToLower(utils.NormalizePropagationStoreTypeForAPI(storeType))] = true
+	}
+
+	var missing []string
+	for _, storeType := range required {
+		if !availableAPI[strings.ToLower(utils.NormalizePropagationStoreTypeForAPI(storeType))] {
+			missing = append(missing, storeType)
+		}
+	}
+	return missing
+}
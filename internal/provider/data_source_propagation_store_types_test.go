@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPropagationStoreTypeAvailability(t *testing.T) {
+	t.Parallel()
+
+	allow := true
+	deny := false
+
+	tests := []struct {
+		name            string
+		entitlements    propagationStoreEntitlements
+		wantUnavailable []string
+	}{
+		{
+			name:            "unreported_flags_are_not_restricted",
+			entitlements:    propagationStoreEntitlements{},
+			wantUnavailable: []string{},
+		},
+		{
+			name:            "no_inbound_provisioning",
+			entitlements:    propagationStoreEntitlements{Provisioning: &allow, InboundProvisioning: &deny},
+			wantUnavailable: []string{"Workday"},
+		},
+		{
+			name:            "no_ldap_gateway",
+			entitlements:    propagationStoreEntitlements{Provisioning: &allow, LdapGateway: &deny},
+			wantUnavailable: []string{"LDAPGateway"},
+		},
+		{
+			name:         "no_outbound_provisioning",
+			entitlements: propagationStoreEntitlements{Provisioning: &deny, InboundProvisioning: &allow},
+			wantUnavailable: []string{
+				"Aquera", "AzureADSAMLV2", "GithubEMU", "GoogleApps", "LDAPGateway", "PingOne",
+				"Salesforce", "SalesforceContacts", "SCIM", "ServiceNow", "Slack", "Zoom",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			available, unavailable := propagationStoreTypeAvailability(tt.entitlements)
+			if !reflect.DeepEqual(unavailable, tt.wantUnavailable) {
+				t.Fatalf("unavailable = %v, want %v", unavailable, tt.wantUnavailable)
+			}
+			if len(available)+len(unavailable) != 13 {
+				t.Fatalf("expected every store type to be classified, got %d available and %d unavailable", len(available), len(unavailable))
+			}
+		})
+	}
+}
+
+func TestMissingPropagationStoreTypes(t *testing.T) {
+	t.Parallel()
+
+	available := []string{"SCIM", "LDAPGateway", "PingOne"}
+
+	got := missingPropagationStoreTypes([]string{"scim", "LdapGateway", "Workday", "pingone"}, available)
+	want := []string{"Workday"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("missingPropagationStoreTypes() = %v, want %v", got, want)
+	}
+}
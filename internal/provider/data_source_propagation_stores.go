@@ -3,17 +3,14 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
+	"net/http"
+	"net/url"
 	"strings"
-	"time"
 
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
-	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/mappers"
 	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -118,96 +115,42 @@ func (d *propagationStoresDataSource) Read(ctx context.Context, req datasource.R
 
 	apiClient := d.client.API
 
-	iterator := apiClient.PropagationStoresApi.ReadAllStores(ctx, environmentID).Execute()
+	var stores []map[string]interface{}
+	var err error
+	if !state.StoreId.IsNull() && !state.StoreId.IsUnknown() {
+		stores, err = readPropagationStoreForList(ctx, apiClient, environmentID, filterStoreId)
+	} else {
+		stores, err = listPropagationStores(ctx, d.client.Endpoints, apiClient, environmentID, d.client.PageSize)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Stores",
+			fmt.Sprintf("Could not list propagation stores: %s", err),
+		)
+		return
+	}
 
 	var propagationStores []customtypes.PropagationStoreModel
 	var ids []string
 
-	for cursor, iterErr := range iterator {
-		if iterErr != nil {
-			resp.Diagnostics.AddError(
-				"Error Reading Propagation Stores",
-				fmt.Sprintf("Could not iterate propagation stores: %s", iterErr),
-			)
-			return
-		}
-
-		if cursor.HTTPResponse == nil {
-			continue
-		}
-
-		bodyBytes, readErr := io.ReadAll(cursor.HTTPResponse.Body)
-		_ = cursor.HTTPResponse.Body.Close()
-		if readErr != nil {
-			resp.Diagnostics.AddError(
-				"Error Reading Propagation Stores",
-				fmt.Sprintf("Could not read propagation stores response: %s", readErr),
-			)
-			return
-		}
-
-		var rawResponse map[string]interface{}
-		if err := json.Unmarshal(bodyBytes, &rawResponse); err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Propagation Stores",
-				fmt.Sprintf("Could not parse propagation stores response: %s", err),
-			)
-			return
-		}
-
-		embedded, ok := rawResponse["_embedded"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		stores, ok := embedded["stores"].([]interface{})
-		if !ok {
-			continue
-		}
+	for _, sMap := range stores {
+		storeTypeRaw, _ := utils.NestedString(sMap, "type")
 
-		for _, s := range stores {
-			sMap, ok := s.(map[string]interface{})
-			if !ok {
+		if !state.Type.IsNull() && !state.Type.IsUnknown() {
+			if !strings.EqualFold(storeTypeRaw, filterTypeAPI) {
 				continue
 			}
+		}
 
-			storeTypeRaw, _ := sMap["type"].(string)
-			storeStatusRaw, _ := sMap["status"].(string)
-
-			storeJSON, err := json.Marshal(sMap)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error Parsing Propagation Store",
-					fmt.Sprintf("Could not marshal propagation store response: %s", err),
-				)
-				return
-			}
-
-			var storeObj management.PropagationStore
-			if err := json.Unmarshal(storeJSON, &storeObj); err != nil {
-				resp.Diagnostics.AddError(
-					"Error Parsing Propagation Store",
-					fmt.Sprintf("Could not unmarshal propagation store response: %s", err),
-				)
-				return
-			}
-
-			if !state.Type.IsNull() && !state.Type.IsUnknown() {
-				if !strings.EqualFold(storeTypeRaw, filterTypeAPI) {
-					continue
-				}
-			}
-
-			if !state.StoreId.IsNull() && !state.StoreId.IsUnknown() {
-				if storeObj.GetId() != filterStoreId {
-					continue
-				}
+		if !state.StoreId.IsNull() && !state.StoreId.IsUnknown() {
+			if id, _ := utils.NestedString(sMap, "id"); id != filterStoreId {
+				continue
 			}
-
-			storeModel := d.apiToModel(&storeObj, storeTypeRaw, storeStatusRaw, environmentID)
-			propagationStores = append(propagationStores, storeModel)
-			ids = append(ids, storeObj.GetId())
 		}
+
+		storeModel := propagationStoreFromJSON(sMap, environmentID, "", nil)
+		propagationStores = append(propagationStores, storeModel)
+		ids = append(ids, storeModel.Id.ValueString())
 	}
 
 	tflog.Info(ctx, "Finished reading propagation stores", map[string]interface{}{
@@ -226,97 +169,38 @@ func (d *propagationStoresDataSource) Read(ctx context.Context, req datasource.R
 	resp.Diagnostics.Append(diags...)
 }
 
-// apiToModel maps the SDK object to the Terraform model.
-func (d *propagationStoresDataSource) apiToModel(apiObj *management.PropagationStore, rawType string, rawStatus string, environmentId string) customtypes.PropagationStoreModel {
-	apiType := rawType
-	if apiType == "" || apiType == "UNKNOWN" {
-		apiType = string(apiObj.GetType())
-	}
-	tfType := utils.NormalizePropagationStoreTypeForTerraform(apiType, "")
-
-	model := customtypes.PropagationStoreModel{
-		Id:            types.StringValue(apiObj.GetId()),
-		EnvironmentId: types.StringValue(environmentId),
-		Name:          types.StringValue(apiObj.GetName()),
-		Type:          types.StringValue(tfType),
-	}
-
-	if v, ok := apiObj.GetDescriptionOk(); ok {
-		model.Description = types.StringValue(*v)
-	} else {
-		model.Description = types.StringNull()
-	}
-
-	if image, ok := apiObj.GetImageOk(); ok && image != nil {
-		if v, ok := image.GetIdOk(); ok && v != nil {
-			model.ImageId = types.StringValue(*v)
-		} else {
-			model.ImageId = types.StringNull()
-		}
-
-		if v, ok := image.GetHrefOk(); ok && v != nil {
-			model.ImageHref = types.StringValue(*v)
-		} else {
-			model.ImageHref = types.StringNull()
-		}
-	} else {
-		model.ImageId = types.StringNull()
-		model.ImageHref = types.StringNull()
-	}
-
-	if v, ok := apiObj.GetManagedOk(); ok {
-		model.Managed = types.BoolValue(*v)
-	} else {
-		model.Managed = types.BoolNull()
-	}
+// listPropagationStores returns every propagation store in the environment as raw JSON objects,
+// following pagination links.
+func listPropagationStores(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, pageSize int32) ([]map[string]interface{}, error) {
+	return listPingOneCollection(
+		ctx,
+		endpoints,
+		apiClient,
+		endpointFamilyPropagationStores,
+		fmt.Sprintf("/environments/%s/propagation/stores", url.PathEscape(environmentID)),
+		pageSize,
+		"stores",
+	)
+}
 
-	status := rawStatus
-	if status == "" {
-		if v, ok := apiObj.GetStatusOk(); ok && v != nil && string(*v) != "" && string(*v) != "UNKNOWN" {
-			status = string(*v)
-		}
+// readPropagationStoreForList reads a single store as a one-item list, or an empty list when the
+// store does not exist, so that filtering by ID does not list every store in the environment.
+func readPropagationStoreForList(ctx context.Context, apiClient *management.APIClient, environmentID string, storeID string) ([]map[string]interface{}, error) {
+	// The SDK can fail to decode stores with newer enum values, so the store is read from the
+	// raw response whenever the request itself succeeded.
+	_, httpResp, err := apiClient.PropagationStoresApi.
+		ReadOnePropagationStore(ctx, environmentID, storeID).
+		Execute()
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return nil, nil
 	}
-	if status != "" {
-		model.Status = types.StringValue(status)
-	} else {
-		model.Status = types.StringNull()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		return nil, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
 	}
 
-	if v, ok := apiObj.GetSyncStatusOk(); ok && v != nil {
-		attrs := map[string]attr.Value{
-			"last_sync_time": types.StringNull(),
-			"next_sync_time": types.StringNull(),
-			"status":         types.StringNull(),
-			"details":        types.StringNull(),
-		}
-
-		if vv, ok := v.GetLastSyncAtOk(); ok && vv != nil {
-			attrs["last_sync_time"] = types.StringValue(vv.Format(time.RFC3339))
-		}
-		if vv, ok := v.GetSyncStateOk(); ok && vv != nil {
-			attrs["status"] = types.StringValue(string(*vv))
-		}
-		if vv, ok := v.GetDetailsOk(); ok && vv != nil {
-			attrs["details"] = types.StringValue(*vv)
-		}
-
-		model.SyncStatus = types.ObjectValueMust(map[string]attr.Type{
-			"last_sync_time": types.StringType,
-			"next_sync_time": types.StringType,
-			"status":         types.StringType,
-			"details":        types.StringType,
-		}, attrs)
-	} else {
-		model.SyncStatus = types.ObjectNull(map[string]attr.Type{
-			"last_sync_time": types.StringType,
-			"next_sync_time": types.StringType,
-			"status":         types.StringType,
-			"details":        types.StringType,
-		})
+	storeObj, err := utils.DecodeResponseJSONObject(httpResp, "store")
+	if err != nil {
+		return nil, err
 	}
-
-	config := apiObj.GetConfiguration()
-	mappers.ApplyPropagationStoreConfigurationFromMap(&model, tfType, config, nil)
-
-	return model
+	return []map[string]interface{}{storeObj}, nil
 }
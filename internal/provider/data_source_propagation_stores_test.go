@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPropagationStoresDataSourceRead_StoreIDReadsOneStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var requests []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		status, body := http.StatusOK, `{"id":"store-1","name":"Slack","type":"Slack","status":"ACTIVE","configuration":{"BASE_URL":"https://slack.example"}}`
+		if r.URL.Path != "/v1/environments/env-id/propagation/stores/store-1" {
+			status, body = http.StatusNotFound, `{"code":"NOT_FOUND","message":"not found"}`
+		}
+
+		return testResponse(r, status, body), nil
+	})
+
+	d := &propagationStoresDataSource{client: &client.Client{API: apiClient}}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	read := func(storeID string) propagationStoresDataSourceModel {
+		config := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		if diags := config.Set(ctx, &propagationStoresDataSourceModel{
+			EnvironmentId: types.StringValue("env-id"),
+			Type:          types.StringNull(),
+			StoreId:       types.StringValue(storeID),
+			Stores:        types.ListNull(schemaResp.Schema.Attributes["stores"].GetType().(types.ListType).ElemType),
+			Ids:           types.ListNull(types.StringType),
+		}); diags.HasError() {
+			t.Fatalf("State.Set: %v", diags)
+		}
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Read: %v", resp.Diagnostics)
+		}
+
+		var got propagationStoresDataSourceModel
+		if diags := resp.State.Get(ctx, &got); diags.HasError() {
+			t.Fatalf("State.Get: %v", diags)
+		}
+		return got
+	}
+
+	got := read("store-1")
+	if len(requests) != 1 || requests[0] != "GET /v1/environments/env-id/propagation/stores/store-1" {
+		t.Fatalf("requests = %v, want a single read of the store", requests)
+	}
+	if ids := got.Ids.Elements(); len(ids) != 1 || ids[0].(types.String).ValueString() != "store-1" {
+		t.Fatalf("ids = %v, want [store-1]", got.Ids)
+	}
+
+	if got := read("missing"); len(got.Ids.Elements()) != 0 {
+		t.Fatalf("ids for a missing store = %v, want none", got.Ids)
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &providerConfigDataSource{}
+	_ datasource.DataSourceWithConfigure = &providerConfigDataSource{}
+)
+
+type providerConfigDataSource struct {
+	client *client.Client
+}
+
+type providerConfigDataSourceModel struct {
+	Region            types.String `tfsdk:"region"`
+	APIHostname       types.String `tfsdk:"api_hostname"`
+	APIBaseURL        types.String `tfsdk:"api_base_url"`
+	TokenURL          types.String `tfsdk:"token_url"`
+	ServerIndex       types.Int64  `tfsdk:"server_index"`
+	FallbackHostnames types.List   `tfsdk:"fallback_hostnames"`
+}
+
+func NewProviderConfigDataSource() datasource.DataSource {
+	return &providerConfigDataSource{}
+}
+
+func (d *providerConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_config"
+}
+
+func (d *providerConfigDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the region and hostnames the provider resolved from its configuration, to help debug multi-region setups. No API requests are made.",
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Description: "The configured region, as the PingOne SDK's long name (for example `NorthAmerica` or `Europe`).",
+				Computed:    true,
+			},
+			"api_hostname": schema.StringAttribute{
+				Description: "The Management API hostname requests are sent to, for example `api.pingone.com`.",
+				Computed:    true,
+			},
+			"api_base_url": schema.StringAttribute{
+				Description: "The full Management API base URL, including the API version path.",
+				Computed:    true,
+			},
+			"token_url": schema.StringAttribute{
+				Description: "The OAuth token URL the provider authenticates against.",
+				Computed:    true,
+			},
+			"server_index": schema.Int64Attribute{
+				Description: "The PingOne SDK server index in use: `0` when the hostname is derived from `region`, `1` when it comes from `api_base_url`.",
+				Computed:    true,
+			},
+			"fallback_hostnames": schema.ListAttribute{
+				Description: "The hostnames propagation rule requests fall back to, in order, when the configured hostname reports the plan or store as not found.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *providerConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *providerConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil || d.client.API == nil {
+		resp.Diagnostics.AddError(
+			"Provider Not Configured",
+			"The provider has not been configured, so its resolved settings are not available.",
+		)
+		return
+	}
+
+	state, err := providerConfigFromClient(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Resolve Provider Configuration",
+			fmt.Sprintf("Could not resolve the Management API base URL: %s", err),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func providerConfigFromClient(clientData *client.Client) (providerConfigDataSourceModel, error) {
+	cfg := clientData.API.GetConfig()
+
+	baseURL, err := cfg.ServerURL(cfg.DefaultServerIndex, nil)
+	if err != nil {
+		return providerConfigDataSourceModel{}, err
+	}
+
+	fallbacks := make([]types.String, 0)
+	for _, hostname := range pingOneFallbackBaseHostnames(clientData.API) {
+		fallbacks = append(fallbacks, types.StringValue(hostname))
+	}
+	fallbackList, _ := types.ListValueFrom(context.Background(), types.StringType, fallbacks)
+
+	return providerConfigDataSourceModel{
+		Region:            types.StringValue(clientData.Region),
+		APIHostname:       types.StringValue(currentPingOneHostname(clientData.API)),
+		APIBaseURL:        types.StringValue(baseURL),
+		TokenURL:          types.StringValue(clientData.TokenURL),
+		ServerIndex:       types.Int64Value(int64(cfg.DefaultServerIndex)),
+		FallbackHostnames: fallbackList,
+	}, nil
+}
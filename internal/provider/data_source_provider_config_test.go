@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+)
+
+func TestProviderConfigFromClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		region       string
+		tokenURL     string
+		apiBaseURL   string
+		wantHostname string
+		wantBaseURL  string
+		wantTokenURL string
+		wantIndex    int64
+	}{
+		{
+			name:         "derived_from_region",
+			region:       "Europe",
+			wantHostname: "api.pingone.eu",
+			wantBaseURL:  "https://api.pingone.eu/v1",
+			wantTokenURL: "https://auth.pingone.eu/env-id/as/token",
+			wantIndex:    0,
+		},
+		{
+			name:         "overridden",
+			region:       "NorthAmerica",
+			tokenURL:     "auth.example/env-id/as/token",
+			apiBaseURL:   "https://api.example",
+			wantHostname: "api.example",
+			wantBaseURL:  "https://api.example/v1",
+			wantTokenURL: "https://auth.example/env-id/as/token",
+			wantIndex:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient, err := newManagementClient(context.Background(), "", "client-id", "client-secret", "env-id", tt.region, tt.tokenURL, tt.apiBaseURL, defaultRequestTimeout, defaultRequestTimeout, nil)
+			if err != nil {
+				t.Fatalf("newManagementClient: %v", err)
+			}
+			tokenURL, err := resolveTokenURL(tt.region, "env-id", tt.tokenURL)
+			if err != nil {
+				t.Fatalf("resolveTokenURL: %v", err)
+			}
+
+			got, err := providerConfigFromClient(&client.Client{API: apiClient, Region: tt.region, TokenURL: tokenURL})
+			if err != nil {
+				t.Fatalf("providerConfigFromClient: %v", err)
+			}
+
+			if got.Region.ValueString() != tt.region {
+				t.Errorf("region = %q, want %q", got.Region.ValueString(), tt.region)
+			}
+			if got.APIHostname.ValueString() != tt.wantHostname {
+				t.Errorf("api_hostname = %q, want %q", got.APIHostname.ValueString(), tt.wantHostname)
+			}
+			if got.APIBaseURL.ValueString() != tt.wantBaseURL {
+				t.Errorf("api_base_url = %q, want %q", got.APIBaseURL.ValueString(), tt.wantBaseURL)
+			}
+			if got.TokenURL.ValueString() != tt.wantTokenURL {
+				t.Errorf("token_url = %q, want %q", got.TokenURL.ValueString(), tt.wantTokenURL)
+			}
+			if got.ServerIndex.ValueInt64() != tt.wantIndex {
+				t.Errorf("server_index = %d, want %d", got.ServerIndex.ValueInt64(), tt.wantIndex)
+			}
+			for _, hostname := range got.FallbackHostnames.Elements() {
+				if hostname.String() == `"`+tt.wantHostname+`"` {
+					t.Errorf("fallback_hostnames includes the configured hostname %s", hostname)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ datasource.DataSource              = &rolePreflightDataSource{}
+	_ datasource.DataSourceWithConfigure = &rolePreflightDataSource{}
+)
+
+// PingOne built-in role names. Each list is the set of roles, any one of which is enough.
+var (
+	rolesConfigurationWrite = []string{"Environment Admin"}
+	rolesConfigurationRead  = []string{"Environment Admin", "Configuration Read Only"}
+	rolesIdentityDataWrite  = []string{"Identity Data Admin"}
+	rolesIdentityDataRead   = []string{"Identity Data Admin", "Identity Data Read Only"}
+)
+
+// resourceRoleRequirements lists, per resource type, the roles the worker application needs in
+// the managed environment. Resource types that only call GitHub need no PingOne role.
+var resourceRoleRequirements = map[string][]string{
+	"pingoneprovisioning_propagation_store":                     rolesConfigurationWrite,
+	"pingoneprovisioning_propagation_store_mirror":              rolesConfigurationWrite,
+	"pingoneprovisioning_propagation_store_pingone_credentials": rolesConfigurationWrite,
+	"pingoneprovisioning_propagation_plan":                      rolesConfigurationWrite,
+	"pingoneprovisioning_propagation_default_plan":              rolesConfigurationWrite,
+	"pingoneprovisioning_propagation_rule":                      rolesConfigurationWrite,
+	"pingoneprovisioning_propagation_rule_set":                  rolesConfigurationWrite,
+	"pingoneprovisioning_user_custom_attributes":                rolesIdentityDataWrite,
+	"pingoneprovisioning_group_membership":                      rolesIdentityDataWrite,
+	"pingoneprovisioning_group_memberships":                     rolesIdentityDataWrite,
+	"pingoneprovisioning_enterprise_team_organizations":         nil,
+	"pingoneprovisioning_enterprise_team_external_group":        nil,
+}
+
+// dataSourceRoleRequirements lists, per data source type, the roles the worker application
+// needs in the managed environment.
+var dataSourceRoleRequirements = map[string][]string{
+	"pingoneprovisioning_propagation_store":            rolesConfigurationRead,
+	"pingoneprovisioning_propagation_stores":           rolesConfigurationRead,
+	"pingoneprovisioning_propagation_plan":             rolesConfigurationRead,
+	"pingoneprovisioning_propagation_rule":             rolesConfigurationRead,
+	"pingoneprovisioning_propagation_rule_mapping":     rolesConfigurationRead,
+	"pingoneprovisioning_propagation_rule_preview":     rolesConfigurationRead,
+	"pingoneprovisioning_propagation_impact":           rolesConfigurationRead,
+	"pingoneprovisioning_propagation_mapping_coverage": rolesConfigurationRead,
+	"pingoneprovisioning_propagation_revisions":        rolesConfigurationRead,
+	"pingoneprovisioning_propagation_store_ready":      rolesConfigurationRead,
+	"pingoneprovisioning_propagation_store_types":      rolesConfigurationRead,
+	"pingoneprovisioning_propagation_store_test":       rolesConfigurationWrite,
+	"pingoneprovisioning_gateway":                      rolesConfigurationRead,
+	"pingoneprovisioning_environment":                  rolesConfigurationRead,
+	"pingoneprovisioning_environments":                 rolesConfigurationRead,
+	"pingoneprovisioning_groups":                       rolesIdentityDataRead,
+	"pingoneprovisioning_user":                         rolesIdentityDataRead,
+	"pingoneprovisioning_user_custom_attributes":       rolesIdentityDataRead,
+	"pingoneprovisioning_provider_config":              nil,
+	"pingoneprovisioning_github_scim_group":            nil,
+	"pingoneprovisioning_github_enterprise_teams":      nil,
+	"pingoneprovisioning_enterprise_organizations":     nil,
+}
+
+type rolePreflightDataSource struct {
+	client *client.Client
+}
+
+type rolePreflightDataSourceModel struct {
+	EnvironmentId   types.String `tfsdk:"environment_id"`
+	ApplicationId   types.String `tfsdk:"application_id"`
+	ResourceTypes   types.List   `tfsdk:"resource_types"`
+	DataSourceTypes types.List   `tfsdk:"data_source_types"`
+	AssignedRoles   types.List   `tfsdk:"assigned_roles"`
+	MissingRoles    types.List   `tfsdk:"missing_roles"`
+}
+
+var roleAssignmentObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"role_id":    types.StringType,
+		"role_name":  types.StringType,
+		"scope_type": types.StringType,
+		"scope_id":   types.StringType,
+	},
+}
+
+// roleAssignment is one role assigned to the worker application.
+type roleAssignment struct {
+	RoleID    string
+	RoleName  string
+	ScopeType string
+	ScopeID   string
+}
+
+func NewRolePreflightDataSource() datasource.DataSource {
+	return &rolePreflightDataSource{}
+}
+
+func (d *rolePreflightDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_preflight"
+}
+
+func (d *rolePreflightDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks the worker application's role assignments against the roles needed by the resource and data source types a configuration uses, and warns about missing roles before an apply fails with 403.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment the configuration manages. Roles must be scoped to this environment or to the organization.",
+				Required:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the worker application to check. Defaults to the provider's `client_id`, which PingOne uses as the application ID.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"resource_types": schema.ListAttribute{
+				Description: "The resource types the configuration manages, for example `pingoneprovisioning_propagation_rule`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(sortedKeys(resourceRoleRequirements)...)),
+				},
+			},
+			"data_source_types": schema.ListAttribute{
+				Description: "The data source types the configuration reads, for example `pingoneprovisioning_user`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(sortedKeys(dataSourceRoleRequirements)...)),
+				},
+			},
+			"assigned_roles": schema.ListNestedAttribute{
+				Description: "The roles assigned to the worker application, in any scope.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role_id": schema.StringAttribute{
+							Description: "The ID of the role.",
+							Computed:    true,
+						},
+						"role_name": schema.StringAttribute{
+							Description: "The name of the role.",
+							Computed:    true,
+						},
+						"scope_type": schema.StringAttribute{
+							Description: "The scope type of the assignment, for example `ENVIRONMENT`, `ORGANIZATION` or `POPULATION`.",
+							Computed:    true,
+						},
+						"scope_id": schema.StringAttribute{
+							Description: "The ID of the environment, organization or population the role is scoped to.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"missing_roles": schema.ListAttribute{
+				Description: "The roles the listed types need but the worker application lacks in `environment_id`. Where any of several roles would do, the entry joins them with ` or `, for example `Identity Data Admin or Identity Data Read Only`. Empty when nothing is missing.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *rolePreflightDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *rolePreflightDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state rolePreflightDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	applicationID := strings.TrimSpace(d.client.ClientID)
+	if !state.ApplicationId.IsNull() && !state.ApplicationId.IsUnknown() {
+		applicationID = strings.TrimSpace(state.ApplicationId.ValueString())
+	}
+
+	var resourceTypes, dataSourceTypes []string
+	if !state.ResourceTypes.IsNull() {
+		resp.Diagnostics.Append(state.ResourceTypes.ElementsAs(ctx, &resourceTypes, false)...)
+	}
+	if !state.DataSourceTypes.IsNull() {
+		resp.Diagnostics.Append(state.DataSourceTypes.ElementsAs(ctx, &dataSourceTypes, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Checking worker application roles", map[string]interface{}{
+		"environment_id": environmentID,
+		"application_id": applicationID,
+	})
+
+	assignments, err := readApplicationRoleAssignments(ctx, d.client.Endpoints, d.client.API, d.client.AuthEnvironmentID, applicationID, d.client.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Role Assignments",
+			fmt.Sprintf("Could not read the role assignments of application '%s': %s", applicationID, err),
+		)
+		return
+	}
+
+	required := make(map[string]*roleRequirement)
+	for _, t := range resourceTypes {
+		addRoleRequirement(required, resourceRoleRequirements[t], t)
+	}
+	for _, t := range dataSourceTypes {
+		addRoleRequirement(required, dataSourceRoleRequirements[t], "data."+t)
+	}
+
+	missing := missingRoles(assignments, environmentID, required)
+	for _, key := range missing {
+		resp.Diagnostics.AddWarning(
+			"Missing PingOne Role",
+			fmt.Sprintf("Application '%s' has no %s role scoped to environment '%s' or its organization, which %s need. Their requests will fail with 403 Forbidden until the role is assigned.", applicationID, key, environmentID, strings.Join(required[key].Types, ", ")),
+		)
+	}
+
+	assigned := make([]attr.Value, 0, len(assignments))
+	for _, a := range assignments {
+		obj, objDiags := types.ObjectValue(roleAssignmentObjectType.AttrTypes, map[string]attr.Value{
+			"role_id":    types.StringValue(a.RoleID),
+			"role_name":  types.StringValue(a.RoleName),
+			"scope_type": types.StringValue(a.ScopeType),
+			"scope_id":   types.StringValue(a.ScopeID),
+		})
+		resp.Diagnostics.Append(objDiags...)
+		assigned = append(assigned, obj)
+	}
+	assignedList, listDiags := types.ListValue(roleAssignmentObjectType, assigned)
+	resp.Diagnostics.Append(listDiags...)
+
+	missingList, listDiags := types.ListValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ApplicationId = types.StringValue(applicationID)
+	state.AssignedRoles = assignedList
+	state.MissingRoles = missingList
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// roleRequirement is a set of roles, any one of which is enough, and the types that need it.
+type roleRequirement struct {
+	Roles []string
+	Types []string
+}
+
+// addRoleRequirement records that typeName needs one of roles. Requirements are keyed by their
+// role names joined with " or ".
+func addRoleRequirement(required map[string]*roleRequirement, roles []string, typeName string) {
+	if len(roles) == 0 {
+		return
+	}
+	key := strings.Join(roles, " or ")
+	if required[key] == nil {
+		required[key] = &roleRequirement{Roles: roles}
+	}
+	required[key].Types = append(required[key].Types, typeName)
+}
+
+// missingRoles returns the keys of the requirements that no assignment scoped to the environment
+// or the organization satisfies, sorted.
+func missingRoles(assignments []roleAssignment, environmentID string, required map[string]*roleRequirement) []string {
+	held := make(map[string]bool)
+	for _, a := range assignments {
+		switch strings.ToUpper(a.ScopeType) {
+		case "ORGANIZATION":
+			held[strings.ToLower(a.RoleName)] = true
+		case "ENVIRONMENT":
+			if a.ScopeID == environmentID {
+				held[strings.ToLower(a.RoleName)] = true
+			}
+		}
+	}
+
+	missing := make([]string, 0)
+	for key, requirement := range required {
+		satisfied := false
+		for _, role := range requirement.Roles {
+			if held[strings.ToLower(role)] {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// readApplicationRoleAssignments lists the application's role assignments with their role
+// names resolved.
+func readApplicationRoleAssignments(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, applicationID string, pageSize int32) ([]roleAssignment, error) {
+	items, err := listPingOneCollection(
+		ctx,
+		endpoints,
+		apiClient,
+		endpointFamilyApplicationRoleAssignments,
+		fmt.Sprintf("/environments/%s/applications/%s/roleAssignments", url.PathEscape(environmentID), url.PathEscape(applicationID)),
+		pageSize,
+		"roleAssignments",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := listPingOneCollection(ctx, endpoints, apiClient, endpointFamilyRoles, "/roles", pageSize, "roles")
+	if err != nil {
+		return nil, err
+	}
+	roleNames := make(map[string]string, len(roles))
+	for _, role := range roles {
+		id, _ := utils.NestedString(role, "id")
+		name, _ := utils.NestedString(role, "name")
+		roleNames[id] = name
+	}
+
+	assignments := make([]roleAssignment, 0, len(items))
+	for _, item := range items {
+		a := roleAssignment{}
+		a.RoleID, _ = utils.NestedString(item, "role", "id")
+		a.ScopeType, _ = utils.NestedString(item, "scope", "type")
+		a.ScopeID, _ = utils.NestedString(item, "scope", "id")
+		a.RoleName = roleNames[a.RoleID]
+		assignments = append(assignments, a)
+	}
+
+	sort.Slice(assignments, func(i, j int) bool {
+		if assignments[i].RoleName != assignments[j].RoleName {
+			return assignments[i].RoleName < assignments[j].RoleName
+		}
+		return assignments[i].ScopeID < assignments[j].ScopeID
+	})
+
+	return assignments, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMissingRoles(t *testing.T) {
+	t.Parallel()
+
+	assignments := []roleAssignment{
+		{RoleName: "Identity Data Read Only", ScopeType: "ENVIRONMENT", ScopeID: "env-id"},
+		{RoleName: "Environment Admin", ScopeType: "ENVIRONMENT", ScopeID: "other-env-id"},
+		{RoleName: "Identity Data Admin", ScopeType: "POPULATION", ScopeID: "population-id"},
+	}
+
+	required := make(map[string]*roleRequirement)
+	addRoleRequirement(required, resourceRoleRequirements["pingoneprovisioning_propagation_rule"], "pingoneprovisioning_propagation_rule")
+	addRoleRequirement(required, resourceRoleRequirements["pingoneprovisioning_group_membership"], "pingoneprovisioning_group_membership")
+	addRoleRequirement(required, dataSourceRoleRequirements["pingoneprovisioning_user"], "data.pingoneprovisioning_user")
+	addRoleRequirement(required, resourceRoleRequirements["pingoneprovisioning_enterprise_team_organizations"], "pingoneprovisioning_enterprise_team_organizations")
+
+	got := missingRoles(assignments, "env-id", required)
+	if want := []string{"Environment Admin", "Identity Data Admin"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("missingRoles = %v, want %v", got, want)
+	}
+
+	assignments = append(assignments, roleAssignment{RoleName: "Environment Admin", ScopeType: "ORGANIZATION", ScopeID: "org-id"})
+	got = missingRoles(assignments, "env-id", required)
+	if want := []string{"Identity Data Admin"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("missingRoles with organization scope = %v, want %v", got, want)
+	}
+}
+
+func TestReadApplicationRoleAssignments(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		body := `{}`
+		switch r.URL.Path {
+		case "/v1/environments/auth-env-id/applications/app-id/roleAssignments":
+			body = `{"_embedded":{"roleAssignments":[
+				{"id":"a1","role":{"id":"role-ida"},"scope":{"id":"env-id","type":"ENVIRONMENT"}},
+				{"id":"a2","role":{"id":"role-ea"},"scope":{"id":"org-id","type":"ORGANIZATION"}}
+			]}}`
+		case "/v1/roles":
+			body = `{"_embedded":{"roles":[{"id":"role-ea","name":"Environment Admin"},{"id":"role-ida","name":"Identity Data Admin"}]}}`
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	got, err := readApplicationRoleAssignments(context.Background(), nil, apiClient, "auth-env-id", "app-id", 0)
+	if err != nil {
+		t.Fatalf("readApplicationRoleAssignments: %v", err)
+	}
+
+	want := []roleAssignment{
+		{RoleID: "role-ea", RoleName: "Environment Admin", ScopeType: "ORGANIZATION", ScopeID: "org-id"},
+		{RoleID: "role-ida", RoleName: "Identity Data Admin", ScopeType: "ENVIRONMENT", ScopeID: "env-id"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("assignments = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ datasource.DataSource                     = &userDataSource{}
+	_ datasource.DataSourceWithConfigure        = &userDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &userDataSource{}
+)
+
+type userDataSource struct {
+	client *client.Client
+}
+
+type userDataSourceModel struct {
+	Id               types.String  `tfsdk:"id"`
+	EnvironmentId    types.String  `tfsdk:"environment_id"`
+	UserId           types.String  `tfsdk:"user_id"`
+	Username         types.String  `tfsdk:"username"`
+	Email            types.String  `tfsdk:"email"`
+	GivenName        types.String  `tfsdk:"given_name"`
+	FamilyName       types.String  `tfsdk:"family_name"`
+	ExternalId       types.String  `tfsdk:"external_id"`
+	Enabled          types.Bool    `tfsdk:"enabled"`
+	PopulationId     types.String  `tfsdk:"population_id"`
+	CustomAttributes types.Dynamic `tfsdk:"custom_attributes"`
+}
+
+func NewUserDataSource() datasource.DataSource {
+	return &userDataSource{}
+}
+
+func (d *userDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *userDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a PingOne user by ID, username or email and returns the core profile with every custom attribute set on the user.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the user.",
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user to look up. Exactly one of `user_id`, `username` or `email` must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "The username of the user to look up.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The email address of the user to look up. The read fails if more than one user has the address.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"given_name": schema.StringAttribute{
+				Description: "The user's given name.",
+				Computed:    true,
+			},
+			"family_name": schema.StringAttribute{
+				Description: "The user's family name.",
+				Computed:    true,
+			},
+			"external_id": schema.StringAttribute{
+				Description: "The user's external ID.",
+				Computed:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Indicates whether the user is enabled.",
+				Computed:    true,
+			},
+			"population_id": schema.StringAttribute{
+				Description: "The ID of the user's population.",
+				Computed:    true,
+			},
+			"custom_attributes": schema.DynamicAttribute{
+				Description: "The values of the user's custom schema attributes, keyed by attribute name. Attributes without a value are omitted.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *userDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("user_id"),
+			path.MatchRoot("username"),
+			path.MatchRoot("email"),
+		),
+	}
+}
+
+func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state userDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := d.client.API
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+
+	userID := strings.TrimSpace(state.UserId.ValueString())
+	if userID == "" {
+		attribute, value := "username", strings.TrimSpace(state.Username.ValueString())
+		if !state.Email.IsNull() && !state.Email.IsUnknown() {
+			attribute, value = "email", strings.TrimSpace(state.Email.ValueString())
+		}
+
+		tflog.Info(ctx, "Looking up PingOne user", map[string]interface{}{
+			"environment_id": environmentID,
+			"attribute":      attribute,
+		})
+
+		var err error
+		userID, err = findUserID(ctx, apiClient, environmentID, attribute, value)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Looking Up User",
+				fmt.Sprintf("Could not find user by %s %q: %s", attribute, value, err),
+			)
+			return
+		}
+	}
+
+	userMap, httpResp, err := readUserCustomAttributes(ctx, d.client.Endpoints, apiClient, environmentID, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			fmt.Sprintf("Could not read user '%s': %s", userID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	schemaAttributes, err := readUserSchemaAttributes(ctx, apiClient, environmentID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User Schema",
+			fmt.Sprintf("Could not read the user schema to find custom attributes: %s", err),
+		)
+		return
+	}
+
+	var customNames []string
+	for _, attribute := range schemaAttributes {
+		if strings.EqualFold(attribute.SchemaType, "CUSTOM") {
+			customNames = append(customNames, attribute.Name)
+		}
+	}
+
+	customAttributes, err := userCustomAttributeValues(userMap, customNames)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			fmt.Sprintf("Could not convert custom attributes of user '%s': %s", userID, err),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(userID)
+	state.UserId = types.StringValue(userID)
+	state.Username = utils.FromMapString(userMap, "username")
+	state.Email = utils.FromMapString(userMap, "email")
+	state.GivenName = userMapString(userMap, "name", "given")
+	state.FamilyName = userMapString(userMap, "name", "family")
+	state.ExternalId = utils.FromMapString(userMap, "externalId")
+	state.Enabled = utils.FromMapBool(userMap, "enabled")
+	state.PopulationId = userMapString(userMap, "population", "id")
+	state.CustomAttributes = types.DynamicValue(customAttributes)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// findUserID returns the ID of the only user whose attribute equals value.
+func findUserID(ctx context.Context, apiClient *management.APIClient, environmentID string, attribute string, value string) (string, error) {
+	if apiClient == nil {
+		return "", fmt.Errorf("nil api client")
+	}
+
+	filter := fmt.Sprintf(`%s eq "%s"`, attribute, strings.ReplaceAll(value, `"`, `\"`))
+
+	var ids []string
+	for cursor, iterErr := range apiClient.UsersApi.ReadAllUsers(ctx, environmentID).Filter(filter).Execute() {
+		if iterErr != nil {
+			return "", iterErr
+		}
+		if cursor.HTTPResponse == nil {
+			continue
+		}
+
+		decoded, err := utils.DecodeResponseJSON(cursor.HTTPResponse)
+		if err != nil {
+			return "", err
+		}
+		list, err := utils.ExtractEmbeddedArray(decoded, "users")
+		if err != nil {
+			return "", err
+		}
+
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, ok := utils.NestedString(m, "id"); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no matching user")
+	case 1:
+		return ids[0], nil
+	default:
+		sort.Strings(ids)
+		return "", fmt.Errorf("%d users match (%s); look the user up by `user_id` instead", len(ids), strings.Join(ids, ", "))
+	}
+}
+
+func userMapString(userMap map[string]interface{}, keys ...string) types.String {
+	if v, ok := utils.NestedString(userMap, keys...); ok {
+		return types.StringValue(v)
+	}
+	return types.StringNull()
+}
+
+// userCustomAttributeValues builds an object of the user's custom attribute values. Values keep
+// their JSON shape: objects become objects and arrays become tuples.
+func userCustomAttributeValues(userMap map[string]interface{}, customNames []string) (types.Object, error) {
+	attrTypes := make(map[string]attr.Type)
+	values := make(map[string]attr.Value)
+
+	for _, name := range customNames {
+		raw, ok := userMap[name]
+		if !ok || raw == nil {
+			continue
+		}
+
+		value, err := jsonToAttrValue(raw)
+		if err != nil {
+			return types.ObjectNull(nil), fmt.Errorf("attribute %s: %w", name, err)
+		}
+		attrTypes[name] = value.Type(context.Background())
+		values[name] = value
+	}
+
+	obj, diags := types.ObjectValue(attrTypes, values)
+	if diags.HasError() {
+		return types.ObjectNull(nil), fmt.Errorf("%v", diags)
+	}
+	return obj, nil
+}
+
+func jsonToAttrValue(raw interface{}) (attr.Value, error) {
+	switch v := raw.(type) {
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, item := range v {
+			if item == nil {
+				continue
+			}
+			elem, err := jsonToAttrValue(item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, elem.Type(context.Background()))
+			elems = append(elems, elem)
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%v", diags)
+		}
+		return tuple, nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		values := make(map[string]attr.Value, len(v))
+		for key, item := range v {
+			if item == nil {
+				continue
+			}
+			value, err := jsonToAttrValue(item)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[key] = value.Type(context.Background())
+			values[key] = value
+		}
+		obj, diags := types.ObjectValue(attrTypes, values)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%v", diags)
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", raw)
+	}
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &userCustomAttributesDataSource{}
+	_ datasource.DataSourceWithConfigure = &userCustomAttributesDataSource{}
+)
+
+type userCustomAttributesDataSource struct {
+	client *client.Client
+}
+
+type userCustomAttributesDataSourceModel struct {
+	Id             types.String  `tfsdk:"id"`
+	EnvironmentId  types.String  `tfsdk:"environment_id"`
+	UserId         types.String  `tfsdk:"user_id"`
+	AttributeNames types.List    `tfsdk:"attribute_names"`
+	Attributes     types.Dynamic `tfsdk:"attributes"`
+}
+
+func NewUserCustomAttributesDataSource() datasource.DataSource {
+	return &userCustomAttributesDataSource{}
+}
+
+func (d *userCustomAttributesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_custom_attributes"
+}
+
+func (d *userCustomAttributesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the current custom attribute values of a PingOne user without managing them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the data source, in the format `<environment_id>/<user_id>`.",
+				Computed:    true,
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user.",
+				Required:    true,
+			},
+			"attribute_names": schema.ListAttribute{
+				Description: "The names of the attributes to read. When omitted, every attribute the user schema marks as `CUSTOM` is read.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"attributes": schema.DynamicAttribute{
+				Description: "The current attribute values, keyed by attribute name. Attributes without a value are omitted.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *userCustomAttributesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clientData
+}
+
+func (d *userCustomAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state userCustomAttributesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := d.client.API
+	environmentID := strings.TrimSpace(state.EnvironmentId.ValueString())
+	userID := strings.TrimSpace(state.UserId.ValueString())
+
+	var names []string
+	if !state.AttributeNames.IsNull() {
+		resp.Diagnostics.Append(state.AttributeNames.ElementsAs(ctx, &names, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Reading PingOne user custom attributes", map[string]interface{}{
+		"environment_id": environmentID,
+		"user_id":        userID,
+		"attributes":     len(names),
+	})
+
+	userMap, httpResp, err := readUserCustomAttributes(ctx, d.client.Endpoints, apiClient, environmentID, userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			fmt.Sprintf("Could not read user '%s': %s", userID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	if names == nil {
+		schemaAttributes, err := readUserSchemaAttributes(ctx, apiClient, environmentID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading User Schema",
+				fmt.Sprintf("Could not read the user schema to find custom attributes: %s", err),
+			)
+			return
+		}
+
+		for _, attribute := range schemaAttributes {
+			if strings.EqualFold(attribute.SchemaType, "CUSTOM") {
+				names = append(names, attribute.Name)
+			}
+		}
+	}
+
+	attributes, err := userCustomAttributeValues(userMap, names)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			fmt.Sprintf("Could not convert custom attributes of user '%s': %s", userID, err),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(buildUserCustomAttributesID(environmentID, userID))
+	state.Attributes = types.DynamicValue(attributes)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUserCustomAttributesDataSourceRead_AttributeNames(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/environments/env-id/users/user-1" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body := `{"id":"user-1","username":"jdoe","costCenter":"cc-100","region":"emea"}`
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	schemaResp := &datasource.SchemaResponse{}
+	d := &userCustomAttributesDataSource{client: &client.Client{API: apiClient}}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	names, diags := types.ListValue(types.StringType, []attr.Value{
+		types.StringValue("costCenter"),
+		types.StringValue("missing"),
+	})
+	if diags.HasError() {
+		t.Fatalf("ListValue: %v", diags)
+	}
+
+	config := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := config.Set(ctx, &userCustomAttributesDataSourceModel{
+		Id:             types.StringNull(),
+		EnvironmentId:  types.StringValue("env-id"),
+		UserId:         types.StringValue("user-1"),
+		AttributeNames: names,
+		Attributes:     types.DynamicNull(),
+	}); diags.HasError() {
+		t.Fatalf("config.Set: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	var got userCustomAttributesDataSourceModel
+	if diags := resp.State.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if got.Id.ValueString() != "env-id/user-1" {
+		t.Fatalf("id = %s", got.Id)
+	}
+
+	obj, ok := got.Attributes.UnderlyingValue().(types.Object)
+	if !ok {
+		t.Fatalf("attributes = %T, want types.Object", got.Attributes.UnderlyingValue())
+	}
+	attrs := obj.Attributes()
+	if len(attrs) != 1 || !attrs["costCenter"].Equal(types.StringValue("cc-100")) {
+		t.Fatalf("attributes = %v, want only costCenter", attrs)
+	}
+}
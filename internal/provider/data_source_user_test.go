@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFindUserID(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		body := `{"_embedded":{"users":[]},"count":0}`
+		switch r.URL.Query().Get("filter") {
+		case `username eq "jdoe"`:
+			body = `{"_embedded":{"users":[{"id":"user-1","username":"jdoe"}]},"count":1}`
+		case `email eq "shared@example.com"`:
+			body = `{"_embedded":{"users":[{"id":"user-3"},{"id":"user-2"}]},"count":2}`
+		}
+
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	id, err := findUserID(context.Background(), apiClient, "env-id", "username", "jdoe")
+	if err != nil || id != "user-1" {
+		t.Fatalf("username lookup = %q, %v", id, err)
+	}
+
+	if _, err := findUserID(context.Background(), apiClient, "env-id", "username", "missing"); err == nil || !strings.Contains(err.Error(), "no matching user") {
+		t.Fatalf("expected no matching user error, got %v", err)
+	}
+
+	if _, err := findUserID(context.Background(), apiClient, "env-id", "email", "shared@example.com"); err == nil || !strings.Contains(err.Error(), "2 users match (user-2, user-3)") {
+		t.Fatalf("expected ambiguous match error, got %v", err)
+	}
+}
+
+func TestUserCustomAttributeValues(t *testing.T) {
+	t.Parallel()
+
+	userMap := map[string]interface{}{
+		"id":         "user-1",
+		"username":   "jdoe",
+		"costCenter": "cc-100",
+		"clearance":  float64(3),
+		"contractor": true,
+		"badges":     []interface{}{"blue", "green"},
+		"manager":    map[string]interface{}{"id": "user-9", "name": nil},
+		"unset":      nil,
+	}
+
+	obj, err := userCustomAttributeValues(userMap, []string{"costCenter", "clearance", "contractor", "badges", "manager", "unset", "missing"})
+	if err != nil {
+		t.Fatalf("userCustomAttributeValues: %v", err)
+	}
+
+	attrs := obj.Attributes()
+	if len(attrs) != 5 {
+		t.Fatalf("attributes = %v, want 5 entries", attrs)
+	}
+	if got := attrs["costCenter"]; !got.Equal(types.StringValue("cc-100")) {
+		t.Fatalf("costCenter = %v", got)
+	}
+	if got := attrs["contractor"]; !got.Equal(types.BoolValue(true)) {
+		t.Fatalf("contractor = %v", got)
+	}
+	if _, ok := attrs["username"]; ok {
+		t.Fatalf("core attribute username should not be included")
+	}
+
+	manager, ok := attrs["manager"].(types.Object)
+	if !ok {
+		t.Fatalf("manager = %T, want types.Object", attrs["manager"])
+	}
+	if len(manager.Attributes()) != 1 {
+		t.Fatalf("manager attributes = %v", manager.Attributes())
+	}
+
+	badges, ok := attrs["badges"].(types.Tuple)
+	if !ok || len(badges.Elements()) != 2 {
+		t.Fatalf("badges = %v", attrs["badges"])
+	}
+}
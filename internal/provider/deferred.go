@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// deferUnknownPlan defers planning a resource to a later plan round when the configured value of
+// any of the top-level attributes is not known yet, such as the ID of an environment or
+// propagation plan that is created in the same apply, and Terraform allows deferred actions. The
+// resource is then planned once those values are known, so that checks against PingOne run
+// instead of being skipped or failing on placeholder values. It reports whether it deferred, in
+// which case the caller should return.
+func deferUnknownPlan(req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attributes ...string) bool {
+	if !req.ClientCapabilities.DeferralAllowed || req.Plan.Raw.IsNull() || !req.Config.Raw.IsKnown() || req.Config.Raw.IsNull() {
+		return false
+	}
+
+	for _, name := range attributes {
+		value, _, err := tftypes.WalkAttributePath(req.Config.Raw, tftypes.NewAttributePath().WithAttributeName(name))
+		if err != nil {
+			continue
+		}
+		if v, ok := value.(tftypes.Value); ok && !v.IsKnown() {
+			resp.Deferred = &resource.Deferred{Reason: resource.DeferredReasonResourceConfigUnknown}
+			return true
+		}
+	}
+	return false
+}
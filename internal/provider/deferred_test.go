@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDeferUnknownPlan(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"environment_id": tftypes.String,
+		"plan_id":        tftypes.String,
+		"name":           tftypes.String,
+	}}
+	value := func(environmentID, planID, name interface{}) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"environment_id": tftypes.NewValue(tftypes.String, environmentID),
+			"plan_id":        tftypes.NewValue(tftypes.String, planID),
+			"name":           tftypes.NewValue(tftypes.String, name),
+		})
+	}
+	null := tftypes.NewValue(objType, nil)
+
+	tests := []struct {
+		name            string
+		deferralAllowed bool
+		config          tftypes.Value
+		plan            tftypes.Value
+		wantDeferred    bool
+	}{
+		{name: "known", deferralAllowed: true, config: value("env-id", "plan-id", "rule"), plan: value("env-id", "plan-id", "rule")},
+		{name: "unknown_environment", deferralAllowed: true, config: value(tftypes.UnknownValue, "plan-id", "rule"), plan: value(tftypes.UnknownValue, "plan-id", "rule"), wantDeferred: true},
+		{name: "unknown_plan", deferralAllowed: true, config: value("env-id", tftypes.UnknownValue, "rule"), plan: value("env-id", tftypes.UnknownValue, "rule"), wantDeferred: true},
+		{name: "unknown_other_attribute", deferralAllowed: true, config: value("env-id", "plan-id", tftypes.UnknownValue), plan: value("env-id", "plan-id", tftypes.UnknownValue)},
+		{name: "deferral_not_allowed", config: value(tftypes.UnknownValue, "plan-id", "rule"), plan: value(tftypes.UnknownValue, "plan-id", "rule")},
+		{name: "destroy", deferralAllowed: true, config: null, plan: null},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := resource.ModifyPlanRequest{
+				ClientCapabilities: resource.ModifyPlanClientCapabilities{DeferralAllowed: tt.deferralAllowed},
+				Config:             tfsdk.Config{Raw: tt.config},
+				Plan:               tfsdk.Plan{Raw: tt.plan},
+			}
+			resp := &resource.ModifyPlanResponse{}
+
+			deferred := deferUnknownPlan(req, resp, "environment_id", "plan_id")
+
+			if deferred != tt.wantDeferred || (resp.Deferred != nil) != tt.wantDeferred {
+				t.Fatalf("deferred = %v, resp.Deferred = %v, want %v", deferred, resp.Deferred, tt.wantDeferred)
+			}
+			if tt.wantDeferred && resp.Deferred.Reason != resource.DeferredReasonResourceConfigUnknown {
+				t.Fatalf("reason = %v, want DeferredReasonResourceConfigUnknown", resp.Deferred.Reason)
+			}
+		})
+	}
+}
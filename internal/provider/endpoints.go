@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// pingOneEndpointFamily names the SDK operation whose server definition supplies the base path
+// for a group of hand-built requests. Requests in one family always share a base path, so it is
+// resolved once per client.
+type pingOneEndpointFamily string
+
+const (
+	endpointFamilyPropagationPlans           pingOneEndpointFamily = "IdentityPropagationPlansApiService.ReadAllPlans"
+	endpointFamilyPropagationRules           pingOneEndpointFamily = "PropagationRulesApiService.EnvironmentsEnvironmentIDPropagationRulesPost"
+	endpointFamilyPropagationMappings        pingOneEndpointFamily = "PropagationMappingsApiService.EnvironmentsEnvironmentIDPropagationMappingsMappingIDGet"
+	endpointFamilyPropagationStores          pingOneEndpointFamily = "PropagationStoresApiService.ReadAllStores"
+	endpointFamilyUsers                      pingOneEndpointFamily = "UsersApiService.ReadUser"
+	endpointFamilyEnvironments               pingOneEndpointFamily = "EnvironmentsApiService.ReadAllEnvironments"
+	endpointFamilyRoles                      pingOneEndpointFamily = "RolesApiService.ReadAllRoles"
+	endpointFamilyApplicationRoleAssignments pingOneEndpointFamily = "ApplicationRoleAssignmentsApiService.ReadApplicationRoleAssignments"
+)
+
+// pingOneServerContextKeys are the context keys through which a request selects SDK servers or
+// server variables other than the client's defaults.
+var pingOneServerContextKeys = []interface{}{
+	management.ContextServerIndex,
+	management.ContextOperationServerIndices,
+	management.ContextServerVariables,
+	management.ContextOperationServerVariables,
+}
+
+// pingOneBasePath returns the base path, without a trailing slash, that the SDK would use for
+// family on apiClient under ctx, along with the HTTP client to send requests with. Base paths
+// resolved with the client's default servers are recorded in endpoints; ones that ctx overrides
+// are resolved for each request.
+func pingOneBasePath(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, family pingOneEndpointFamily) (string, *http.Client, error) {
+	if apiClient == nil {
+		return "", nil, fmt.Errorf("nil api client")
+	}
+
+	cfg := apiClient.GetConfig()
+	if cfg == nil {
+		return "", nil, fmt.Errorf("api client has nil config")
+	}
+	if cfg.HTTPClient == nil {
+		return "", nil, fmt.Errorf("api client has nil http client")
+	}
+
+	cacheable := true
+	for _, key := range pingOneServerContextKeys {
+		if ctx.Value(key) != nil {
+			cacheable = false
+			break
+		}
+	}
+	if cacheable {
+		if basePath, ok := endpoints.BasePath(apiClient, string(family)); ok {
+			return basePath, cfg.HTTPClient, nil
+		}
+	}
+
+	basePath, err := cfg.ServerURLWithContext(ctx, string(family))
+	if err != nil {
+		return "", nil, err
+	}
+	basePath = strings.TrimRight(strings.TrimSpace(basePath), "/")
+	if family == endpointFamilyPropagationMappings {
+		basePath = normalizePropagationMappingBasePath(basePath)
+	}
+
+	if cacheable {
+		endpoints.SetBasePath(apiClient, string(family), basePath)
+	}
+	return basePath, cfg.HTTPClient, nil
+}
+
+// pingOneEndpoint returns the URL for segments under the base path of family. Each segment is
+// path-escaped.
+func pingOneEndpoint(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, family pingOneEndpointFamily, segments ...string) (string, *http.Client, error) {
+	basePath, httpClient, err := pingOneBasePath(ctx, endpoints, apiClient, family)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(basePath)
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String(), httpClient, nil
+}
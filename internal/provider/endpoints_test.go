@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+func TestPingOneEndpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	apiClient := newManagementTestClient(t, "api.example", nil)
+
+	got, httpClient, err := pingOneEndpoint(ctx, nil, apiClient, endpointFamilyUsers, "environments", "env-id", "users", "a/b")
+	if err != nil {
+		t.Fatalf("pingOneEndpoint error: %v", err)
+	}
+	if httpClient == nil {
+		t.Fatal("expected the client's HTTP client")
+	}
+	if want := "https://api.example/v1/environments/env-id/users/a%2Fb"; got != want {
+		t.Fatalf("endpoint = %q, want %q", got, want)
+	}
+
+	// A client for another hostname resolves its own base path.
+	other := newManagementTestClient(t, "api.other", nil)
+	got, _, err = pingOneEndpoint(ctx, nil, other, endpointFamilyUsers, "environments")
+	if err != nil {
+		t.Fatalf("pingOneEndpoint error: %v", err)
+	}
+	if want := "https://api.other/v1/environments"; got != want {
+		t.Fatalf("endpoint = %q, want %q", got, want)
+	}
+}
+
+func TestPingOneBasePath_CachedPerClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	apiClient := newManagementTestClient(t, "api.example", nil)
+	endpoints := client.NewEndpoints()
+
+	first, _, err := pingOneBasePath(ctx, endpoints, apiClient, endpointFamilyPropagationRules)
+	if err != nil {
+		t.Fatalf("pingOneBasePath error: %v", err)
+	}
+
+	// The cached value is used even if the configuration is changed afterwards.
+	if err := apiClient.GetConfig().SetDefaultServerVariableDefaultValue("baseHostname", "api.changed"); err != nil {
+		t.Fatalf("SetDefaultServerVariableDefaultValue: %v", err)
+	}
+	second, _, err := pingOneBasePath(ctx, endpoints, apiClient, endpointFamilyPropagationRules)
+	if err != nil {
+		t.Fatalf("pingOneBasePath error: %v", err)
+	}
+	if first != second || first != "https://api.example/v1" {
+		t.Fatalf("base paths = %q, %q; want the cached https://api.example/v1", first, second)
+	}
+
+	// Another provider instance does not see the cached value.
+	other, _, err := pingOneBasePath(ctx, client.NewEndpoints(), apiClient, endpointFamilyPropagationRules)
+	if err != nil {
+		t.Fatalf("pingOneBasePath error: %v", err)
+	}
+	if other != "https://api.changed/v1" {
+		t.Fatalf("base path = %q, want https://api.changed/v1", other)
+	}
+}
+
+func TestPingOneBasePath_ContextServerVariables(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", nil)
+	endpoints := client.NewEndpoints()
+
+	overridden := context.WithValue(context.Background(), management.ContextServerVariables, map[string]string{"baseHostname": "api.override"})
+	got, _, err := pingOneBasePath(overridden, endpoints, apiClient, endpointFamilyUsers)
+	if err != nil {
+		t.Fatalf("pingOneBasePath error: %v", err)
+	}
+	if got != "https://api.override/v1" {
+		t.Fatalf("base path = %q, want https://api.override/v1", got)
+	}
+
+	// The overridden base path is not cached for requests without the override.
+	got, _, err = pingOneBasePath(context.Background(), endpoints, apiClient, endpointFamilyUsers)
+	if err != nil {
+		t.Fatalf("pingOneBasePath error: %v", err)
+	}
+	if got != "https://api.example/v1" {
+		t.Fatalf("base path = %q, want https://api.example/v1", got)
+	}
+}
+
+func TestPingOneBasePath_NilClient(t *testing.T) {
+	t.Parallel()
+
+	if _, _, err := pingOneBasePath(context.Background(), nil, nil, endpointFamilyUsers); err == nil {
+		t.Fatal("expected an error for a nil client")
+	}
+}
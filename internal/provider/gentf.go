@@ -0,0 +1,451 @@
+//go:build gentf
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fixtureRedacted replaces the value of every sensitive attribute in generated HCL.
+const fixtureRedacted = "REDACTED"
+
+// FixtureOptions configures GenerateFixtures.
+type FixtureOptions struct {
+	ClientID     string
+	ClientSecret string
+	// EnvironmentID is the environment the worker application authenticates against.
+	EnvironmentID string
+	Region        string
+	// TargetEnvironmentID is the environment to read. Defaults to EnvironmentID.
+	TargetEnvironmentID string
+	PageSize            int32
+}
+
+// GenerateFixtures reads the propagation plans, stores and rules of an environment and writes
+// them to w as example HCL. Sensitive attributes are written as "REDACTED", and references
+// between the generated resources use resource addresses instead of IDs.
+func GenerateFixtures(ctx context.Context, w io.Writer, opts FixtureOptions) error {
+	region := opts.Region
+	if region == "" {
+		region = "NA"
+	}
+
+	apiClient, err := newManagementClient(ctx, "gentf", opts.ClientID, opts.ClientSecret, opts.EnvironmentID, mapRegion(region), "", "", defaultRequestTimeout, defaultRequestTimeout, nil)
+	if err != nil {
+		return err
+	}
+	endpoints := client.NewEndpoints()
+
+	environmentID := opts.TargetEnvironmentID
+	if environmentID == "" {
+		environmentID = opts.EnvironmentID
+	}
+
+	g := &fixtureGenerator{
+		names: make(map[string]bool),
+		refs:  make(map[string]string),
+	}
+	g.b.WriteString("# Generated by cmd/gentf. Sensitive values are redacted.\n\n")
+	g.b.WriteString("variable \"environment_id\" {\n  type = string\n}\n\n")
+
+	envRef := map[string]string{"environment_id": "var.environment_id"}
+
+	plans, err := listPropagationPlans(ctx, endpoints, apiClient, environmentID, opts.PageSize)
+	if err != nil {
+		return fmt.Errorf("list propagation plans: %w", err)
+	}
+	for _, plan := range plans {
+		model := propagationPlanFromAPI(&plan, environmentID)
+		name := g.uniqueName("plan", model.Name.ValueString())
+		if err := g.writeResource(ctx, &propagationPlanResource{}, "pingoneprovisioning_propagation_plan", name, &model, envRef); err != nil {
+			return err
+		}
+		g.refs[model.Id.ValueString()] = fmt.Sprintf("pingoneprovisioning_propagation_plan.%s.id", name)
+	}
+
+	stores, err := listPropagationStores(ctx, endpoints, apiClient, environmentID, opts.PageSize)
+	if err != nil {
+		return fmt.Errorf("list propagation stores: %w", err)
+	}
+	for _, sMap := range stores {
+		storeTypeRaw, _ := sMap["type"].(string)
+		storeName, _ := sMap["name"].(string)
+
+		// The PingOne directory store exists in every environment and cannot be managed.
+		if strings.EqualFold(storeTypeRaw, "directory") {
+			fmt.Fprintf(&g.b, "# Skipped store %q: the PingOne directory store is not managed by Terraform.\n\n", storeName)
+			continue
+		}
+
+		model := customtypes.PropagationStoreResourceModel{
+			PropagationStoreModel:  propagationStoreFromJSON(sMap, environmentID, "", nil),
+			DisableInsteadOfDelete: types.BoolNull(),
+			SecretRefsSha256:       types.StringNull(),
+			Preset:                 types.StringNull(),
+			Credentials:            propagationStoreCredentialsNull(ctx),
+		}
+		name := g.uniqueName("store", model.Name.ValueString())
+		if err := g.writeResource(ctx, &propagationStoreResource{}, "pingoneprovisioning_propagation_store", name, &model, envRef); err != nil {
+			return err
+		}
+		g.refs[model.Id.ValueString()] = fmt.Sprintf("pingoneprovisioning_propagation_store.%s.id", name)
+	}
+
+	service := &sdkPropagationService{api: apiClient, pageSize: opts.PageSize, endpoints: endpoints}
+	for _, plan := range plans {
+		rules, err := service.RuleList(ctx, environmentID, plan.GetId())
+		if err != nil {
+			return fmt.Errorf("list propagation rules for plan %s: %w", plan.GetId(), err)
+		}
+
+		for _, ruleObj := range rules {
+			ruleID, _ := utils.NestedString(ruleObj, "id")
+
+			var model customtypes.PropagationRuleResourceModel
+			applyRuleAPIToStateDataSource(ruleObj, &model.PropagationRuleModel)
+			model.Id = types.StringValue(ruleID)
+			model.EnvironmentId = types.StringValue(environmentID)
+			// The population expression already includes the rule's populations.
+			model.PopulationIds = types.SetNull(types.StringType)
+
+			_, mappings, err := resolvePropagationRuleMappings(ctx, service, environmentID, ruleID, nil, false)
+			if err != nil {
+				return fmt.Errorf("list mappings for propagation rule %s: %w", ruleID, err)
+			}
+			model.Mappings = mappings
+			model.AuthoritativeMappings = types.BoolNull()
+			model.UnmanagedMappings = types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes})
+			model.ExternalMappings = types.BoolNull()
+			if model.Active.ValueBool() && len(mappings) == 0 {
+				model.ExternalMappings = types.BoolValue(true)
+			}
+
+			overrides := map[string]string{"environment_id": "var.environment_id"}
+			for attrName, id := range map[string]string{
+				"plan_id":         model.PlanId.ValueString(),
+				"source_store_id": model.SourceStoreId.ValueString(),
+				"target_store_id": model.TargetStoreId.ValueString(),
+			} {
+				if ref, ok := g.refs[id]; ok {
+					overrides[attrName] = ref
+				}
+			}
+
+			name := g.uniqueName("rule", model.Name.ValueString())
+			if err := g.writeResource(ctx, &propagationRuleResource{}, "pingoneprovisioning_propagation_rule", name, &model, overrides); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = io.WriteString(w, g.b.String())
+	return err
+}
+
+type fixtureGenerator struct {
+	b     strings.Builder
+	names map[string]bool
+	// refs maps the IDs of generated resources to their `<type>.<name>.id` addresses.
+	refs map[string]string
+}
+
+// uniqueName turns a display name into a unique Terraform resource name.
+func (g *fixtureGenerator) uniqueName(prefix string, displayName string) string {
+	var sb strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.ToLower(displayName) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			lastUnderscore = false
+			continue
+		}
+		if !lastUnderscore && sb.Len() > 0 {
+			sb.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+
+	base := strings.TrimRight(sb.String(), "_")
+	if base == "" {
+		base = prefix
+	} else if base[0] >= '0' && base[0] <= '9' {
+		base = prefix + "_" + base
+	}
+
+	name := base
+	for i := 2; g.names[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.names[name] = true
+	return name
+}
+
+// writeResource writes a resource block for model using the resource's own schema, so only
+// configurable attributes are written and sensitive ones are redacted. overrides replaces
+// top-level attribute values with raw HCL expressions.
+func (g *fixtureGenerator) writeResource(ctx context.Context, r resource.Resource, resourceType string, name string, model any, overrides map[string]string) error {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		return fmt.Errorf("%s.%s: %s", resourceType, name, fixtureDiagsError(diags))
+	}
+
+	var values map[string]tftypes.Value
+	if err := state.Raw.As(&values); err != nil {
+		return fmt.Errorf("%s.%s: %w", resourceType, name, err)
+	}
+
+	fmt.Fprintf(&g.b, "resource %q %q {\n", resourceType, name)
+	if err := writeFixtureBody(&g.b, 1, s.Attributes, s.Blocks, values, overrides); err != nil {
+		return fmt.Errorf("%s.%s: %w", resourceType, name, err)
+	}
+	g.b.WriteString("}\n\n")
+	return nil
+}
+
+func writeFixtureBody(b *strings.Builder, depth int, attributes map[string]schema.Attribute, blocks map[string]schema.Block, values map[string]tftypes.Value, overrides map[string]string) error {
+	indent := strings.Repeat("  ", depth)
+
+	type line struct{ name, value string }
+	var lines []line
+	width := 0
+
+	for _, name := range sortedFixtureKeys(attributes) {
+		a := attributes[name]
+		v := values[name]
+
+		var rendered string
+		switch expr, ok := overrides[name]; {
+		case ok:
+			rendered = expr
+		case !a.IsRequired() && !a.IsOptional(), a.GetDeprecationMessage() != "":
+			continue
+		case v.IsNull() || !v.IsKnown() || isEmptyFixtureCollection(v):
+			continue
+		case a.IsSensitive():
+			rendered = strconv.Quote(fixtureRedacted)
+		default:
+			var err error
+			if rendered, err = renderFixtureAttribute(a, v, depth); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		lines = append(lines, line{name: name, value: rendered})
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+
+	for _, l := range lines {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, l.name, l.value)
+	}
+
+	for _, name := range sortedFixtureKeys(blocks) {
+		blk := blocks[name]
+		v := values[name]
+		if blk.GetDeprecationMessage() != "" || v.IsNull() || !v.IsKnown() {
+			continue
+		}
+
+		var elems []tftypes.Value
+		var nestedAttributes map[string]schema.Attribute
+		var nestedBlocks map[string]schema.Block
+		switch blk := blk.(type) {
+		case schema.SingleNestedBlock:
+			elems = []tftypes.Value{v}
+			nestedAttributes, nestedBlocks = blk.Attributes, blk.Blocks
+		case schema.ListNestedBlock:
+			if err := v.As(&elems); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			nestedAttributes, nestedBlocks = blk.NestedObject.Attributes, blk.NestedObject.Blocks
+		case schema.SetNestedBlock:
+			if err := v.As(&elems); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			nestedAttributes, nestedBlocks = blk.NestedObject.Attributes, blk.NestedObject.Blocks
+		default:
+			return fmt.Errorf("%s: unsupported block type %T", name, blk)
+		}
+
+		for _, elem := range elems {
+			var nested map[string]tftypes.Value
+			if err := elem.As(&nested); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			fmt.Fprintf(b, "\n%s%s {\n", indent, name)
+			if err := writeFixtureBody(b, depth+1, nestedAttributes, nestedBlocks, nested, nil); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			fmt.Fprintf(b, "%s}\n", indent)
+		}
+	}
+
+	return nil
+}
+
+func renderFixtureAttribute(a schema.Attribute, v tftypes.Value, depth int) (string, error) {
+	var nestedAttributes map[string]schema.Attribute
+	switch a := a.(type) {
+	case schema.SingleNestedAttribute:
+		return renderFixtureObject(a.Attributes, v, depth)
+	case schema.ListNestedAttribute:
+		nestedAttributes = a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		nestedAttributes = a.NestedObject.Attributes
+	default:
+		return renderFixturePrimitive(v, depth)
+	}
+
+	var elems []tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return "", err
+	}
+
+	indent := strings.Repeat("  ", depth)
+	var sb strings.Builder
+	sb.WriteString("[\n")
+	for _, elem := range elems {
+		obj, err := renderFixtureObject(nestedAttributes, elem, depth+1)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "%s  %s,\n", indent, obj)
+	}
+	sb.WriteString(indent + "]")
+	return sb.String(), nil
+}
+
+func renderFixtureObject(attributes map[string]schema.Attribute, v tftypes.Value, depth int) (string, error) {
+	var values map[string]tftypes.Value
+	if err := v.As(&values); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	if err := writeFixtureBody(&sb, depth+1, attributes, nil, values, nil); err != nil {
+		return "", err
+	}
+	sb.WriteString(strings.Repeat("  ", depth) + "}")
+	return sb.String(), nil
+}
+
+func renderFixturePrimitive(v tftypes.Value, depth int) (string, error) {
+	switch t := v.Type(); {
+	case t.Equal(tftypes.String):
+		var s string
+		if err := v.As(&s); err != nil {
+			return "", err
+		}
+		return quoteFixtureString(s), nil
+	case t.Equal(tftypes.Bool):
+		var bv bool
+		if err := v.As(&bv); err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(bv), nil
+	case t.Equal(tftypes.Number):
+		n := new(big.Float)
+		if err := v.As(&n); err != nil {
+			return "", err
+		}
+		return n.Text('f', -1), nil
+	}
+
+	switch v.Type().(type) {
+	case tftypes.List, tftypes.Set:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return "", err
+		}
+		parts := make([]string, 0, len(elems))
+		for _, elem := range elems {
+			part, err := renderFixturePrimitive(elem, depth)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case tftypes.Map:
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return "", err
+		}
+		indent := strings.Repeat("  ", depth)
+		var sb strings.Builder
+		sb.WriteString("{\n")
+		for _, key := range sortedFixtureKeys(elems) {
+			part, err := renderFixturePrimitive(elems[key], depth+1)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&sb, "%s  %s = %s\n", indent, quoteFixtureString(key), part)
+		}
+		sb.WriteString(indent + "}")
+		return sb.String(), nil
+	}
+
+	return "", fmt.Errorf("unsupported value type %s", v.Type())
+}
+
+func isEmptyFixtureCollection(v tftypes.Value) bool {
+	switch v.Type().(type) {
+	case tftypes.List, tftypes.Set:
+		var elems []tftypes.Value
+		return v.As(&elems) == nil && len(elems) == 0
+	case tftypes.Map:
+		var elems map[string]tftypes.Value
+		return v.As(&elems) == nil && len(elems) == 0
+	}
+	return false
+}
+
+// quoteFixtureString quotes s as an HCL string literal, escaping template sequences so values
+// are written literally.
+func quoteFixtureString(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+func sortedFixtureKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fixtureDiagsError(diags diag.Diagnostics) string {
+	var parts []string
+	for _, d := range diags.Errors() {
+		parts = append(parts, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return strings.Join(parts, "; ")
+}
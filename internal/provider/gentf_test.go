@@ -0,0 +1,75 @@
+//go:build gentf
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFixtureGeneratorWriteResource_RedactsSensitiveValues(t *testing.T) {
+	t.Parallel()
+
+	g := &fixtureGenerator{names: make(map[string]bool), refs: make(map[string]string)}
+	store := customtypes.PropagationStoreModel{
+		Id:            types.StringValue("store-id"),
+		EnvironmentId: types.StringValue("env-id"),
+		Name:          types.StringValue("SCIM ${app}"),
+		Type:          types.StringValue("SCIM"),
+		Status:        types.StringValue("ACTIVE"),
+		SyncStatus:    types.ObjectNull(customtypes.SyncStatusAttrTypes),
+		Links:         types.MapNull(types.StringType),
+		ConfigurationScim: &customtypes.ConfigurationScim{
+			ScimUrl:          types.StringValue("https://scim.example/v2"),
+			OauthAccessToken: types.StringValue("secret-token"),
+			CreateUsers:      types.BoolValue(true),
+		},
+	}
+	model := customtypes.PropagationStoreResourceModel{PropagationStoreModel: store, DisableInsteadOfDelete: types.BoolNull(), SecretRefsSha256: types.StringNull(), Preset: types.StringNull(), Credentials: propagationStoreCredentialsNull(context.Background())}
+
+	err := g.writeResource(context.Background(), &propagationStoreResource{}, "pingoneprovisioning_propagation_store", g.uniqueName("store", "SCIM ${app}"), &model, map[string]string{"environment_id": "var.environment_id"})
+	if err != nil {
+		t.Fatalf("writeResource error: %v", err)
+	}
+
+	got := g.b.String()
+	for _, want := range []string{
+		`resource "pingoneprovisioning_propagation_store" "scim_app" {`,
+		`environment_id = var.environment_id`,
+		`name           = "SCIM $${app}"`,
+		`configuration_scim {`,
+		`oauth_access_token = "REDACTED"`,
+		`scim_url           = "https://scim.example/v2"`,
+		`create_users       = true`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"secret-token", "store-id", "scim_configuration"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("output contains %q:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestFixtureGeneratorUniqueName(t *testing.T) {
+	t.Parallel()
+
+	g := &fixtureGenerator{names: make(map[string]bool)}
+
+	for _, tt := range []struct{ display, want string }{
+		{"Users to SCIM", "users_to_scim"},
+		{"Users to SCIM!", "users_to_scim_2"},
+		{"2024 Store", "store_2024_store"},
+		{"***", "store"},
+	} {
+		if got := g.uniqueName("store", tt.display); got != tt.want {
+			t.Errorf("uniqueName(%q) = %q, want %q", tt.display, got, tt.want)
+		}
+	}
+}
@@ -14,7 +14,7 @@ func requireGitHubClient(diags *diag.Diagnostics, client *client.GitHubClient) b
 	if client == nil {
 		diags.AddError(
 			"Missing GitHub Configuration",
-			"Configure `github_token` (or the `GITHUB_TOKEN` environment variable) to use GitHub SCIM data sources.",
+			"Configure `github_token` (or the `GITHUB_TOKEN` environment variable) to use GitHub data sources and resources.",
 		)
 		return false
 	}
@@ -26,7 +26,7 @@ func githubResponseError(resp *http.Response) error {
 		return fmt.Errorf("empty response")
 	}
 	bodyBytes, _ := utils.ReadAndRestoreResponseBody(resp)
-	body := strings.TrimSpace(string(bodyBytes))
+	body := strings.TrimSpace(utils.ResponseBodyForError(bodyBytes))
 	if body == "" {
 		body = resp.Status
 	}
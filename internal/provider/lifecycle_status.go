@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// Values of `lifecycle_status`. A failure is reported as lifecycleStatusErrorPrefix followed by
+// the reason, for example "ERROR: invalid credentials".
+const (
+	lifecycleStatusInactive             = "INACTIVE"
+	lifecycleStatusConfiguredNotSynced  = "CONFIGURED_NOT_SYNCED"
+	lifecycleStatusConfiguredNoRules    = "CONFIGURED_NO_RULES"
+	lifecycleStatusConfiguredNoMappings = "CONFIGURED_NO_MAPPINGS"
+	lifecycleStatusSyncing              = "SYNCING"
+	lifecycleStatusActive               = "ACTIVE"
+	lifecycleStatusActiveHealthy        = "ACTIVE_HEALTHY"
+	lifecycleStatusErrorPrefix          = "ERROR: "
+)
+
+// propagationStoreLifecycleStatus summarizes a store's status and sync status.
+func propagationStoreLifecycleStatus(model *customtypes.PropagationStoreModel) types.String {
+	if model.Status.ValueString() == "INACTIVE" {
+		return types.StringValue(lifecycleStatusInactive)
+	}
+	if model.SyncStatus.IsNull() || model.SyncStatus.IsUnknown() {
+		return types.StringValue(lifecycleStatusConfiguredNotSynced)
+	}
+
+	attrs := model.SyncStatus.Attributes()
+	attrString := func(name string) string {
+		v, ok := attrs[name].(types.String)
+		if !ok {
+			return ""
+		}
+		return v.ValueString()
+	}
+
+	switch attrString("status") {
+	case "FAILED":
+		reason := attrString("last_error")
+		if reason == "" {
+			reason = "the last sync failed"
+		}
+		return types.StringValue(lifecycleStatusErrorPrefix + reason)
+	case "SYNCING":
+		return types.StringValue(lifecycleStatusSyncing)
+	case "":
+		if attrString("last_sync_time") == "" {
+			return types.StringValue(lifecycleStatusConfiguredNotSynced)
+		}
+	}
+	return types.StringValue(lifecycleStatusActiveHealthy)
+}
+
+// propagationPlanLifecycleStatus summarizes a plan's status and rule count. A plan has no sync
+// status of its own, so an active plan with rules is reported as ACTIVE.
+func propagationPlanLifecycleStatus(model *customtypes.PropagationPlanModel) types.String {
+	if model.Status.ValueString() == "INACTIVE" {
+		return types.StringValue(lifecycleStatusInactive)
+	}
+	if !model.RuleCount.IsNull() && !model.RuleCount.IsUnknown() && model.RuleCount.ValueInt64() == 0 {
+		return types.StringValue(lifecycleStatusConfiguredNoRules)
+	}
+	return types.StringValue(lifecycleStatusActive)
+}
+
+// propagationRuleLifecycleStatus summarizes whether a rule is active, has mappings, and how the
+// last sync into its target store went. It returns null when the target store cannot be read.
+func propagationRuleLifecycleStatus(ctx context.Context, apiClient *management.APIClient, model *customtypes.PropagationRuleModel) types.String {
+	if !model.Active.ValueBool() {
+		return types.StringValue(lifecycleStatusInactive)
+	}
+	if !model.MappingCount.IsNull() && !model.MappingCount.IsUnknown() && model.MappingCount.ValueInt64() == 0 {
+		return types.StringValue(lifecycleStatusConfiguredNoMappings)
+	}
+
+	environmentID := model.EnvironmentId.ValueString()
+	storeID := model.TargetStoreId.ValueString()
+	_, httpResp, err := apiClient.PropagationStoresApi.ReadOnePropagationStore(ctx, environmentID, storeID).Execute()
+	if err = ignoreStoreDecodeError(httpResp, err); err == nil {
+		var storeObj map[string]interface{}
+		storeObj, err = utils.DecodeResponseJSONObject(httpResp, "store")
+		if err == nil {
+			store := propagationStoreFromJSON(storeObj, environmentID, "", nil)
+			status := propagationStoreLifecycleStatus(&store)
+			if status.ValueString() == lifecycleStatusInactive {
+				return types.StringValue(lifecycleStatusErrorPrefix + "the target store is inactive")
+			}
+			return status
+		}
+	}
+
+	tflog.Debug(ctx, "Could not read the target store of the propagation rule", map[string]interface{}{
+		"store_id": storeID,
+		"error":    err.Error(),
+	})
+	return types.StringNull()
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPropagationStoreLifecycleStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		store map[string]interface{}
+		want  string
+	}{
+		{
+			name:  "inactive",
+			store: map[string]interface{}{"status": "INACTIVE", "syncStatus": map[string]interface{}{"syncState": "FAILED", "details": "invalid credentials"}},
+			want:  "INACTIVE",
+		},
+		{
+			name:  "never synced",
+			store: map[string]interface{}{"status": "ACTIVE"},
+			want:  "CONFIGURED_NOT_SYNCED",
+		},
+		{
+			name:  "syncing",
+			store: map[string]interface{}{"status": "ACTIVE", "syncStatus": map[string]interface{}{"syncState": "SYNCING"}},
+			want:  "SYNCING",
+		},
+		{
+			name:  "failed",
+			store: map[string]interface{}{"status": "ACTIVE", "syncStatus": map[string]interface{}{"syncState": "FAILED", "details": "invalid credentials"}},
+			want:  "ERROR: invalid credentials",
+		},
+		{
+			name:  "failed without details",
+			store: map[string]interface{}{"status": "ACTIVE", "syncStatus": map[string]interface{}{"syncState": "FAILED"}},
+			want:  "ERROR: the last sync failed",
+		},
+		{
+			name:  "synced",
+			store: map[string]interface{}{"status": "ACTIVE", "syncStatus": map[string]interface{}{"lastSyncAt": "2026-10-01T00:00:00Z"}},
+			want:  "ACTIVE_HEALTHY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.store["type"] = "scim"
+			model := propagationStoreFromJSON(tt.store, "env-id", "", nil)
+			if got := propagationStoreLifecycleStatus(&model).ValueString(); got != tt.want {
+				t.Fatalf("lifecycle status = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPropagationPlanLifecycleStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status    string
+		ruleCount types.Int64
+		want      string
+	}{
+		{status: "INACTIVE", ruleCount: types.Int64Value(2), want: "INACTIVE"},
+		{status: "ACTIVE", ruleCount: types.Int64Value(0), want: "CONFIGURED_NO_RULES"},
+		{status: "ACTIVE", ruleCount: types.Int64Value(2), want: "ACTIVE"},
+		{status: "ACTIVE", ruleCount: types.Int64Null(), want: "ACTIVE"},
+	}
+
+	for _, tt := range tests {
+		model := customtypes.PropagationPlanModel{Status: types.StringValue(tt.status), RuleCount: tt.ruleCount}
+		if got := propagationPlanLifecycleStatus(&model).ValueString(); got != tt.want {
+			t.Errorf("status %s, rule count %s: lifecycle status = %q, want %q", tt.status, tt.ruleCount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// linksAttributeDescription describes the computed `links` attribute of objects that carry HAL
+// links.
+const linksAttributeDescription = "The API URLs PingOne returns for the object, keyed by link relation (for example `self` and `environment`)."
+
+// linksFromSDK returns the hrefs of HAL links decoded by the SDK, keyed by relation, or a null
+// map when there are none.
+func linksFromSDK(links map[string]management.LinksHATEOASValue) types.Map {
+	if len(links) == 0 {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(links))
+	for rel, link := range links {
+		if link.Href == "" {
+			continue
+		}
+		elements[rel] = types.StringValue(link.Href)
+	}
+	if len(elements) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// linksFromJSON returns the hrefs in the `_links` object of a decoded API response, keyed by
+// relation, or a null map when there are none.
+func linksFromJSON(apiObj map[string]interface{}) types.Map {
+	raw, ok := apiObj["_links"].(map[string]interface{})
+	if !ok {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(raw))
+	for rel, value := range raw {
+		link, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if href, ok := link["href"].(string); ok && href != "" {
+			elements[rel] = types.StringValue(href)
+		}
+	}
+	if len(elements) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+func TestLinksFromJSON(t *testing.T) {
+	t.Parallel()
+
+	got := linksFromJSON(map[string]interface{}{
+		"_links": map[string]interface{}{
+			"self":        map[string]interface{}{"href": "https://api.example/v1/environments/env-id/propagation/rules/rule-id"},
+			"environment": map[string]interface{}{"href": "https://api.example/v1/environments/env-id"},
+			"broken":      "not-a-link",
+		},
+	})
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"self":        types.StringValue("https://api.example/v1/environments/env-id/propagation/rules/rule-id"),
+		"environment": types.StringValue("https://api.example/v1/environments/env-id"),
+	})
+	if !got.Equal(want) {
+		t.Fatalf("links = %s, want %s", got, want)
+	}
+
+	if got := linksFromJSON(map[string]interface{}{"id": "rule-id"}); !got.IsNull() {
+		t.Fatalf("links = %s, want null", got)
+	}
+}
+
+func TestLinksFromSDK(t *testing.T) {
+	t.Parallel()
+
+	got := linksFromSDK(map[string]management.LinksHATEOASValue{
+		"self": {Href: "https://api.example/v1/environments/env-id/propagation/stores/store-id"},
+	})
+	if v, ok := got.Elements()["self"].(types.String); !ok || v.ValueString() != "https://api.example/v1/environments/env-id/propagation/stores/store-id" {
+		t.Fatalf("links = %s", got)
+	}
+
+	if got := linksFromSDK(nil); !got.IsNull() {
+		t.Fatalf("links = %s, want null", got)
+	}
+}
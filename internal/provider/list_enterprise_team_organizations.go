@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &enterpriseTeamOrganizationsListResource{}
+	_ list.ListResourceWithConfigure = &enterpriseTeamOrganizationsListResource{}
+)
+
+// enterpriseTeamOrganizationsListResource lists the teams of a GitHub enterprise so that
+// `terraform query` can generate an import and resource block for each team.
+type enterpriseTeamOrganizationsListResource struct {
+	client *client.GitHubClient
+}
+
+type enterpriseTeamOrganizationsListModel struct {
+	Enterprise types.String `tfsdk:"enterprise"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	NameRegex  types.String `tfsdk:"name_regex"`
+}
+
+func NewEnterpriseTeamOrganizationsListResource() list.ListResource {
+	return &enterpriseTeamOrganizationsListResource{}
+}
+
+func (r *enterpriseTeamOrganizationsListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_enterprise_team_organizations"
+}
+
+func (r *enterpriseTeamOrganizationsListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the teams of a GitHub enterprise, one result per team, with the organizations each team is assigned to. Requires a GitHub token configured on the provider.",
+		Attributes: map[string]listschema.Attribute{
+			"enterprise": listschema.StringAttribute{
+				Description: "The enterprise slug.",
+				Required:    true,
+			},
+			"name_prefix": listschema.StringAttribute{
+				Description: "Only list teams whose name starts with this prefix. Compared case-insensitively.",
+				Optional:    true,
+			},
+			"name_regex": listschema.StringAttribute{
+				Description: "Only list teams whose name matches this regular expression.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *enterpriseTeamOrganizationsListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.GitHub
+}
+
+func (r *enterpriseTeamOrganizationsListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config enterpriseTeamOrganizationsListModel
+
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	if !requireGitHubClient(&diags, r.client) {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	enterprise := strings.TrimSpace(config.Enterprise.ValueString())
+
+	var nameRegex *regexp.Regexp
+	if pattern := config.NameRegex.ValueString(); pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("Could not compile name_regex: %s", err),
+			)
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		nameRegex = compiled
+	}
+
+	teams, err := listGitHubEnterpriseTeams(ctx, r.client, enterprise)
+	if err != nil {
+		diags.AddError(
+			"Error Listing Enterprise Teams",
+			fmt.Sprintf("Could not list teams for enterprise %q: %s", enterprise, err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	teams = filterGitHubEnterpriseTeams(teams, config.NamePrefix.ValueString(), nameRegex)
+
+	tflog.Debug(ctx, "Listing enterprise teams", map[string]interface{}{
+		"enterprise": enterprise,
+		"teams":      len(teams),
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, team := range teams {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = team.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, enterpriseTeamOrganizationsIdentity(enterprise, team.Slug))...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				result.Diagnostics.Append(r.setResource(ctx, &result, enterprise, team)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// setResource fills in the resource for a listed team in authoritative mode, so the generated
+// configuration matches the team's current assignments.
+func (r *enterpriseTeamOrganizationsListResource) setResource(ctx context.Context, result *list.ListResult, enterprise string, team githubEnterpriseTeamResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	organizations, _, err := listEnterpriseTeamOrganizations(ctx, r.client, enterprise, team.Slug)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Enterprise Team Organizations",
+			fmt.Sprintf("Could not list organizations for team %q: %s", team.Slug, err),
+		)
+		return diags
+	}
+
+	orgSet, setDiags := types.SetValueFrom(ctx, customtypes.CaseInsensitiveStringType{}, enterpriseTeamOrganizationsForState(nil, organizations, true))
+	diags.Append(setDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	model := customtypes.EnterpriseTeamOrganizationsModel{
+		Id:            types.StringValue(buildEnterpriseTeamOrganizationsID(enterprise, team.Slug)),
+		Enterprise:    customtypes.NewCaseInsensitiveStringValue(enterprise),
+		TeamSlug:      customtypes.NewCaseInsensitiveStringValue(team.Slug),
+		TeamId:        types.Int64Value(team.Id),
+		Organizations: orgSet,
+		Authoritative: types.BoolValue(true),
+	}
+	diags.Append(result.Resource.Set(ctx, &model)...)
+	return diags
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEnterpriseTeamOrganizationsListResource_List(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/enterprises/acme/teams":
+			_, _ = w.Write([]byte(`[{"id":1,"name":"platform-api","slug":"platform-api"},{"id":2,"name":"security","slug":"security"}]`))
+		case "/enterprises/acme/teams/platform-api/organizations":
+			_, _ = w.Write([]byte(`[{"id":10,"login":"org-b"},{"id":11,"login":"org-a"}]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+
+	ctx := context.Background()
+	listResource := &enterpriseTeamOrganizationsListResource{client: gh}
+
+	var configSchema list.ListResourceSchemaResponse
+	listResource.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchema)
+
+	managed := &enterpriseTeamOrganizationsResource{}
+	var resourceSchema resource.SchemaResponse
+	managed.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	var identitySchema resource.IdentitySchemaResponse
+	managed.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	config := tfsdk.Config{
+		Schema: configSchema.Schema,
+		Raw: tftypes.NewValue(configSchema.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"enterprise":  tftypes.NewValue(tftypes.String, "acme"),
+			"name_prefix": tftypes.NewValue(tftypes.String, "platform"),
+			"name_regex":  tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	var stream list.ListResultsStream
+	listResource.List(ctx, list.ListRequest{
+		Config:                 config,
+		IncludeResource:        true,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	if len(results) != 1 {
+		t.Fatalf("results = %d, want 1", len(results))
+	}
+	result := results[0]
+	if result.Diagnostics.HasError() {
+		t.Fatalf("diagnostics: %v", result.Diagnostics)
+	}
+	if result.DisplayName != "platform-api" {
+		t.Fatalf("DisplayName = %q", result.DisplayName)
+	}
+
+	var identity customtypes.EnterpriseTeamOrganizationsIdentityModel
+	if diags := result.Identity.Get(ctx, &identity); diags.HasError() {
+		t.Fatalf("identity: %v", diags)
+	}
+	if identity.Enterprise.ValueString() != "acme" || identity.TeamSlug.ValueString() != "platform-api" {
+		t.Fatalf("identity = %+v", identity)
+	}
+
+	var model customtypes.EnterpriseTeamOrganizationsModel
+	if diags := result.Resource.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("resource: %v", diags)
+	}
+	var orgs []string
+	if diags := model.Organizations.ElementsAs(ctx, &orgs, false); diags.HasError() {
+		t.Fatalf("organizations: %v", diags)
+	}
+	if len(orgs) != 2 || orgs[0] != "org-a" || orgs[1] != "org-b" || model.Id.ValueString() != "acme/platform-api" {
+		t.Fatalf("resource = %+v, organizations = %v", model, orgs)
+	}
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// maxPageSize is the largest `limit` PingOne accepts on list requests.
+const maxPageSize = 1000
+
+// maxCollectionPages bounds next-link following so a misbehaving API cannot loop forever.
+const maxCollectionPages = 1000
+
+// listPingOneCollection reads every page of a PingOne collection endpoint and returns the
+// embedded items sorted by `id`, so that reads do not depend on the order PingOne returns.
+//
+// The base URL is derived from the SDK server definition for `family` (see pingOneBasePath),
+// `collectionPath` is appended (for example `/environments/{id}/propagation/stores`), and
+// `_links.next.href` is followed until the last page. When pageSize is greater than zero it is
+// sent as the `limit` query parameter on the first request; PingOne carries it forward in the
+// next links.
+func listPingOneCollection(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, family pingOneEndpointFamily, collectionPath string, pageSize int32, embeddedKeys ...string) ([]map[string]interface{}, error) {
+	basePath, httpClient, err := pingOneBasePath(ctx, endpoints, apiClient, family)
+	if err != nil {
+		return nil, err
+	}
+
+	nextURL := basePath + collectionPath
+	if pageSize > 0 {
+		parsed, err := url.Parse(nextURL)
+		if err != nil {
+			return nil, err
+		}
+		query := parsed.Query()
+		query.Set("limit", strconv.Itoa(int(pageSize)))
+		parsed.RawQuery = query.Encode()
+		nextURL = parsed.String()
+	}
+
+	var items []map[string]interface{}
+	seen := make(map[string]bool)
+
+	for page := 0; nextURL != ""; page++ {
+		if page >= maxCollectionPages {
+			return nil, fmt.Errorf("stopped after %d pages while listing %s", maxCollectionPages, collectionPath)
+		}
+		if seen[nextURL] {
+			return nil, fmt.Errorf("pagination loop detected while listing %s", collectionPath)
+		}
+		seen[nextURL] = true
+
+		decoded, err := getPingOneCollectionPage(ctx, httpClient, nextURL)
+		if err != nil {
+			return nil, err
+		}
+
+		list, err := collectionPageItems(decoded, embeddedKeys...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range list {
+			if m, ok := v.(map[string]interface{}); ok {
+				items = append(items, m)
+			}
+		}
+
+		nextURL = ""
+		if root, ok := decoded.(map[string]interface{}); ok {
+			if links, ok := root["_links"].(map[string]interface{}); ok {
+				nextURL, _ = utils.NestedString(links, "next", "href")
+			}
+		}
+
+		tflog.Trace(ctx, "Read PingOne collection page", map[string]interface{}{
+			"path":       collectionPath,
+			"page":       page + 1,
+			"page_items": len(list),
+			"has_next":   nextURL != "",
+		})
+	}
+
+	utils.SortByString(items, "id")
+	return items, nil
+}
+
+// collectionPageItems returns the items embedded in a decoded page of a PingOne collection. An
+// empty collection can omit `_embedded`, or the embedded key, entirely, so such a page has no
+// items. Only a response that is not a collection at all is an error.
+func collectionPageItems(decoded any, embeddedKeys ...string) ([]interface{}, error) {
+	list, err := utils.ExtractEmbeddedArray(decoded, embeddedKeys...)
+	if err == nil {
+		return list, nil
+	}
+
+	root, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, err
+	}
+	if embedded := root["_embedded"]; embedded != nil {
+		if _, ok := embedded.(map[string]interface{}); !ok {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func getPingOneCollectionPage(ctx context.Context, httpClient *http.Client, pageURL string) (any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil {
+		respBody, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s", utils.HandleSDKError(fmt.Errorf("%s", resp.Status), resp))
+	}
+
+	return utils.DecodeResponseJSON(resp)
+}
+
+// countPingOneCollection returns the number of items in a PingOne collection. It asks for a
+// single item and reads the `count` PingOne reports for the whole collection, falling back to
+// listing every page when the response has no count.
+func countPingOneCollection(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, family pingOneEndpointFamily, collectionPath string, embeddedKeys ...string) (int64, error) {
+	basePath, httpClient, err := pingOneBasePath(ctx, endpoints, apiClient, family)
+	if err != nil {
+		return 0, err
+	}
+
+	decoded, err := getPingOneCollectionPage(ctx, httpClient, basePath+collectionPath+"?limit=1")
+	if err != nil {
+		return 0, err
+	}
+	if root, ok := decoded.(map[string]interface{}); ok {
+		if count, ok := root["count"].(float64); ok && count >= 0 {
+			return int64(count), nil
+		}
+	}
+
+	tflog.Debug(ctx, "PingOne collection has no count; listing it", map[string]interface{}{
+		"path": collectionPath,
+	})
+	items, err := listPingOneCollection(ctx, endpoints, apiClient, family, collectionPath, 0, embeddedKeys...)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(items)), nil
+}
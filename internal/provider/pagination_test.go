@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestListPingOneCollection_FollowsNextLinks(t *testing.T) {
+	t.Parallel()
+
+	var requested []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.String())
+
+		body := `{"_embedded":{"stores":[{"id":"store-2"}]},"_links":{}}`
+		if r.URL.Query().Get("cursor") == "" {
+			body = `{"_embedded":{"stores":[{"id":"store-1"}]},"_links":{"next":{"href":"https://api.example/v1/environments/env-id/propagation/stores?limit=1&cursor=abc"}}}`
+		}
+
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	stores, err := listPropagationStores(context.Background(), nil, apiClient, "env-id", 1)
+	if err != nil {
+		t.Fatalf("listPropagationStores error: %v", err)
+	}
+
+	if len(stores) != 2 || stores[0]["id"] != "store-1" || stores[1]["id"] != "store-2" {
+		t.Fatalf("stores = %v, want store-1 and store-2", stores)
+	}
+
+	if len(requested) != 2 {
+		t.Fatalf("requests = %d, want 2", len(requested))
+	}
+	if requested[0] != "https://api.example/v1/environments/env-id/propagation/stores?limit=1" {
+		t.Fatalf("first url = %s", requested[0])
+	}
+}
+
+func TestListPingOneCollection_EmptyPages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		body      string
+		wantError bool
+	}{
+		{name: "links_only", body: `{"_links":{"self":{"href":"https://api.example/v1/environments/env-id/propagation/stores"}}}`},
+		{name: "null_embedded", body: `{"_embedded":null,"_links":{}}`},
+		{name: "embedded_without_key", body: `{"_embedded":{},"_links":{}}`},
+		{name: "invalid_embedded", body: `{"_embedded":[]}`, wantError: true},
+		{name: "not_an_object", body: `"stores"`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+				return testResponse(r, http.StatusOK, tt.body), nil
+			})
+
+			stores, err := listPropagationStores(context.Background(), nil, apiClient, "env-id", 0)
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("expected an error, got stores %v", stores)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("listPropagationStores error: %v", err)
+			}
+			if len(stores) != 0 {
+				t.Fatalf("stores = %v, want none", stores)
+			}
+		})
+	}
+}
+
+func TestListPingOneCollection_DetectsLoop(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		return testResponse(r, http.StatusOK, `{"_embedded":{"stores":[]},"_links":{"next":{"href":"https://api.example/v1/environments/env-id/propagation/stores"}}}`), nil
+	})
+
+	if _, err := listPropagationStores(context.Background(), nil, apiClient, "env-id", 0); err == nil {
+		t.Fatal("expected pagination loop error")
+	}
+}
+
+func TestCountPingOneCollection(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		firstPage    string
+		fullList     string
+		wantCount    int64
+		wantRequests int
+	}{
+		"reported count": {
+			firstPage:    `{"_embedded":{"rules":[{"id":"rule-1"}]},"count":7,"size":1}`,
+			wantCount:    7,
+			wantRequests: 1,
+		},
+		"listed when count is missing": {
+			firstPage:    `{"_embedded":{"rules":[{"id":"rule-1"}]}}`,
+			fullList:     `{"_embedded":{"rules":[{"id":"rule-1"},{"id":"rule-2"}]}}`,
+			wantCount:    2,
+			wantRequests: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			requests := 0
+			apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+				requests++
+				if r.URL.Path != "/v1/environments/env-id/propagation/plans/plan-id/rules" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				body := tc.fullList
+				if r.URL.Query().Get("limit") == "1" {
+					body = tc.firstPage
+				}
+
+				return testResponse(r, http.StatusOK, body), nil
+			})
+
+			count := propagationPlanRuleCount(context.Background(), nil, apiClient, "env-id", "plan-id")
+			if count.IsNull() || count.ValueInt64() != tc.wantCount {
+				t.Fatalf("count = %s, want %d", count, tc.wantCount)
+			}
+			if requests != tc.wantRequests {
+				t.Fatalf("requests = %d, want %d", requests, tc.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PingOneProvisioningPolicyModel describes the provider's policy block.
+type PingOneProvisioningPolicyModel struct {
+	ForbidActiveWithoutFilter types.Bool `tfsdk:"forbid_active_without_filter"`
+	ForbidDeprovision         types.Bool `tfsdk:"forbid_deprovision"`
+}
+
+// policyFromModel returns the guardrails configured in the policy block, or none when the block
+// is omitted.
+func policyFromModel(model *PingOneProvisioningPolicyModel) client.Policy {
+	if model == nil {
+		return client.Policy{}
+	}
+	return client.Policy{
+		ForbidActiveWithoutFilter: model.ForbidActiveWithoutFilter.ValueBool(),
+		ForbidDeprovision:         model.ForbidDeprovision.ValueBool(),
+	}
+}
+
+// policyOf returns the configured policy, treating an unconfigured client as having none.
+func policyOf(c *client.Client) client.Policy {
+	if c == nil {
+		return client.Policy{}
+	}
+	return c.Policy
+}
+
+// checkRulePolicy checks a planned rule against policy. Values that are not known yet are
+// checked once they are.
+func checkRulePolicy(policy client.Policy, typeName string, active types.Bool, filter types.String, populationIDs types.Set, deprovision types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if policy.ForbidActiveWithoutFilter && !active.IsUnknown() && active.ValueBool() {
+		hasFilter := filter.IsUnknown() || filter.ValueString() != ""
+		hasPopulations := populationIDs.IsUnknown() || len(populationIDs.Elements()) > 0
+		if !hasFilter && !hasPopulations {
+			diags.AddAttributeError(
+				path.Root("active"),
+				"Policy Forbids Active Rule Without Filter",
+				fmt.Sprintf("The provider policy sets `forbid_active_without_filter`, and this %s resource is active with neither a `filter` nor `population_ids`, so it would provision every user. "+
+					"Set a `filter` or `population_ids`, or set `active = false`.", typeName),
+			)
+		}
+	}
+
+	if policy.ForbidDeprovision && !deprovision.IsUnknown() && deprovision.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("deprovision"),
+			"Policy Forbids Deprovisioning",
+			fmt.Sprintf("The provider policy sets `forbid_deprovision`, and this %s resource sets `deprovision = true`. Remove `deprovision` or set it to `false`.", typeName),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckRulePolicy(t *testing.T) {
+	t.Parallel()
+
+	strict := client.Policy{ForbidActiveWithoutFilter: true, ForbidDeprovision: true}
+	noPopulations := types.SetNull(types.StringType)
+	onePopulation := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("pop-1")})
+
+	tests := []struct {
+		name          string
+		policy        client.Policy
+		active        types.Bool
+		filter        types.String
+		populationIDs types.Set
+		deprovision   types.Bool
+		wantErrors    int
+	}{
+		{name: "no_policy", policy: client.Policy{}, active: types.BoolValue(true), filter: types.StringNull(), populationIDs: noPopulations, deprovision: types.BoolValue(true)},
+		{name: "active_without_filter", policy: strict, active: types.BoolValue(true), filter: types.StringNull(), populationIDs: noPopulations, deprovision: types.BoolNull(), wantErrors: 1},
+		{name: "active_with_empty_filter", policy: strict, active: types.BoolValue(true), filter: types.StringValue(""), populationIDs: types.SetValueMust(types.StringType, nil), deprovision: types.BoolNull(), wantErrors: 1},
+		{name: "active_with_filter", policy: strict, active: types.BoolValue(true), filter: types.StringValue(`user.department eq "Sales"`), populationIDs: noPopulations, deprovision: types.BoolNull()},
+		{name: "active_with_populations", policy: strict, active: types.BoolValue(true), filter: types.StringNull(), populationIDs: onePopulation, deprovision: types.BoolNull()},
+		{name: "active_with_unknown_filter", policy: strict, active: types.BoolValue(true), filter: types.StringUnknown(), populationIDs: noPopulations, deprovision: types.BoolNull()},
+		{name: "inactive_without_filter", policy: strict, active: types.BoolNull(), filter: types.StringNull(), populationIDs: noPopulations, deprovision: types.BoolValue(false)},
+		{name: "deprovision", policy: strict, active: types.BoolValue(false), filter: types.StringNull(), populationIDs: noPopulations, deprovision: types.BoolValue(true), wantErrors: 1},
+		{name: "deprovision_unknown", policy: strict, active: types.BoolValue(false), filter: types.StringNull(), populationIDs: noPopulations, deprovision: types.BoolUnknown()},
+		{name: "both", policy: strict, active: types.BoolValue(true), filter: types.StringNull(), populationIDs: noPopulations, deprovision: types.BoolValue(true), wantErrors: 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := checkRulePolicy(tt.policy, "pingoneprovisioning_propagation_rule", tt.active, tt.filter, tt.populationIDs, tt.deprovision)
+			if diags.ErrorsCount() != tt.wantErrors {
+				t.Fatalf("ErrorsCount() = %d, want %d (diags: %v)", diags.ErrorsCount(), tt.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestPolicyFromModel(t *testing.T) {
+	t.Parallel()
+
+	if got := policyFromModel(nil); got != (client.Policy{}) {
+		t.Fatalf("policyFromModel(nil) = %+v, want no policy", got)
+	}
+
+	got := policyFromModel(&PingOneProvisioningPolicyModel{
+		ForbidActiveWithoutFilter: types.BoolValue(true),
+		ForbidDeprovision:         types.BoolNull(),
+	})
+	if !got.ForbidActiveWithoutFilter || got.ForbidDeprovision {
+		t.Fatalf("policyFromModel = %+v", got)
+	}
+}
+
+func TestPropagationRulePolicy_UnknownMappings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &propagationRuleResource{client: &client.Client{Policy: client.Policy{ForbidActiveWithoutFilter: true, ForbidDeprovision: true}}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	// A rule whose mappings come from a CSV that depends on another resource.
+	rule := func(mappings interface{}) tftypes.Value {
+		attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+		for name, attrType := range objType.AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+		for name, value := range map[string]string{"environment_id": "env-id", "plan_id": "plan-id", "name": "users", "source_store_id": "source-id", "target_store_id": "target-id"} {
+			attrs[name] = tftypes.NewValue(tftypes.String, value)
+		}
+		attrs["active"] = tftypes.NewValue(tftypes.Bool, true)
+		attrs["deprovision"] = tftypes.NewValue(tftypes.Bool, true)
+		attrs["mappings_csv"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		attrs["mappings"] = tftypes.NewValue(objType.AttributeTypes["mappings"], mappings)
+		return tftypes.NewValue(objType, attrs)
+	}
+
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: rule(tftypes.UnknownValue)}}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: rule(nil)},
+		Plan:   resp.Plan,
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)},
+	}, resp)
+
+	var summaries []string
+	for _, d := range resp.Diagnostics.Errors() {
+		summaries = append(summaries, d.Summary())
+	}
+	want := []string{"Policy Forbids Active Rule Without Filter", "Policy Forbids Deprovisioning"}
+	if len(summaries) != len(want) || summaries[0] != want[0] || summaries[1] != want[1] {
+		t.Fatalf("errors = %v, want %v", summaries, want)
+	}
+
+	// Create checks the policy again, before sending any request, now that every value is known.
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: rule(nil)}}, createResp)
+	if createResp.Diagnostics.ErrorsCount() != 2 || !createResp.State.Raw.IsNull() {
+		t.Fatalf("Create diagnostics = %v, want the policy errors and no state", createResp.Diagnostics)
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ruleActivationWindow is the period in which a rule may be active. A nil bound is open.
+type ruleActivationWindow struct {
+	start *time.Time
+	end   *time.Time
+}
+
+// set reports whether either bound is configured.
+func (w ruleActivationWindow) set() bool {
+	return w.start != nil || w.end != nil
+}
+
+// open reports whether now is inside the window: at or after start, and before end.
+func (w ruleActivationWindow) open(now time.Time) bool {
+	return (w.start == nil || !now.Before(*w.start)) && (w.end == nil || now.Before(*w.end))
+}
+
+// parseRuleActivationWindow parses `activate_after` and `deactivate_after`. Both must be known.
+func parseRuleActivationWindow(activateAfter types.String, deactivateAfter types.String) (ruleActivationWindow, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var window ruleActivationWindow
+
+	parse := func(value types.String, attribute string) *time.Time {
+		if value.IsNull() {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Activation Window",
+				fmt.Sprintf("`%s` must be an RFC3339 timestamp such as \"2026-01-31T09:00:00Z\", got %q.", attribute, value.ValueString()),
+			)
+			return nil
+		}
+		return &t
+	}
+
+	window.start = parse(activateAfter, "activate_after")
+	window.end = parse(deactivateAfter, "deactivate_after")
+	if window.start != nil && window.end != nil && !window.start.Before(*window.end) {
+		diags.AddAttributeError(
+			path.Root("deactivate_after"),
+			"Invalid Activation Window",
+			"`deactivate_after` must be later than `activate_after`.",
+		)
+	}
+	return window, diags
+}
+
+// planRuleActivationWindow plans `active` when it is not configured: from the activation window
+// when one is set, or null otherwise. A rule configured with `active = true` outside its window
+// is refused, since the provider cannot activate it then.
+func planRuleActivationWindow(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var configActive types.Bool
+	var activateAfter types.String
+	var deactivateAfter types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("active"), &configActive)...)
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("activate_after"), &activateAfter)...)
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("deactivate_after"), &deactivateAfter)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// A window that is not known yet is applied when the rule is created or updated.
+	if activateAfter.IsUnknown() || deactivateAfter.IsUnknown() || configActive.IsUnknown() {
+		return diags
+	}
+
+	window, windowDiags := parseRuleActivationWindow(activateAfter, deactivateAfter)
+	diags.Append(windowDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	switch {
+	case configActive.IsNull() && window.set():
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("active"), types.BoolValue(window.open(now)))...)
+	case configActive.IsNull():
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("active"), types.BoolNull())...)
+	case configActive.ValueBool() && !window.open(now):
+		diags.Append(ruleActivationWindowClosed(window, now))
+	}
+	return diags
+}
+
+// resolveRuleActivationWindow sets `active` from the window when it was not known at plan time,
+// and refuses to activate a rule outside its window, which it may have left since the plan.
+func resolveRuleActivationWindow(plan *customtypes.PropagationRuleResourceModel, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	window, windowDiags := parseRuleActivationWindow(plan.ActivateAfter, plan.DeactivateAfter)
+	diags.Append(windowDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if plan.Active.IsUnknown() {
+		if window.set() {
+			plan.Active = types.BoolValue(window.open(now))
+		} else {
+			plan.Active = types.BoolNull()
+		}
+	}
+	if !plan.Active.IsNull() && plan.Active.ValueBool() && !window.open(now) {
+		diags.Append(ruleActivationWindowClosed(window, now))
+	}
+	return diags
+}
+
+func ruleActivationWindowClosed(window ruleActivationWindow, now time.Time) diag.Diagnostic {
+	if window.start != nil && now.Before(*window.start) {
+		return diag.NewAttributeErrorDiagnostic(
+			path.Root("active"),
+			"Propagation Rule Activation Window Not Open",
+			fmt.Sprintf("`active` is `true`, but `activate_after` (%s) has not been reached, so the rule is not activated. "+
+				"Remove `active` to let the window activate the rule, or set `active = false`.", window.start.Format(time.RFC3339)),
+		)
+	}
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("active"),
+		"Propagation Rule Activation Window Closed",
+		fmt.Sprintf("`active` is `true`, but `deactivate_after` (%s) has passed, so the rule is not activated. "+
+			"Remove `active` to let the window deactivate the rule, or set `active = false`.", window.end.Format(time.RFC3339)),
+	)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveRuleActivationWindow(t *testing.T) {
+	t.Parallel()
+
+	start := types.StringValue("2026-11-01T09:00:00Z")
+	end := types.StringValue("2026-12-01T09:00:00Z")
+	before := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	during := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 12, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		active     types.Bool
+		now        time.Time
+		wantActive types.Bool
+		wantErr    bool
+	}{
+		{name: "unset before window", active: types.BoolUnknown(), now: before, wantActive: types.BoolValue(false)},
+		{name: "unset in window", active: types.BoolUnknown(), now: during, wantActive: types.BoolValue(true)},
+		{name: "unset after window", active: types.BoolUnknown(), now: after, wantActive: types.BoolValue(false)},
+		{name: "active before window", active: types.BoolValue(true), now: before, wantErr: true},
+		{name: "active after window", active: types.BoolValue(true), now: after, wantErr: true},
+		{name: "active in window", active: types.BoolValue(true), now: during, wantActive: types.BoolValue(true)},
+		{name: "inactive outside window", active: types.BoolValue(false), now: after, wantActive: types.BoolValue(false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := customtypes.PropagationRuleResourceModel{ActivateAfter: start, DeactivateAfter: end}
+			plan.Active = tt.active
+
+			diags := resolveRuleActivationWindow(&plan, tt.now)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("diags = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if !tt.wantErr && !plan.Active.Equal(tt.wantActive) {
+				t.Fatalf("active = %s, want %s", plan.Active, tt.wantActive)
+			}
+		})
+	}
+
+	plan := customtypes.PropagationRuleResourceModel{ActivateAfter: types.StringNull(), DeactivateAfter: types.StringNull()}
+	plan.Active = types.BoolUnknown()
+	if diags := resolveRuleActivationWindow(&plan, during); diags.HasError() || !plan.Active.IsNull() {
+		t.Fatalf("without a window: active = %s, diags = %v; want null", plan.Active, diags)
+	}
+}
+
+func TestParseRuleActivationWindow_Invalid(t *testing.T) {
+	t.Parallel()
+
+	if _, diags := parseRuleActivationWindow(types.StringValue("tomorrow"), types.StringNull()); !diags.HasError() {
+		t.Fatal("a timestamp that is not RFC3339 should be rejected")
+	}
+	if _, diags := parseRuleActivationWindow(types.StringValue("2026-12-01T00:00:00Z"), types.StringValue("2026-11-01T00:00:00Z")); !diags.HasError() {
+		t.Fatal("deactivate_after before activate_after should be rejected")
+	}
+}
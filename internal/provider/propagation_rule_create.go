@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// propagationRuleCreator sends the request that creates a rule in a propagation plan. Resources
+// get one from propagationRuleCreatorFor, so that the way rules are created can change without
+// touching them.
+type propagationRuleCreator interface {
+	createRule(ctx context.Context, apiClient *management.APIClient, environmentID string, planID string, payload map[string]interface{}) (*http.Response, error)
+}
+
+// planScopedRuleCreator posts the rule to the plan's rules collection with a raw request, since
+// the SDK has no operation for it, and falls back to the environment's rules collection when
+// PingOne does not serve the plan-scoped endpoint.
+//
+// Deprecated: once the SDK supports plan-scoped rule creation, sdkRuleCreator should call it
+// and become the default, and this creator should be removed.
+type planScopedRuleCreator struct {
+	endpoints *client.Endpoints
+}
+
+func (c planScopedRuleCreator) createRule(ctx context.Context, apiClient *management.APIClient, environmentID string, planID string, payload map[string]interface{}) (*http.Response, error) {
+	httpResp, err := createPropagationRuleForPlan(ctx, c.endpoints, apiClient, environmentID, planID, payload)
+	if err == nil || httpResp == nil || (httpResp.StatusCode != http.StatusNotFound && httpResp.StatusCode != http.StatusMethodNotAllowed) {
+		return httpResp, err
+	}
+	return sdkRuleCreator{}.createRule(ctx, apiClient, environmentID, planID, payload)
+}
+
+// sdkRuleCreator creates the rule with the SDK. The SDK only posts to the environment's rules
+// collection, so the plan is taken from the payload.
+type sdkRuleCreator struct{}
+
+func (sdkRuleCreator) createRule(ctx context.Context, apiClient *management.APIClient, environmentID string, _ string, payload map[string]interface{}) (*http.Response, error) {
+	httpResp, err := apiClient.PropagationRulesApi.
+		EnvironmentsEnvironmentIDPropagationRulesPost(ctx, environmentID).
+		Body(payload).
+		Execute()
+	if err != nil {
+		return httpResp, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
+	}
+	return httpResp, nil
+}
+
+// propagationRuleCreatorFor returns the rule creator selected by the provider's
+// `use_sdk_rule_create`.
+func propagationRuleCreatorFor(c *client.Client) propagationRuleCreator {
+	if c != nil && c.UseSDKRuleCreate {
+		return sdkRuleCreator{}
+	}
+	return planScopedRuleCreator{endpoints: endpointsOf(c)}
+}
@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mappingExpressionBuilderKinds are the arguments of an expression builder, of which exactly one
+// is set.
+var mappingExpressionBuilderKinds = []string{"concat", "lower", "upper", "substring", "default"}
+
+// mappingExpressionBuilderResourceSchema returns the `expression_builder` attribute of a
+// propagation rule resource mapping.
+func mappingExpressionBuilderResourceSchema() schema.SingleNestedAttribute {
+	kinds := make([]path.Expression, 0, len(mappingExpressionBuilderKinds))
+	for _, kind := range mappingExpressionBuilderKinds {
+		kinds = append(kinds, path.MatchRelative().AtName(kind))
+	}
+
+	operand := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"attribute": schema.StringAttribute{
+				Description: "A source attribute, for example `user.name.given`.",
+				Optional:    true,
+			},
+			"literal": schema.StringAttribute{
+				Description: "A fixed string.",
+				Optional:    true,
+			},
+		},
+		Validators: []validator.Object{
+			objectvalidator.ExactlyOneOf(path.MatchRelative().AtName("attribute"), path.MatchRelative().AtName("literal")),
+		},
+	}
+
+	return schema.SingleNestedAttribute{
+		Description: "Builds the mapping's expression from structured arguments instead of an expression string. Set exactly one of `concat`, `lower`, `upper`, `substring` or `default`; the provider compiles it into the PingOne expression syntax. Conflicts with `source_attribute`, `expression` and `sensitive_expression`.",
+		Optional:    true,
+		Validators: []validator.Object{
+			objectvalidator.ExactlyOneOf(kinds...),
+			objectvalidator.ConflictsWith(
+				path.MatchRelative().AtParent().AtName("source_attribute"),
+				path.MatchRelative().AtParent().AtName("expression"),
+				path.MatchRelative().AtParent().AtName("sensitive_expression"),
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"concat": schema.ListNestedAttribute{
+				Description:  "Joins two or more source attributes and literals, in order. Compiles to `${a} + \" \" + ${b}`.",
+				Optional:     true,
+				NestedObject: operand,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(2),
+				},
+			},
+			"lower": schema.SingleNestedAttribute{
+				Description: "Lower-cases a source attribute or literal. Compiles to `${a}.toLowerCase()`.",
+				Optional:    true,
+				Attributes:  operand.Attributes,
+				Validators:  operand.Validators,
+			},
+			"upper": schema.SingleNestedAttribute{
+				Description: "Upper-cases a source attribute or literal. Compiles to `${a}.toUpperCase()`.",
+				Optional:    true,
+				Attributes:  operand.Attributes,
+				Validators:  operand.Validators,
+			},
+			"substring": schema.SingleNestedAttribute{
+				Description: "Takes the characters of a source attribute from `start` up to, but not including, `end`, or to the end of the value when `end` is unset. Compiles to `${a}.substring(start, end)`.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"attribute": schema.StringAttribute{
+						Description: "The source attribute.",
+						Required:    true,
+					},
+					"start": schema.Int64Attribute{
+						Description: "The index of the first character, from 0.",
+						Required:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"end": schema.Int64Attribute{
+						Description: "The index after the last character. Must be greater than `start`.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+			"default": schema.SingleNestedAttribute{
+				Description: "Uses a source attribute, or `value` when the attribute is not set. Compiles to `${a} != null ? ${a} : \"value\"`.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"attribute": schema.StringAttribute{
+						Description: "The source attribute.",
+						Required:    true,
+					},
+					"value": schema.StringAttribute{
+						Description: "The value used when the attribute is not set.",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// mappingExpressionBuilderDataSourceSchema returns the computed `expression_builder` attribute
+// of a propagation rule data source mapping.
+func mappingExpressionBuilderDataSourceSchema() dsschema.SingleNestedAttribute {
+	operand := map[string]dsschema.Attribute{
+		"attribute": dsschema.StringAttribute{Computed: true},
+		"literal":   dsschema.StringAttribute{Computed: true},
+	}
+	source := func(extra map[string]dsschema.Attribute) map[string]dsschema.Attribute {
+		attributes := map[string]dsschema.Attribute{
+			"attribute": dsschema.StringAttribute{Computed: true},
+		}
+		for name, attribute := range extra {
+			attributes[name] = attribute
+		}
+		return attributes
+	}
+
+	return dsschema.SingleNestedAttribute{
+		Description: "The mapping's expression as structured arguments, when it has one of the forms the `pingoneprovisioning_propagation_rule` resource's `expression_builder` compiles to. Null for other expressions.",
+		Computed:    true,
+		Attributes: map[string]dsschema.Attribute{
+			"concat": dsschema.ListNestedAttribute{
+				Computed:     true,
+				NestedObject: dsschema.NestedAttributeObject{Attributes: operand},
+			},
+			"lower": dsschema.SingleNestedAttribute{Computed: true, Attributes: operand},
+			"upper": dsschema.SingleNestedAttribute{Computed: true, Attributes: operand},
+			"substring": dsschema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: source(map[string]dsschema.Attribute{
+					"start": dsschema.Int64Attribute{Computed: true},
+					"end":   dsschema.Int64Attribute{Computed: true},
+				}),
+			},
+			"default": dsschema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: source(map[string]dsschema.Attribute{
+					"value": dsschema.StringAttribute{Computed: true},
+				}),
+			},
+		},
+	}
+}
+
+// compileMappingExpression returns the PingOne expression b describes. It returns "" without an
+// error when an argument is not known yet.
+func compileMappingExpression(b *customtypes.PropagationRuleMappingExpressionBuilderModel) (string, error) {
+	if b == nil {
+		return "", nil
+	}
+
+	switch {
+	case b.Concat != nil:
+		if len(b.Concat) < 2 {
+			return "", fmt.Errorf("`concat` needs at least two parts")
+		}
+		parts := make([]string, 0, len(b.Concat))
+		for i := range b.Concat {
+			part, err := compileMappingExpressionOperand(&b.Concat[i])
+			if err != nil || part == "" {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, " + "), nil
+	case b.Lower != nil:
+		operand, err := compileMappingExpressionOperand(b.Lower)
+		if err != nil || operand == "" {
+			return "", err
+		}
+		return operand + ".toLowerCase()", nil
+	case b.Upper != nil:
+		operand, err := compileMappingExpressionOperand(b.Upper)
+		if err != nil || operand == "" {
+			return "", err
+		}
+		return operand + ".toUpperCase()", nil
+	case b.Substring != nil:
+		s := b.Substring
+		if !knownNonEmpty(s.Attribute) || s.Start.IsUnknown() || s.End.IsUnknown() {
+			return "", nil
+		}
+		start := s.Start.ValueInt64()
+		if start < 0 {
+			return "", fmt.Errorf("`substring.start` must be at least 0")
+		}
+		reference := mappingExpressionReference(s.Attribute.ValueString())
+		if s.End.IsNull() {
+			return fmt.Sprintf("%s.substring(%d)", reference, start), nil
+		}
+		if end := s.End.ValueInt64(); end <= start {
+			return "", fmt.Errorf("`substring.end` (%d) must be greater than `substring.start` (%d)", end, start)
+		}
+		return fmt.Sprintf("%s.substring(%d, %d)", reference, start, s.End.ValueInt64()), nil
+	case b.Default != nil:
+		d := b.Default
+		if !knownNonEmpty(d.Attribute) || d.Value.IsUnknown() || d.Value.IsNull() {
+			return "", nil
+		}
+		reference := mappingExpressionReference(d.Attribute.ValueString())
+		return fmt.Sprintf("%s != null ? %s : %s", reference, reference, mappingExpressionLiteral(d.Value.ValueString())), nil
+	default:
+		return "", fmt.Errorf("set one of %s", strings.Join(mappingExpressionBuilderKinds, ", "))
+	}
+}
+
+func compileMappingExpressionOperand(o *customtypes.PropagationRuleMappingExpressionOperandModel) (string, error) {
+	attributeSet := !o.Attribute.IsNull()
+	literalSet := !o.Literal.IsNull()
+	switch {
+	case o.Attribute.IsUnknown() || o.Literal.IsUnknown():
+		return "", nil
+	case attributeSet == literalSet:
+		return "", fmt.Errorf("set exactly one of `attribute` and `literal`")
+	case attributeSet:
+		if strings.TrimSpace(o.Attribute.ValueString()) == "" {
+			return "", fmt.Errorf("`attribute` must not be empty")
+		}
+		return mappingExpressionReference(o.Attribute.ValueString()), nil
+	default:
+		return mappingExpressionLiteral(o.Literal.ValueString()), nil
+	}
+}
+
+func knownNonEmpty(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown() && strings.TrimSpace(v.ValueString()) != ""
+}
+
+func mappingExpressionReference(attribute string) string {
+	return "${" + strings.TrimSpace(attribute) + "}"
+}
+
+func mappingExpressionLiteral(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+var (
+	mappingExpressionSubstringPattern = regexp.MustCompile(`^\$\{([^{}]+)\}\.substring\((\d+)(?:,\s*(\d+))?\)$`)
+	mappingExpressionDefaultPattern   = regexp.MustCompile(`^\$\{([^{}]+)\}\s*!=\s*null\s*\?\s*\$\{([^{}]+)\}\s*:\s*("(?:[^"\\]|\\.)*")$`)
+)
+
+// decompileMappingExpression returns the builder that compiles to expression, or nil when the
+// expression has none of the builder's forms.
+func decompileMappingExpression(expression string) *customtypes.PropagationRuleMappingExpressionBuilderModel {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil
+	}
+
+	if m := mappingExpressionSubstringPattern.FindStringSubmatch(expression); m != nil {
+		start, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil {
+			return nil
+		}
+		end := types.Int64Null()
+		if m[3] != "" {
+			value, err := strconv.ParseInt(m[3], 10, 64)
+			if err != nil || value <= start {
+				return nil
+			}
+			end = types.Int64Value(value)
+		}
+		return &customtypes.PropagationRuleMappingExpressionBuilderModel{
+			Substring: &customtypes.PropagationRuleMappingExpressionSubstringModel{
+				Attribute: types.StringValue(m[1]),
+				Start:     types.Int64Value(start),
+				End:       end,
+			},
+		}
+	}
+
+	if m := mappingExpressionDefaultPattern.FindStringSubmatch(expression); m != nil && m[1] == m[2] {
+		value, ok := parseMappingExpressionLiteral(m[3])
+		if !ok {
+			return nil
+		}
+		return &customtypes.PropagationRuleMappingExpressionBuilderModel{
+			Default: &customtypes.PropagationRuleMappingExpressionDefaultModel{
+				Attribute: types.StringValue(m[1]),
+				Value:     types.StringValue(value),
+			},
+		}
+	}
+
+	if operand, ok := strings.CutSuffix(expression, ".toLowerCase()"); ok {
+		if o := parseMappingExpressionOperand(operand); o != nil {
+			return &customtypes.PropagationRuleMappingExpressionBuilderModel{Lower: o}
+		}
+		return nil
+	}
+	if operand, ok := strings.CutSuffix(expression, ".toUpperCase()"); ok {
+		if o := parseMappingExpressionOperand(operand); o != nil {
+			return &customtypes.PropagationRuleMappingExpressionBuilderModel{Upper: o}
+		}
+		return nil
+	}
+
+	parts := splitMappingExpressionConcat(expression)
+	if len(parts) < 2 {
+		return nil
+	}
+	concat := make([]customtypes.PropagationRuleMappingExpressionOperandModel, 0, len(parts))
+	for _, part := range parts {
+		o := parseMappingExpressionOperand(part)
+		if o == nil {
+			return nil
+		}
+		concat = append(concat, *o)
+	}
+	return &customtypes.PropagationRuleMappingExpressionBuilderModel{Concat: concat}
+}
+
+func parseMappingExpressionOperand(s string) *customtypes.PropagationRuleMappingExpressionOperandModel {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}") {
+		attribute := s[2 : len(s)-1]
+		if attribute == "" || strings.ContainsAny(attribute, "{}") {
+			return nil
+		}
+		return &customtypes.PropagationRuleMappingExpressionOperandModel{
+			Attribute: types.StringValue(attribute),
+			Literal:   types.StringNull(),
+		}
+	}
+	if value, ok := parseMappingExpressionLiteral(s); ok {
+		return &customtypes.PropagationRuleMappingExpressionOperandModel{
+			Attribute: types.StringNull(),
+			Literal:   types.StringValue(value),
+		}
+	}
+	return nil
+}
+
+// parseMappingExpressionLiteral unquotes a double-quoted literal in which only `\"` and `\\` are
+// escaped.
+func parseMappingExpressionLiteral(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", false
+	}
+
+	var b strings.Builder
+	inner := s[1 : len(s)-1]
+	for i := 0; i < len(inner); i++ {
+		switch inner[i] {
+		case '\\':
+			if i+1 == len(inner) || (inner[i+1] != '\\' && inner[i+1] != '"') {
+				return "", false
+			}
+			i++
+			b.WriteByte(inner[i])
+		case '"':
+			return "", false
+		default:
+			b.WriteByte(inner[i])
+		}
+	}
+	return b.String(), true
+}
+
+// splitMappingExpressionConcat splits expression at the `+` operators outside literals.
+func splitMappingExpressionConcat(expression string) []string {
+	var parts []string
+	start := 0
+	inLiteral := false
+	for i := 0; i < len(expression); i++ {
+		switch c := expression[i]; {
+		case inLiteral && c == '\\':
+			i++
+		case c == '"':
+			inLiteral = !inLiteral
+		case !inLiteral && c == '+':
+			parts = append(parts, expression[start:i])
+			start = i + 1
+		}
+	}
+	if inLiteral {
+		return nil
+	}
+	return append(parts, expression[start:])
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCompileMappingExpression(t *testing.T) {
+	t.Parallel()
+
+	attribute := func(name string) customtypes.PropagationRuleMappingExpressionOperandModel {
+		return customtypes.PropagationRuleMappingExpressionOperandModel{Attribute: types.StringValue(name), Literal: types.StringNull()}
+	}
+	literal := func(value string) customtypes.PropagationRuleMappingExpressionOperandModel {
+		return customtypes.PropagationRuleMappingExpressionOperandModel{Attribute: types.StringNull(), Literal: types.StringValue(value)}
+	}
+	lower := attribute("user.email")
+
+	tests := []struct {
+		name    string
+		builder customtypes.PropagationRuleMappingExpressionBuilderModel
+		want    string
+	}{
+		{
+			name: "concat",
+			builder: customtypes.PropagationRuleMappingExpressionBuilderModel{Concat: []customtypes.PropagationRuleMappingExpressionOperandModel{
+				attribute("user.name.given"), literal(` "\ `), attribute("user.name.family"),
+			}},
+			want: `${user.name.given} + " \"\\ " + ${user.name.family}`,
+		},
+		{
+			name:    "lower",
+			builder: customtypes.PropagationRuleMappingExpressionBuilderModel{Lower: &lower},
+			want:    `${user.email}.toLowerCase()`,
+		},
+		{
+			name: "substring to end",
+			builder: customtypes.PropagationRuleMappingExpressionBuilderModel{Substring: &customtypes.PropagationRuleMappingExpressionSubstringModel{
+				Attribute: types.StringValue("user.username"), Start: types.Int64Value(2), End: types.Int64Null(),
+			}},
+			want: `${user.username}.substring(2)`,
+		},
+		{
+			name: "substring",
+			builder: customtypes.PropagationRuleMappingExpressionBuilderModel{Substring: &customtypes.PropagationRuleMappingExpressionSubstringModel{
+				Attribute: types.StringValue("user.username"), Start: types.Int64Value(0), End: types.Int64Value(3),
+			}},
+			want: `${user.username}.substring(0, 3)`,
+		},
+		{
+			name: "default",
+			builder: customtypes.PropagationRuleMappingExpressionBuilderModel{Default: &customtypes.PropagationRuleMappingExpressionDefaultModel{
+				Attribute: types.StringValue("user.title"), Value: types.StringValue("Employee"),
+			}},
+			want: `${user.title} != null ? ${user.title} : "Employee"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := compileMappingExpression(&tt.builder)
+			if err != nil {
+				t.Fatalf("compileMappingExpression error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("compileMappingExpression = %q, want %q", got, tt.want)
+			}
+
+			decompiled := decompileMappingExpression(got)
+			if !reflect.DeepEqual(decompiled, &tt.builder) {
+				t.Fatalf("decompileMappingExpression(%q) = %+v, want %+v", got, decompiled, tt.builder)
+			}
+		})
+	}
+}
+
+func TestCompileMappingExpression_UnknownAndInvalid(t *testing.T) {
+	t.Parallel()
+
+	unknown := customtypes.PropagationRuleMappingExpressionBuilderModel{Default: &customtypes.PropagationRuleMappingExpressionDefaultModel{
+		Attribute: types.StringValue("user.title"), Value: types.StringUnknown(),
+	}}
+	if got, err := compileMappingExpression(&unknown); err != nil || got != "" {
+		t.Fatalf("unknown value = %q, %v; want empty without error", got, err)
+	}
+
+	backwards := customtypes.PropagationRuleMappingExpressionBuilderModel{Substring: &customtypes.PropagationRuleMappingExpressionSubstringModel{
+		Attribute: types.StringValue("user.username"), Start: types.Int64Value(3), End: types.Int64Value(3),
+	}}
+	if _, err := compileMappingExpression(&backwards); err == nil {
+		t.Fatal("expected an error for end <= start")
+	}
+	mapping := customtypes.PropagationRuleMappingModel{
+		SourceAttribute:     types.StringNull(),
+		TargetAttribute:     types.StringValue("title"),
+		Expression:          types.StringNull(),
+		SensitiveExpression: types.StringNull(),
+		ExpressionBuilder:   &backwards,
+	}
+	if diags := validatePropagationRuleMappings([]customtypes.PropagationRuleMappingModel{mapping}); !diags.HasError() {
+		t.Fatal("expected validation errors for an invalid builder")
+	}
+}
+
+func TestDecompileMappingExpression_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, expression := range []string{
+		`${user.email}`,
+		`${user.a} + ${user.b}.toLowerCase()`,
+		`${user.a} != null ? ${user.b} : "x"`,
+		`${user.a}.substring(3, 1)`,
+		`"unterminated + ${user.a}`,
+		`#string.trim(${user.a})`,
+	} {
+		if got := decompileMappingExpression(expression); got != nil {
+			t.Errorf("decompileMappingExpression(%q) = %+v, want nil", expression, got)
+		}
+	}
+}
+
+func TestMappingExpression_FromBuilder(t *testing.T) {
+	t.Parallel()
+
+	upper := customtypes.PropagationRuleMappingExpressionOperandModel{Attribute: types.StringValue("user.locale"), Literal: types.StringNull()}
+	mapping := customtypes.PropagationRuleMappingModel{
+		SourceAttribute:     types.StringNull(),
+		TargetAttribute:     types.StringValue("locale"),
+		Expression:          types.StringNull(),
+		SensitiveExpression: types.StringNull(),
+		ExpressionBuilder:   &customtypes.PropagationRuleMappingExpressionBuilderModel{Upper: &upper},
+		Enabled:             types.BoolNull(),
+	}
+
+	if got := propagationMappingPayload(mapping)["expression"]; got != `${user.locale}.toUpperCase()` {
+		t.Fatalf("payload expression = %v", got)
+	}
+	if diags := validatePropagationRuleMappings([]customtypes.PropagationRuleMappingModel{mapping}); diags.HasError() {
+		t.Fatalf("unexpected validation errors: %v", diags)
+	}
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mappingsCSVHeader is the optional first row of mappings_csv.
+var mappingsCSVHeader = []string{"source", "target", "expression"}
+
+// planMappingsFromCSV sets the planned mappings from mappings_csv. Mappings that match one in
+// state keep its ID, so an unchanged CSV plans no difference. Without mappings_csv, unset
+// mappings are planned as null rather than left to be computed.
+func planMappingsFromCSV(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var mappingsCSV types.String
+	var configured types.List
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("mappings_csv"), &mappingsCSV)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("mappings"), &configured)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	switch {
+	case mappingsCSV.IsNull():
+		if configured.IsNull() {
+			diags.Append(resp.Plan.SetAttribute(ctx, path.Root("mappings"), configured)...)
+		}
+		return diags
+	case mappingsCSV.IsUnknown():
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("mappings"), types.ListUnknown(configured.ElementType(ctx)))...)
+		return diags
+	}
+
+	mappings, parseDiags := parseMappingsCSV(mappingsCSV.ValueString())
+	diags.Append(parseDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var prior []customtypes.PropagationRuleMappingModel
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("mappings"), &prior)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	priorIDs := make(map[string]types.String, len(prior))
+	for _, m := range prior {
+		if m.SensitiveExpression.IsNull() {
+			priorIDs[mappingKey(m.SourceAttribute.ValueString(), m.TargetAttribute.ValueString(), m.Expression.ValueString())] = m.Id
+		}
+	}
+	for i, m := range mappings {
+		if id, ok := priorIDs[mappingKey(m.SourceAttribute.ValueString(), m.TargetAttribute.ValueString(), m.Expression.ValueString())]; ok && !id.IsNull() {
+			mappings[i].Id = id
+		}
+	}
+
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("mappings"), mappings)...)
+	return diags
+}
+
+// parseMappingsCSV parses `source,target,expression` rows into mappings. The expression column
+// is optional, a first row naming the columns is skipped, and lines starting with `#` are
+// comments. Every invalid row is reported with its line number.
+func parseMappingsCSV(raw string) ([]customtypes.PropagationRuleMappingModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	attrPath := path.Root("mappings_csv")
+
+	reader := csv.NewReader(strings.NewReader(raw))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	// SCIM targets such as `emails[type eq "work"].value` quote values without quoting the field.
+	reader.LazyQuotes = true
+
+	var mappings []customtypes.PropagationRuleMappingModel
+	lines := make(map[string]int)
+	first := true
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Invalid Mappings CSV", fmt.Sprintf("Could not parse the CSV: %s", err))
+			return nil, diags
+		}
+		line, _ := reader.FieldPos(0)
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if first {
+			first = false
+			if isMappingsCSVHeader(record) {
+				continue
+			}
+		}
+
+		if len(record) < 2 || len(record) > 3 {
+			diags.AddAttributeError(attrPath, "Invalid Mappings CSV Row",
+				fmt.Sprintf("Line %d: expected `source,target,expression` with an optional expression, got %d fields.", line, len(record)))
+			continue
+		}
+
+		source, target, expression := record[0], record[1], ""
+		if len(record) == 3 {
+			expression = record[2]
+		}
+
+		switch {
+		case target == "":
+			diags.AddAttributeError(attrPath, "Invalid Mappings CSV Row",
+				fmt.Sprintf("Line %d: the target attribute is empty.", line))
+			continue
+		case source == "" && expression == "":
+			diags.AddAttributeError(attrPath, "Invalid Mappings CSV Row",
+				fmt.Sprintf("Line %d: set a source attribute or an expression for target `%s`.", line, target))
+			continue
+		case source != "" && expression != "":
+			diags.AddAttributeError(attrPath, "Invalid Mappings CSV Row",
+				fmt.Sprintf("Line %d: set either a source attribute or an expression for target `%s`, not both.", line, target))
+			continue
+		}
+
+		key := mappingKey(source, target, expression)
+		if previous, ok := lines[key]; ok {
+			diags.AddAttributeError(attrPath, "Invalid Mappings CSV Row",
+				fmt.Sprintf("Line %d repeats the mapping on line %d.", line, previous))
+			continue
+		}
+		lines[key] = line
+
+		mapping := customtypes.PropagationRuleMappingModel{
+			Id:                  types.StringUnknown(),
+			SourceAttribute:     types.StringNull(),
+			TargetAttribute:     types.StringValue(target),
+			Expression:          types.StringNull(),
+			SensitiveExpression: types.StringNull(),
+			Enabled:             types.BoolNull(),
+		}
+		if source != "" {
+			mapping.SourceAttribute = types.StringValue(source)
+		}
+		if expression != "" {
+			mapping.Expression = types.StringValue(expression)
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if !diags.HasError() && len(mappings) == 0 {
+		diags.AddAttributeError(attrPath, "Invalid Mappings CSV", "The CSV has no mapping rows. Remove `mappings_csv` to manage the rule without mappings.")
+	}
+
+	return mappings, diags
+}
+
+func isMappingsCSVHeader(record []string) bool {
+	if len(record) < 2 || len(record) > len(mappingsCSVHeader) {
+		return false
+	}
+	for i, field := range record {
+		if !strings.EqualFold(field, mappingsCSVHeader[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseMappingsCSV(t *testing.T) {
+	t.Parallel()
+
+	raw := "source,target,expression\n" +
+		"# work address\n" +
+		"email, emails[type eq \"work\"].value\n" +
+		",displayName,\"${user.name.given}, ${user.name.family}\"\n"
+
+	mappings, diags := parseMappingsCSV(raw)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(mappings) != 2 {
+		t.Fatalf("mappings = %+v, want 2", mappings)
+	}
+	if mappings[0].SourceAttribute.ValueString() != "email" || mappings[0].TargetAttribute.ValueString() != `emails[type eq "work"].value` || !mappings[0].Expression.IsNull() {
+		t.Fatalf("first mapping = %+v", mappings[0])
+	}
+	if !mappings[1].SourceAttribute.IsNull() || mappings[1].Expression.ValueString() != "${user.name.given}, ${user.name.family}" {
+		t.Fatalf("second mapping = %+v", mappings[1])
+	}
+}
+
+func TestParseMappingsCSV_RowErrors(t *testing.T) {
+	t.Parallel()
+
+	raw := "email,mail\n" +
+		"email\n" +
+		"email,\n" +
+		",title\n" +
+		"email,upn,${user.email}\n" +
+		"email,mail\n"
+
+	_, diags := parseMappingsCSV(raw)
+	if got := diags.ErrorsCount(); got != 5 {
+		t.Fatalf("errors = %d, want 5: %v", got, diags)
+	}
+	for i, want := range []string{"Line 2:", "Line 3:", "Line 4:", "Line 5:", "Line 6 repeats the mapping on line 1."} {
+		if detail := diags.Errors()[i].Detail(); !strings.HasPrefix(detail, want) {
+			t.Fatalf("error %d = %q, want prefix %q", i, detail, want)
+		}
+	}
+
+	if _, diags := parseMappingsCSV("source,target\n"); !diags.HasError() {
+		t.Fatal("expected an error for a CSV without rows")
+	}
+}
+
+func TestPlanMappingsFromCSV(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&propagationRuleResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	model := func(mappingsCSV types.String, mappings []customtypes.PropagationRuleMappingModel) tftypes.Value {
+		t.Helper()
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		if diags := state.Set(ctx, &customtypes.PropagationRuleResourceModel{
+			PropagationRuleModel: customtypes.PropagationRuleModel{
+				Id:                    types.StringValue("rule-1"),
+				EnvironmentId:         types.StringValue("env-id"),
+				PlanId:                types.StringValue("plan-id"),
+				Name:                  types.StringValue("users"),
+				SourceStoreId:         types.StringValue("source-id"),
+				TargetStoreId:         types.StringValue("target-id"),
+				PopulationIds:         types.SetNull(types.StringType),
+				GroupIds:              types.SetNull(types.StringType),
+				Configuration:         types.MapNull(types.StringType),
+				CorrelationAttributes: types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleCorrelationAttributeAttrTypes}),
+				Links:                 types.MapNull(types.StringType),
+				Mappings:              mappings,
+				Active:                types.BoolNull(),
+				PopulationMatch:       types.StringNull(),
+			},
+			AuthoritativeMappings: types.BoolValue(true),
+			UnmanagedMappings:     types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes}),
+			MappingsCsv:           mappingsCSV,
+		}); diags.HasError() {
+			t.Fatalf("State.Set: %v", diags)
+		}
+		return state.Raw
+	}
+
+	prior := []customtypes.PropagationRuleMappingModel{{
+		Id:                  types.StringValue("map-1"),
+		SourceAttribute:     types.StringValue("email"),
+		TargetAttribute:     types.StringValue("mail"),
+		Expression:          types.StringNull(),
+		SensitiveExpression: types.StringNull(),
+		Enabled:             types.BoolNull(),
+	}}
+	mappingsCSV := types.StringValue("email,mail\nusername,upn\n")
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: model(mappingsCSV, nil)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: model(mappingsCSV, nil)},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: model(types.StringNull(), prior)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	if diags := planMappingsFromCSV(ctx, req, resp); diags.HasError() {
+		t.Fatalf("planMappingsFromCSV: %v", diags)
+	}
+
+	var planned []customtypes.PropagationRuleMappingModel
+	if diags := resp.Plan.GetAttribute(ctx, path.Root("mappings"), &planned); diags.HasError() {
+		t.Fatalf("GetAttribute: %v", diags)
+	}
+	if len(planned) != 2 {
+		t.Fatalf("planned mappings = %+v, want 2", planned)
+	}
+	if planned[0].Id.ValueString() != "map-1" {
+		t.Fatalf("first mapping id = %s, want the ID from state", planned[0].Id)
+	}
+	if !planned[1].Id.IsUnknown() || planned[1].TargetAttribute.ValueString() != "upn" {
+		t.Fatalf("second mapping = %+v, want a new mapping to upn", planned[1])
+	}
+}
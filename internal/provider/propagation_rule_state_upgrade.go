@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ruleIDSetAttributes are the rule and rule set attributes that schema version 0 declared as
+// lists. They are sets from version 1, so that reordering the IDs in the configuration is not a
+// change.
+var ruleIDSetAttributes = []string{"population_ids", "group_ids"}
+
+// ruleSchemaV0 returns current as schema version 0, in which ruleIDSetAttributes were lists.
+func ruleSchemaV0(current schema.Schema) schema.Schema {
+	prior := current
+	prior.Version = 0
+	prior.Attributes = make(map[string]schema.Attribute, len(current.Attributes))
+	for name, attribute := range current.Attributes {
+		prior.Attributes[name] = attribute
+	}
+	for _, name := range ruleIDSetAttributes {
+		set := current.Attributes[name].(schema.SetAttribute)
+		prior.Attributes[name] = schema.ListAttribute{
+			Description: set.Description,
+			Optional:    set.Optional,
+			ElementType: set.ElementType,
+		}
+	}
+	return prior
+}
+
+// upgradeRuleIDListsToSets copies a version 0 rule or rule set state attribute by attribute and
+// converts ruleIDSetAttributes to sets, dropping duplicate IDs.
+func upgradeRuleIDListsToSets(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	for name := range req.State.Schema.GetAttributes() {
+		var value attr.Value
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if list, ok := value.(types.List); ok && slices.Contains(ruleIDSetAttributes, name) {
+			value = ruleIDListToSet(list)
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+}
+
+func ruleIDListToSet(list types.List) types.Set {
+	if list.IsNull() || list.IsUnknown() {
+		return types.SetNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, 0, len(list.Elements()))
+	for _, element := range list.Elements() {
+		if !slices.ContainsFunc(elements, element.Equal) {
+			elements = append(elements, element)
+		}
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// propagationService is the part of the PingOne propagation API that rules and their mappings are
+// managed through. The rule helpers depend on it rather than on the SDK client, so that their
+// reconciliation logic can be tested against an in-memory implementation.
+//
+// Errors follow the SDK: the response is returned alongside the error so callers can check the
+// status code, and RuleUpdate, RuleDelete, MappingCreate and MappingUpdate leave the response body
+// to be described by utils.HandleSDKError.
+type propagationService interface {
+	// RuleCreate creates a rule in a plan and returns its ID, adopting a rule created by a request
+	// that did not complete.
+	RuleCreate(ctx context.Context, environmentID string, planID string, payload map[string]interface{}) (string, *http.Response, error)
+	RuleRead(ctx context.Context, environmentID string, ruleID string) (map[string]interface{}, *http.Response, error)
+	RuleList(ctx context.Context, environmentID string, planID string) ([]map[string]interface{}, error)
+	// RuleUpdate replaces a rule with payload.
+	RuleUpdate(ctx context.Context, environmentID string, ruleID string, payload map[string]interface{}) (*http.Response, error)
+	RuleDelete(ctx context.Context, environmentID string, ruleID string) (*http.Response, error)
+
+	MappingList(ctx context.Context, environmentID string, ruleID string) ([]map[string]interface{}, error)
+	MappingCount(ctx context.Context, environmentID string, ruleID string) (int64, error)
+	MappingCreate(ctx context.Context, environmentID string, ruleID string, payload map[string]interface{}) (*http.Response, error)
+	MappingUpdate(ctx context.Context, environmentID string, mappingID string, payload map[string]interface{}) (*http.Response, error)
+	// MappingDelete deletes a mapping. A mapping that is already gone is not an error.
+	MappingDelete(ctx context.Context, environmentID string, mappingID string) (*http.Response, error)
+}
+
+// sdkPropagationService sends propagation requests through a PingOne management client. Mapping
+// writes start from the fallback hostname that last worked for api and try the other regional
+// hostnames when PingOne does not recognize the request.
+type sdkPropagationService struct {
+	api       *management.APIClient
+	creator   propagationRuleCreator
+	pageSize  int32
+	endpoints *client.Endpoints
+}
+
+// propagationServiceFor returns the service for requests sent through apiClient, with the rule
+// creator, page size and endpoints of the provider.
+func propagationServiceFor(c *client.Client, apiClient *management.APIClient) propagationService {
+	var pageSize int32
+	if c != nil {
+		pageSize = c.PageSize
+	}
+	return &sdkPropagationService{api: apiClient, creator: propagationRuleCreatorFor(c), pageSize: pageSize, endpoints: endpointsOf(c)}
+}
+
+func (s *sdkPropagationService) RuleCreate(ctx context.Context, environmentID string, planID string, payload map[string]interface{}) (string, *http.Response, error) {
+	return createPropagationRuleViaPlan(ctx, s.endpoints, s.api, s.creator, environmentID, planID, payload, s.pageSize)
+}
+
+func (s *sdkPropagationService) RuleRead(ctx context.Context, environmentID string, ruleID string) (map[string]interface{}, *http.Response, error) {
+	return readPropagationRule(ctx, s.api, environmentID, ruleID)
+}
+
+func (s *sdkPropagationService) RuleList(ctx context.Context, environmentID string, planID string) ([]map[string]interface{}, error) {
+	return listPropagationRulesForPlan(ctx, s.endpoints, s.api, environmentID, planID, s.pageSize)
+}
+
+func (s *sdkPropagationService) RuleUpdate(ctx context.Context, environmentID string, ruleID string, payload map[string]interface{}) (*http.Response, error) {
+	return s.api.PropagationRulesApi.
+		EnvironmentsEnvironmentIDPropagationRulesStoreIDPut(ctx, environmentID, ruleID).
+		Body(payload).
+		Execute()
+}
+
+func (s *sdkPropagationService) RuleDelete(ctx context.Context, environmentID string, ruleID string) (*http.Response, error) {
+	return s.api.PropagationRulesApi.
+		EnvironmentsEnvironmentIDPropagationRulesRuleIDDelete(ctx, environmentID, ruleID).
+		Execute()
+}
+
+func (s *sdkPropagationService) MappingList(ctx context.Context, environmentID string, ruleID string) ([]map[string]interface{}, error) {
+	return listPropagationRuleMappings(ctx, s.endpoints, s.api, environmentID, ruleID, s.pageSize)
+}
+
+func (s *sdkPropagationService) MappingCount(ctx context.Context, environmentID string, ruleID string) (int64, error) {
+	return countPropagationRuleMappings(ctx, s.endpoints, s.api, environmentID, ruleID)
+}
+
+func (s *sdkPropagationService) MappingCreate(ctx context.Context, environmentID string, ruleID string, payload map[string]interface{}) (*http.Response, error) {
+	requestClient := pingOneRequestClient(s.endpoints, s.api)
+	httpResp, err := requestClient.PropagationMappingsApi.
+		EnvironmentsEnvironmentIDPropagationRulesRuleIDMappingsPost(ctx, environmentID, ruleID).
+		Body(payload).
+		Execute()
+	if err == nil || !shouldTryAlternateHostname(err, httpResp) {
+		return httpResp, err
+	}
+
+	for _, hostname := range pingOneFallbackBaseHostnames(requestClient) {
+		altClient, altErr := cloneManagementClientWithBaseHostname(requestClient, hostname)
+		if altErr != nil || altClient == nil {
+			continue
+		}
+
+		httpResp, err = altClient.PropagationMappingsApi.
+			EnvironmentsEnvironmentIDPropagationRulesRuleIDMappingsPost(ctx, environmentID, ruleID).
+			Body(payload).
+			Execute()
+		if err == nil {
+			logHostnameFallback(ctx, requestClient, hostname, "create propagation mapping")
+			rememberHostnameFallback(s.endpoints, s.api, altClient)
+			return httpResp, nil
+		}
+		if !shouldTryAlternateHostname(err, httpResp) {
+			break
+		}
+	}
+	return httpResp, err
+}
+
+func (s *sdkPropagationService) MappingUpdate(ctx context.Context, environmentID string, mappingID string, payload map[string]interface{}) (*http.Response, error) {
+	return pingOneRequestClient(s.endpoints, s.api).PropagationMappingsApi.
+		EnvironmentsEnvironmentIDPropagationMappingsMappingIDPut(ctx, environmentID, mappingID).
+		Body(payload).
+		Execute()
+}
+
+func (s *sdkPropagationService) MappingDelete(ctx context.Context, environmentID string, mappingID string) (*http.Response, error) {
+	return deletePropagationMappingWithFallback(ctx, s.endpoints, s.api, environmentID, mappingID)
+}
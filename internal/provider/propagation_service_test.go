@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ propagationService = &fakePropagationService{}
+
+// fakePropagationService keeps rules and mappings in memory and records the calls made to it.
+type fakePropagationService struct {
+	rules    map[string]map[string]interface{}
+	mappings map[string][]map[string]interface{}
+	calls    []string
+	nextID   int
+
+	// mappingUpdateStatus, when set, is the status every MappingUpdate fails with.
+	mappingUpdateStatus int
+	// ruleUpdateStatuses are the statuses the next RuleUpdate calls fail with, in order.
+	ruleUpdateStatuses []int
+}
+
+func newFakePropagationService() *fakePropagationService {
+	return &fakePropagationService{
+		rules:    map[string]map[string]interface{}{},
+		mappings: map[string][]map[string]interface{}{},
+	}
+}
+
+func (f *fakePropagationService) record(format string, args ...interface{}) {
+	f.calls = append(f.calls, fmt.Sprintf(format, args...))
+}
+
+func (f *fakePropagationService) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+func fakeResponse(status int) (*http.Response, error) {
+	resp := &http.Response{StatusCode: status, Status: http.StatusText(status), Body: io.NopCloser(strings.NewReader(""))}
+	if status >= 300 {
+		return resp, fmt.Errorf("%d %s", status, http.StatusText(status))
+	}
+	return resp, nil
+}
+
+func (f *fakePropagationService) RuleCreate(_ context.Context, _ string, planID string, payload map[string]interface{}) (string, *http.Response, error) {
+	id := f.newID("rule")
+	f.record("RuleCreate %s", planID)
+	f.rules[id] = cloneInterfaceMap(payload)
+	f.rules[id]["id"] = id
+	resp, err := fakeResponse(http.StatusCreated)
+	return id, resp, err
+}
+
+func (f *fakePropagationService) RuleRead(_ context.Context, _ string, ruleID string) (map[string]interface{}, *http.Response, error) {
+	f.record("RuleRead %s", ruleID)
+	rule, ok := f.rules[ruleID]
+	if !ok {
+		resp, err := fakeResponse(http.StatusNotFound)
+		return nil, resp, err
+	}
+	resp, err := fakeResponse(http.StatusOK)
+	return cloneInterfaceMap(rule), resp, err
+}
+
+func (f *fakePropagationService) RuleList(_ context.Context, _ string, planID string) ([]map[string]interface{}, error) {
+	f.record("RuleList %s", planID)
+	var rules []map[string]interface{}
+	for _, rule := range f.rules {
+		if id, _ := utils.NestedString(rule, "plan", "id"); id == planID {
+			rules = append(rules, cloneInterfaceMap(rule))
+		}
+	}
+	return rules, nil
+}
+
+func (f *fakePropagationService) RuleUpdate(_ context.Context, _ string, ruleID string, payload map[string]interface{}) (*http.Response, error) {
+	f.record("RuleUpdate %s", ruleID)
+	if len(f.ruleUpdateStatuses) > 0 {
+		status := f.ruleUpdateStatuses[0]
+		f.ruleUpdateStatuses = f.ruleUpdateStatuses[1:]
+		return fakeResponse(status)
+	}
+	if _, ok := f.rules[ruleID]; !ok {
+		return fakeResponse(http.StatusNotFound)
+	}
+	f.rules[ruleID] = cloneInterfaceMap(payload)
+	f.rules[ruleID]["id"] = ruleID
+	return fakeResponse(http.StatusOK)
+}
+
+func (f *fakePropagationService) RuleDelete(_ context.Context, _ string, ruleID string) (*http.Response, error) {
+	f.record("RuleDelete %s", ruleID)
+	if _, ok := f.rules[ruleID]; !ok {
+		return fakeResponse(http.StatusNotFound)
+	}
+	delete(f.rules, ruleID)
+	delete(f.mappings, ruleID)
+	return fakeResponse(http.StatusNoContent)
+}
+
+func (f *fakePropagationService) MappingList(_ context.Context, _ string, ruleID string) ([]map[string]interface{}, error) {
+	f.record("MappingList %s", ruleID)
+	mappings := make([]map[string]interface{}, 0, len(f.mappings[ruleID]))
+	for _, m := range f.mappings[ruleID] {
+		mappings = append(mappings, cloneInterfaceMap(m))
+	}
+	return mappings, nil
+}
+
+func (f *fakePropagationService) MappingCount(_ context.Context, _ string, ruleID string) (int64, error) {
+	f.record("MappingCount %s", ruleID)
+	return int64(len(f.mappings[ruleID])), nil
+}
+
+func (f *fakePropagationService) MappingCreate(_ context.Context, _ string, ruleID string, payload map[string]interface{}) (*http.Response, error) {
+	id := f.newID("mapping")
+	f.record("MappingCreate %s %s", ruleID, payload["targetAttribute"])
+	mapping := cloneInterfaceMap(payload)
+	mapping["id"] = id
+	f.mappings[ruleID] = append(f.mappings[ruleID], mapping)
+	return fakeResponse(http.StatusCreated)
+}
+
+func (f *fakePropagationService) MappingUpdate(_ context.Context, _ string, mappingID string, payload map[string]interface{}) (*http.Response, error) {
+	f.record("MappingUpdate %s", mappingID)
+	if f.mappingUpdateStatus != 0 {
+		return fakeResponse(f.mappingUpdateStatus)
+	}
+	for _, mappings := range f.mappings {
+		for i, m := range mappings {
+			if m["id"] == mappingID {
+				mappings[i] = cloneInterfaceMap(payload)
+				mappings[i]["id"] = mappingID
+				return fakeResponse(http.StatusOK)
+			}
+		}
+	}
+	return fakeResponse(http.StatusNotFound)
+}
+
+func (f *fakePropagationService) MappingDelete(_ context.Context, _ string, mappingID string) (*http.Response, error) {
+	f.record("MappingDelete %s", mappingID)
+	for ruleID, mappings := range f.mappings {
+		f.mappings[ruleID] = slices.DeleteFunc(mappings, func(m map[string]interface{}) bool { return m["id"] == mappingID })
+	}
+	return fakeResponse(http.StatusNoContent)
+}
+
+// mappingKeys returns the keys of the rule's mappings, with their IDs.
+func (f *fakePropagationService) mappingKeys(ruleID string) []string {
+	var keys []string
+	for _, m := range f.mappings[ruleID] {
+		keys = append(keys, fmt.Sprintf("%s=%s", m["id"], mappingKeyFromAPI(m)))
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func sourceMapping(source string, target string) customtypes.PropagationRuleMappingModel {
+	return customtypes.PropagationRuleMappingModel{
+		SourceAttribute:     types.StringValue(source),
+		TargetAttribute:     types.StringValue(target),
+		Expression:          types.StringNull(),
+		SensitiveExpression: types.StringNull(),
+		Enabled:             types.BoolNull(),
+	}
+}
+
+func TestEnsurePropagationRuleMappings_FakeService(t *testing.T) {
+	t.Parallel()
+
+	existing := func() map[string][]map[string]interface{} {
+		return map[string][]map[string]interface{}{"rule-id": {
+			{"id": "map-name", "sourceAttribute": "username", "targetAttribute": "userName"},
+			{"id": "map-mail", "sourceAttribute": "email", "targetAttribute": "mail"},
+			{"id": "map-phone", "sourceAttribute": "phone", "targetAttribute": "phoneNumber"},
+		}}
+	}
+	desired := []customtypes.PropagationRuleMappingModel{
+		sourceMapping("username", "userName"),
+		sourceMapping("primaryEmail", "mail"),
+		sourceMapping("title", "title"),
+	}
+
+	tests := []struct {
+		name                string
+		mappingUpdateStatus int
+		wantCalls           []string
+		wantMappings        []string
+	}{
+		{
+			name: "updates_in_place",
+			wantCalls: []string{
+				"MappingList rule-id",
+				"MappingDelete map-phone",
+				"MappingUpdate map-mail",
+				"MappingCreate rule-id title",
+			},
+			wantMappings: []string{
+				"map-mail=src:primaryEmail->mail",
+				"map-name=src:username->userName",
+				"mapping-1=src:title->title",
+			},
+		},
+		{
+			name:                "recreates_when_update_is_not_allowed",
+			mappingUpdateStatus: http.StatusMethodNotAllowed,
+			wantCalls: []string{
+				"MappingList rule-id",
+				"MappingDelete map-phone",
+				"MappingUpdate map-mail",
+				"MappingDelete map-mail",
+				"MappingCreate rule-id title",
+				"MappingCreate rule-id mail",
+			},
+			wantMappings: []string{
+				"map-name=src:username->userName",
+				"mapping-1=src:title->title",
+				"mapping-2=src:primaryEmail->mail",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := newFakePropagationService()
+			service.mappings = existing()
+			service.mappingUpdateStatus = tt.mappingUpdateStatus
+
+			if err := ensurePropagationRuleMappings(context.Background(), service, "env-id", "rule-id", nil, desired, true); err != nil {
+				t.Fatalf("ensurePropagationRuleMappings error: %v", err)
+			}
+			if !slices.Equal(service.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", service.calls, tt.wantCalls)
+			}
+			if got := service.mappingKeys("rule-id"); !slices.Equal(got, tt.wantMappings) {
+				t.Errorf("mappings = %v, want %v", got, tt.wantMappings)
+			}
+		})
+	}
+}
+
+func TestConfigureCreatedPropagationRule_FakeService(t *testing.T) {
+	prior := ruleActivationBackoff
+	ruleActivationBackoff = 0
+	t.Cleanup(func() { ruleActivationBackoff = prior })
+
+	service := newFakePropagationService()
+	service.rules["rule-id"] = map[string]interface{}{"id": "rule-id", "active": false}
+	// PingOne answers the first enable with a conflict until it sees the new mappings.
+	service.ruleUpdateStatuses = []int{http.StatusConflict}
+
+	model := customtypes.PropagationRuleModel{
+		EnvironmentId: types.StringValue("env-id"),
+		Name:          types.StringValue("rule"),
+		Active:        types.BoolValue(true),
+		Configuration: types.MapNull(types.StringType),
+		PopulationIds: types.SetNull(types.StringType),
+		Mappings:      []customtypes.PropagationRuleMappingModel{sourceMapping("username", "userName")},
+	}
+	payload := map[string]interface{}{"name": "rule", "active": true}
+
+	diags := configureCreatedPropagationRule(context.Background(), service, "rule-id", payload, &model, true)
+	if diags.HasError() {
+		t.Fatalf("configureCreatedPropagationRule: %v", diags)
+	}
+
+	wantCalls := []string{
+		"MappingList rule-id",
+		"MappingCreate rule-id userName",
+		"RuleUpdate rule-id",
+		"RuleUpdate rule-id",
+	}
+	if !slices.Equal(service.calls, wantCalls) {
+		t.Errorf("calls = %v, want %v", service.calls, wantCalls)
+	}
+	if active, _ := service.rules["rule-id"]["active"].(bool); !active {
+		t.Errorf("rule = %v, want it active", service.rules["rule-id"])
+	}
+}
+
+func TestConfigureCreatedPropagationRule_ActivationFailure(t *testing.T) {
+	t.Parallel()
+
+	service := newFakePropagationService()
+	service.rules["rule-id"] = map[string]interface{}{"id": "rule-id", "active": false}
+	service.ruleUpdateStatuses = []int{http.StatusBadRequest}
+
+	model := customtypes.PropagationRuleModel{
+		EnvironmentId: types.StringValue("env-id"),
+		Name:          types.StringValue("rule"),
+		Active:        types.BoolValue(true),
+		Configuration: types.MapNull(types.StringType),
+		PopulationIds: types.SetNull(types.StringType),
+		Mappings:      []customtypes.PropagationRuleMappingModel{sourceMapping("username", "userName")},
+	}
+	payload := map[string]interface{}{"name": "rule", "active": true}
+
+	diags := configureCreatedPropagationRule(context.Background(), service, "rule-id", payload, &model, true)
+	errs := diags.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "Error Activating Propagation Rule" {
+		t.Fatalf("diags = %v, want one activation error", diags)
+	}
+	if detail := errs[0].Detail(); !strings.Contains(detail, "Activating propagation rule 'rule' (rule-id) failed") || !strings.Contains(detail, "Creating the rule did not fail") {
+		t.Errorf("detail = %q, want it to say activation failed and creation did not", detail)
+	}
+	if active, _ := service.rules["rule-id"]["active"].(bool); active {
+		t.Errorf("rule = %v, want it inactive", service.rules["rule-id"])
+	}
+}
+
+func TestDeletePropagationRuleWithMappings_FakeService(t *testing.T) {
+	t.Parallel()
+
+	service := newFakePropagationService()
+	service.rules["rule-id"] = map[string]interface{}{"id": "rule-id"}
+	service.mappings["rule-id"] = []map[string]interface{}{
+		{"id": "map-name", "sourceAttribute": "username", "targetAttribute": "userName"},
+		{"id": "map-mail", "sourceAttribute": "email", "targetAttribute": "mail"},
+	}
+
+	if _, err := deletePropagationRuleWithMappings(context.Background(), service, "env-id", "rule-id"); err != nil {
+		t.Fatalf("deletePropagationRuleWithMappings error: %v", err)
+	}
+
+	wantCalls := []string{
+		"MappingList rule-id",
+		"MappingDelete map-name",
+		"MappingDelete map-mail",
+		"RuleDelete rule-id",
+	}
+	if !slices.Equal(service.calls, wantCalls) {
+		t.Errorf("calls = %v, want %v", service.calls, wantCalls)
+	}
+	if len(service.rules) != 0 {
+		t.Errorf("rules = %v, want none", service.rules)
+	}
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// attributeGetter is satisfied by tfsdk.Config, tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target interface{}) diag.Diagnostics
+}
+
+// propagationStoreCredentialsNull returns an unset credentials block.
+func propagationStoreCredentialsNull(ctx context.Context) types.Object {
+	var resp resource.SchemaResponse
+	(&propagationStoreResource{}).Schema(ctx, resource.SchemaRequest{}, &resp)
+	return types.ObjectNull(resp.Schema.Blocks["credentials"].Type().(types.ObjectType).AttrTypes)
+}
+
+// propagationStoreCredentials returns the attributes set in a store's credentials block, by name.
+// Values that are not known yet are included.
+func propagationStoreCredentials(credentials types.Object) map[string]types.String {
+	set := make(map[string]types.String)
+	if credentials.IsNull() || credentials.IsUnknown() {
+		return set
+	}
+	for name, value := range credentials.Attributes() {
+		if s, ok := value.(types.String); ok && !s.IsNull() {
+			set[name] = s
+		}
+	}
+	return set
+}
+
+// applyPropagationStoreCredentials sets the credentials in the configuration map sent to PingOne,
+// under the key of the configuration attribute each one stands in for.
+func applyPropagationStoreCredentials(configMap map[string]interface{}, credentials types.Object) {
+	for name, value := range propagationStoreCredentials(credentials) {
+		if !value.IsUnknown() {
+			configMap[strings.ToUpper(name)] = value.ValueString()
+		}
+	}
+}
+
+// propagationStoreConfigurationBlock returns the name of the configuration block set in data, or
+// "" when none is set yet.
+func propagationStoreConfigurationBlock(ctx context.Context, data attributeGetter) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	for _, name := range propagationStoreConfigurationBlocks {
+		var block types.Object
+		diags.Append(data.GetAttribute(ctx, path.Root(name), &block)...)
+		if diags.HasError() {
+			return "", diags
+		}
+		if !block.IsNull() && !block.IsUnknown() {
+			return name, diags
+		}
+	}
+	return "", diags
+}
+
+// validatePropagationStoreCredentials checks that each attribute of credentials is a sensitive
+// attribute of the configuration block in plan, and that the block does not also set it or a
+// secret reference for it.
+func validatePropagationStoreCredentials(ctx context.Context, plan tfsdk.Plan, credentials types.Object) diag.Diagnostics {
+	set := propagationStoreCredentials(credentials)
+	if len(set) == 0 {
+		return nil
+	}
+	block, diags := propagationStoreConfigurationBlock(ctx, plan)
+	if diags.HasError() || block == "" {
+		return diags
+	}
+
+	attributes := plan.Schema.GetBlocks()[block].GetNestedObject().GetAttributes()
+	for _, name := range slices.Sorted(maps.Keys(set)) {
+		credentialPath := path.Root("credentials").AtName(name)
+		if attribute, ok := attributes[name]; !ok || !attribute.IsSensitive() {
+			diags.AddAttributeError(
+				credentialPath,
+				"Credential Not Used By Store Type",
+				fmt.Sprintf("%s has no %s attribute, so credentials.%s would not be sent to PingOne. Remove it from credentials.", block, name, name),
+			)
+			continue
+		}
+
+		for _, conflicting := range []string{name, name + "_ref"} {
+			if _, ok := attributes[conflicting]; !ok {
+				continue
+			}
+			var value types.String
+			diags.Append(plan.GetAttribute(ctx, path.Root(block).AtName(conflicting), &value)...)
+			if diags.HasError() {
+				return diags
+			}
+			if !value.IsNull() {
+				diags.AddAttributeError(
+					credentialPath,
+					"Conflicting Credential",
+					fmt.Sprintf("credentials.%s conflicts with %s.%s. Set the credential in only one of them.", name, block, conflicting),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+// clearPropagationStoreCredentials nulls the attributes of the configuration block in state that
+// credentials supplies, so that the values PingOne returns for them are neither recorded twice nor
+// reported as changes to the block.
+func clearPropagationStoreCredentials(ctx context.Context, state *tfsdk.State, credentials types.Object) diag.Diagnostics {
+	set := propagationStoreCredentials(credentials)
+	if len(set) == 0 {
+		return nil
+	}
+	block, diags := propagationStoreConfigurationBlock(ctx, state)
+	if diags.HasError() || block == "" {
+		return diags
+	}
+
+	attributes := state.Schema.GetBlocks()[block].GetNestedObject().GetAttributes()
+	for name := range set {
+		if _, ok := attributes[name]; ok {
+			diags.Append(state.SetAttribute(ctx, path.Root(block).AtName(name), types.StringNull())...)
+		}
+	}
+	return diags
+}
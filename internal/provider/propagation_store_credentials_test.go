@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testPropagationStoreCredentials returns a credentials block with values set.
+func testPropagationStoreCredentials(ctx context.Context, values map[string]string) types.Object {
+	attrTypes := propagationStoreCredentialsNull(ctx).AttributeTypes(ctx)
+	attrs := make(map[string]attr.Value, len(attrTypes))
+	for name := range attrTypes {
+		attrs[name] = types.StringNull()
+	}
+	for name, value := range values {
+		attrs[name] = types.StringValue(value)
+	}
+	return types.ObjectValueMust(attrTypes, attrs)
+}
+
+// testPropagationStoreState returns a Slack store's state with credentials.
+func testPropagationStoreState(t *testing.T, ctx context.Context, slack *customtypes.ConfigurationSlack, credentials types.Object) tfsdk.State {
+	t.Helper()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&propagationStoreResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &customtypes.PropagationStoreResourceModel{
+		PropagationStoreModel: customtypes.PropagationStoreModel{
+			EnvironmentId:      types.StringValue("env-id"),
+			Name:               types.StringValue("Slack"),
+			Type:               types.StringValue("Slack"),
+			SyncStatus:         types.ObjectNull(customtypes.SyncStatusAttrTypes),
+			Links:              types.MapNull(types.StringType),
+			ConfigurationSlack: slack,
+		},
+		Credentials: credentials,
+	}); diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+	return state
+}
+
+func TestApplyPropagationStoreCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	configMap := map[string]interface{}{"BEARER_TOKEN": "", "SCIM_URL": "https://slack.example/scim"}
+	applyPropagationStoreCredentials(configMap, testPropagationStoreCredentials(ctx, map[string]string{"bearer_token": "xoxb-1"}))
+
+	if configMap["BEARER_TOKEN"] != "xoxb-1" || configMap["SCIM_URL"] != "https://slack.example/scim" {
+		t.Fatalf("configMap = %v", configMap)
+	}
+	if _, ok := configMap["PASSWORD"]; ok {
+		t.Fatalf("configMap = %v, want unset credentials left out", configMap)
+	}
+
+	applyPropagationStoreCredentials(configMap, propagationStoreCredentialsNull(ctx))
+	if configMap["BEARER_TOKEN"] != "xoxb-1" {
+		t.Fatalf("BEARER_TOKEN = %v after applying no credentials", configMap["BEARER_TOKEN"])
+	}
+}
+
+func TestValidatePropagationStoreCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		slack       *customtypes.ConfigurationSlack
+		credentials map[string]string
+		wantError   bool
+	}{
+		{
+			name:        "credential_of_the_block",
+			slack:       &customtypes.ConfigurationSlack{BearerToken: types.StringNull(), BearerTokenRef: types.StringNull()},
+			credentials: map[string]string{"bearer_token": "xoxb-1"},
+		},
+		{
+			name:        "credential_the_block_does_not_have",
+			slack:       &customtypes.ConfigurationSlack{BearerToken: types.StringNull(), BearerTokenRef: types.StringNull()},
+			credentials: map[string]string{"password": "secret"},
+			wantError:   true,
+		},
+		{
+			name:        "also_set_in_the_block",
+			slack:       &customtypes.ConfigurationSlack{BearerToken: types.StringValue("xoxb-0"), BearerTokenRef: types.StringNull()},
+			credentials: map[string]string{"bearer_token": "xoxb-1"},
+			wantError:   true,
+		},
+		{
+			name:        "also_referenced_in_the_block",
+			slack:       &customtypes.ConfigurationSlack{BearerToken: types.StringNull(), BearerTokenRef: types.StringValue("slack_token")},
+			credentials: map[string]string{"bearer_token": "xoxb-1"},
+			wantError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			credentials := testPropagationStoreCredentials(ctx, tt.credentials)
+			state := testPropagationStoreState(t, ctx, tt.slack, credentials)
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+			diags := validatePropagationStoreCredentials(ctx, plan, credentials)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("validatePropagationStoreCredentials = %v, want error %v", diags, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestClearPropagationStoreCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	credentials := testPropagationStoreCredentials(ctx, map[string]string{"bearer_token": "xoxb-1"})
+	state := testPropagationStoreState(t, ctx, &customtypes.ConfigurationSlack{
+		ScimUrl:     types.StringValue("https://slack.example/scim"),
+		BearerToken: types.StringValue("xoxb-1"),
+	}, credentials)
+
+	if diags := clearPropagationStoreCredentials(ctx, &state, credentials); diags.HasError() {
+		t.Fatalf("clearPropagationStoreCredentials: %v", diags)
+	}
+
+	var token, scimURL types.String
+	state.GetAttribute(ctx, path.Root("configuration_slack").AtName("bearer_token"), &token)
+	state.GetAttribute(ctx, path.Root("configuration_slack").AtName("scim_url"), &scimURL)
+	if !token.IsNull() {
+		t.Fatalf("bearer_token = %v, want null", token)
+	}
+	if scimURL.ValueString() != "https://slack.example/scim" {
+		t.Fatalf("scim_url = %v, want it kept", scimURL)
+	}
+}
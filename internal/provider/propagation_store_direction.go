@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// planStoreType reads the API type of a store referenced by a planned rule, such as `scim` or
+// `directory`. It returns "" when the store is not known yet or cannot be read, so that missing
+// read permissions never block a plan. Types that were read are cached on the client per store.
+func planStoreType(ctx context.Context, clientData *client.Client, environmentID types.String, storeID types.String) string {
+	if clientData == nil || clientData.API == nil {
+		return ""
+	}
+	if environmentID.IsNull() || environmentID.IsUnknown() || storeID.IsNull() || storeID.IsUnknown() {
+		return ""
+	}
+
+	cacheKey := environmentID.ValueString() + "/" + storeID.ValueString()
+	if storeType, ok := clientData.StoreTypes.Get(cacheKey); ok {
+		return storeType
+	}
+
+	_, storeResp, err := clientData.API.PropagationStoresApi.ReadOnePropagationStore(ctx, environmentID.ValueString(), storeID.ValueString()).Execute()
+	if err != nil && (storeResp == nil || storeResp.StatusCode >= 300) {
+		tflog.Debug(ctx, "Skipping store checks: could not read store", map[string]interface{}{
+			"store_id": storeID.ValueString(),
+			"error":    err.Error(),
+		})
+		return ""
+	}
+
+	storeType, _, err := utils.ExtractPropagationStoreTypeStatus(storeResp)
+	if err != nil {
+		return ""
+	}
+	clientData.StoreTypes.Set(cacheKey, storeType)
+	return storeType
+}
+
+// validateRuleStoreDirections rejects a rule whose source store can only be a target, such as
+// Slack, or whose target store can only be a source, such as Workday. An empty store type is
+// not checked.
+func validateRuleStoreDirections(sourceStoreType string, targetPath path.Path, targetStoreType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if sourceStoreType != "" && !utils.PropagationStoreCanBeSource(sourceStoreType) {
+		diags.AddAttributeError(
+			path.Root("source_store_id"),
+			"Incompatible Source Store",
+			fmt.Sprintf("The source store is a `%s` store, whose `provisioning_direction` is `%s`; PingOne only provisions to it, so it cannot be a rule's source.",
+				utils.CanonicalPropagationStoreType(sourceStoreType), utils.PropagationStoreProvisioningDirection(sourceStoreType)),
+		)
+	}
+
+	if targetStoreType != "" && !utils.PropagationStoreCanBeTarget(targetStoreType) {
+		diags.AddAttributeError(
+			targetPath,
+			"Incompatible Target Store",
+			fmt.Sprintf("The target store is a `%s` store, whose `provisioning_direction` is `%s`; it only provisions into PingOne, so it cannot be a rule's target.",
+				utils.CanonicalPropagationStoreType(targetStoreType), utils.PropagationStoreProvisioningDirection(targetStoreType)),
+		)
+	}
+
+	return diags
+}
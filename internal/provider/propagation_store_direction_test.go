@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateRuleStoreDirections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		source     string
+		target     string
+		wantErrors int
+	}{
+		{name: "directory_to_scim", source: "directory", target: "scim"},
+		{name: "workday_to_directory", source: "Workday", target: "directory"},
+		{name: "ldap_gateway_both_ways", source: "LdapGateway", target: "LdapGateway"},
+		{name: "unknown_stores", source: "", target: ""},
+		{name: "outbound_source", source: "Slack", target: "directory", wantErrors: 1},
+		{name: "inbound_target", source: "directory", target: "Workday", wantErrors: 1},
+		{name: "reversed", source: "scim", target: "Workday", wantErrors: 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateRuleStoreDirections(tt.source, path.Root("target_store_id"), tt.target)
+			if diags.ErrorsCount() != tt.wantErrors {
+				t.Fatalf("ErrorsCount() = %d, want %d (diags: %v)", diags.ErrorsCount(), tt.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestPlanStoreType(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		calls++
+
+		status, body := http.StatusOK, ``
+		switch r.URL.Path {
+		case "/v1/environments/env-id/propagation/stores/workday-id":
+			body = `{"id":"workday-id","name":"Workday","type":"Workday","configuration":{}}`
+		default:
+			status, body = http.StatusForbidden, `{"code":"ACCESS_FAILED","message":"forbidden"}`
+		}
+
+		return testResponse(r, status, body), nil
+	})
+
+	clientData := &client.Client{API: apiClient, StoreTypes: client.NewStoreTypeCache()}
+	environmentID := types.StringValue("env-id")
+
+	for range 2 {
+		if got := planStoreType(context.Background(), clientData, environmentID, types.StringValue("workday-id")); got != "Workday" {
+			t.Fatalf("planStoreType = %q, want Workday", got)
+		}
+	}
+	if got := planStoreType(context.Background(), clientData, environmentID, types.StringValue("hidden-id")); got != "" {
+		t.Fatalf("planStoreType for an unreadable store = %q, want empty", got)
+	}
+	if got := planStoreType(context.Background(), clientData, environmentID, types.StringUnknown()); got != "" {
+		t.Fatalf("planStoreType for an unknown store = %q, want empty", got)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestPropagationRuleSetValidateStoreDirections_ReadsNewStores(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var reads []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		storeID := strings.TrimPrefix(r.URL.Path, "/v1/environments/env-id/propagation/stores/")
+		reads = append(reads, storeID)
+		storeType := "scim"
+		switch storeID {
+		case "source-id":
+			storeType = "directory"
+		case "workday-id":
+			storeType = "Workday"
+		}
+		body := `{"id":"` + storeID + `","name":"` + storeID + `","type":"` + storeType + `","configuration":{}}`
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	r := &propagationRuleSetResource{client: &client.Client{API: apiClient, StoreTypes: client.NewStoreTypeCache()}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	ruleSet := func(source string, targets ...string) tftypes.Value {
+		attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+		for name, attrType := range objType.AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+		targetValues := make([]tftypes.Value, 0, len(targets))
+		for _, target := range targets {
+			targetValues = append(targetValues, tftypes.NewValue(tftypes.String, target))
+		}
+		attrs["environment_id"] = tftypes.NewValue(tftypes.String, "env-id")
+		attrs["source_store_id"] = tftypes.NewValue(tftypes.String, source)
+		attrs["target_store_ids"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, targetValues)
+		return tftypes.NewValue(objType, attrs)
+	}
+	validate := func(prior tftypes.Value, planned tftypes.Value) []string {
+		reads = nil
+		diags := r.validateStoreDirections(ctx, resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: prior},
+			Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned},
+		})
+		if diags.HasError() {
+			t.Fatalf("validateStoreDirections: %v", diags)
+		}
+		slices.Sort(reads)
+		return reads
+	}
+
+	if got := validate(tftypes.NewValue(objType, nil), ruleSet("source-id", "scim-1")); !slices.Equal(got, []string{"scim-1", "source-id"}) {
+		t.Fatalf("reads on create = %v, want both stores", got)
+	}
+	if got := validate(ruleSet("source-id", "scim-1"), ruleSet("source-id", "scim-1")); len(got) != 0 {
+		t.Fatalf("reads without changes = %v, want none", got)
+	}
+	if got := validate(ruleSet("source-id", "scim-1"), ruleSet("source-id", "scim-1", "scim-2")); !slices.Equal(got, []string{"scim-2"}) {
+		t.Fatalf("reads for an added target = %v, want only the new target", got)
+	}
+
+	diags := r.validateStoreDirections(ctx, resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: ruleSet("source-id", "scim-1")},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: ruleSet("source-id", "scim-1", "workday-id")},
+	})
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("diags = %v, want the Workday target rejected", diags)
+	}
+}
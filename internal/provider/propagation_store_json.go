@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/mappers"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// propagationStoreFromJSON maps a decoded propagation store to the Terraform model.
+//
+// The raw JSON is used rather than the SDK model: the SDK turns a type, status or sync state
+// it does not know into "UNKNOWN", so a connector added to PingOne after the SDK release would
+// be read back as a different store. preferredType is the type in configuration or state, used
+// when the response has none; prior is passed on to the configuration mapper.
+func propagationStoreFromJSON(storeObj map[string]interface{}, environmentID string, preferredType string, prior *customtypes.PropagationStoreModel) customtypes.PropagationStoreModel {
+	id, _ := utils.NestedString(storeObj, "id")
+	name, _ := utils.NestedString(storeObj, "name")
+
+	apiType, _ := utils.NestedString(storeObj, "type")
+	if apiType == "" {
+		apiType = preferredType
+	}
+	tfType := utils.NormalizePropagationStoreTypeForTerraform(apiType, preferredType)
+
+	model := customtypes.PropagationStoreModel{
+		Id:                    types.StringValue(id),
+		EnvironmentId:         types.StringValue(environmentID),
+		Name:                  types.StringValue(name),
+		Type:                  types.StringValue(tfType),
+		ProvisioningDirection: types.StringValue(utils.PropagationStoreProvisioningDirection(tfType)),
+		Description:           types.StringNull(),
+		ImageId:               types.StringNull(),
+		ImageHref:             types.StringNull(),
+		Managed:               types.BoolNull(),
+		Status:                types.StringNull(),
+		SyncStatus:            propagationStoreSyncStatus(storeObj),
+		Links:                 linksFromJSON(storeObj),
+	}
+
+	if v, ok := utils.NestedString(storeObj, "description"); ok {
+		model.Description = types.StringValue(v)
+	}
+	if v, ok := utils.NestedString(storeObj, "image", "id"); ok {
+		model.ImageId = types.StringValue(v)
+	}
+	if v, ok := utils.NestedString(storeObj, "image", "href"); ok {
+		model.ImageHref = types.StringValue(v)
+	}
+	if v, ok := utils.NestedBool(storeObj, "managed"); ok {
+		model.Managed = types.BoolValue(v)
+	}
+	if v, _ := utils.NestedString(storeObj, "status"); v != "" {
+		model.Status = types.StringValue(v)
+	}
+
+	config, _ := storeObj["configuration"].(map[string]interface{})
+	mappers.ApplyPropagationStoreConfigurationFromMap(&model, tfType, config, prior)
+
+	return model
+}
+
+// propagationStoreSyncStatus maps the store's sync status. PingOne reports the details of the
+// last sync, which are also recorded as `last_error` when that sync failed.
+func propagationStoreSyncStatus(storeObj map[string]interface{}) types.Object {
+	syncStatus, ok := storeObj["syncStatus"].(map[string]interface{})
+	if !ok {
+		return types.ObjectNull(customtypes.SyncStatusAttrTypes)
+	}
+
+	attrs := map[string]attr.Value{
+		"last_sync_time": types.StringNull(),
+		"status":         types.StringNull(),
+		"details":        types.StringNull(),
+		"last_error":     types.StringNull(),
+	}
+	if v, ok := utils.NestedString(syncStatus, "lastSyncAt"); ok && v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			v = t.Format(time.RFC3339)
+		}
+		attrs["last_sync_time"] = types.StringValue(v)
+	}
+	state, _ := utils.NestedString(syncStatus, "syncState")
+	if state != "" {
+		attrs["status"] = types.StringValue(state)
+	}
+	if v, ok := utils.NestedString(syncStatus, "details"); ok {
+		attrs["details"] = types.StringValue(v)
+		if state == "FAILED" && v != "" {
+			attrs["last_error"] = types.StringValue(v)
+		}
+	}
+
+	return types.ObjectValueMust(customtypes.SyncStatusAttrTypes, attrs)
+}
+
+// ignoreStoreDecodeError drops the error the SDK returns for a successful response it could
+// not decode. The store is mapped from the raw JSON, so a field the SDK does not understand
+// must not fail the operation.
+func ignoreStoreDecodeError(httpResp *http.Response, err error) error {
+	if err != nil && httpResp != nil && httpResp.StatusCode >= 200 && httpResp.StatusCode < 300 {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/mappers"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// portablePropagationStoreVersion is the version of the portable store configuration format.
+// Bump it when a change would make older exports mean something different.
+const portablePropagationStoreVersion = 1
+
+// portablePropagationStore is the environment-independent configuration of a propagation store,
+// exported by the propagation store data source and applied by the propagation store mirror
+// resource. Configuration is keyed by PingOne configuration key, for example `SCIM_URL`.
+// RedactedKeys lists the configuration keys whose values were replaced by a placeholder and must
+// be supplied again when the configuration is applied.
+type portablePropagationStore struct {
+	Version       int                    `json:"version"`
+	Type          string                 `json:"type"`
+	Name          string                 `json:"name"`
+	Description   *string                `json:"description,omitempty"`
+	Managed       *bool                  `json:"managed,omitempty"`
+	ImageId       *string                `json:"image_id,omitempty"`
+	Configuration map[string]interface{} `json:"configuration"`
+	RedactedKeys  []string               `json:"redacted_keys,omitempty"`
+}
+
+// exportPortablePropagationStore builds the portable configuration of exported. original is the
+// same store before secrets were redacted, or nil when nothing was redacted; configuration keys
+// whose values differ between the two are recorded as redacted.
+func exportPortablePropagationStore(exported *customtypes.PropagationStoreModel, original *customtypes.PropagationStoreModel) (string, error) {
+	config, err := mappers.ModelToConfigurationMap(exported)
+	if err != nil {
+		return "", err
+	}
+
+	portable := portablePropagationStore{
+		Version:       portablePropagationStoreVersion,
+		Type:          utils.CanonicalPropagationStoreType(exported.Type.ValueString()),
+		Name:          exported.Name.ValueString(),
+		Configuration: config,
+	}
+	if !exported.Description.IsNull() && !exported.Description.IsUnknown() {
+		portable.Description = exported.Description.ValueStringPointer()
+	}
+	if !exported.Managed.IsNull() && !exported.Managed.IsUnknown() {
+		portable.Managed = exported.Managed.ValueBoolPointer()
+	}
+	if !exported.ImageId.IsNull() && !exported.ImageId.IsUnknown() {
+		portable.ImageId = exported.ImageId.ValueStringPointer()
+	}
+
+	if original != nil {
+		originalConfig, err := mappers.ModelToConfigurationMap(original)
+		if err != nil {
+			return "", err
+		}
+		for key, value := range config {
+			if !sameJSONValue(value, originalConfig[key]) {
+				portable.RedactedKeys = append(portable.RedactedKeys, key)
+			}
+		}
+		sort.Strings(portable.RedactedKeys)
+	}
+
+	raw, err := json.Marshal(portable)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// parsePortablePropagationStore decodes a portable store configuration and checks that it can
+// be applied.
+func parsePortablePropagationStore(raw string) (*portablePropagationStore, error) {
+	var portable portablePropagationStore
+	if err := json.Unmarshal([]byte(raw), &portable); err != nil {
+		return nil, fmt.Errorf("not valid JSON: %w", err)
+	}
+
+	if portable.Version != portablePropagationStoreVersion {
+		return nil, fmt.Errorf("unsupported version %d; this provider reads version %d", portable.Version, portablePropagationStoreVersion)
+	}
+	if !slices.Contains(utils.PropagationStoreTerraformTypes, utils.CanonicalPropagationStoreType(portable.Type)) {
+		return nil, fmt.Errorf("unsupported store type %q", portable.Type)
+	}
+	if portable.Name == "" {
+		return nil, fmt.Errorf("name is missing")
+	}
+	if portable.Configuration == nil {
+		return nil, fmt.Errorf("configuration is missing")
+	}
+
+	return &portable, nil
+}
+
+// missingSecrets returns the redacted configuration keys that secrets does not supply.
+func (p *portablePropagationStore) missingSecrets(secrets map[string]string) []string {
+	var missing []string
+	for _, key := range p.RedactedKeys {
+		if _, ok := secrets[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// payload builds the store to send to PingOne, named name, with secrets written over the
+// configuration.
+func (p *portablePropagationStore) payload(name string, secrets map[string]string) (*management.PropagationStore, error) {
+	if missing := p.missingSecrets(secrets); len(missing) > 0 {
+		return nil, fmt.Errorf("the portable configuration has redacted values for %s; set them in secrets", strings.Join(missing, ", "))
+	}
+
+	config := make(map[string]interface{}, len(p.Configuration)+len(secrets))
+	for key, value := range p.Configuration {
+		config[key] = value
+	}
+	for key, value := range secrets {
+		config[key] = value
+	}
+
+	payload := management.NewPropagationStore(
+		config,
+		name,
+		management.EnumPropagationStoreType(utils.NormalizePropagationStoreTypeForAPI(p.Type)),
+	)
+	if p.Description != nil {
+		payload.SetDescription(*p.Description)
+	}
+	if p.Managed != nil {
+		payload.SetManaged(*p.Managed)
+	}
+	if p.ImageId != nil && *p.ImageId != "" {
+		image := management.NewPropagationStoreImage()
+		image.SetId(*p.ImageId)
+		payload.SetImage(*image)
+	}
+
+	return payload, nil
+}
+
+// refreshFrom updates p with the values of store that differ, and reports whether anything
+// changed. Secret and redacted keys, and keys PingOne does not return, keep their values, since
+// PingOne does not return secrets as they were set. The name is only compared when
+// compareName is set.
+func (p *portablePropagationStore) refreshFrom(store *management.PropagationStore, secrets map[string]string, compareName bool) bool {
+	changed := false
+
+	if compareName && store.GetName() != p.Name {
+		p.Name = store.GetName()
+		changed = true
+	}
+
+	if description := store.GetDescription(); description != stringValue(p.Description) {
+		p.Description = nil
+		if description != "" {
+			p.Description = &description
+		}
+		changed = true
+	}
+
+	if managed, ok := store.GetManagedOk(); ok && p.Managed != nil && *p.Managed != *managed {
+		p.Managed = managed
+		changed = true
+	}
+
+	config := store.GetConfiguration()
+	for key, value := range p.Configuration {
+		if _, ok := secrets[key]; ok || slices.Contains(p.RedactedKeys, key) {
+			continue
+		}
+		current, ok := config[key]
+		if !ok || sameJSONValue(value, current) {
+			continue
+		}
+		p.Configuration[key] = current
+		changed = true
+	}
+
+	return changed
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// sameJSONValue reports whether a and b encode to the same JSON, so that numbers decoded as
+// float64 compare equal to the same numbers held as integers.
+func sameJSONValue(a, b interface{}) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(rawA) == string(rawB)
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+func TestPortablePropagationStoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	(&propagationStoreDataSource{}).Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	model := customtypes.PropagationStoreDataSourceModel{
+		PropagationStoreModel: customtypes.PropagationStoreModel{
+			Id:          types.StringValue("store-1"),
+			Name:        types.StringValue("Corporate SCIM"),
+			Type:        types.StringValue("scim"),
+			Description: types.StringValue("Primary region"),
+			SyncStatus:  types.ObjectNull(customtypes.SyncStatusAttrTypes),
+			Links:       types.MapNull(types.StringType),
+			ConfigurationScim: &customtypes.ConfigurationScim{
+				ScimUrl:           types.StringValue("https://scim.example"),
+				OauthClientId:     types.StringValue("client-id"),
+				OauthClientSecret: types.StringValue("s3cret"),
+				CreateUsers:       types.BoolValue(true),
+			},
+		},
+		RedactSecrets:      types.BoolValue(true),
+		SecretsPlaceholder: types.StringValue("REDACTED"),
+		ConfigurationRaw:   types.MapNull(types.StringType),
+	}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+	if diags := redactPropagationStoreSecrets(ctx, &state, "REDACTED"); diags.HasError() {
+		t.Fatalf("redactPropagationStoreSecrets: %v", diags)
+	}
+	if diags := setPortablePropagationStore(ctx, &state, &model.PropagationStoreModel); diags.HasError() {
+		t.Fatalf("setPortablePropagationStore: %v", diags)
+	}
+
+	var got customtypes.PropagationStoreDataSourceModel
+	if diags := state.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+
+	portable, err := parsePortablePropagationStore(got.PortableConfiguration.ValueString())
+	if err != nil {
+		t.Fatalf("parsePortablePropagationStore: %v", err)
+	}
+	if portable.Type != "SCIM" || portable.Name != "Corporate SCIM" {
+		t.Fatalf("type, name = %q, %q", portable.Type, portable.Name)
+	}
+	if !reflect.DeepEqual(portable.RedactedKeys, []string{"OAUTH_CLIENT_ID", "OAUTH_CLIENT_SECRET"}) {
+		t.Fatalf("redacted_keys = %v", portable.RedactedKeys)
+	}
+
+	if _, err := portable.payload("Corporate SCIM (DR)", nil); err == nil {
+		t.Fatal("expected an error for the missing secret")
+	}
+
+	payload, err := portable.payload("Corporate SCIM (DR)", map[string]string{
+		"OAUTH_CLIENT_ID":     "dr-client-id",
+		"OAUTH_CLIENT_SECRET": "dr-s3cret",
+	})
+	if err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	config := payload.GetConfiguration()
+	if payload.GetName() != "Corporate SCIM (DR)" || payload.GetType() != management.ENUMPROPAGATIONSTORETYPE_SCIM {
+		t.Fatalf("name, type = %q, %q", payload.GetName(), payload.GetType())
+	}
+	if config["OAUTH_CLIENT_SECRET"] != "dr-s3cret" || config["SCIM_URL"] != "https://scim.example" || config["CREATE_USERS"] != true {
+		t.Fatalf("configuration = %v", config)
+	}
+	if payload.GetDescription() != "Primary region" {
+		t.Fatalf("description = %q", payload.GetDescription())
+	}
+}
+
+func TestPortablePropagationStoreRefreshFrom(t *testing.T) {
+	t.Parallel()
+
+	description := "Primary region"
+	portable := &portablePropagationStore{
+		Version:     portablePropagationStoreVersion,
+		Type:        "SCIM",
+		Name:        "Corporate SCIM",
+		Description: &description,
+		Configuration: map[string]interface{}{
+			"SCIM_URL":            "https://scim.example",
+			"OAUTH_CLIENT_SECRET": "REDACTED",
+			"BASIC_AUTH_PASSWORD": "",
+			"PAGE_SIZE":           int64(100),
+		},
+		RedactedKeys: []string{"OAUTH_CLIENT_SECRET"},
+	}
+
+	store := management.NewPropagationStore(map[string]interface{}{
+		"SCIM_URL":            "https://scim.example",
+		"OAUTH_CLIENT_SECRET": "********",
+		"PAGE_SIZE":           float64(100),
+	}, "Corporate SCIM", management.ENUMPROPAGATIONSTORETYPE_SCIM)
+	store.SetDescription("Primary region")
+
+	if portable.refreshFrom(store, nil, true) {
+		t.Fatalf("unchanged store reported as changed: %+v", portable)
+	}
+
+	store.GetConfiguration()["SCIM_URL"] = "https://other.example"
+	store.SetName("Renamed")
+	if !portable.refreshFrom(store, nil, false) {
+		t.Fatal("changed store not reported")
+	}
+	if portable.Configuration["SCIM_URL"] != "https://other.example" {
+		t.Fatalf("SCIM_URL = %v", portable.Configuration["SCIM_URL"])
+	}
+	if portable.Name != "Corporate SCIM" {
+		t.Fatalf("name = %q, want it unchanged when not compared", portable.Name)
+	}
+	if portable.Configuration["OAUTH_CLIENT_SECRET"] != "REDACTED" {
+		t.Fatalf("redacted value was overwritten: %v", portable.Configuration["OAUTH_CLIENT_SECRET"])
+	}
+}
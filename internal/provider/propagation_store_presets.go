@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// propagationStorePreset is a set of vendor-recommended provisioning settings for one store type.
+type propagationStorePreset struct {
+	storeTypes []string
+	block      string
+	values     map[string]attr.Value
+}
+
+// standardProvisioningSettings creates and updates users, disables users removed from scope
+// instead of deleting them, and names groups after their common name.
+func standardProvisioningSettings() map[string]attr.Value {
+	return map[string]attr.Value{
+		"create_users":      types.BoolValue(true),
+		"update_users":      types.BoolValue(true),
+		"remove_action":     types.StringValue("Disable"),
+		"group_name_source": types.StringValue("Common Name"),
+	}
+}
+
+var propagationStorePresets = map[string]propagationStorePreset{
+	"github-emu-standard": {
+		storeTypes: []string{"GithubEMU", "GitHubEMU"},
+		block:      "configuration_github_emu",
+		values:     standardProvisioningSettings(),
+	},
+	"google-apps-standard": {
+		storeTypes: []string{"GoogleApps"},
+		block:      "configuration_google_apps",
+		values:     standardProvisioningSettings(),
+	},
+	"salesforce-standard": {
+		storeTypes: []string{"Salesforce"},
+		block:      "configuration_salesforce",
+		values:     standardProvisioningSettings(),
+	},
+	"servicenow-standard": {
+		storeTypes: []string{"ServiceNow"},
+		block:      "configuration_service_now",
+		values:     standardProvisioningSettings(),
+	},
+	"slack-standard": {
+		storeTypes: []string{"Slack"},
+		block:      "configuration_slack",
+		values:     standardProvisioningSettings(),
+	},
+}
+
+// propagationStorePresetNames returns the names of the presets in order.
+func propagationStorePresetNames() []string {
+	names := make([]string, 0, len(propagationStorePresets))
+	for name := range propagationStorePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// propagationStoreConfigurationBlocks lists the resource's configuration blocks.
+var propagationStoreConfigurationBlocks = []string{
+	"configuration_aquera", "configuration_azure_ad_saml_v2", "configuration_github_emu",
+	"configuration_google_apps", "configuration_ldap_gateway", "configuration_ping_one",
+	"configuration_salesforce", "configuration_salesforce_contacts", "configuration_scim",
+	"scim_configuration", "configuration_service_now", "configuration_slack",
+	"configuration_workday", "configuration_zoom",
+}
+
+// presetStringSettings are the preset settings without a schema default. When neither the
+// configuration nor a preset sets them, they are planned as null.
+var presetStringSettings = []string{"remove_action", "group_name_source"}
+
+// planPropagationStorePreset fills the settings of the store's `preset` that the configuration
+// leaves unset into the plan, and plans the remaining unset preset settings as null.
+func planPropagationStorePreset(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var presetName, storeType types.String
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("preset"), &presetName)...)
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &storeType)...)
+	if diags.HasError() || presetName.IsUnknown() {
+		return diags
+	}
+
+	var preset *propagationStorePreset
+	if !presetName.IsNull() {
+		p, ok := propagationStorePresets[presetName.ValueString()]
+		if !ok {
+			// The schema validator reports unknown presets.
+			return diags
+		}
+		if !storeType.IsUnknown() && !slices.Contains(p.storeTypes, storeType.ValueString()) {
+			diags.AddAttributeError(
+				path.Root("preset"),
+				"Preset Does Not Match Store Type",
+				fmt.Sprintf("The %q preset applies to stores of type %s, not %q.", presetName.ValueString(), strings.Join(p.storeTypes, " or "), storeType.ValueString()),
+			)
+			return diags
+		}
+		preset = &p
+	}
+
+	for _, block := range propagationStoreConfigurationBlocks {
+		var planned, configured types.Object
+		diags.Append(resp.Plan.GetAttribute(ctx, path.Root(block), &planned)...)
+		diags.Append(req.Config.GetAttribute(ctx, path.Root(block), &configured)...)
+		if diags.HasError() {
+			return diags
+		}
+		if planned.IsNull() || planned.IsUnknown() || configured.IsNull() || configured.IsUnknown() {
+			continue
+		}
+
+		settings := map[string]attr.Value{}
+		for _, name := range presetStringSettings {
+			settings[name] = types.StringNull()
+		}
+		if preset != nil && preset.block == block {
+			for name, value := range preset.values {
+				settings[name] = value
+			}
+		}
+
+		attributeTypes := planned.AttributeTypes(ctx)
+		configuredValues := configured.Attributes()
+		for name, value := range settings {
+			if _, ok := attributeTypes[name]; !ok {
+				continue
+			}
+			if v, ok := configuredValues[name]; ok && !v.IsNull() {
+				continue
+			}
+			diags.Append(resp.Plan.SetAttribute(ctx, path.Root(block).AtName(name), value)...)
+		}
+	}
+
+	return diags
+}
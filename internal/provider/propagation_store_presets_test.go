@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlanPropagationStorePreset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&propagationStoreResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	model := func(storeType string, preset types.String, slack *customtypes.ConfigurationSlack) tftypes.Value {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		if diags := state.Set(ctx, &customtypes.PropagationStoreResourceModel{
+			PropagationStoreModel: customtypes.PropagationStoreModel{
+				EnvironmentId:      types.StringValue("env-id"),
+				Name:               types.StringValue("Slack"),
+				Type:               types.StringValue(storeType),
+				SyncStatus:         types.ObjectUnknown(customtypes.SyncStatusAttrTypes),
+				Links:              types.MapUnknown(types.StringType),
+				ConfigurationSlack: slack,
+			},
+			Preset:      preset,
+			Credentials: propagationStoreCredentialsNull(ctx),
+		}); diags.HasError() {
+			t.Fatalf("State.Set: %v", diags)
+		}
+		return state.Raw
+	}
+	slack := func(createUsers types.Bool, removeAction types.String) *customtypes.ConfigurationSlack {
+		return &customtypes.ConfigurationSlack{
+			BaseUrl:         types.StringValue("https://slack.example"),
+			ScimUrl:         types.StringValue("https://slack.example/scim"),
+			CreateUsers:     createUsers,
+			RemoveAction:    removeAction,
+			GroupNameSource: types.StringNull(),
+		}
+	}
+	modifyPlan := func(storeType string, preset types.String, configured, planned *customtypes.ConfigurationSlack) (*customtypes.ConfigurationSlack, *resource.ModifyPlanResponse) {
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: model(storeType, preset, configured)},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: model(storeType, preset, planned)},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		resp.Diagnostics.Append(planPropagationStorePreset(ctx, req, resp)...)
+
+		var got *customtypes.ConfigurationSlack
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("configuration_slack"), &got)...)
+		return got, resp
+	}
+
+	// create_users is planned with its schema default; remove_action is configured.
+	got, resp := modifyPlan("Slack", types.StringValue("slack-standard"),
+		slack(types.BoolValue(false), types.StringNull()),
+		slack(types.BoolValue(false), types.StringUnknown()),
+	)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("planPropagationStorePreset: %v", resp.Diagnostics)
+	}
+	if got.RemoveAction.ValueString() != "Disable" || got.GroupNameSource.ValueString() != "Common Name" {
+		t.Fatalf("remove_action = %s, group_name_source = %s; want the preset's", got.RemoveAction, got.GroupNameSource)
+	}
+	if got.CreateUsers.ValueBool() {
+		t.Fatal("create_users = true, want the configured false")
+	}
+
+	configured := slack(types.BoolNull(), types.StringValue("Delete"))
+	got, _ = modifyPlan("Slack", types.StringValue("slack-standard"), configured, slack(types.BoolValue(true), types.StringValue("Delete")))
+	if got.RemoveAction.ValueString() != "Delete" {
+		t.Fatalf("remove_action = %s, want the configured Delete", got.RemoveAction)
+	}
+
+	got, _ = modifyPlan("Slack", types.StringNull(), slack(types.BoolNull(), types.StringNull()), slack(types.BoolValue(true), types.StringUnknown()))
+	if !got.RemoveAction.IsNull() || !got.GroupNameSource.IsNull() {
+		t.Fatalf("without a preset remove_action = %s, group_name_source = %s; want null", got.RemoveAction, got.GroupNameSource)
+	}
+
+	_, resp = modifyPlan("Zoom", types.StringValue("slack-standard"), slack(types.BoolNull(), types.StringNull()), slack(types.BoolValue(true), types.StringUnknown()))
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a preset of another store type")
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// propagationStoreBearerToken returns the configuration block of m that has a bearer token, and
+// its bearer_token and bearer_token_ref. It returns nil pointers when no such block is set.
+func propagationStoreBearerToken(m *customtypes.PropagationStoreModel) (string, *types.String, *types.String) {
+	switch {
+	case m.ConfigurationAquera != nil:
+		return "configuration_aquera", &m.ConfigurationAquera.BearerToken, &m.ConfigurationAquera.BearerTokenRef
+	case m.ConfigurationAzureAdSamlV2 != nil:
+		return "configuration_azure_ad_saml_v2", &m.ConfigurationAzureAdSamlV2.BearerToken, &m.ConfigurationAzureAdSamlV2.BearerTokenRef
+	case m.ConfigurationLdapGateway != nil:
+		return "configuration_ldap_gateway", &m.ConfigurationLdapGateway.BearerToken, &m.ConfigurationLdapGateway.BearerTokenRef
+	case m.ConfigurationPingOne != nil:
+		return "configuration_ping_one", &m.ConfigurationPingOne.BearerToken, &m.ConfigurationPingOne.BearerTokenRef
+	case m.ConfigurationSalesforce != nil:
+		return "configuration_salesforce", &m.ConfigurationSalesforce.BearerToken, &m.ConfigurationSalesforce.BearerTokenRef
+	case m.ConfigurationSalesforceContacts != nil:
+		return "configuration_salesforce_contacts", &m.ConfigurationSalesforceContacts.BearerToken, &m.ConfigurationSalesforceContacts.BearerTokenRef
+	case m.ConfigurationSlack != nil:
+		return "configuration_slack", &m.ConfigurationSlack.BearerToken, &m.ConfigurationSlack.BearerTokenRef
+	default:
+		return "", nil, nil
+	}
+}
+
+// propagationStoreSecretRefs returns the provider secrets m references, keyed by the
+// configuration attribute that uses each one. It reports references to secrets the provider
+// does not define. References that are not known yet are left out.
+func propagationStoreSecretRefs(m *customtypes.PropagationStoreModel, secrets map[string]string) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	refs := make(map[string]string)
+
+	block, _, ref := propagationStoreBearerToken(m)
+	if ref == nil || ref.IsNull() || ref.IsUnknown() {
+		return refs, diags
+	}
+
+	name := strings.TrimSpace(ref.ValueString())
+	secret, ok := secrets[name]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root(block).AtName("bearer_token_ref"),
+			"Unknown Provider Secret",
+			fmt.Sprintf("The provider has no secret named %q. Define it in the provider's `secrets` or with the %s%s environment variable.", name, providerSecretEnvPrefix, strings.ToUpper(name)),
+		)
+		return refs, diags
+	}
+	refs["BEARER_TOKEN"] = secret
+	return refs, diags
+}
+
+// applyPropagationStoreSecretRefs sets the secrets m references in the configuration map sent
+// to PingOne.
+func applyPropagationStoreSecretRefs(configMap map[string]interface{}, m *customtypes.PropagationStoreModel, secrets map[string]string) diag.Diagnostics {
+	refs, diags := propagationStoreSecretRefs(m, secrets)
+	for key, secret := range refs {
+		configMap[key] = secret
+	}
+	return diags
+}
+
+// propagationStoreSecretRefsSHA256 returns the SHA-256 of the secrets m references, or null
+// when it references none, so that rotating a secret changes the plan without the value
+// appearing in it.
+func propagationStoreSecretRefsSHA256(m *customtypes.PropagationStoreModel, secrets map[string]string) (types.String, diag.Diagnostics) {
+	refs, diags := propagationStoreSecretRefs(m, secrets)
+	if diags.HasError() || len(refs) == 0 {
+		return types.StringNull(), diags
+	}
+
+	keys := make([]string, 0, len(refs))
+	for key := range refs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(h, "%s=%s\x00", key, refs[key])
+	}
+	return types.StringValue(hex.EncodeToString(h.Sum(nil))), diags
+}
+
+// keepPropagationStoreSecretRefs records prior's secret references on model, which was read from
+// PingOne, in place of the values PingOne returns for them.
+func keepPropagationStoreSecretRefs(model *customtypes.PropagationStoreModel, prior *customtypes.PropagationStoreModel) {
+	if prior == nil {
+		return
+	}
+	_, _, priorRef := propagationStoreBearerToken(prior)
+	_, token, ref := propagationStoreBearerToken(model)
+	if priorRef == nil || priorRef.IsNull() || ref == nil {
+		return
+	}
+	*ref = *priorRef
+	*token = types.StringNull()
+}
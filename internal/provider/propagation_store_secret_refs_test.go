@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderSecrets_ConfigOverridesEnvironment(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	configured := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"slack_token": types.StringValue("from-config"),
+	})
+	environ := []string{
+		"PINGONE_SECRET_SLACK_TOKEN=from-env",
+		"PINGONE_SECRET_ZOOM_TOKEN=zoom",
+		"PINGONE_SECRET_EMPTY=",
+		"PINGONE_CLIENT_SECRET=not-a-named-secret",
+	}
+
+	got := providerSecrets(context.Background(), &diags, configured, environ)
+	if diags.HasError() {
+		t.Fatalf("providerSecrets: %v", diags)
+	}
+	want := map[string]string{"slack_token": "from-config", "zoom_token": "zoom"}
+	if len(got) != len(want) {
+		t.Fatalf("secrets = %v, want %v", got, want)
+	}
+	for name, secret := range want {
+		if got[name] != secret {
+			t.Fatalf("secret %q = %q, want %q", name, got[name], secret)
+		}
+	}
+}
+
+func TestPropagationStoreSecretRefs(t *testing.T) {
+	t.Parallel()
+
+	model := customtypes.PropagationStoreModel{
+		Type: types.StringValue("Slack"),
+		ConfigurationSlack: &customtypes.ConfigurationSlack{
+			BearerToken:    types.StringNull(),
+			BearerTokenRef: types.StringValue("slack_token"),
+		},
+	}
+	secrets := map[string]string{"slack_token": "xoxb-1"}
+
+	configMap := map[string]interface{}{}
+	if diags := applyPropagationStoreSecretRefs(configMap, &model, secrets); diags.HasError() {
+		t.Fatalf("applyPropagationStoreSecretRefs: %v", diags)
+	}
+	if configMap["BEARER_TOKEN"] != "xoxb-1" {
+		t.Fatalf("BEARER_TOKEN = %v", configMap["BEARER_TOKEN"])
+	}
+
+	first, diags := propagationStoreSecretRefsSHA256(&model, secrets)
+	if diags.HasError() || first.IsNull() {
+		t.Fatalf("sha256 = %v, %v", first, diags)
+	}
+	rotated, _ := propagationStoreSecretRefsSHA256(&model, map[string]string{"slack_token": "xoxb-2"})
+	if rotated.Equal(first) {
+		t.Fatal("rotating the secret should change the sha256")
+	}
+
+	if _, diags := propagationStoreSecretRefs(&model, map[string]string{}); !diags.HasError() {
+		t.Fatal("expected an error for an undefined secret")
+	}
+
+	unreferenced := customtypes.PropagationStoreModel{ConfigurationSlack: &customtypes.ConfigurationSlack{BearerToken: types.StringValue("literal")}}
+	if got, _ := propagationStoreSecretRefsSHA256(&unreferenced, secrets); !got.IsNull() {
+		t.Fatalf("sha256 without references = %v, want null", got)
+	}
+}
+
+func TestKeepPropagationStoreSecretRefs(t *testing.T) {
+	t.Parallel()
+
+	prior := customtypes.PropagationStoreModel{ConfigurationSlack: &customtypes.ConfigurationSlack{
+		BearerToken:    types.StringNull(),
+		BearerTokenRef: types.StringValue("slack_token"),
+	}}
+	read := customtypes.PropagationStoreModel{ConfigurationSlack: &customtypes.ConfigurationSlack{
+		BearerToken: types.StringValue("xoxb-1"),
+	}}
+
+	keepPropagationStoreSecretRefs(&read, &prior)
+	if !read.ConfigurationSlack.BearerToken.IsNull() {
+		t.Fatalf("bearer_token = %v, want null", read.ConfigurationSlack.BearerToken)
+	}
+	if read.ConfigurationSlack.BearerTokenRef.ValueString() != "slack_token" {
+		t.Fatalf("bearer_token_ref = %v", read.ConfigurationSlack.BearerTokenRef)
+	}
+}
@@ -9,41 +9,86 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+// defaultRequestTimeout bounds token and Management API requests unless the provider
+// configuration overrides it.
+const defaultRequestTimeout = 90 * time.Second
+
+// defaultRevisionRetryTimeout bounds the retries of a failed propagation revision when
+// revision_failure_behavior is "retry" unless the provider configuration overrides it.
+const defaultRevisionRetryTimeout = 2 * time.Minute
+
+// revisionFailureBehaviors maps the values of revision_failure_behavior to the client setting.
+var revisionFailureBehaviors = map[string]client.RevisionFailureBehavior{
+	"warn":  client.RevisionFailureWarn,
+	"error": client.RevisionFailureError,
+	"retry": client.RevisionFailureRetry,
+}
+
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ provider.Provider = &PingOneProvisioningProvider{}
+	_ provider.Provider                  = &PingOneProvisioningProvider{}
+	_ provider.ProviderWithListResources = &PingOneProvisioningProvider{}
 )
 
 // PingOneProvisioningProvider is the provider implementation.
 type PingOneProvisioningProvider struct {
 	Version string
+
+	// metrics are the API call counters of the client Configure built, which
+	// LogAPIMetricsSummary reports.
+	metrics *client.APIMetrics
 }
 
 // PingOneProvisioningProviderModel describes the provider data model.
 type PingOneProvisioningProviderModel struct {
-	ClientId         types.String `tfsdk:"client_id"`
-	ClientSecret     types.String `tfsdk:"client_secret"`
-	EnvironmentId    types.String `tfsdk:"environment_id"`
-	Region           types.String `tfsdk:"region"`
-	OauthTokenURL    types.String `tfsdk:"oauth_token_url"`
-	APIBaseURL       types.String `tfsdk:"api_base_url"`
-	GithubToken      types.String `tfsdk:"github_token"`
-	GithubAPIBaseURL types.String `tfsdk:"github_api_base_url"`
-	GithubAPIVersion types.String `tfsdk:"github_api_version"`
+	ClientId                   types.String `tfsdk:"client_id"`
+	ClientSecret               types.String `tfsdk:"client_secret"`
+	EnvironmentId              types.String `tfsdk:"environment_id"`
+	Region                     types.String `tfsdk:"region"`
+	OauthTokenURL              types.String `tfsdk:"oauth_token_url"`
+	APIBaseURL                 types.String `tfsdk:"api_base_url"`
+	GithubToken                types.String `tfsdk:"github_token"`
+	GithubAPIBaseURL           types.String `tfsdk:"github_api_base_url"`
+	GithubAPIVersion           types.String `tfsdk:"github_api_version"`
+	GithubPageSize             types.Int64  `tfsdk:"github_page_size"`
+	GithubConditionalRequests  types.Bool   `tfsdk:"github_conditional_requests"`
+	PageSize                   types.Int64  `tfsdk:"page_size"`
+	ReadOnly                   types.Bool   `tfsdk:"read_only"`
+	ReadOnlyMode               types.String `tfsdk:"read_only_mode"`
+	ValidateTargetAttributes   types.Bool   `tfsdk:"validate_target_attributes"`
+	TokenRequestTimeout        types.String `tfsdk:"token_request_timeout"`
+	APIRequestTimeout          types.String `tfsdk:"api_request_timeout"`
+	ValidateCredentials        types.Bool   `tfsdk:"validate_credentials"`
+	CreatePropagationRevisions types.Bool   `tfsdk:"create_propagation_revisions"`
+	RevisionFailureBehavior    types.String `tfsdk:"revision_failure_behavior"`
+	RevisionRetryTimeout       types.String `tfsdk:"revision_retry_timeout"`
+	SkipMappingRefreshOnPlan   types.Bool   `tfsdk:"skip_mapping_refresh_on_plan"`
+	UseSDKRuleCreate           types.Bool   `tfsdk:"use_sdk_rule_create"`
+	Secrets                    types.Map    `tfsdk:"secrets"`
+
+	Policy *PingOneProvisioningPolicyModel `tfsdk:"policy"`
 }
 
 // New is a helper function to simplify the provider implementation.
@@ -104,6 +149,95 @@ func (p *PingOneProvisioningProvider) Schema(_ context.Context, _ provider.Schem
 				Description: "Optional override for the GitHub API version header (default: `2022-11-28`). Can also be set with the `GITHUB_API_VERSION` environment variable.",
 				Optional:    true,
 			},
+			"github_page_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of items to request per page when listing GitHub enterprise teams, team organizations and enterprise organizations. Must be between `1` and `%d`. Can also be set with the `GITHUB_PAGE_SIZE` environment variable. Default: `%d`.", client.GitHubPageSize, client.GitHubPageSize),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, client.GitHubPageSize),
+				},
+			},
+			"github_conditional_requests": schema.BoolAttribute{
+				Description: "When `true`, the provider remembers the ETag of each GitHub response it reads and sends later reads of the same URL as conditional requests. GitHub answers an unchanged resource with `304 Not Modified`, which does not count against the rate limit. Can also be set with the `GITHUB_CONDITIONAL_REQUESTS` environment variable. Default: `true`.",
+				Optional:    true,
+			},
+			"page_size": schema.Int64Attribute{
+				Description: "The number of items to request per page when listing PingOne collections (stores, plans, rules and mappings). Must be between `1` and `1000`. Can also be set with the `PINGONE_PAGE_SIZE` environment variable. If unset, the PingOne API default is used.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxPageSize),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "When `true`, the provider refuses to create, update or delete any resource; data sources and refreshes still work. Use it to point existing state at another environment's credentials without risk of writes. Can also be set with the `PINGONE_READ_ONLY` environment variable. Default: `false`.",
+				Optional:    true,
+			},
+			"read_only_mode": schema.StringAttribute{
+				Description: "How `read_only` reports planned writes. `error` (the default) fails the plan. `simulate` lets the plan complete and reports each write as a warning; applying still fails before any request is sent.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(readOnlyModeError, readOnlyModeSimulate),
+				},
+			},
+			"validate_target_attributes": schema.BoolAttribute{
+				Description: "When `true`, propagation rule mappings are checked at plan time against the target store's attribute catalog, for store types where PingOne exposes one (Aquera, Salesforce, Salesforce Contacts and SCIM). Each check reads the store and its metadata, so plans make extra API calls. Can also be set with the `PINGONE_VALIDATE_TARGET_ATTRIBUTES` environment variable. Default: `false`.",
+				Optional:    true,
+			},
+			"token_request_timeout": schema.StringAttribute{
+				Description: fmt.Sprintf("How long a request for an OAuth access token may take, as a duration such as `30s` or `2m`. A token failure is reported separately from the API request that needed it. Can also be set with the `PINGONE_TOKEN_REQUEST_TIMEOUT` environment variable. Default: `%s`.", defaultRequestTimeout),
+				Optional:    true,
+			},
+			"api_request_timeout": schema.StringAttribute{
+				Description: fmt.Sprintf("How long a Management API request may take, including retries of rate-limited and failed requests, as a duration such as `30s` or `5m`. Can also be set with the `PINGONE_API_REQUEST_TIMEOUT` environment variable. Default: `%s`.", defaultRequestTimeout),
+				Optional:    true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				Description: "When `true`, the provider requests an access token and reads the worker application's environment while it is configured, and fails with a specific error for bad credentials, a region mismatch or missing roles. Can also be set with the `PINGONE_VALIDATE_CREDENTIALS` environment variable. Default: `false`.",
+				Optional:    true,
+			},
+			"create_propagation_revisions": schema.BoolAttribute{
+				Description: "When `true`, resources create a propagation revision after they change a propagation plan, store or rule, so the PingOne UI shows the change straight away. Set to `false` to leave revisions to another process. Can also be set with the `PINGONE_CREATE_PROPAGATION_REVISIONS` environment variable. Default: `true`.",
+				Optional:    true,
+			},
+			"revision_failure_behavior": schema.StringAttribute{
+				Description: "How a propagation revision that could not be created is reported. `warn` (the default) reports a warning and the apply succeeds, so the PingOne UI can lag the configuration. `error` fails the apply. `retry` retries the revision with backoff until `revision_retry_timeout`, then fails the apply. Can also be set with the `PINGONE_REVISION_FAILURE_BEHAVIOR` environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("warn", "error", "retry"),
+				},
+			},
+			"revision_retry_timeout": schema.StringAttribute{
+				Description: fmt.Sprintf("How long to retry a failed propagation revision when `revision_failure_behavior` is `retry`, as a duration such as `30s` or `5m`. Can also be set with the `PINGONE_REVISION_RETRY_TIMEOUT` environment variable. Default: `%s`.", defaultRevisionRetryTimeout),
+				Optional:    true,
+			},
+			"skip_mapping_refresh_on_plan": schema.BoolAttribute{
+				Description: "When `true`, refreshing a `pingoneprovisioning_propagation_rule` keeps the mappings recorded in state instead of listing them, which speeds up plans for states with many rules. Mappings changed outside Terraform then show no drift, but creates and updates still read and reconcile mappings in full. Can also be set with the `PINGONE_SKIP_MAPPING_REFRESH_ON_PLAN` environment variable. Default: `false`.",
+				Optional:    true,
+			},
+			"use_sdk_rule_create": schema.BoolAttribute{
+				Description: "When `true`, propagation rules are created with the PingOne SDK, which posts to the environment's rules collection with the plan in the request, instead of with the provider's own request to the plan's rules collection. The SDK path will become the default once the SDK supports creating rules in a plan; set this to try it early. Can also be set with the `PINGONE_USE_SDK_RULE_CREATE` environment variable. Default: `false`.",
+				Optional:    true,
+			},
+			"secrets": schema.MapAttribute{
+				Description: "Named secrets that propagation store configuration blocks reference with `bearer_token_ref` instead of setting `bearer_token`, so that several stores share one secret and the value is not stored in their state. A secret can also be set with a `PINGONE_SECRET_<NAME>` environment variable, for example `PINGONE_SECRET_SLACK_TOKEN` for `slack_token`; a value set here takes precedence.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"policy": schema.SingleNestedBlock{
+				Description: "Guardrails enforced at plan time on every propagation rule and rule set managed through this provider, whatever the module that declares them sets.",
+				Attributes: map[string]schema.Attribute{
+					"forbid_active_without_filter": schema.BoolAttribute{
+						Description: "When `true`, plans fail for active rules with neither a `filter` nor `population_ids`, which would provision every user. Default: `false`.",
+						Optional:    true,
+					},
+					"forbid_deprovision": schema.BoolAttribute{
+						Description: "When `true`, plans fail for rules that set `deprovision = true`. Default: `false`.",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -166,6 +300,194 @@ func (p *PingOneProvisioningProvider) Configure(ctx context.Context, req provide
 		githubAPIVersion = strings.TrimSpace(config.GithubAPIVersion.ValueString())
 	}
 
+	var githubPageSize int64
+	if v := strings.TrimSpace(os.Getenv("GITHUB_PAGE_SIZE")); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 1 || parsed > client.GitHubPageSize {
+			resp.Diagnostics.AddError(
+				"Invalid GitHub Page Size",
+				fmt.Sprintf("GITHUB_PAGE_SIZE must be an integer between 1 and %d, got %q.", client.GitHubPageSize, v),
+			)
+			return
+		}
+		githubPageSize = parsed
+	}
+	if !config.GithubPageSize.IsNull() && !config.GithubPageSize.IsUnknown() {
+		githubPageSize = config.GithubPageSize.ValueInt64()
+	}
+
+	githubConditionalRequests := true
+	if v := strings.TrimSpace(os.Getenv("GITHUB_CONDITIONAL_REQUESTS")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid GitHub Conditional Request Setting",
+				fmt.Sprintf("GITHUB_CONDITIONAL_REQUESTS must be a boolean, got %q.", v),
+			)
+			return
+		}
+		githubConditionalRequests = parsed
+	}
+	if !config.GithubConditionalRequests.IsNull() && !config.GithubConditionalRequests.IsUnknown() {
+		githubConditionalRequests = config.GithubConditionalRequests.ValueBool()
+	}
+
+	var pageSize int64
+	if v := strings.TrimSpace(os.Getenv("PINGONE_PAGE_SIZE")); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 1 || parsed > maxPageSize {
+			resp.Diagnostics.AddError(
+				"Invalid Page Size",
+				fmt.Sprintf("PINGONE_PAGE_SIZE must be an integer between 1 and %d, got %q.", maxPageSize, v),
+			)
+			return
+		}
+		pageSize = parsed
+	}
+	if !config.PageSize.IsNull() && !config.PageSize.IsUnknown() {
+		pageSize = config.PageSize.ValueInt64()
+	}
+
+	readOnly := false
+	if v := strings.TrimSpace(os.Getenv("PINGONE_READ_ONLY")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Read-Only Setting",
+				fmt.Sprintf("PINGONE_READ_ONLY must be a boolean, got %q.", v),
+			)
+			return
+		}
+		readOnly = parsed
+	}
+	if !config.ReadOnly.IsNull() && !config.ReadOnly.IsUnknown() {
+		readOnly = config.ReadOnly.ValueBool()
+	}
+
+	readOnlyMode := client.ReadOnlyDisabled
+	if readOnly {
+		readOnlyMode = client.ReadOnlyError
+		if !config.ReadOnlyMode.IsNull() && config.ReadOnlyMode.ValueString() == readOnlyModeSimulate {
+			readOnlyMode = client.ReadOnlySimulate
+		}
+	}
+
+	validateTargetAttributes := false
+	if v := strings.TrimSpace(os.Getenv("PINGONE_VALIDATE_TARGET_ATTRIBUTES")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Target Attribute Validation Setting",
+				fmt.Sprintf("PINGONE_VALIDATE_TARGET_ATTRIBUTES must be a boolean, got %q.", v),
+			)
+			return
+		}
+		validateTargetAttributes = parsed
+	}
+	if !config.ValidateTargetAttributes.IsNull() && !config.ValidateTargetAttributes.IsUnknown() {
+		validateTargetAttributes = config.ValidateTargetAttributes.ValueBool()
+	}
+
+	validateCreds := false
+	if v := strings.TrimSpace(os.Getenv("PINGONE_VALIDATE_CREDENTIALS")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Credential Validation Setting",
+				fmt.Sprintf("PINGONE_VALIDATE_CREDENTIALS must be a boolean, got %q.", v),
+			)
+			return
+		}
+		validateCreds = parsed
+	}
+	if !config.ValidateCredentials.IsNull() && !config.ValidateCredentials.IsUnknown() {
+		validateCreds = config.ValidateCredentials.ValueBool()
+	}
+
+	createRevisions := true
+	if v := strings.TrimSpace(os.Getenv("PINGONE_CREATE_PROPAGATION_REVISIONS")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Propagation Revision Setting",
+				fmt.Sprintf("PINGONE_CREATE_PROPAGATION_REVISIONS must be a boolean, got %q.", v),
+			)
+			return
+		}
+		createRevisions = parsed
+	}
+	if !config.CreatePropagationRevisions.IsNull() && !config.CreatePropagationRevisions.IsUnknown() {
+		createRevisions = config.CreatePropagationRevisions.ValueBool()
+	}
+
+	revisionFailureBehavior := client.RevisionFailureWarn
+	if v := strings.TrimSpace(os.Getenv("PINGONE_REVISION_FAILURE_BEHAVIOR")); v != "" {
+		parsed, ok := revisionFailureBehaviors[strings.ToLower(v)]
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Invalid Propagation Revision Setting",
+				fmt.Sprintf("PINGONE_REVISION_FAILURE_BEHAVIOR must be one of \"warn\", \"error\" or \"retry\", got %q.", v),
+			)
+			return
+		}
+		revisionFailureBehavior = parsed
+	}
+	if !config.RevisionFailureBehavior.IsNull() && !config.RevisionFailureBehavior.IsUnknown() {
+		revisionFailureBehavior = revisionFailureBehaviors[config.RevisionFailureBehavior.ValueString()]
+	}
+
+	revisionRetryTimeout, ok := timeoutSetting(&resp.Diagnostics, config.RevisionRetryTimeout, "revision_retry_timeout", "PINGONE_REVISION_RETRY_TIMEOUT", defaultRevisionRetryTimeout)
+	if !ok {
+		return
+	}
+
+	skipMappingRefresh := false
+	if v := strings.TrimSpace(os.Getenv("PINGONE_SKIP_MAPPING_REFRESH_ON_PLAN")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Mapping Refresh Setting",
+				fmt.Sprintf("PINGONE_SKIP_MAPPING_REFRESH_ON_PLAN must be a boolean, got %q.", v),
+			)
+			return
+		}
+		skipMappingRefresh = parsed
+	}
+	if !config.SkipMappingRefreshOnPlan.IsNull() && !config.SkipMappingRefreshOnPlan.IsUnknown() {
+		skipMappingRefresh = config.SkipMappingRefreshOnPlan.ValueBool()
+	}
+
+	useSDKRuleCreate := false
+	if v := strings.TrimSpace(os.Getenv("PINGONE_USE_SDK_RULE_CREATE")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Rule Create Setting",
+				fmt.Sprintf("PINGONE_USE_SDK_RULE_CREATE must be a boolean, got %q.", v),
+			)
+			return
+		}
+		useSDKRuleCreate = parsed
+	}
+	if !config.UseSDKRuleCreate.IsNull() && !config.UseSDKRuleCreate.IsUnknown() {
+		useSDKRuleCreate = config.UseSDKRuleCreate.ValueBool()
+	}
+
+	secrets := providerSecrets(ctx, &resp.Diagnostics, config.Secrets, os.Environ())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenRequestTimeout, ok := requestTimeoutSetting(&resp.Diagnostics, config.TokenRequestTimeout, "token_request_timeout", "PINGONE_TOKEN_REQUEST_TIMEOUT")
+	if !ok {
+		return
+	}
+
+	apiRequestTimeout, ok := requestTimeoutSetting(&resp.Diagnostics, config.APIRequestTimeout, "api_request_timeout", "PINGONE_API_REQUEST_TIMEOUT")
+	if !ok {
+		return
+	}
+
 	// Map short codes (terraform standard) to Long Codes (SDK Requirement)
 	mappedRegion := mapRegion(region)
 
@@ -177,7 +499,17 @@ func (p *PingOneProvisioningProvider) Configure(ctx context.Context, req provide
 		return
 	}
 
-	apiClient, err := newManagementClient(ctx, p.Version, clientId, clientSecret, environmentId, mappedRegion, oauthTokenURL, apiBaseURL)
+	metrics := client.NewAPIMetrics()
+	apiClient, err := newManagementClient(ctx, p.Version, clientId, clientSecret, environmentId, mappedRegion, oauthTokenURL, apiBaseURL, tokenRequestTimeout, apiRequestTimeout, metrics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create PingOne Client",
+			fmt.Sprintf("An error occurred when creating the PingOne client: %s", err),
+		)
+		return
+	}
+
+	tokenURL, err := resolveTokenURL(mappedRegion, environmentId, oauthTokenURL)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create PingOne Client",
@@ -186,10 +518,37 @@ func (p *PingOneProvisioningProvider) Configure(ctx context.Context, req provide
 		return
 	}
 
+	if validateCreds {
+		resp.Diagnostics.Append(validateCredentials(ctx, apiClient, environmentId, tokenURL)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Create the provider client structure to pass to resources
 	clientData := &client.Client{
-		API: apiClient,
-	}
+		API:                      apiClient,
+		Region:                   mappedRegion,
+		TokenURL:                 tokenURL,
+		ClientID:                 clientId,
+		AuthEnvironmentID:        environmentId,
+		UserSchemaAttributes:     client.NewAttributeCache(),
+		PageSize:                 int32(pageSize),
+		ReadOnly:                 readOnlyMode,
+		ValidateTargetAttributes: validateTargetAttributes,
+		TargetStoreAttributes:    client.NewAttributeCache(),
+		StoreTypes:               client.NewStoreTypeCache(),
+		SkipPropagationRevisions: !createRevisions,
+		RevisionFailureBehavior:  revisionFailureBehavior,
+		RevisionRetryTimeout:     revisionRetryTimeout,
+		SkipMappingRefresh:       skipMappingRefresh,
+		UseSDKRuleCreate:         useSDKRuleCreate,
+		Secrets:                  secrets,
+		Policy:                   policyFromModel(config.Policy),
+		Endpoints:                client.NewEndpoints(),
+		Metrics:                  metrics,
+	}
+	p.metrics = metrics
 
 	if githubToken != "" {
 		userAgent := "terraform-provider-pingoneprovisioning"
@@ -206,27 +565,29 @@ func (p *PingOneProvisioningProvider) Configure(ctx context.Context, req provide
 			return
 		}
 
+		githubClient.HTTPClient.Transport = metrics.Transport(githubClient.HTTPClient.Transport, client.GitHubAPIFamily)
+		githubClient.Metrics = metrics
+		githubClient.PageSize = int(githubPageSize)
+		if githubConditionalRequests {
+			githubClient.ETags = client.NewGitHubETagCache()
+		}
 		clientData.GitHub = githubClient
 	}
 
 	resp.DataSourceData = clientData
 	resp.ResourceData = clientData
+	resp.ListResourceData = clientData
 }
 
-func newManagementClient(ctx context.Context, providerVersion string, clientID string, clientSecret string, authEnvironmentID string, region string, oauthTokenURL string, apiBaseURL string) (*management.APIClient, error) {
+func newManagementClient(ctx context.Context, providerVersion string, clientID string, clientSecret string, authEnvironmentID string, region string, oauthTokenURL string, apiBaseURL string, tokenRequestTimeout time.Duration, apiRequestTimeout time.Duration, metrics *client.APIMetrics) (*management.APIClient, error) {
 	regionSuffix, err := regionToURLSuffix(region)
 	if err != nil {
 		return nil, err
 	}
 
-	tokenURL := strings.TrimSpace(oauthTokenURL)
-	if tokenURL == "" {
-		tokenURL = fmt.Sprintf("https://auth.pingone.%s/%s/as/token", regionSuffix, authEnvironmentID)
-	}
-
-	tokenURL, err = normalizeURL(tokenURL, "https")
+	tokenURL, err := resolveTokenURL(region, authEnvironmentID, oauthTokenURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid oauth_token_url %q: %w", oauthTokenURL, err)
+		return nil, err
 	}
 
 	tokenCfg := &clientcredentials.Config{
@@ -243,24 +604,28 @@ func newManagementClient(ctx context.Context, providerVersion string, clientID s
 
 	// Wrap with retry transport for 429/5xx handling with exponential backoff
 	// Retries for up to 5 minutes with exponential backoff starting at 1 second
-	baseRT = newRetryTransport(baseRT)
+	// Every attempt is counted in metrics, below the retries.
+	baseRT = newRetryTransport(metrics.Transport(baseRT, client.PingOneAPIFamily), WithMetrics(metrics))
 
 	// Do not use the provider Configure() request context for the token source.
 	// That context is canceled after Configure returns, which would make all
 	// subsequent token refreshes fail with `context canceled`.
 	tokenHTTPClient := &http.Client{
 		Transport: baseRT,
-		Timeout:   90 * time.Second,
+		Timeout:   tokenRequestTimeout,
 	}
 	tokenCtx := context.WithValue(context.WithoutCancel(ctx), oauth2.HTTPClient, tokenHTTPClient)
-	tokenSource := tokenCfg.TokenSource(tokenCtx)
+	tokenSource := &tokenErrorSource{
+		source:   tokenCfg.TokenSource(tokenCtx),
+		tokenURL: tokenURL,
+	}
 
 	httpClient := &http.Client{
 		Transport: &oauth2.Transport{
 			Source: oauth2.ReuseTokenSource(nil, tokenSource),
 			Base:   baseRT,
 		},
-		Timeout: 90 * time.Second,
+		Timeout: apiRequestTimeout,
 	}
 
 	cfg := management.NewConfiguration()
@@ -308,6 +673,102 @@ func newManagementClient(ctx context.Context, providerVersion string, clientID s
 	return apiClient, nil
 }
 
+// resolveTokenURL returns the OAuth token URL the management client authenticates against:
+// the `oauth_token_url` override when set, otherwise the region's auth host for the
+// environment.
+func resolveTokenURL(region string, authEnvironmentID string, oauthTokenURL string) (string, error) {
+	tokenURL := strings.TrimSpace(oauthTokenURL)
+	if tokenURL == "" {
+		regionSuffix, err := regionToURLSuffix(region)
+		if err != nil {
+			return "", err
+		}
+		tokenURL = fmt.Sprintf("https://auth.pingone.%s/%s/as/token", regionSuffix, authEnvironmentID)
+	}
+
+	tokenURL, err := normalizeURL(tokenURL, "https")
+	if err != nil {
+		return "", fmt.Errorf("invalid oauth_token_url %q: %w", oauthTokenURL, err)
+	}
+
+	return tokenURL, nil
+}
+
+// tokenErrorSource marks token failures as utils.TokenError so that error messages can tell
+// them apart from failures of the API request itself.
+type tokenErrorSource struct {
+	source   oauth2.TokenSource
+	tokenURL string
+}
+
+func (s *tokenErrorSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, &utils.TokenError{TokenURL: s.tokenURL, Err: err}
+	}
+	return token, nil
+}
+
+// requestTimeoutSetting resolves a request timeout from the provider attribute, then the
+// environment variable, then defaultRequestTimeout. It reports an invalid value and returns
+// false.
+func requestTimeoutSetting(diags *diag.Diagnostics, value types.String, attribute string, envVar string) (time.Duration, bool) {
+	return timeoutSetting(diags, value, attribute, envVar, defaultRequestTimeout)
+}
+
+// timeoutSetting resolves a timeout from the provider attribute, then the environment variable,
+// then defaultTimeout. It reports an invalid value and returns false.
+func timeoutSetting(diags *diag.Diagnostics, value types.String, attribute string, envVar string, defaultTimeout time.Duration) (time.Duration, bool) {
+	raw, source := strings.TrimSpace(os.Getenv(envVar)), envVar
+	if !value.IsNull() && !value.IsUnknown() {
+		raw, source = strings.TrimSpace(value.ValueString()), attribute
+	}
+	if raw == "" {
+		return defaultTimeout, true
+	}
+
+	timeout, err := time.ParseDuration(raw)
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Timeout",
+			fmt.Sprintf("%s must be a positive duration such as \"30s\" or \"2m\", got %q.", source, raw),
+		)
+		return 0, false
+	}
+	return timeout, true
+}
+
+// providerSecretEnvPrefix starts the environment variables that set provider secrets.
+const providerSecretEnvPrefix = "PINGONE_SECRET_"
+
+// providerSecrets returns the named secrets from `PINGONE_SECRET_<NAME>` environment variables in
+// environ, named in lower case, overridden by the `secrets` attribute.
+func providerSecrets(ctx context.Context, diags *diag.Diagnostics, value types.Map, environ []string) map[string]string {
+	secrets := make(map[string]string)
+	for _, entry := range environ {
+		key, secret, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(key, providerSecretEnvPrefix) || secret == "" {
+			continue
+		}
+		if name := strings.ToLower(strings.TrimPrefix(key, providerSecretEnvPrefix)); name != "" {
+			secrets[name] = secret
+		}
+	}
+
+	if value.IsNull() || value.IsUnknown() {
+		return secrets
+	}
+	var configured map[string]types.String
+	diags.Append(value.ElementsAs(ctx, &configured, false)...)
+	for name, secret := range configured {
+		if !secret.IsNull() && !secret.IsUnknown() {
+			secrets[name] = secret.ValueString()
+		}
+	}
+	return secrets
+}
+
 type loggingTransport struct {
 	rt http.RoundTripper
 }
@@ -424,9 +885,24 @@ func mapRegion(code string) string {
 func (p *PingOneProvisioningProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewPropagationStoreResource,
+		NewPropagationStoreMirrorResource,
+		NewPropagationStorePingOneCredentialsResource,
 		NewPropagationPlanResource,
+		NewPropagationDefaultPlanResource,
 		NewPropagationRuleResource,
+		NewPropagationRuleSetResource,
 		NewUserCustomAttributesResource,
+		NewEnterpriseTeamOrganizationsResource,
+		NewEnterpriseTeamExternalGroupResource,
+		NewGroupMembershipResource,
+		NewGroupMembershipsResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider, used by `terraform query`.
+func (p *PingOneProvisioningProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewEnterpriseTeamOrganizationsListResource,
 	}
 }
 
@@ -437,7 +913,24 @@ func (p *PingOneProvisioningProvider) DataSources(_ context.Context) []func() da
 		NewPropagationStoresDataSource,
 		NewPropagationPlanDataSource,
 		NewPropagationRuleDataSource,
+		NewPropagationRuleMappingDataSource,
 		NewGroupsDataSource,
 		NewGithubScimGroupDataSource,
+		NewGithubEnterpriseTeamsDataSource,
+		NewEnterpriseOrganizationsDataSource,
+		NewGatewayDataSource,
+		NewPropagationStoreTypesDataSource,
+		NewPropagationRulePreviewDataSource,
+		NewPropagationImpactDataSource,
+		NewPropagationMappingCoverageDataSource,
+		NewPropagationRevisionsDataSource,
+		NewPropagationStoreReadyDataSource,
+		NewPropagationStoreTestDataSource,
+		NewProviderConfigDataSource,
+		NewUserDataSource,
+		NewUserCustomAttributesDataSource,
+		NewRolePreflightDataSource,
+		NewEnvironmentDataSource,
+		NewEnvironmentsDataSource,
 	}
 }
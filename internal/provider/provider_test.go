@@ -2,11 +2,17 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestNewManagementClient_DoesNotUseCanceledContextForToken(t *testing.T) {
@@ -48,13 +54,7 @@ func TestNewManagementClient_DoesNotUseCanceledContextForToken(t *testing.T) {
 			}
 
 			body := `{"access_token":"test-token","token_type":"Bearer","expires_in":3600}`
-			return &http.Response{
-				StatusCode: http.StatusOK,
-				Status:     "200 OK",
-				Header:     http.Header{"Content-Type": []string{"application/json"}},
-				Body:       io.NopCloser(strings.NewReader(body)),
-				Request:    r,
-			}, nil
+			return testResponse(r, http.StatusOK, body), nil
 		default:
 			apiRequests.Add(1)
 
@@ -85,6 +85,9 @@ func TestNewManagementClient_DoesNotUseCanceledContextForToken(t *testing.T) {
 		"NorthAmerica",
 		"https://auth.example/as/token",
 		"",
+		defaultRequestTimeout,
+		defaultRequestTimeout,
+		nil,
 	)
 	if err != nil {
 		t.Fatalf("newManagementClient error: %v", err)
@@ -109,8 +112,89 @@ func TestNewManagementClient_DoesNotUseCanceledContextForToken(t *testing.T) {
 	}
 }
 
-type roundTripperFunc func(*http.Request) (*http.Response, error)
+func TestNewManagementClient_ReportsTokenFailure(t *testing.T) {
+	var apiRequests atomic.Int32
+
+	originalDefaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/as/token" {
+			apiRequests.Add(1)
+			return nil, errors.New("unexpected api request")
+		}
+
+		body := `{"error":"invalid_client","error_description":"Request denied: Invalid client credentials"}`
+		return testResponse(r, http.StatusUnauthorized, body), nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = originalDefaultTransport })
+
+	apiClient, err := newManagementClient(
+		context.Background(),
+		"test",
+		"client-id",
+		"wrong-secret",
+		"auth-environment-id",
+		"NorthAmerica",
+		"https://auth.example/as/token",
+		"",
+		defaultRequestTimeout,
+		defaultRequestTimeout,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("newManagementClient error: %v", err)
+	}
+
+	_, err = apiClient.GetConfig().HTTPClient.Get("https://api.example/ping")
+	if err == nil {
+		t.Fatal("expected the api request to fail")
+	}
+
+	var tokenErr *utils.TokenError
+	if !errors.As(err, &tokenErr) {
+		t.Fatalf("error %v is not a token error", err)
+	}
+	if tokenErr.TokenURL != "https://auth.example/as/token" {
+		t.Fatalf("TokenURL = %q", tokenErr.TokenURL)
+	}
+	if msg := utils.HandleSDKError(err, nil); !strings.Contains(msg, "check client_id and client_secret") {
+		t.Fatalf("HandleSDKError = %q, want a credentials hint", msg)
+	}
+	if apiRequests.Load() != 0 {
+		t.Fatalf("expected no api requests, got %d", apiRequests.Load())
+	}
+}
+
+func TestRequestTimeoutSetting(t *testing.T) {
+	t.Setenv("PINGONE_API_REQUEST_TIMEOUT", "45s")
+
+	tests := []struct {
+		name    string
+		value   types.String
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "environment variable", value: types.StringNull(), want: 45 * time.Second},
+		{name: "attribute wins", value: types.StringValue("2m"), want: 2 * time.Minute},
+		{name: "not a duration", value: types.StringValue("30"), wantErr: true},
+		{name: "not positive", value: types.StringValue("0s"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got, ok := requestTimeoutSetting(&diags, tt.value, "api_request_timeout", "PINGONE_API_REQUEST_TIMEOUT")
+			if ok == tt.wantErr || diags.HasError() != tt.wantErr {
+				t.Fatalf("ok = %v, diags = %v, wantErr %v", ok, diags, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("timeout = %s, want %s", got, tt.want)
+			}
+		})
+	}
 
-func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
-	return f(r)
+	t.Setenv("PINGONE_API_REQUEST_TIMEOUT", "")
+	var diags diag.Diagnostics
+	if got, ok := requestTimeoutSetting(&diags, types.StringNull(), "api_request_timeout", "PINGONE_API_REQUEST_TIMEOUT"); !ok || got != defaultRequestTimeout {
+		t.Fatalf("default = %s, %v", got, ok)
+	}
 }
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+const (
+	readOnlyModeError    = "error"
+	readOnlyModeSimulate = "simulate"
+)
+
+// readOnlyModeOf returns the configured read-only mode, treating an unconfigured client as
+// writable.
+func readOnlyModeOf(c *client.Client) client.ReadOnlyMode {
+	if c == nil {
+		return client.ReadOnlyDisabled
+	}
+	return c.ReadOnly
+}
+
+// readOnlyPlanAction describes the write a plan would perform, or returns "" when the plan
+// makes no change.
+func readOnlyPlanAction(req resource.ModifyPlanRequest) string {
+	switch {
+	case req.Plan.Raw.IsNull() && req.State.Raw.IsNull():
+		return ""
+	case req.Plan.Raw.IsNull():
+		return "delete"
+	case req.State.Raw.IsNull():
+		return "create"
+	case !req.Plan.Raw.Equal(req.State.Raw):
+		return "update"
+	default:
+		return ""
+	}
+}
+
+// checkReadOnlyPlan reports planned writes when the provider is read-only: as an error in the
+// default mode, or as a warning in simulate mode.
+func checkReadOnlyPlan(_ context.Context, mode client.ReadOnlyMode, typeName string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if mode == client.ReadOnlyDisabled {
+		return
+	}
+
+	action := readOnlyPlanAction(req)
+	if action == "" {
+		return
+	}
+
+	switch mode {
+	case client.ReadOnlySimulate:
+		resp.Diagnostics.AddWarning(
+			"Read-Only Provider: Write Simulated",
+			fmt.Sprintf("The provider is in read-only simulate mode. This plan would %s a %s resource; applying it will fail without sending any request.", action, typeName),
+		)
+	default:
+		resp.Diagnostics.AddError(
+			"Read-Only Provider",
+			fmt.Sprintf("The provider is configured with `read_only = true`, so this plan cannot %s a %s resource. Set `read_only_mode = \"simulate\"` to review the plan without failing.", action, typeName),
+		)
+	}
+}
+
+// checkReadOnlyApply fails an apply-time write when the provider is read-only. It returns
+// false when the caller must stop.
+func checkReadOnlyApply(diags *diag.Diagnostics, mode client.ReadOnlyMode, action string, typeName string) bool {
+	if mode == client.ReadOnlyDisabled {
+		return true
+	}
+
+	diags.AddError(
+		"Read-Only Provider",
+		fmt.Sprintf("The provider is read-only; refusing to %s a %s resource. No request was sent.", action, typeName),
+	)
+	return false
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckReadOnlyPlan(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}
+	value := func(name string) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, name)})
+	}
+	null := tftypes.NewValue(objType, nil)
+
+	tests := []struct {
+		name        string
+		mode        client.ReadOnlyMode
+		state       tftypes.Value
+		plan        tftypes.Value
+		wantError   bool
+		wantWarning bool
+	}{
+		{name: "disabled_create", mode: client.ReadOnlyDisabled, state: null, plan: value("a")},
+		{name: "error_create", mode: client.ReadOnlyError, state: null, plan: value("a"), wantError: true},
+		{name: "error_update", mode: client.ReadOnlyError, state: value("a"), plan: value("b"), wantError: true},
+		{name: "error_delete", mode: client.ReadOnlyError, state: value("a"), plan: null, wantError: true},
+		{name: "error_no_change", mode: client.ReadOnlyError, state: value("a"), plan: value("a")},
+		{name: "simulate_update", mode: client.ReadOnlySimulate, state: value("a"), plan: value("b"), wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Raw: tt.state},
+				Plan:  tfsdk.Plan{Raw: tt.plan},
+			}
+			resp := &resource.ModifyPlanResponse{}
+
+			checkReadOnlyPlan(context.Background(), tt.mode, "pingoneprovisioning_propagation_store", req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("HasError() = %v, want %v", resp.Diagnostics.HasError(), tt.wantError)
+			}
+			if gotWarning := resp.Diagnostics.WarningsCount() > 0; gotWarning != tt.wantWarning {
+				t.Fatalf("warning = %v, want %v", gotWarning, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &enterpriseTeamExternalGroupResource{}
+	_ resource.ResourceWithConfigure   = &enterpriseTeamExternalGroupResource{}
+	_ resource.ResourceWithImportState = &enterpriseTeamExternalGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &enterpriseTeamExternalGroupResource{}
+	_ resource.ResourceWithIdentity    = &enterpriseTeamExternalGroupResource{}
+)
+
+type enterpriseTeamExternalGroupResource struct {
+	client   *client.GitHubClient
+	readOnly client.ReadOnlyMode
+	metrics  *client.APIMetrics
+}
+
+func NewEnterpriseTeamExternalGroupResource() resource.Resource {
+	return &enterpriseTeamExternalGroupResource{}
+}
+
+func (r *enterpriseTeamExternalGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_enterprise_team_external_group"
+	// The identity holds the team slug, which changes when the team is renamed.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *enterpriseTeamExternalGroupResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = enterpriseTeamIdentitySchema()
+}
+
+func (r *enterpriseTeamExternalGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Links a GitHub enterprise team to an external (IdP) group, so that team membership follows the group's SCIM-provisioned members. Requires a GitHub token configured on the provider.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier in the form `<enterprise>/<team_slug>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enterprise": schema.StringAttribute{
+				Description: "The enterprise slug. Compared case-insensitively.",
+				Required:    true,
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfNotEqualFold(),
+				},
+			},
+			"team_slug": schema.StringAttribute{
+				Description: "The slug of the enterprise team. Compared case-insensitively.",
+				Required:    true,
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfNotEqualFold(),
+				},
+			},
+			"team_id": schema.Int64Attribute{
+				Description: "The numeric ID of the enterprise team, used to find the team if it is renamed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the external group to link the team to: the `id` of the `pingoneprovisioning_github_scim_group` data source for the group a propagation rule provisions. Changing it relinks the team in place.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *enterpriseTeamExternalGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.GitHub
+	r.readOnly = clientData.ReadOnly
+	r.metrics = clientData.Metrics
+}
+
+func (r *enterpriseTeamExternalGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, r.readOnly, "pingoneprovisioning_enterprise_team_external_group", req, resp)
+}
+
+func (r *enterpriseTeamExternalGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, r.readOnly, "create", "pingoneprovisioning_enterprise_team_external_group") {
+		return
+	}
+	defer reportAPIMetrics(ctx, r.metrics, &resp.Diagnostics)
+
+	var plan customtypes.EnterpriseTeamExternalGroupModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	groupID := plan.GroupId.ValueString()
+	team, _, err := setEnterpriseTeamGroup(ctx, r.client, plan.Enterprise.ValueString(), plan.TeamSlug.ValueString(), &groupID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Linking Enterprise Team to External Group",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.TeamId = types.Int64Value(team.Id)
+	plan.Id = types.StringValue(buildEnterpriseTeamOrganizationsID(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, enterpriseTeamOrganizationsIdentity(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))...)
+}
+
+func (r *enterpriseTeamExternalGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.EnterpriseTeamExternalGroupModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	team, httpResp, err := readEnterpriseTeam(ctx, r.client, state.Enterprise.ValueString(), enterpriseTeamExternalGroupRef(state))
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Enterprise Team",
+			err.Error(),
+		)
+		return
+	}
+
+	// A team unlinked outside Terraform has no link left to manage; removing it from state plans
+	// the link again.
+	if team.GroupId == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.TeamId = types.Int64Value(team.Id)
+	state.GroupId = types.StringValue(team.GroupId)
+	state.Id = types.StringValue(buildEnterpriseTeamOrganizationsID(state.Enterprise.ValueString(), state.TeamSlug.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, enterpriseTeamOrganizationsIdentity(state.Enterprise.ValueString(), state.TeamSlug.ValueString()))...)
+}
+
+func (r *enterpriseTeamExternalGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, r.readOnly, "update", "pingoneprovisioning_enterprise_team_external_group") {
+		return
+	}
+	defer reportAPIMetrics(ctx, r.metrics, &resp.Diagnostics)
+
+	var plan, state customtypes.EnterpriseTeamExternalGroupModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	plan.TeamId = state.TeamId
+	groupID := plan.GroupId.ValueString()
+	team, _, err := setEnterpriseTeamGroup(ctx, r.client, plan.Enterprise.ValueString(), enterpriseTeamExternalGroupRef(plan), &groupID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Linking Enterprise Team to External Group",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.TeamId = types.Int64Value(team.Id)
+	plan.Id = types.StringValue(buildEnterpriseTeamOrganizationsID(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, enterpriseTeamOrganizationsIdentity(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))...)
+}
+
+func (r *enterpriseTeamExternalGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, r.readOnly, "delete", "pingoneprovisioning_enterprise_team_external_group") {
+		return
+	}
+	defer reportAPIMetrics(ctx, r.metrics, &resp.Diagnostics)
+
+	var state customtypes.EnterpriseTeamExternalGroupModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	// Unlinking keeps the team and its current members; GitHub stops syncing them from the group.
+	_, httpResp, err := setEnterpriseTeamGroup(ctx, r.client, state.Enterprise.ValueString(), enterpriseTeamExternalGroupRef(state), nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Unlinking Enterprise Team from External Group",
+			err.Error(),
+		)
+	}
+}
+
+func (r *enterpriseTeamExternalGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := utils.SplitImportID(req.ID, 2)
+	if req.ID == "" && req.Identity != nil {
+		// Imported through an `identity` block, for example one generated by `terraform query`.
+		var identity customtypes.EnterpriseTeamOrganizationsIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		parts = []string{identity.Enterprise.ValueString(), identity.TeamSlug.ValueString()}
+	}
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			"Expected import identifier format: <enterprise>/<team_slug>.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("enterprise"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_slug"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildEnterpriseTeamOrganizationsID(parts[0], parts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), types.Int64Null())...)
+}
+
+// enterpriseTeamExternalGroupRef returns the team's numeric ID when known, otherwise its slug.
+func enterpriseTeamExternalGroupRef(model customtypes.EnterpriseTeamExternalGroupModel) string {
+	if !model.TeamId.IsNull() && !model.TeamId.IsUnknown() {
+		return strconv.FormatInt(model.TeamId.ValueInt64(), 10)
+	}
+	return model.TeamSlug.ValueString()
+}
+
+// setEnterpriseTeamGroup links the enterprise team to the external group groupID, or unlinks it
+// when groupID is nil, and returns the updated team.
+func setEnterpriseTeamGroup(ctx context.Context, c *client.GitHubClient, enterprise string, teamRef string, groupID *string) (githubEnterpriseTeamResponse, *http.Response, error) {
+	var team githubEnterpriseTeamResponse
+
+	payload := map[string]interface{}{
+		"group_id": groupID,
+	}
+
+	httpResp, err := c.Do(ctx, http.MethodPatch, enterpriseTeamsPath(enterprise)+"/"+url.PathEscape(strings.TrimSpace(teamRef)), nil, payload)
+	if err != nil {
+		return team, nil, fmt.Errorf("request failed: %s", err)
+	}
+	if httpResp.StatusCode >= 300 {
+		return team, httpResp, fmt.Errorf("API error: %s", githubResponseErrorWithHint(httpResp, c))
+	}
+
+	if err := utils.DecodeResponseJSONInto(httpResp, &team); err != nil {
+		return team, httpResp, fmt.Errorf("could not parse response: %s", err)
+	}
+
+	return team, httpResp, nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+)
+
+func TestSetEnterpriseTeamGroup(t *testing.T) {
+	t.Parallel()
+
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/enterprises/acme/teams/42" {
+			t.Errorf("request = %s %s, want PATCH /enterprises/acme/teams/42", r.Method, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		bodies = append(bodies, body)
+
+		groupID, _ := body["group_id"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "slug": "platform", "group_id": groupID})
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+
+	groupID := "scim-group-1"
+	team, _, err := setEnterpriseTeamGroup(context.Background(), gh, "acme", "42", &groupID)
+	if err != nil {
+		t.Fatalf("link error: %v", err)
+	}
+	if team.Id != 42 || team.GroupId != groupID {
+		t.Fatalf("team = %+v", team)
+	}
+
+	if _, _, err := setEnterpriseTeamGroup(context.Background(), gh, "acme", "42", nil); err != nil {
+		t.Fatalf("unlink error: %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0]["group_id"] != groupID {
+		t.Fatalf("bodies = %v", bodies)
+	}
+	if value, ok := bodies[1]["group_id"]; !ok || value != nil {
+		t.Fatalf("unlink body = %v, want group_id null", bodies[1])
+	}
+}
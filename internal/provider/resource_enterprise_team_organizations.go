@@ -0,0 +1,636 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &enterpriseTeamOrganizationsResource{}
+	_ resource.ResourceWithConfigure   = &enterpriseTeamOrganizationsResource{}
+	_ resource.ResourceWithImportState = &enterpriseTeamOrganizationsResource{}
+	_ resource.ResourceWithModifyPlan  = &enterpriseTeamOrganizationsResource{}
+	_ resource.ResourceWithIdentity    = &enterpriseTeamOrganizationsResource{}
+)
+
+type enterpriseTeamOrganizationsResource struct {
+	client   *client.GitHubClient
+	readOnly client.ReadOnlyMode
+	metrics  *client.APIMetrics
+}
+
+type githubOrganizationResponse struct {
+	Id    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+func NewEnterpriseTeamOrganizationsResource() resource.Resource {
+	return &enterpriseTeamOrganizationsResource{}
+}
+
+func (r *enterpriseTeamOrganizationsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_enterprise_team_organizations"
+	// The identity holds the team slug, which changes when the team is renamed.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *enterpriseTeamOrganizationsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the organizations a GitHub enterprise team is assigned to. Requires a GitHub token configured on the provider.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier in the form `<enterprise>/<team_slug>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enterprise": schema.StringAttribute{
+				Description: "The enterprise slug. Compared case-insensitively.",
+				Required:    true,
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfNotEqualFold(),
+				},
+			},
+			"team_slug": schema.StringAttribute{
+				Description: "The slug of the enterprise team. Compared case-insensitively. When the team is renamed, the new slug is read back through `team_id`; changing this value to the team's new slug updates the resource in place, while changing it to another team's slug requires replacement.",
+				Required:    true,
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+			},
+			"team_id": schema.Int64Attribute{
+				Description: "The numeric ID of the enterprise team. Unlike the slug it does not change when the team is renamed, so it is used to find the team after creation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"organizations": schema.SetAttribute{
+				Description: "The organization slugs the team is assigned to. Compared case-insensitively.",
+				Required:    true,
+				ElementType: customtypes.CaseInsensitiveStringType{},
+			},
+			"authoritative": schema.BoolAttribute{
+				Description: "When `true` (the default), organizations assigned outside Terraform are removed and show as drift. When `false`, only the listed organizations are ensured to be present and any others are left alone, for teams whose organizations are managed by more than one owner.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *enterpriseTeamOrganizationsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = enterpriseTeamIdentitySchema()
+}
+
+// enterpriseTeamIdentitySchema is the identity schema of resources addressed by an enterprise
+// team, matching the `<enterprise>/<team_slug>` of their import identifier.
+func enterpriseTeamIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"enterprise": identityschema.StringAttribute{
+				Description:       "The enterprise slug.",
+				RequiredForImport: true,
+			},
+			"team_slug": identityschema.StringAttribute{
+				Description:       "The slug of the enterprise team.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *enterpriseTeamOrganizationsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData.GitHub
+	r.readOnly = clientData.ReadOnly
+	r.metrics = clientData.Metrics
+}
+
+func (r *enterpriseTeamOrganizationsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, r.readOnly, "pingoneprovisioning_enterprise_team_organizations", req, resp)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state customtypes.EnterpriseTeamOrganizationsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.planTeamSlugChange(ctx, plan, state, resp)
+}
+
+// planTeamSlugChange decides how a change of team_slug is applied. A slug that still names the
+// team recorded in team_id is a rename and is applied in place, as is a slug no team has, which is
+// what a configuration shows after the team was renamed outside Terraform. Any other slug names a
+// different team and requires replacement.
+func (r *enterpriseTeamOrganizationsResource) planTeamSlugChange(ctx context.Context, plan customtypes.EnterpriseTeamOrganizationsModel, state customtypes.EnterpriseTeamOrganizationsModel, resp *resource.ModifyPlanResponse) {
+	plannedSlug := plan.TeamSlug.ValueString()
+	if plan.TeamSlug.IsUnknown() || strings.EqualFold(plannedSlug, state.TeamSlug.ValueString()) {
+		return
+	}
+	// A new enterprise already requires replacement.
+	if plan.Enterprise.IsUnknown() || !strings.EqualFold(plan.Enterprise.ValueString(), state.Enterprise.ValueString()) {
+		return
+	}
+	// State written before team_id was tracked cannot tell a rename from another team.
+	if state.TeamId.IsNull() || state.TeamId.IsUnknown() || r.client == nil {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("team_slug"))
+		return
+	}
+
+	enterprise := plan.Enterprise.ValueString()
+	team, httpResp, err := readEnterpriseTeam(ctx, r.client, enterprise, plannedSlug)
+	switch {
+	case err != nil && httpResp != nil && httpResp.StatusCode == http.StatusNotFound:
+		// The refresh read the team's new slug through team_id while the configuration still has
+		// the old one. The team is still found by team_id, so the change is planned in place
+		// instead of failing every plan until the configuration is edited.
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("team_slug"),
+			"Enterprise Team Renamed",
+			fmt.Sprintf("No team in enterprise %q has the slug %q. The team managed by this resource (ID %d) is currently %q, so it was probably renamed outside Terraform. The plan keeps managing that team in place and records the configured slug; set team_slug to %q to match the team.", enterprise, plannedSlug, state.TeamId.ValueInt64(), state.TeamSlug.ValueString(), state.TeamSlug.ValueString()),
+		)
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Error Reading Enterprise Team",
+			err.Error(),
+		)
+		return
+	case team.Id != state.TeamId.ValueInt64():
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("team_slug"))
+		return
+	default:
+		tflog.Debug(ctx, "Planning enterprise team slug change as a rename", map[string]interface{}{
+			"enterprise": enterprise,
+			"team_id":    team.Id,
+			"from":       state.TeamSlug.ValueString(),
+			"to":         plannedSlug,
+		})
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), buildEnterpriseTeamOrganizationsID(enterprise, plannedSlug))...)
+}
+
+func (r *enterpriseTeamOrganizationsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, r.readOnly, "create", "pingoneprovisioning_enterprise_team_organizations") {
+		return
+	}
+	defer reportAPIMetrics(ctx, r.metrics, &resp.Diagnostics)
+
+	var plan customtypes.EnterpriseTeamOrganizationsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	var desired []string
+	resp.Diagnostics.Append(plan.Organizations.ElementsAs(ctx, &desired, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, _, err := readEnterpriseTeam(ctx, r.client, plan.Enterprise.ValueString(), plan.TeamSlug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Enterprise Team",
+			err.Error(),
+		)
+		return
+	}
+	plan.TeamId = types.Int64Value(team.Id)
+
+	if err := r.reconcile(ctx, plan.Enterprise.ValueString(), enterpriseTeamRef(plan), nil, desired, plan.Authoritative.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Assigning Enterprise Team Organizations",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(buildEnterpriseTeamOrganizationsID(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, enterpriseTeamOrganizationsIdentity(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))...)
+}
+
+func (r *enterpriseTeamOrganizationsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.EnterpriseTeamOrganizationsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	// The team is found by ID when known, so a team renamed outside Terraform is still found.
+	team, httpResp, err := readEnterpriseTeam(ctx, r.client, state.Enterprise.ValueString(), enterpriseTeamRef(state))
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Enterprise Team",
+			err.Error(),
+		)
+		return
+	}
+	state.TeamId = types.Int64Value(team.Id)
+	if team.Slug != "" && !strings.EqualFold(team.Slug, state.TeamSlug.ValueString()) {
+		tflog.Info(ctx, "Enterprise team was renamed", map[string]interface{}{
+			"enterprise": state.Enterprise.ValueString(),
+			"team_id":    team.Id,
+			"from":       state.TeamSlug.ValueString(),
+			"to":         team.Slug,
+		})
+		state.TeamSlug = customtypes.NewCaseInsensitiveStringValue(team.Slug)
+	}
+
+	actual, httpResp, err := listEnterpriseTeamOrganizations(ctx, r.client, state.Enterprise.ValueString(), enterpriseTeamRef(state))
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Enterprise Team Organizations",
+			err.Error(),
+		)
+		return
+	}
+
+	authoritative := state.Authoritative.IsNull() || state.Authoritative.ValueBool()
+
+	var tracked []string
+	if !state.Organizations.IsNull() && !state.Organizations.IsUnknown() {
+		resp.Diagnostics.Append(state.Organizations.ElementsAs(ctx, &tracked, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	organizations := enterpriseTeamOrganizationsForState(tracked, actual, authoritative)
+
+	orgSet, diags := types.SetValueFrom(ctx, customtypes.CaseInsensitiveStringType{}, organizations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Organizations = orgSet
+	state.Authoritative = types.BoolValue(authoritative)
+	state.Id = types.StringValue(buildEnterpriseTeamOrganizationsID(state.Enterprise.ValueString(), state.TeamSlug.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, enterpriseTeamOrganizationsIdentity(state.Enterprise.ValueString(), state.TeamSlug.ValueString()))...)
+}
+
+func (r *enterpriseTeamOrganizationsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, r.readOnly, "update", "pingoneprovisioning_enterprise_team_organizations") {
+		return
+	}
+	defer reportAPIMetrics(ctx, r.metrics, &resp.Diagnostics)
+
+	var plan customtypes.EnterpriseTeamOrganizationsModel
+	var state customtypes.EnterpriseTeamOrganizationsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	var desired, prior []string
+	resp.Diagnostics.Append(plan.Organizations.ElementsAs(ctx, &desired, false)...)
+	resp.Diagnostics.Append(state.Organizations.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.reconcile(ctx, plan.Enterprise.ValueString(), enterpriseTeamRef(plan), prior, desired, plan.Authoritative.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Enterprise Team Organizations",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(buildEnterpriseTeamOrganizationsID(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, enterpriseTeamOrganizationsIdentity(plan.Enterprise.ValueString(), plan.TeamSlug.ValueString()))...)
+}
+
+func (r *enterpriseTeamOrganizationsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, r.readOnly, "delete", "pingoneprovisioning_enterprise_team_organizations") {
+		return
+	}
+	defer reportAPIMetrics(ctx, r.metrics, &resp.Diagnostics)
+
+	var state customtypes.EnterpriseTeamOrganizationsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !requireGitHubClient(&resp.Diagnostics, r.client) {
+		return
+	}
+
+	var tracked []string
+	resp.Diagnostics.Append(state.Organizations.ElementsAs(ctx, &tracked, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the organizations recorded in state are removed; in authoritative mode that is every
+	// assignment, otherwise assignments made outside Terraform are left in place.
+	httpResp, err := changeEnterpriseTeamOrganizations(ctx, r.client, state.Enterprise.ValueString(), enterpriseTeamRef(state), "remove", tracked)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Removing Enterprise Team Organizations",
+			err.Error(),
+		)
+	}
+}
+
+func (r *enterpriseTeamOrganizationsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := utils.SplitImportID(req.ID, 2)
+	if req.ID == "" && req.Identity != nil {
+		// Imported through an `identity` block, for example one generated by `terraform query`.
+		var identity customtypes.EnterpriseTeamOrganizationsIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		parts = []string{identity.Enterprise.ValueString(), identity.TeamSlug.ValueString()}
+	}
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			"Expected import identifier format: <enterprise>/<team_slug>.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("enterprise"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_slug"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildEnterpriseTeamOrganizationsID(parts[0], parts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), types.Int64Null())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("authoritative"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organizations"), types.SetNull(customtypes.CaseInsensitiveStringType{}))...)
+}
+
+// reconcile adds the desired organizations that are missing from the team. In authoritative
+// mode every other organization is removed; otherwise only organizations dropped from the
+// configuration since the last apply (prior minus desired) are removed.
+func (r *enterpriseTeamOrganizationsResource) reconcile(ctx context.Context, enterprise string, teamRef string, prior []string, desired []string, authoritative bool) error {
+	actual, _, err := listEnterpriseTeamOrganizations(ctx, r.client, enterprise, teamRef)
+	if err != nil {
+		return err
+	}
+
+	toAdd, toRemove := enterpriseTeamOrganizationChanges(prior, desired, actual, authoritative)
+
+	tflog.Debug(ctx, "Reconciling enterprise team organizations", map[string]interface{}{
+		"enterprise":    enterprise,
+		"team":          teamRef,
+		"authoritative": authoritative,
+		"add":           toAdd,
+		"remove":        toRemove,
+	})
+
+	if _, err := changeEnterpriseTeamOrganizations(ctx, r.client, enterprise, teamRef, "add", toAdd); err != nil {
+		return err
+	}
+	if _, err := changeEnterpriseTeamOrganizations(ctx, r.client, enterprise, teamRef, "remove", toRemove); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// enterpriseTeamOrganizationChanges computes which organizations to add and remove. GitHub
+// slugs are case-insensitive, so comparisons ignore case.
+func enterpriseTeamOrganizationChanges(prior []string, desired []string, actual []string, authoritative bool) ([]string, []string) {
+	desiredSet := lowerSet(desired)
+	actualSet := lowerSet(actual)
+
+	var toAdd []string
+	for _, org := range desired {
+		if !actualSet[strings.ToLower(org)] {
+			toAdd = append(toAdd, org)
+		}
+	}
+
+	var toRemove []string
+	if authoritative {
+		for _, org := range actual {
+			if !desiredSet[strings.ToLower(org)] {
+				toRemove = append(toRemove, org)
+			}
+		}
+	} else {
+		for _, org := range prior {
+			if !desiredSet[strings.ToLower(org)] && actualSet[strings.ToLower(org)] {
+				toRemove = append(toRemove, org)
+			}
+		}
+	}
+
+	sort.Strings(toAdd)
+	sort.Strings(toRemove)
+	return toAdd, toRemove
+}
+
+// enterpriseTeamOrganizationsForState returns the organizations to record in state. In
+// authoritative mode that is every assigned organization, so extras show as drift. Otherwise
+// it is the tracked organizations that are still assigned, so only missing ones show as drift.
+func enterpriseTeamOrganizationsForState(tracked []string, actual []string, authoritative bool) []string {
+	if authoritative {
+		out := append([]string{}, actual...)
+		// Keep the configured spelling for organizations that are still assigned.
+		trackedByLower := make(map[string]string, len(tracked))
+		for _, org := range tracked {
+			trackedByLower[strings.ToLower(org)] = org
+		}
+		for i, org := range out {
+			if v, ok := trackedByLower[strings.ToLower(org)]; ok {
+				out[i] = v
+			}
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	actualSet := lowerSet(actual)
+	out := make([]string, 0, len(tracked))
+	for _, org := range tracked {
+		if actualSet[strings.ToLower(org)] {
+			out = append(out, org)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// requiresReplaceIfNotEqualFold requires replacement when a slug changes, ignoring changes
+// that only differ by case.
+func requiresReplaceIfNotEqualFold() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !strings.EqualFold(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"Changing the value (other than its case) requires replacement.",
+		"Changing the value (other than its case) requires replacement.",
+	)
+}
+
+func enterpriseTeamOrganizationsIdentity(enterprise string, teamSlug string) customtypes.EnterpriseTeamOrganizationsIdentityModel {
+	return customtypes.EnterpriseTeamOrganizationsIdentityModel{
+		Enterprise: types.StringValue(enterprise),
+		TeamSlug:   types.StringValue(teamSlug),
+	}
+}
+
+// enterpriseTeamRef returns the value used for the team in API paths: its numeric ID when known,
+// since the ID survives renames, otherwise its slug. GitHub accepts either.
+func enterpriseTeamRef(model customtypes.EnterpriseTeamOrganizationsModel) string {
+	if !model.TeamId.IsNull() && !model.TeamId.IsUnknown() {
+		return strconv.FormatInt(model.TeamId.ValueInt64(), 10)
+	}
+	return model.TeamSlug.ValueString()
+}
+
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(v)] = true
+	}
+	return set
+}
+
+// readEnterpriseTeam reads one enterprise team by slug or numeric ID.
+func readEnterpriseTeam(ctx context.Context, c *client.GitHubClient, enterprise string, teamRef string) (githubEnterpriseTeamResponse, *http.Response, error) {
+	var team githubEnterpriseTeamResponse
+
+	httpResp, err := c.Do(ctx, http.MethodGet, enterpriseTeamsPath(enterprise)+"/"+url.PathEscape(strings.TrimSpace(teamRef)), nil, nil)
+	if err != nil {
+		return team, nil, fmt.Errorf("request failed: %s", err)
+	}
+	if httpResp.StatusCode >= 300 {
+		return team, httpResp, fmt.Errorf("API error: %s", githubResponseErrorWithHint(httpResp, c))
+	}
+
+	if err := utils.DecodeResponseJSONInto(httpResp, &team); err != nil {
+		return team, httpResp, fmt.Errorf("could not parse response: %s", err)
+	}
+
+	return team, httpResp, nil
+}
+
+func listEnterpriseTeamOrganizations(ctx context.Context, c *client.GitHubClient, enterprise string, teamRef string) ([]string, *http.Response, error) {
+	var orgs []string
+	var lastResp *http.Response
+
+	err := c.ListAll(ctx, enterpriseTeamOrganizationsPath(enterprise, teamRef), nil, func(httpResp *http.Response) error {
+		lastResp = httpResp
+		if httpResp.StatusCode >= 300 {
+			return fmt.Errorf("API error: %s", githubResponseErrorWithHint(httpResp, c))
+		}
+
+		var page []githubOrganizationResponse
+		if err := utils.DecodeResponseJSONInto(httpResp, &page); err != nil {
+			return fmt.Errorf("could not parse response: %s", err)
+		}
+
+		for _, org := range page {
+			if org.Login != "" {
+				orgs = append(orgs, org.Login)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, lastResp, err
+	}
+
+	return orgs, lastResp, nil
+}
+
+// changeEnterpriseTeamOrganizations calls the bulk add or remove endpoint. action is `add` or
+// `remove`; an empty list is a no-op.
+func changeEnterpriseTeamOrganizations(ctx context.Context, c *client.GitHubClient, enterprise string, teamRef string, action string, orgs []string) (*http.Response, error) {
+	if len(orgs) == 0 {
+		return nil, nil
+	}
+
+	payload := map[string]interface{}{
+		"organization_slugs": orgs,
+	}
+
+	httpResp, err := c.Do(ctx, http.MethodPost, enterpriseTeamOrganizationsPath(enterprise, teamRef)+"/"+action, nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %s", err)
+	}
+	if httpResp.StatusCode >= 300 {
+		return httpResp, fmt.Errorf("%s organizations: %s", action, githubResponseErrorWithHint(httpResp, c))
+	}
+
+	return httpResp, nil
+}
+
+func enterpriseTeamOrganizationsPath(enterprise string, teamRef string) string {
+	return fmt.Sprintf("/enterprises/%s/teams/%s/organizations", url.PathEscape(strings.TrimSpace(enterprise)), url.PathEscape(strings.TrimSpace(teamRef)))
+}
+
+func buildEnterpriseTeamOrganizationsID(enterprise string, teamSlug string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSpace(enterprise), strings.TrimSpace(teamSlug))
+}
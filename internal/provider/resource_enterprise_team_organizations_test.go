@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEnterpriseTeamOrganizationChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		prior         []string
+		desired       []string
+		actual        []string
+		authoritative bool
+		wantAdd       []string
+		wantRemove    []string
+	}{
+		{
+			name:          "authoritative_removes_extras",
+			desired:       []string{"org-a", "org-b"},
+			actual:        []string{"org-a", "manual-org"},
+			authoritative: true,
+			wantAdd:       []string{"org-b"},
+			wantRemove:    []string{"manual-org"},
+		},
+		{
+			name:          "non_authoritative_keeps_extras",
+			desired:       []string{"org-a", "org-b"},
+			actual:        []string{"org-a", "manual-org"},
+			authoritative: false,
+			wantAdd:       []string{"org-b"},
+		},
+		{
+			name:          "non_authoritative_removes_dropped",
+			prior:         []string{"org-a", "org-b"},
+			desired:       []string{"org-a"},
+			actual:        []string{"org-a", "org-b", "manual-org"},
+			authoritative: false,
+			wantRemove:    []string{"org-b"},
+		},
+		{
+			name:          "case_insensitive",
+			desired:       []string{"MyOrg"},
+			actual:        []string{"myorg"},
+			authoritative: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := enterpriseTeamOrganizationChanges(tt.prior, tt.desired, tt.actual, tt.authoritative)
+			if !reflect.DeepEqual(add, tt.wantAdd) {
+				t.Fatalf("add = %v, want %v", add, tt.wantAdd)
+			}
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Fatalf("remove = %v, want %v", remove, tt.wantRemove)
+			}
+		})
+	}
+}
+
+func TestEnterpriseTeamOrganizationsForState(t *testing.T) {
+	t.Parallel()
+
+	actual := []string{"manual-org", "org-a"}
+
+	if got := enterpriseTeamOrganizationsForState([]string{"org-a", "org-b"}, actual, true); !reflect.DeepEqual(got, []string{"manual-org", "org-a"}) {
+		t.Fatalf("authoritative = %v", got)
+	}
+	if got := enterpriseTeamOrganizationsForState([]string{"org-a", "org-b"}, actual, false); !reflect.DeepEqual(got, []string{"org-a"}) {
+		t.Fatalf("non-authoritative = %v", got)
+	}
+}
+
+func TestCaseInsensitiveStringSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	prior := customtypes.NewCaseInsensitiveStringValue("MyOrg")
+
+	equal, diags := prior.StringSemanticEquals(context.Background(), customtypes.NewCaseInsensitiveStringValue("myorg"))
+	if diags.HasError() || !equal {
+		t.Fatalf("MyOrg vs myorg: equal = %v, diags = %v", equal, diags)
+	}
+
+	equal, diags = prior.StringSemanticEquals(context.Background(), customtypes.NewCaseInsensitiveStringValue("other-org"))
+	if diags.HasError() || equal {
+		t.Fatalf("MyOrg vs other-org: equal = %v, diags = %v", equal, diags)
+	}
+}
+
+func TestReadEnterpriseTeam_ByIDAfterRename(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/enterprises/acme/teams/42" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":42,"name":"Platform Engineering","slug":"platform-engineering"}`))
+	}))
+	defer server.Close()
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+
+	model := customtypes.EnterpriseTeamOrganizationsModel{
+		TeamSlug: customtypes.NewCaseInsensitiveStringValue("platform"),
+		TeamId:   types.Int64Value(42),
+	}
+	if ref := enterpriseTeamRef(model); ref != "42" {
+		t.Fatalf("ref = %q, want 42", ref)
+	}
+
+	team, _, err := readEnterpriseTeam(context.Background(), gh, "acme", enterpriseTeamRef(model))
+	if err != nil {
+		t.Fatalf("readEnterpriseTeam error: %v", err)
+	}
+	if team.Id != 42 || team.Slug != "platform-engineering" {
+		t.Fatalf("team = %+v", team)
+	}
+
+	// Without a team ID the old slug is used and the renamed team is not found.
+	model.TeamId = types.Int64Null()
+	_, httpResp, err := readEnterpriseTeam(context.Background(), gh, "acme", enterpriseTeamRef(model))
+	if err == nil || httpResp == nil || httpResp.StatusCode != http.StatusNotFound {
+		t.Fatalf("slug lookup: err = %v, resp = %v", err, httpResp)
+	}
+}
+
+func TestEnterpriseTeamOrganizationsPlanTeamSlugChange(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/enterprises/acme/teams/platform-engineering":
+			_, _ = w.Write([]byte(`{"id":42,"name":"Platform Engineering","slug":"platform-engineering"}`))
+		case "/enterprises/acme/teams/security":
+			_, _ = w.Write([]byte(`{"id":7,"name":"Security","slug":"security"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	gh, err := client.NewGitHubClient("token", server.URL, "", "", server.Client())
+	if err != nil {
+		t.Fatalf("NewGitHubClient: %v", err)
+	}
+	r := &enterpriseTeamOrganizationsResource{client: gh}
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name          string
+		stateSlug     string
+		plannedSlug   string
+		wantReplace   bool
+		wantWarning   bool
+		wantPlannedID string
+	}{
+		{name: "rename", stateSlug: "platform", plannedSlug: "platform-engineering", wantPlannedID: "acme/platform-engineering"},
+		{name: "renamed_outside_terraform", stateSlug: "platform-engineering", plannedSlug: "platform", wantWarning: true, wantPlannedID: "acme/platform"},
+		{name: "other_team", stateSlug: "platform-engineering", plannedSlug: "security", wantReplace: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := customtypes.EnterpriseTeamOrganizationsModel{
+				Id:            types.StringValue(buildEnterpriseTeamOrganizationsID("acme", tt.stateSlug)),
+				Enterprise:    customtypes.NewCaseInsensitiveStringValue("acme"),
+				TeamSlug:      customtypes.NewCaseInsensitiveStringValue(tt.stateSlug),
+				TeamId:        types.Int64Value(42),
+				Organizations: types.SetNull(customtypes.CaseInsensitiveStringType{}),
+				Authoritative: types.BoolValue(true),
+			}
+			plan := state
+			plan.TeamSlug = customtypes.NewCaseInsensitiveStringValue(tt.plannedSlug)
+
+			resp := &resource.ModifyPlanResponse{
+				Plan: tfsdk.Plan{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			if diags := resp.Plan.Set(ctx, &plan); diags.HasError() {
+				t.Fatalf("Plan.Set: %v", diags)
+			}
+
+			r.planTeamSlugChange(ctx, plan, state, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if got := len(resp.Diagnostics.Warnings()) > 0; got != tt.wantWarning {
+				t.Fatalf("warning = %v, want %v (diags: %v)", got, tt.wantWarning, resp.Diagnostics)
+			}
+			if got := len(resp.RequiresReplace) > 0; got != tt.wantReplace {
+				t.Fatalf("requires replace = %v, want %v", got, tt.wantReplace)
+			}
+			if tt.wantPlannedID != "" {
+				var id types.String
+				if diags := resp.Plan.GetAttribute(ctx, path.Root("id"), &id); diags.HasError() {
+					t.Fatalf("GetAttribute(id): %v", diags)
+				}
+				if id.ValueString() != tt.wantPlannedID {
+					t.Fatalf("planned id = %s, want %s", id, tt.wantPlannedID)
+				}
+			}
+		})
+	}
+}
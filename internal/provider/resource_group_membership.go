@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ resource.Resource                = &groupMembershipResource{}
+	_ resource.ResourceWithConfigure   = &groupMembershipResource{}
+	_ resource.ResourceWithImportState = &groupMembershipResource{}
+	_ resource.ResourceWithModifyPlan  = &groupMembershipResource{}
+)
+
+type groupMembershipResource struct {
+	client *client.Client
+}
+
+func NewGroupMembershipResource() resource.Resource {
+	return &groupMembershipResource{}
+}
+
+func (r *groupMembershipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_membership"
+}
+
+func (r *groupMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the membership of one PingOne user in one group.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier in the form `<user_id>/<group_id>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *groupMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData
+}
+
+func (r *groupMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_group_membership", req, resp)
+	deferUnknownPlan(req, resp, "environment_id")
+}
+
+func (r *groupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_group_membership") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.GroupMembershipModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := plan.UserId.ValueString()
+	groupID := plan.GroupId.ValueString()
+
+	httpResp, err := addUserToGroup(ctx, r.client.API, plan.EnvironmentId.ValueString(), userID, groupID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Adding Group Membership",
+			fmt.Sprintf("Could not add user '%s' to group '%s': %s", userID, groupID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(buildGroupMembershipID(userID, groupID))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *groupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.GroupMembershipModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := state.UserId.ValueString()
+	groupID := state.GroupId.ValueString()
+
+	member, httpResp, err := isUserInGroup(ctx, r.client.API, state.EnvironmentId.ValueString(), userID, groupID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group Membership",
+			fmt.Sprintf("Could not read membership of user '%s' in group '%s': %s", userID, groupID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+	if !member {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(buildGroupMembershipID(userID, groupID))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *groupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_group_membership") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	// Every argument requires replacement, so there is nothing to update in place.
+	var plan customtypes.GroupMembershipModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(buildGroupMembershipID(plan.UserId.ValueString(), plan.GroupId.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *groupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_group_membership") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var state customtypes.GroupMembershipModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := state.UserId.ValueString()
+	groupID := state.GroupId.ValueString()
+
+	httpResp, err := removeUserFromGroup(ctx, r.client.API, state.EnvironmentId.ValueString(), userID, groupID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Removing Group Membership",
+			fmt.Sprintf("Could not remove user '%s' from group '%s': %s", userID, groupID, utils.HandleSDKError(err, httpResp)),
+		)
+	}
+}
+
+func (r *groupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := utils.SplitImportID(req.ID, 3)
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			"Expected import identifier format: <environment_id>/<user_id>/<group_id>.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildGroupMembershipID(parts[1], parts[2]))...)
+}
+
+func buildGroupMembershipID(userID string, groupID string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSpace(userID), strings.TrimSpace(groupID))
+}
+
+// addUserToGroup adds a user to a group. Adding a user who is already a member succeeds.
+func addUserToGroup(ctx context.Context, apiClient *management.APIClient, environmentID string, userID string, groupID string) (*http.Response, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("nil api client")
+	}
+
+	_, httpResp, err := apiClient.GroupMembershipApi.
+		AddUserToGroup(ctx, environmentID, userID).
+		GroupMembership(*management.NewGroupMembership(groupID)).
+		Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		if httpResp != nil && httpResp.StatusCode == http.StatusConflict {
+			return httpResp, nil
+		}
+		return httpResp, err
+	}
+
+	return httpResp, nil
+}
+
+// removeUserFromGroup removes a user from a group. A membership that no longer exists is not an
+// error.
+func removeUserFromGroup(ctx context.Context, apiClient *management.APIClient, environmentID string, userID string, groupID string) (*http.Response, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("nil api client")
+	}
+
+	httpResp, err := apiClient.GroupMembershipApi.
+		RemoveUserFromGroup(ctx, environmentID, userID, groupID).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return httpResp, nil
+		}
+		return httpResp, err
+	}
+
+	return httpResp, nil
+}
+
+// isUserInGroup reports whether the user is a direct member of the group. A missing user or
+// group is reported as not a member.
+func isUserInGroup(ctx context.Context, apiClient *management.APIClient, environmentID string, userID string, groupID string) (bool, *http.Response, error) {
+	if apiClient == nil {
+		return false, nil, fmt.Errorf("nil api client")
+	}
+
+	_, httpResp, err := apiClient.GroupMembershipApi.
+		ReadOneGroupMembershipForUser(ctx, environmentID, userID, groupID).
+		Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return false, httpResp, nil
+		}
+		return false, httpResp, err
+	}
+
+	return true, httpResp, nil
+}
@@ -0,0 +1,395 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ resource.Resource                = &groupMembershipsResource{}
+	_ resource.ResourceWithConfigure   = &groupMembershipsResource{}
+	_ resource.ResourceWithImportState = &groupMembershipsResource{}
+	_ resource.ResourceWithModifyPlan  = &groupMembershipsResource{}
+)
+
+type groupMembershipsResource struct {
+	client *client.Client
+}
+
+func NewGroupMembershipsResource() resource.Resource {
+	return &groupMembershipsResource{}
+}
+
+func (r *groupMembershipsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_memberships"
+}
+
+func (r *groupMembershipsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of PingOne users as members of one group. Members added outside Terraform are left alone.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier in the form `<environment_id>/<group_id>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_ids": schema.SetAttribute{
+				Description: "The IDs of the users that must be members of the group. Removing an ID removes only that user from the group.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *groupMembershipsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData
+}
+
+func (r *groupMembershipsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_group_memberships", req, resp)
+	deferUnknownPlan(req, resp, "environment_id")
+}
+
+func (r *groupMembershipsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_group_memberships") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.GroupMembershipsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired []string
+	resp.Diagnostics.Append(plan.UserIds.ElementsAs(ctx, &desired, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := r.reconcile(ctx, plan.EnvironmentId.ValueString(), plan.GroupId.ValueString(), nil, desired)
+	resp.Diagnostics.Append(diags...)
+
+	// Users that could not be added are left out of state so the next plan retries them.
+	r.setState(ctx, &resp.State, &resp.Diagnostics, plan, members)
+}
+
+func (r *groupMembershipsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.GroupMembershipsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	environmentID := state.EnvironmentId.ValueString()
+	groupID := state.GroupId.ValueString()
+
+	_, httpResp, err := apiClient.GroupsApi.ReadOneGroup(ctx, environmentID, groupID).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode >= 300) {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Group",
+			fmt.Sprintf("Could not read group '%s': %s", groupID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	var members []string
+	if state.UserIds.IsNull() || state.UserIds.IsUnknown() {
+		// After import every current member of the group is adopted.
+		members, err = listGroupMemberUserIDs(ctx, apiClient, environmentID, groupID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Group Memberships",
+				fmt.Sprintf("Could not list members of group '%s': %s", groupID, err),
+			)
+			return
+		}
+	} else {
+		var tracked []string
+		resp.Diagnostics.Append(state.UserIds.ElementsAs(ctx, &tracked, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, userID := range tracked {
+			member, memberResp, err := isUserInGroup(ctx, apiClient, environmentID, userID, groupID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Group Memberships",
+					fmt.Sprintf("Could not read membership of user '%s' in group '%s': %s", userID, groupID, utils.HandleSDKError(err, memberResp)),
+				)
+				return
+			}
+			if member {
+				members = append(members, userID)
+			}
+		}
+	}
+
+	r.setState(ctx, &resp.State, &resp.Diagnostics, state, members)
+}
+
+func (r *groupMembershipsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_group_memberships") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.GroupMembershipsModel
+	var state customtypes.GroupMembershipsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired, prior []string
+	resp.Diagnostics.Append(plan.UserIds.ElementsAs(ctx, &desired, false)...)
+	resp.Diagnostics.Append(state.UserIds.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := r.reconcile(ctx, plan.EnvironmentId.ValueString(), plan.GroupId.ValueString(), prior, desired)
+	resp.Diagnostics.Append(diags...)
+
+	r.setState(ctx, &resp.State, &resp.Diagnostics, plan, members)
+}
+
+func (r *groupMembershipsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_group_memberships") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var state customtypes.GroupMembershipsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tracked []string
+	resp.Diagnostics.Append(state.UserIds.ElementsAs(ctx, &tracked, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.reconcile(ctx, state.EnvironmentId.ValueString(), state.GroupId.ValueString(), tracked, nil)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *groupMembershipsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := utils.SplitImportID(req.ID, 2)
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			"Expected import identifier format: <environment_id>/<group_id>.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildGroupMembershipsID(parts[0], parts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_ids"), types.SetNull(types.StringType))...)
+}
+
+// reconcile adds the desired users that were not tracked before and removes the tracked users
+// that are no longer desired. It carries on past individual failures and returns the users that
+// are members afterwards, as far as this resource knows.
+func (r *groupMembershipsResource) reconcile(ctx context.Context, environmentID string, groupID string, prior []string, desired []string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	toAdd, toRemove := groupMembershipChanges(prior, desired)
+
+	tflog.Debug(ctx, "Reconciling group memberships", map[string]interface{}{
+		"group_id": groupID,
+		"add":      len(toAdd),
+		"remove":   len(toRemove),
+	})
+
+	members := make(map[string]bool, len(prior)+len(toAdd))
+	for _, userID := range prior {
+		members[userID] = true
+	}
+
+	for _, userID := range toRemove {
+		httpResp, err := removeUserFromGroup(ctx, r.client.API, environmentID, userID, groupID)
+		if err != nil {
+			diags.AddError(
+				"Error Removing Group Membership",
+				fmt.Sprintf("Could not remove user '%s' from group '%s': %s", userID, groupID, utils.HandleSDKError(err, httpResp)),
+			)
+			continue
+		}
+		delete(members, userID)
+	}
+
+	for _, userID := range toAdd {
+		httpResp, err := addUserToGroup(ctx, r.client.API, environmentID, userID, groupID)
+		if err != nil {
+			diags.AddError(
+				"Error Adding Group Membership",
+				fmt.Sprintf("Could not add user '%s' to group '%s': %s", userID, groupID, utils.HandleSDKError(err, httpResp)),
+			)
+			continue
+		}
+		members[userID] = true
+	}
+
+	out := make([]string, 0, len(members))
+	for userID := range members {
+		out = append(out, userID)
+	}
+	sort.Strings(out)
+
+	return out, diags
+}
+
+func (r *groupMembershipsResource) setState(ctx context.Context, state *tfsdk.State, diags *diag.Diagnostics, model customtypes.GroupMembershipsModel, members []string) {
+	if members == nil {
+		members = []string{}
+	}
+
+	userIDs, setDiags := types.SetValueFrom(ctx, types.StringType, members)
+	diags.Append(setDiags...)
+	if setDiags.HasError() {
+		return
+	}
+
+	model.UserIds = userIDs
+	model.Id = types.StringValue(buildGroupMembershipsID(model.EnvironmentId.ValueString(), model.GroupId.ValueString()))
+
+	diags.Append(state.Set(ctx, &model)...)
+}
+
+// groupMembershipChanges returns the users to add (desired but not previously tracked) and to
+// remove (previously tracked but no longer desired), sorted.
+func groupMembershipChanges(prior []string, desired []string) ([]string, []string) {
+	priorSet := make(map[string]bool, len(prior))
+	for _, userID := range prior {
+		priorSet[userID] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, userID := range desired {
+		desiredSet[userID] = true
+	}
+
+	var toAdd, toRemove []string
+	for userID := range desiredSet {
+		if !priorSet[userID] {
+			toAdd = append(toAdd, userID)
+		}
+	}
+	for userID := range priorSet {
+		if !desiredSet[userID] {
+			toRemove = append(toRemove, userID)
+		}
+	}
+	sort.Strings(toAdd)
+	sort.Strings(toRemove)
+
+	return toAdd, toRemove
+}
+
+// listGroupMemberUserIDs returns the IDs of the users who are direct members of the group.
+func listGroupMemberUserIDs(ctx context.Context, apiClient *management.APIClient, environmentID string, groupID string) ([]string, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("nil api client")
+	}
+
+	filter := fmt.Sprintf(`memberOfGroups[id eq "%s"]`, strings.ReplaceAll(groupID, `"`, `\"`))
+
+	var ids []string
+	for cursor, iterErr := range apiClient.UsersApi.ReadAllUsers(ctx, environmentID).Filter(filter).Execute() {
+		if iterErr != nil {
+			return nil, iterErr
+		}
+		if cursor.HTTPResponse == nil {
+			continue
+		}
+
+		decoded, err := utils.DecodeResponseJSON(cursor.HTTPResponse)
+		if err != nil {
+			return nil, err
+		}
+		list, err := utils.ExtractEmbeddedArray(decoded, "users")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, ok := utils.NestedString(m, "id"); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func buildGroupMembershipsID(environmentID string, groupID string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSpace(environmentID), strings.TrimSpace(groupID))
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGroupMembershipChanges(t *testing.T) {
+	t.Parallel()
+
+	toAdd, toRemove := groupMembershipChanges(
+		[]string{"user-a", "user-b", "user-c"},
+		[]string{"user-c", "user-d", "user-b", "user-d"},
+	)
+
+	if want := []string{"user-d"}; !reflect.DeepEqual(toAdd, want) {
+		t.Fatalf("toAdd = %v, want %v", toAdd, want)
+	}
+	if want := []string{"user-a"}; !reflect.DeepEqual(toRemove, want) {
+		t.Fatalf("toRemove = %v, want %v", toRemove, want)
+	}
+}
+
+func TestGroupMembershipHelpers(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{}`
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/environments/env-id/users/member-id/memberOfGroups":
+			status, body = http.StatusConflict, `{"code":"UNIQUENESS_VIOLATION"}`
+		case "POST /v1/environments/env-id/users/new-id/memberOfGroups":
+			status, body = http.StatusCreated, `{"id":"group-id"}`
+		case "DELETE /v1/environments/env-id/users/gone-id/memberOfGroups/group-id":
+			status, body = http.StatusNotFound, `{"code":"NOT_FOUND"}`
+		case "GET /v1/environments/env-id/users/member-id/memberOfGroups/group-id":
+			body = `{"id":"group-id"}`
+		case "GET /v1/environments/env-id/users/gone-id/memberOfGroups/group-id":
+			status, body = http.StatusNotFound, `{"code":"NOT_FOUND"}`
+		case "GET /v1/environments/env-id/users":
+			if got := r.URL.Query().Get("filter"); got != `memberOfGroups[id eq "group-id"]` {
+				t.Errorf("filter = %q", got)
+			}
+			body = `{"_embedded":{"users":[{"id":"user-b"},{"id":"user-a"}]}}`
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		return testResponse(r, status, body), nil
+	})
+
+	ctx := context.Background()
+
+	if _, err := addUserToGroup(ctx, apiClient, "env-id", "member-id", "group-id"); err != nil {
+		t.Fatalf("addUserToGroup(existing member): %v", err)
+	}
+	if _, err := addUserToGroup(ctx, apiClient, "env-id", "new-id", "group-id"); err != nil {
+		t.Fatalf("addUserToGroup(new member): %v", err)
+	}
+	if _, err := removeUserFromGroup(ctx, apiClient, "env-id", "gone-id", "group-id"); err != nil {
+		t.Fatalf("removeUserFromGroup(missing membership): %v", err)
+	}
+
+	if member, _, err := isUserInGroup(ctx, apiClient, "env-id", "member-id", "group-id"); err != nil || !member {
+		t.Fatalf("isUserInGroup(member) = %v, %v", member, err)
+	}
+	if member, _, err := isUserInGroup(ctx, apiClient, "env-id", "gone-id", "group-id"); err != nil || member {
+		t.Fatalf("isUserInGroup(non-member) = %v, %v", member, err)
+	}
+
+	ids, err := listGroupMemberUserIDs(ctx, apiClient, "env-id", "group-id")
+	if err != nil {
+		t.Fatalf("listGroupMemberUserIDs: %v", err)
+	}
+	if want := []string{"user-a", "user-b"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+}
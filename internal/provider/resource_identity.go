@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// environmentResourceIdentitySchema is the identity schema of resources addressed by their
+// environment and ID, such as the `<environment_id>/<id>` of their import identifier. object
+// names the resource in descriptions, for example "propagation store".
+func environmentResourceIdentitySchema(object string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"environment_id": identityschema.StringAttribute{
+				Description:       fmt.Sprintf("The ID of the environment of the %s.", object),
+				RequiredForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       fmt.Sprintf("The ID of the %s.", object),
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func environmentResourceIdentity(environmentID types.String, id types.String) customtypes.EnvironmentResourceIdentityModel {
+	return customtypes.EnvironmentResourceIdentityModel{
+		EnvironmentId: environmentID,
+		Id:            id,
+	}
+}
+
+// environmentResourceImportID returns the import identifier of req: its ID, or
+// `<environment_id>/<id>` when the resource is imported through an `identity` block, for
+// example one generated by `terraform query`.
+func environmentResourceImportID(ctx context.Context, req resource.ImportStateRequest, diags *diag.Diagnostics) string {
+	if req.ID != "" || req.Identity == nil {
+		return req.ID
+	}
+
+	var identity customtypes.EnvironmentResourceIdentityModel
+	diags.Append(req.Identity.Get(ctx, &identity)...)
+	return identity.EnvironmentId.ValueString() + "/" + identity.Id.ValueString()
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPropagationStoreResourceImportState_Identity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &propagationStoreResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identity := emptyResourceIdentity(t, r)
+	if diags := identity.Set(ctx, environmentResourceIdentity(types.StringValue("env-id"), types.StringValue("store-1"))); diags.HasError() {
+		t.Fatalf("Identity.Set: %v", diags)
+	}
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+		Identity: identity,
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", resp.Diagnostics)
+	}
+
+	for attr, want := range map[string]string{"environment_id": "env-id", "id": "store-1"} {
+		var got types.String
+		if diags := resp.State.GetAttribute(ctx, path.Root(attr), &got); diags.HasError() {
+			t.Fatalf("GetAttribute(%s): %v", attr, diags)
+		}
+		if got.ValueString() != want {
+			t.Fatalf("%s = %q, want %q", attr, got.ValueString(), want)
+		}
+	}
+
+	var got customtypes.EnvironmentResourceIdentityModel
+	if diags := resp.Identity.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("Identity.Get: %v", diags)
+	}
+	if got.EnvironmentId.ValueString() != "env-id" || got.Id.ValueString() != "store-1" {
+		t.Fatalf("identity = %+v, want env-id/store-1", got)
+	}
+}
+
+// emptyResourceIdentity returns a null identity of r, as the framework passes to resources that
+// have not set theirs yet.
+func emptyResourceIdentity(t *testing.T, r resource.ResourceWithIdentity) *tfsdk.ResourceIdentity {
+	t.Helper()
+
+	ctx := context.Background()
+	resp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("IdentitySchema: %v", resp.Diagnostics)
+	}
+
+	return &tfsdk.ResourceIdentity{
+		Schema: resp.IdentitySchema,
+		Raw:    tftypes.NewValue(resp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+}
@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ resource.Resource                = &propagationDefaultPlanResource{}
+	_ resource.ResourceWithConfigure   = &propagationDefaultPlanResource{}
+	_ resource.ResourceWithImportState = &propagationDefaultPlanResource{}
+	_ resource.ResourceWithModifyPlan  = &propagationDefaultPlanResource{}
+	_ resource.ResourceWithIdentity    = &propagationDefaultPlanResource{}
+)
+
+// propagationDefaultPlanResource manages the one propagation plan an environment can hold. Unlike
+// propagationPlanResource, it adopts a plan that already exists instead of failing.
+type propagationDefaultPlanResource struct {
+	client *client.Client
+}
+
+func NewPropagationDefaultPlanResource() resource.Resource {
+	return &propagationDefaultPlanResource{}
+}
+
+func (r *propagationDefaultPlanResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_default_plan"
+}
+
+func (r *propagationDefaultPlanResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the propagation plan of a PingOne environment. An environment holds at most one plan: the plan is created if the environment has none, and an existing plan is adopted and renamed to `name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique ID of the propagation plan.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the propagation plan. Changing the name renames the plan in place.",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the propagation plan.",
+				Computed:    true,
+			},
+			"rule_count": schema.Int64Attribute{
+				Description: "Number of propagation rules in the plan. Null when the rules could not be counted.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"lifecycle_status": schema.StringAttribute{
+				Description: "A summary of the plan's readiness from `status` and `rule_count`: `INACTIVE`, `CONFIGURED_NO_RULES` when the plan has no rules, or `ACTIVE`.",
+				Computed:    true,
+			},
+			"delete_on_destroy": schema.BoolAttribute{
+				Description: "Whether destroying the resource deletes the plan. Defaults to `false`, which only removes the plan from state, because deleting the plan also deletes its rules.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *propagationDefaultPlanResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"environment_id": identityschema.StringAttribute{
+				Description:       "The ID of the environment of the propagation plan.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *propagationDefaultPlanResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData
+}
+
+func (r *propagationDefaultPlanResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_propagation_default_plan", req, resp)
+	deferUnknownPlan(req, resp, "environment_id")
+}
+
+func (r *propagationDefaultPlanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_propagation_default_plan") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.PropagationDefaultPlanModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := plan.EnvironmentId.ValueString()
+	result, err := ensurePropagationDefaultPlan(ctx, r.client.Endpoints, r.client.API, environmentID, plan.Name.ValueString(), r.client.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Propagation Plan",
+			fmt.Sprintf("Could not create or adopt the environment's propagation plan: %s", err),
+		)
+		return
+	}
+
+	state := customtypes.PropagationDefaultPlanModel{
+		PropagationPlanModel: propagationPlanFromAPI(result, environmentID),
+		DeleteOnDestroy:      plan.DeleteOnDestroy,
+	}
+	state.RuleCount = propagationPlanRuleCount(ctx, r.client.Endpoints, r.client.API, environmentID, state.Id.ValueString())
+	state.LifecycleStatus = propagationPlanLifecycleStatus(&state.PropagationPlanModel)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, customtypes.EnvironmentIdentityModel{EnvironmentId: state.EnvironmentId})...)
+}
+
+// ensurePropagationDefaultPlan returns the environment's propagation plan, named name. The plan
+// is created when the environment has none; an existing plan is adopted and renamed if needed.
+func ensurePropagationDefaultPlan(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, name string, pageSize int32) (*management.IdentityPropagationPlan, error) {
+	plans, err := listPropagationPlans(ctx, endpoints, apiClient, environmentID, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list propagation plans: %w", err)
+	}
+	if len(plans) > 1 {
+		return nil, fmt.Errorf("found %d propagation plans in environment %q; expected at most 1", len(plans), environmentID)
+	}
+
+	var existing *management.IdentityPropagationPlan
+	if len(plans) == 1 {
+		existing = &plans[0]
+	} else {
+		payload := management.NewIdentityPropagationPlan(name)
+		created, httpResp, err := createOrAdopt(ctx, "propagation plan", name,
+			func(ctx context.Context) (*management.IdentityPropagationPlan, *http.Response, error) {
+				return apiClient.IdentityPropagationPlansApi.
+					CreatePlan(ctx, environmentID).
+					IdentityPropagationPlan(*payload).
+					Execute()
+			},
+			func(ctx context.Context) (*management.IdentityPropagationPlan, *http.Response, bool, error) {
+				return findPropagationPlanByName(ctx, endpoints, apiClient, environmentID, name, pageSize)
+			},
+		)
+		if err == nil {
+			return created, nil
+		}
+		if !isPropagationPlanEnvironmentAlreadyHasPlanError(httpResp) {
+			return nil, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
+		}
+
+		// Another client created a plan after the list above.
+		existing, err = readSingletonPropagationPlan(ctx, endpoints, apiClient, environmentID, pageSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tflog.Info(ctx, "Adopting the environment's existing propagation plan", map[string]interface{}{
+		"environment_id": environmentID,
+		"plan_id":        existing.GetId(),
+		"plan_name":      existing.GetName(),
+	})
+
+	if existing.GetName() == name {
+		return existing, nil
+	}
+
+	payload := management.NewIdentityPropagationPlan(name)
+	renamed, httpResp, err := apiClient.IdentityPropagationPlansApi.
+		UpdatePlan(ctx, environmentID, existing.GetId()).
+		IdentityPropagationPlan(*payload).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("rename propagation plan %s: %s", existing.GetId(), utils.HandleSDKError(err, httpResp))
+	}
+	return renamed, nil
+}
+
+func (r *propagationDefaultPlanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.PropagationDefaultPlanModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	environmentID := state.EnvironmentId.ValueString()
+
+	var result *management.IdentityPropagationPlan
+	if planID := strings.TrimSpace(state.Id.ValueString()); planID == "" {
+		// Imported by environment ID.
+		plan, err := readSingletonPropagationPlan(ctx, r.client.Endpoints, apiClient, environmentID, r.client.PageSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Plan",
+				fmt.Sprintf("Could not read the environment's propagation plan: %s", err),
+			)
+			return
+		}
+		result = plan
+	} else {
+		plan, httpResp, err := apiClient.IdentityPropagationPlansApi.
+			ReadOnePlan(ctx, environmentID, planID).
+			Execute()
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Plan",
+				fmt.Sprintf("Could not read propagation plan: %s", utils.HandleSDKError(err, httpResp)),
+			)
+			return
+		}
+		result = plan
+	}
+
+	newState := customtypes.PropagationDefaultPlanModel{
+		PropagationPlanModel: propagationPlanFromAPI(result, environmentID),
+		DeleteOnDestroy:      state.DeleteOnDestroy,
+	}
+	if newState.DeleteOnDestroy.IsNull() {
+		newState.DeleteOnDestroy = types.BoolValue(false)
+	}
+	newState.RuleCount = propagationPlanRuleCount(ctx, r.client.Endpoints, r.client.API, environmentID, newState.Id.ValueString())
+	newState.LifecycleStatus = propagationPlanLifecycleStatus(&newState.PropagationPlanModel)
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, customtypes.EnvironmentIdentityModel{EnvironmentId: newState.EnvironmentId})...)
+}
+
+func (r *propagationDefaultPlanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_propagation_default_plan") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.PropagationDefaultPlanModel
+	var state customtypes.PropagationDefaultPlanModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := state.EnvironmentId.ValueString()
+	newState := state
+	newState.DeleteOnDestroy = plan.DeleteOnDestroy
+
+	renamed := plan.Name.ValueString() != state.Name.ValueString()
+	if renamed {
+		result := renamePropagationPlan(ctx, r.client.API, environmentID, state.Id.ValueString(), plan.Name.ValueString(), &resp.Diagnostics)
+		if result == nil {
+			return
+		}
+		newState.PropagationPlanModel = propagationPlanFromAPI(result, environmentID)
+		newState.RuleCount = state.RuleCount
+		newState.LifecycleStatus = propagationPlanLifecycleStatus(&newState.PropagationPlanModel)
+	}
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, customtypes.EnvironmentIdentityModel{EnvironmentId: newState.EnvironmentId})...)
+	if resp.Diagnostics.HasError() || !renamed {
+		return
+	}
+
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, r.client.API, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Plan was renamed, but the provider could not create a propagation revision. The PingOne UI may not reflect the new name until a revision is created. Error: %s", revErr))
+	}
+}
+
+func (r *propagationDefaultPlanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_propagation_default_plan") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var state customtypes.PropagationDefaultPlanModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeleteOnDestroy.ValueBool() {
+		tflog.Info(ctx, "Leaving the propagation plan in place; delete_on_destroy is false", map[string]interface{}{
+			"environment_id": state.EnvironmentId.ValueString(),
+			"plan_id":        state.Id.ValueString(),
+		})
+		return
+	}
+
+	environmentID := state.EnvironmentId.ValueString()
+	httpResp, err := r.client.API.IdentityPropagationPlansApi.
+		DeletePlan(ctx, environmentID, state.Id.ValueString()).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Propagation Plan",
+			fmt.Sprintf("Could not delete propagation plan: %s", utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, r.client.API, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Plan was deleted, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
+	}
+}
+
+func (r *propagationDefaultPlanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	environmentID := strings.TrimSpace(req.ID)
+	if req.ID == "" && req.Identity != nil {
+		// Imported through an `identity` block, for example one generated by `terraform query`.
+		var identity customtypes.EnvironmentIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		environmentID = strings.TrimSpace(identity.EnvironmentId.ValueString())
+	}
+	if environmentID == "" || strings.Contains(environmentID, "/") {
+		resp.Diagnostics.AddError(
+			"Error Importing Propagation Plan",
+			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>'.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentID)...)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEnsurePropagationDefaultPlan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		existing  string
+		wantCalls []string
+		wantID    string
+	}{
+		{
+			name:     "creates_when_absent",
+			existing: `{"_embedded":{"plans":[]}}`,
+			wantCalls: []string{
+				"GET /v1/environments/env-id/propagation/plans",
+				"POST /v1/environments/env-id/propagation/plans",
+			},
+			wantID: "plan-new",
+		},
+		{
+			name:     "adopts_existing_plan",
+			existing: `{"_embedded":{"plans":[{"id":"plan-old","name":"Default"}]}}`,
+			wantCalls: []string{
+				"GET /v1/environments/env-id/propagation/plans",
+			},
+			wantID: "plan-old",
+		},
+		{
+			name:     "renames_adopted_plan",
+			existing: `{"_embedded":{"plans":[{"id":"plan-old","name":"Legacy"}]}}`,
+			wantCalls: []string{
+				"GET /v1/environments/env-id/propagation/plans",
+				"PUT /v1/environments/env-id/propagation/plans/plan-old",
+			},
+			wantID: "plan-old",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []string
+			apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				body := tt.existing
+				switch r.Method {
+				case http.MethodPost:
+					body = `{"id":"plan-new","name":"Default"}`
+				case http.MethodPut:
+					body = `{"id":"plan-old","name":"Default"}`
+				}
+				return testResponse(r, http.StatusOK, body), nil
+			})
+
+			plan, err := ensurePropagationDefaultPlan(context.Background(), nil, apiClient, "env-id", "Default", 0)
+			if err != nil {
+				t.Fatalf("ensurePropagationDefaultPlan error: %v", err)
+			}
+			if plan.GetId() != tt.wantID || plan.GetName() != "Default" {
+				t.Fatalf("plan = %s/%s, want %s/Default", plan.GetId(), plan.GetName(), tt.wantID)
+			}
+			if strings.Join(calls, ",") != strings.Join(tt.wantCalls, ",") {
+				t.Fatalf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
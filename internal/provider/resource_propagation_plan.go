@@ -2,16 +2,20 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
 	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,6 +26,8 @@ var (
 	_ resource.Resource                = &propagationPlanResource{}
 	_ resource.ResourceWithConfigure   = &propagationPlanResource{}
 	_ resource.ResourceWithImportState = &propagationPlanResource{}
+	_ resource.ResourceWithModifyPlan  = &propagationPlanResource{}
+	_ resource.ResourceWithIdentity    = &propagationPlanResource{}
 )
 
 type propagationPlanResource struct {
@@ -62,10 +68,25 @@ func (r *propagationPlanResource) Schema(_ context.Context, req resource.SchemaR
 				Description: "Status of the propagation plan.",
 				Computed:    true,
 			},
+			"rule_count": schema.Int64Attribute{
+				Description: "Number of propagation rules in the plan. Null when the rules could not be counted.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"lifecycle_status": schema.StringAttribute{
+				Description: "A summary of the plan's readiness from `status` and `rule_count`: `INACTIVE`, `CONFIGURED_NO_RULES` when the plan has no rules, or `ACTIVE`.",
+				Computed:    true,
+			},
 		},
 	}
 }
 
+func (r *propagationPlanResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = environmentResourceIdentitySchema("propagation plan")
+}
+
 func (r *propagationPlanResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -83,7 +104,17 @@ func (r *propagationPlanResource) Configure(_ context.Context, req resource.Conf
 	r.client = clientData
 }
 
+func (r *propagationPlanResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_propagation_plan", req, resp)
+	deferUnknownPlan(req, resp, "environment_id")
+}
+
 func (r *propagationPlanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_propagation_plan") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
 	var plan customtypes.PropagationPlanModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -94,16 +125,24 @@ func (r *propagationPlanResource) Create(ctx context.Context, req resource.Creat
 
 	apiClient := r.client.API
 
+	environmentID := plan.EnvironmentId.ValueString()
 	payload := management.NewIdentityPropagationPlan(plan.Name.ValueString())
-	result, httpResp, err := apiClient.IdentityPropagationPlansApi.
-		CreatePlan(ctx, plan.EnvironmentId.ValueString()).
-		IdentityPropagationPlan(*payload).
-		Execute()
+	result, httpResp, err := createOrAdopt(ctx, "propagation plan", plan.Name.ValueString(),
+		func(ctx context.Context) (*management.IdentityPropagationPlan, *http.Response, error) {
+			return apiClient.IdentityPropagationPlansApi.
+				CreatePlan(ctx, environmentID).
+				IdentityPropagationPlan(*payload).
+				Execute()
+		},
+		func(ctx context.Context) (*management.IdentityPropagationPlan, *http.Response, bool, error) {
+			return findPropagationPlanByName(ctx, r.client.Endpoints, apiClient, environmentID, plan.Name.ValueString(), r.client.PageSize)
+		},
+	)
 	if err != nil {
 		if isPropagationPlanEnvironmentAlreadyHasPlanError(httpResp) {
 			detail := "A propagation plan already exists in this environment. Import the existing plan into state or use the propagation plan data source."
 
-			if existingPlan, listErr := readSingletonPropagationPlan(ctx, apiClient, plan.EnvironmentId.ValueString()); listErr == nil {
+			if existingPlan, listErr := readSingletonPropagationPlan(ctx, r.client.Endpoints, apiClient, plan.EnvironmentId.ValueString(), r.client.PageSize); listErr == nil {
 				detail = fmt.Sprintf(
 					"Propagation plan %q (%s) already exists in this environment. Import it into state or use the propagation plan data source.",
 					existingPlan.GetName(),
@@ -126,9 +165,12 @@ func (r *propagationPlanResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	state := propagationPlanFromAPI(result, plan.EnvironmentId.ValueString())
+	state.RuleCount = propagationPlanRuleCount(ctx, r.client.Endpoints, apiClient, environmentID, state.Id.ValueString())
+	state.LifecycleStatus = propagationPlanLifecycleStatus(&state)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(state.EnvironmentId, state.Id))...)
 }
 
 func isPropagationPlanEnvironmentAlreadyHasPlanError(resp *http.Response) bool {
@@ -184,30 +226,10 @@ func isPropagationPlanEnvironmentAlreadyHasPlanError(resp *http.Response) bool {
 	return false
 }
 
-func readSingletonPropagationPlan(ctx context.Context, apiClient *management.APIClient, environmentID string) (*management.IdentityPropagationPlan, error) {
-	iterator := apiClient.IdentityPropagationPlansApi.ReadAllPlans(ctx, environmentID).Execute()
-
-	var plans []management.IdentityPropagationPlan
-
-	for cursor, iterErr := range iterator {
-		if iterErr != nil {
-			return nil, iterErr
-		}
-		if cursor.EntityArray == nil {
-			continue
-		}
-
-		embedded, ok := cursor.EntityArray.GetEmbeddedOk()
-		if !ok || embedded == nil {
-			continue
-		}
-
-		embeddedPlans, ok := embedded.GetPlansOk()
-		if !ok || embeddedPlans == nil {
-			continue
-		}
-
-		plans = append(plans, embeddedPlans...)
+func readSingletonPropagationPlan(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, pageSize int32) (*management.IdentityPropagationPlan, error) {
+	plans, err := listPropagationPlans(ctx, endpoints, apiClient, environmentID, pageSize)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(plans) == 0 {
@@ -221,6 +243,51 @@ func readSingletonPropagationPlan(ctx context.Context, apiClient *management.API
 	return &plan, nil
 }
 
+// findPropagationPlanByName returns the environment's plan when it is named name. An
+// environment holds at most one plan, so a plan with another name is reported as not found.
+func findPropagationPlanByName(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, name string, pageSize int32) (*management.IdentityPropagationPlan, *http.Response, bool, error) {
+	plans, err := listPropagationPlans(ctx, endpoints, apiClient, environmentID, pageSize)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	for _, p := range plans {
+		if p.GetName() == name {
+			found := p
+			return &found, nil, true, nil
+		}
+	}
+	return nil, nil, false, nil
+}
+
+// listPropagationPlans returns every propagation plan in the environment, following pagination links.
+func listPropagationPlans(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, pageSize int32) ([]management.IdentityPropagationPlan, error) {
+	items, err := listPingOneCollection(ctx, endpoints, apiClient,
+		endpointFamilyPropagationPlans,
+		fmt.Sprintf("/environments/%s/propagation/plans", url.PathEscape(environmentID)),
+		pageSize,
+		"plans",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]management.IdentityPropagationPlan, 0, len(items))
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var p management.IdentityPropagationPlan
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, err
+		}
+		plans = append(plans, p)
+	}
+
+	return plans, nil
+}
+
 func (r *propagationPlanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state customtypes.PropagationPlanModel
 
@@ -251,12 +318,20 @@ func (r *propagationPlanResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	newState := propagationPlanFromAPI(result, environmentID)
+	newState.RuleCount = propagationPlanRuleCount(ctx, r.client.Endpoints, apiClient, environmentID, planID)
+	newState.LifecycleStatus = propagationPlanLifecycleStatus(&newState)
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(newState.EnvironmentId, newState.Id))...)
 }
 
 func (r *propagationPlanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_propagation_plan") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
 	var plan customtypes.PropagationPlanModel
 	var state customtypes.PropagationPlanModel
 
@@ -272,26 +347,64 @@ func (r *propagationPlanResource) Update(ctx context.Context, req resource.Updat
 	environmentID := state.EnvironmentId.ValueString()
 	planID := state.Id.ValueString()
 
-	payload := management.NewIdentityPropagationPlan(plan.Name.ValueString())
+	result := renamePropagationPlan(ctx, apiClient, environmentID, planID, plan.Name.ValueString(), &resp.Diagnostics)
+	if result == nil {
+		return
+	}
+
+	newState := propagationPlanFromAPI(result, environmentID)
+	// Renaming does not change the rules; the next refresh counts them.
+	newState.RuleCount = state.RuleCount
+	newState.LifecycleStatus = propagationPlanLifecycleStatus(&newState)
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(newState.EnvironmentId, newState.Id))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rules display the plan name, so a rename needs a new propagation revision before the
+	// PingOne UI reflects it.
+	if plan.Name.ValueString() != state.Name.ValueString() {
+		if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, apiClient, environmentID); revErr != nil {
+			addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Plan was renamed, but the provider could not create a propagation revision. The PingOne UI may not reflect the new name until a revision is created. Error: %s", revErr))
+		}
+	}
+}
+
+// renamePropagationPlan sets the name of a propagation plan. A name that is already in use in
+// the environment is reported against the name attribute; nil is returned on any error.
+func renamePropagationPlan(ctx context.Context, apiClient *management.APIClient, environmentID, planID, name string, diags *diag.Diagnostics) *management.IdentityPropagationPlan {
+	payload := management.NewIdentityPropagationPlan(name)
 	result, httpResp, err := apiClient.IdentityPropagationPlansApi.
 		UpdatePlan(ctx, environmentID, planID).
 		IdentityPropagationPlan(*payload).
 		Execute()
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if httpResp != nil && httpResp.StatusCode == http.StatusConflict {
+			diags.AddAttributeError(
+				path.Root("name"),
+				"Propagation Plan Name Conflict",
+				fmt.Sprintf("Could not rename propagation plan %s to %q because the name is already in use in environment %s. Choose another name. Error: %s", planID, name, environmentID, utils.HandleSDKError(err, httpResp)),
+			)
+			return nil
+		}
+		diags.AddError(
 			"Error Updating Propagation Plan",
 			fmt.Sprintf("Could not update propagation plan: %s", utils.HandleSDKError(err, httpResp)),
 		)
-		return
+		return nil
 	}
-
-	newState := propagationPlanFromAPI(result, environmentID)
-
-	diags = resp.State.Set(ctx, &newState)
-	resp.Diagnostics.Append(diags...)
+	return result
 }
 
 func (r *propagationPlanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_propagation_plan") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
 	var state customtypes.PropagationPlanModel
 
 	diags := req.State.Get(ctx, &state)
@@ -301,8 +414,9 @@ func (r *propagationPlanResource) Delete(ctx context.Context, req resource.Delet
 	}
 
 	apiClient := r.client.API
+	environmentID := state.EnvironmentId.ValueString()
 	httpResp, err := apiClient.IdentityPropagationPlansApi.
-		DeletePlan(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString()).
+		DeletePlan(ctx, environmentID, state.Id.ValueString()).
 		Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -311,14 +425,22 @@ func (r *propagationPlanResource) Delete(ctx context.Context, req resource.Delet
 		)
 		return
 	}
+
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, apiClient, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Plan was deleted, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
+	}
 }
 
 func (r *propagationPlanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := utils.SplitImportID(req.ID, 2)
+	importID := environmentResourceImportID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	idParts := utils.SplitImportID(importID, 2)
 	if idParts == nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Propagation Plan",
-			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>/<plan_id>'.", req.ID),
+			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>/<plan_id>'.", importID),
 		)
 		return
 	}
@@ -333,6 +455,7 @@ func propagationPlanFromAPI(apiObj *management.IdentityPropagationPlan, environm
 		EnvironmentId: types.StringValue(environmentID),
 		Name:          types.StringValue(apiObj.GetName()),
 		Status:        types.StringNull(),
+		RuleCount:     types.Int64Null(),
 	}
 
 	if v, ok := apiObj.GetStatusOk(); ok && v != nil {
@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 )
 
 func TestIsPropagationPlanEnvironmentAlreadyHasPlanError(t *testing.T) {
@@ -78,3 +83,61 @@ func TestIsPropagationPlanEnvironmentAlreadyHasPlanError(t *testing.T) {
 		})
 	}
 }
+
+func TestRenamePropagationPlanConflict(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		return testResponse(r, http.StatusConflict, `{"code":"UNIQUENESS_VIOLATION","message":"name must be unique"}`), nil
+	})
+
+	var diags diag.Diagnostics
+	result := renamePropagationPlan(context.Background(), apiClient, "env-id", "plan-id", "Taken", &diags)
+	if result != nil {
+		t.Fatalf("result = %v, want nil", result)
+	}
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("errors = %d, want 1: %v", diags.ErrorsCount(), diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Fatalf("error is not reported against name: %v", diags.Errors()[0])
+	}
+	if got := diags.Errors()[0].Summary(); got != "Propagation Plan Name Conflict" {
+		t.Fatalf("summary = %q, want %q", got, "Propagation Plan Name Conflict")
+	}
+}
+
+func TestCreatePropagationRevisionUnlessDisabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		client    *client.Client
+		wantCalls int
+	}{
+		{name: "enabled", client: &client.Client{}, wantCalls: 1},
+		{name: "no_client", client: nil, wantCalls: 1},
+		{name: "disabled", client: &client.Client{SkipPropagationRevisions: true}, wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+				calls++
+				return testResponse(r, http.StatusCreated, `{}`), nil
+			})
+
+			if _, err := createPropagationRevisionUnlessDisabled(context.Background(), tt.client, apiClient, "env-id"); err != nil {
+				t.Fatalf("createPropagationRevisionUnlessDisabled error: %v", err)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
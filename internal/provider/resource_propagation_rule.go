@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -15,20 +16,29 @@ import (
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
 	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
 )
 
 var (
-	_ resource.Resource                = &propagationRuleResource{}
-	_ resource.ResourceWithConfigure   = &propagationRuleResource{}
-	_ resource.ResourceWithImportState = &propagationRuleResource{}
+	_ resource.Resource                 = &propagationRuleResource{}
+	_ resource.ResourceWithConfigure    = &propagationRuleResource{}
+	_ resource.ResourceWithImportState  = &propagationRuleResource{}
+	_ resource.ResourceWithModifyPlan   = &propagationRuleResource{}
+	_ resource.ResourceWithUpgradeState = &propagationRuleResource{}
+	_ resource.ResourceWithIdentity     = &propagationRuleResource{}
 )
 
 type propagationRuleResource struct {
@@ -45,6 +55,8 @@ func (r *propagationRuleResource) Metadata(_ context.Context, req resource.Metad
 
 func (r *propagationRuleResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 changes population_ids and group_ids from lists to sets.
+		Version:     1,
 		Description: "Manages a PingOne provisioning propagation rule and its mappings.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -86,8 +98,24 @@ func (r *propagationRuleResource) Schema(_ context.Context, req resource.SchemaR
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"description": schema.StringAttribute{
+				Description: "A description of the propagation rule's purpose.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"active": schema.BoolAttribute{
-				Description: "Whether the propagation rule is active.",
+				Description: "Whether the propagation rule is active. When it is not set and `activate_after` or `deactivate_after` is, the provider sets it from that window on each plan.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"activate_after": schema.StringAttribute{
+				Description: "An RFC3339 timestamp before which the provider does not activate the rule. With `active` unset, the first apply after it activates the rule; with `active = true`, plans and applies before it fail.",
+				Optional:    true,
+			},
+			"deactivate_after": schema.StringAttribute{
+				Description: "An RFC3339 timestamp from which the provider does not keep the rule active. With `active` unset, the first apply after it deactivates the rule; with `active = true`, plans and applies after it fail. Must be later than `activate_after`.",
 				Optional:    true,
 			},
 			"filter": schema.StringAttribute{
@@ -98,24 +126,136 @@ func (r *propagationRuleResource) Schema(_ context.Context, req resource.SchemaR
 				Description: "Whether to deprovision users in the target store when they are removed from the source.",
 				Optional:    true,
 			},
-			"population_ids": schema.ListAttribute{
-				Description: "Optional list of population IDs in scope for this rule.",
+			"population_ids": schema.SetAttribute{
+				Description: "Optional set of population IDs in scope for this rule.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
-			"group_ids": schema.ListAttribute{
-				Description: "Optional list of group IDs to scope group provisioning for this rule.",
+			"population_match": schema.StringAttribute{
+				Description: "How `population_ids` are combined in the population expression: `any` (the default) joins them with `or`, `all` joins them with `and`. A PingOne user belongs to one population, so `all` is rejected with more than one population ID.",
 				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(populationMatchAny, populationMatchAll),
+					populationMatchAllValidator{},
+				},
+			},
+			"group_ids": schema.SetAttribute{
+				Description: "Optional set of group IDs to scope group provisioning for this rule.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"links": schema.MapAttribute{
+				Description: linksAttributeDescription,
+				Computed:    true,
 				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mapping_count": schema.Int64Attribute{
+				Description: "Number of attribute mappings on the rule, including mappings managed outside this resource. Null when the mappings could not be counted.",
+				Computed:    true,
+			},
+			"lifecycle_status": schema.StringAttribute{
+				Description: "A summary of the rule's readiness from `active`, `mapping_count` and the `sync_status` of the target store: `INACTIVE`, `CONFIGURED_NO_MAPPINGS` when the rule has no mappings, then the target store's `lifecycle_status` (`CONFIGURED_NOT_SYNCED`, `SYNCING`, `ACTIVE_HEALTHY` or `ERROR: ` followed by the reason the last sync failed). An inactive target store is reported as `ERROR: the target store is inactive`. Null when the target store cannot be read.",
+				Computed:    true,
 			},
 			"configuration": schema.MapAttribute{
 				Description: "Optional rule configuration map (for example, `MFA_USER_DEVICE_MANAGEMENT`).",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"correlation_attributes": schema.ListNestedAttribute{
+				Description: "Attribute pairs PingOne uses to match a user to a user that already exists in the target store, instead of creating a duplicate. A user matches when every target attribute equals its source attribute. When not set, PingOne uses its default matching for the target store.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_attribute": schema.StringAttribute{
+							Description: "The PingOne user attribute to match on, for example `email`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"target_attribute": schema.StringAttribute{
+							Description: "The target store attribute compared to `source_attribute`, for example `userName`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"external_mappings": schema.BoolAttribute{
+				Description: "Set to `true` when the rule's mappings are managed outside this resource. Disables the plan-time check that prevents activating a rule without `mappings`.",
+				Optional:    true,
+			},
+			"authoritative_mappings": schema.BoolAttribute{
+				Description: "When `true` (the default), `mappings` is the complete list: mappings added outside Terraform show as drift and are removed, and configured mappings deleted outside Terraform show as drift and are re-created. When `false`, mappings added outside Terraform are left on the rule and listed in `unmanaged_mappings`, and only mappings removed from the configuration are deleted.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"update_strategy": schema.StringAttribute{
+				Description: "How updates are sent. PingOne only replaces rules as a whole, so with `patch` (the default) the provider reads the rule and sends it back with only the attributes that changed in the plan, keeping fields PingOne manages. With `put` the rule is replaced with the configured values only.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(ruleUpdateStrategyPatch),
+				Validators: []validator.String{
+					stringvalidator.OneOf(ruleUpdateStrategyPatch, ruleUpdateStrategyPut),
+				},
+			},
+			"unmanaged_mappings": schema.ListNestedAttribute{
+				Description: "Mappings on the rule that are not in `mappings`, when `authoritative_mappings` is `false`. Null otherwise.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The mapping ID.",
+							Computed:    true,
+						},
+						"source_attribute": schema.StringAttribute{
+							Description: "Source attribute expression.",
+							Computed:    true,
+						},
+						"target_attribute": schema.StringAttribute{
+							Description: "Target attribute expression.",
+							Computed:    true,
+						},
+						"expression": schema.StringAttribute{
+							Description: "Expression used to compute the target attribute value.",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the mapping is applied.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"api_hostname": schema.StringAttribute{
+				Description: "The Management API hostname the rule was created through. It differs from the provider's configured hostname when the create only succeeded after falling back to another PingOne region.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"population_expression": schema.StringAttribute{
+				Description: "The exact `populationExpression` the provider sends to PingOne, built from `filter`, `population_ids` and `population_match`. An active rule without a filter or populations is sent `population.id pr`. Null when no expression is sent. After a refresh it holds the expression PingOne stores.",
+				Computed:    true,
+			},
+			"mappings_csv": schema.StringAttribute{
+				Description: "The rule's mappings as CSV, one `source,target,expression` row per mapping, for example `file(\"mappings.csv\")`. Set either the source attribute or the expression of each row; the expression column can be left out, and a value containing a comma must be quoted. A first row of column names is skipped, and lines starting with `#` are comments. The rows are checked at plan time and become the planned `mappings`. Conflicts with `mappings`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("mappings")),
+				},
+			},
 			"mappings": schema.ListNestedAttribute{
-				Description: "Optional list of attribute mappings for this rule.",
+				Description: "Optional list of attribute mappings for this rule. Computed from `mappings_csv` when that is set instead.",
 				Optional:    true,
+				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
@@ -134,6 +274,16 @@ func (r *propagationRuleResource) Schema(_ context.Context, req resource.SchemaR
 							Description: "Optional expression used to compute the target attribute value.",
 							Optional:    true,
 						},
+						"sensitive_expression": schema.StringAttribute{
+							Description: "Use instead of `expression` when the expression embeds a secret, such as a default password. The value is sent as the mapping's expression but is hidden in plan output.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"expression_builder": mappingExpressionBuilderResourceSchema(),
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the mapping is applied (maps to the API field `enabled`). Set to `false` to keep the mapping on the rule without applying it. When unset, the provider does not manage the flag and PingOne's value is kept.",
+							Optional:    true,
+						},
 					},
 				},
 			},
@@ -141,6 +291,10 @@ func (r *propagationRuleResource) Schema(_ context.Context, req resource.SchemaR
 	}
 }
 
+func (r *propagationRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = environmentResourceIdentitySchema("propagation rule")
+}
+
 func (r *propagationRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -158,121 +312,260 @@ func (r *propagationRuleResource) Configure(_ context.Context, req resource.Conf
 	r.client = clientData
 }
 
-func (r *propagationRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan customtypes.PropagationRuleModel
+func (r *propagationRuleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	schemaV0 := ruleSchemaV0(current.Schema)
 
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	return map[int64]resource.StateUpgrader{
+		0: {PriorSchema: &schemaV0, StateUpgrader: upgradeRuleIDListsToSets},
+	}
+}
+
+func (r *propagationRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_propagation_rule", req, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var mappingsAttr types.List
-	diags = req.Plan.GetAttribute(ctx, path.Root("mappings"), &mappingsAttr)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	// Nothing to validate on destroy.
+	if req.Plan.Raw.IsNull() {
 		return
 	}
-	manageMappings := !mappingsAttr.IsNull() && !mappingsAttr.IsUnknown()
-	if !manageMappings {
-		plan.Mappings = nil
+	if deferUnknownPlan(req, resp, "environment_id", "plan_id") {
+		return
 	}
 
-	validationDiags := validatePropagationRuleMappings(plan.Mappings)
-	resp.Diagnostics.Append(validationDiags...)
+	resp.Diagnostics.Append(planRuleActivationWindow(ctx, req, resp, time.Now())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	apiClient := r.client.API
-	requestClient := apiClient
+	resp.Diagnostics.Append(planMappingsFromCSV(ctx, req, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	payload, payloadDiags := propagationRulePayloadFromModel(ctx, &plan)
-	resp.Diagnostics.Append(payloadDiags...)
+	var mappings types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("mappings"), &mappings)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	environmentID := plan.EnvironmentId.ValueString()
+	resp.Diagnostics.Append(planPopulationExpression(ctx, req, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	desiredActive := !plan.Active.IsNull() && !plan.Active.IsUnknown() && plan.Active.ValueBool()
-	manageConfiguration := !plan.Configuration.IsNull() && !plan.Configuration.IsUnknown()
+	var active types.Bool
+	var externalMappings types.Bool
+	var filter types.String
+	var populationIDs types.Set
+	var deprovision types.Bool
 
-	payloadForCreate := cloneInterfaceMap(payload)
-	// The plan-scoped create endpoint requires mappings to exist before enabling a rule.
-	// Create inactive first, then apply mappings, then enable via update if desired.
-	payloadForCreate["active"] = false
-	delete(payloadForCreate, "configuration")
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("active"), &active)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("external_mappings"), &externalMappings)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("filter"), &filter)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("population_ids"), &populationIDs)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("deprovision"), &deprovision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	ruleID, httpResp, err := createPropagationRuleViaPlan(ctx, requestClient, environmentID, plan.PlanId.ValueString(), payloadForCreate)
-	if err != nil && shouldTryAlternateHostname(err, httpResp) {
-		for _, hostname := range pingOneFallbackBaseHostnames(apiClient) {
-			altClient, altErr := cloneManagementClientWithBaseHostname(apiClient, hostname)
-			if altErr != nil || altClient == nil {
-				continue
-			}
+	resp.Diagnostics.Append(checkRulePolicy(policyOf(r.client), "pingoneprovisioning_propagation_rule", active, filter, populationIDs, deprovision)...)
 
-			altRuleID, altResp, altReqErr := createPropagationRuleViaPlan(ctx, altClient, environmentID, plan.PlanId.ValueString(), payloadForCreate)
-			httpResp = altResp
-			err = altReqErr
-			ruleID = altRuleID
+	resp.Diagnostics.Append(validatePropagationRuleActivation(active, externalMappings, mappings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			if err == nil {
-				requestClient = altClient
-				break
-			}
-			if !shouldTryAlternateHostname(err, httpResp) {
-				break
-			}
+	var environmentID types.String
+	var sourceStoreID types.String
+	var targetStoreID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &environmentID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source_store_id"), &sourceStoreID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("target_store_id"), &targetStoreID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The stores and mappings were checked when they were planned, so they are only checked
+	// again, reading the stores' types, when they change.
+	storesChanged, mappingsChanged := true, true
+	if !req.State.Raw.IsNull() {
+		var priorSourceStoreID types.String
+		var priorTargetStoreID types.String
+		var priorMappings types.List
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_store_id"), &priorSourceStoreID)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("target_store_id"), &priorTargetStoreID)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("mappings"), &priorMappings)...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		storesChanged = !sourceStoreID.Equal(priorSourceStoreID) || !targetStoreID.Equal(priorTargetStoreID)
+		mappingsChanged = !mappings.Equal(priorMappings)
 	}
+
+	var sourceStoreType string
+	if storesChanged {
+		sourceStoreType = planStoreType(ctx, r.client, environmentID, sourceStoreID)
+		targetStoreType := planStoreType(ctx, r.client, environmentID, targetStoreID)
+		resp.Diagnostics.Append(validateRuleStoreDirections(sourceStoreType, path.Root("target_store_id"), targetStoreType)...)
+	}
+	// Mappings from a CSV that is not known yet are checked once it is.
+	if resp.Diagnostics.HasError() || mappings.IsNull() || mappings.IsUnknown() || (!storesChanged && !mappingsChanged) {
+		return
+	}
+	if !storesChanged {
+		sourceStoreType = planStoreType(ctx, r.client, environmentID, sourceStoreID)
+	}
+
+	var plannedMappings []customtypes.PropagationRuleMappingModel
+	resp.Diagnostics.Append(mappings.ElementsAs(ctx, &plannedMappings, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateMappingSourceAttributesAgainstSchema(ctx, environmentID, sourceStoreType, plannedMappings)...)
+
+	targetAttributes := make([]types.String, 0, len(plannedMappings))
+	for _, m := range plannedMappings {
+		targetAttributes = append(targetAttributes, m.TargetAttribute)
+	}
+	resp.Diagnostics.Append(validateMappingTargetAttributesAgainstStore(ctx, r.client, environmentID, targetStoreID, targetAttributes)...)
+}
+
+// validateMappingSourceAttributesAgainstSchema checks mapping source attributes against the
+// PingOne user schema when the rule's source store, of type sourceStoreType, is the PingOne
+// directory. Lookup failures are logged and skipped so that missing read permissions never
+// block a plan.
+func (r *propagationRuleResource) validateMappingSourceAttributesAgainstSchema(ctx context.Context, environmentID types.String, sourceStoreType string, mappings []customtypes.PropagationRuleMappingModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.client == nil || r.client.API == nil || len(mappings) == 0 || !strings.EqualFold(sourceStoreType, "directory") {
+		return diags
+	}
+
+	envID := environmentID.ValueString()
+
+	names, err := userSchemaAttributeNames(ctx, r.client, envID)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Propagation Rule",
-			fmt.Sprintf("Could not create propagation rule: %s", err),
+		tflog.Debug(ctx, "Skipping mapping source attribute validation: could not read user schema", map[string]interface{}{
+			"environment_id": envID,
+			"error":          err.Error(),
+		})
+		return diags
+	}
+
+	diags.Append(validateMappingSourceAttributes(mappings, names)...)
+	return diags
+}
+
+// validatePropagationRuleActivation rejects plans that enable a rule without mappings, which
+// PingOne otherwise reports as an API error part-way through apply.
+func validatePropagationRuleActivation(active types.Bool, externalMappings types.Bool, mappings types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if mappings.IsUnknown() {
+		return diags
+	}
+
+	external := !externalMappings.IsNull() && !externalMappings.IsUnknown() && externalMappings.ValueBool()
+	hasMappings := !mappings.IsNull() && len(mappings.Elements()) > 0
+
+	if external && hasMappings {
+		diags.AddAttributeError(
+			path.Root("external_mappings"),
+			"Conflicting Arguments",
+			"`external_mappings` cannot be `true` when `mappings` are configured on the rule.",
 		)
+		return diags
+	}
+
+	if active.IsNull() || active.IsUnknown() || !active.ValueBool() || external || hasMappings {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("active"),
+		"Propagation Rule Has No Mappings",
+		"`active` is `true` but the rule has no `mappings`, and PingOne rejects enabling a rule without attribute mappings. "+
+			"Add at least one mapping, set `active = false`, or set `external_mappings = true` if the mappings are managed outside this resource.",
+	)
+	return diags
+}
+
+func (r *propagationRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_propagation_rule") {
 		return
 	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
 
-	if manageMappings && len(plan.Mappings) > 0 {
-		if err := ensurePropagationRuleMappings(ctx, requestClient, plan.EnvironmentId.ValueString(), ruleID, nil, plan.Mappings); err != nil {
-			resp.Diagnostics.AddError(
-				"Error Creating Propagation Rule Mappings",
-				fmt.Sprintf("Could not reconcile mappings: %s", err),
-			)
-			return
-		}
+	var plan customtypes.PropagationRuleResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if desiredActive || manageConfiguration {
-		updatePayload := cloneInterfaceMap(payload)
-		if desiredActive {
-			updatePayload["active"] = true
-			updatePayload["populationExpression"] = populationExpressionForModel(ctx, &plan)
-		}
+	resp.Diagnostics.Append(resolveRuleActivationWindow(&plan, time.Now())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		updateResp, updateErr := requestClient.PropagationRulesApi.
-			EnvironmentsEnvironmentIDPropagationRulesStoreIDPut(ctx, environmentID, ruleID).
-			Body(updatePayload).
-			Execute()
-		if updateErr != nil {
-			action := "update"
-			if desiredActive {
-				action = "enable"
-			}
-			resp.Diagnostics.AddError(
-				"Error Updating Propagation Rule",
-				fmt.Sprintf("Could not %s propagation rule: %s", action, utils.HandleSDKError(updateErr, updateResp)),
-			)
-			return
-		}
+	// Values that were unknown at plan time are checked against the policy now.
+	resp.Diagnostics.Append(checkRulePolicy(policyOf(r.client), "pingoneprovisioning_propagation_rule", plan.Active, plan.Filter, plan.PopulationIds, plan.Deprovision)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	var mappingsAttr types.List
+	diags = req.Plan.GetAttribute(ctx, path.Root("mappings"), &mappingsAttr)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	manageMappings := !mappingsAttr.IsNull() && !mappingsAttr.IsUnknown()
+	if !manageMappings {
+		plan.Mappings = nil
+	}
+
+	validationDiags := validatePropagationRuleMappings(plan.Mappings)
+	resp.Diagnostics.Append(validationDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := plan.EnvironmentId.ValueString()
+
+	ruleID, requestClient, createDiags := createPropagationRuleWithMappings(ctx, r.client, r.client.API, &plan.PropagationRuleModel, manageMappings)
+	if createDiags.HasError() && ruleID != "" {
+		// The rule exists, so it is recorded in state next to the error instead of being left
+		// behind. Terraform does not compare the state of a failed create with the plan.
+		resp.Diagnostics.Append(createDiags...)
+		r.setPartialRuleState(ctx, requestClient, plan, ruleID, resp)
+		return
+	}
+	resp.Diagnostics.Append(createDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addHostnameFallbackWarning(&resp.Diagnostics, r.client.API, requestClient, fmt.Sprintf("Propagation rule '%s' was created", plan.Name.ValueString()))
+	service := propagationServiceFor(r.client, requestClient)
+
 	state := plan
 	state.Id = types.StringValue(ruleID)
+	state.ApiHostname = types.StringValue(currentPingOneHostname(requestClient))
+	if state.PopulationExpression.IsUnknown() {
+		state.PopulationExpression = populationExpressionSent(ctx, &plan.PropagationRuleModel)
+	}
+	state.Links = readPropagationRuleLinks(ctx, service, state.EnvironmentId.ValueString(), ruleID)
 
+	state.UnmanagedMappings = types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes})
 	if manageMappings {
-		resolvedMappings, err := resolvePropagationRuleMappings(ctx, requestClient, state.EnvironmentId.ValueString(), ruleID, state.Mappings)
+		matched, extra, err := resolvePropagationRuleMappings(ctx, service, state.EnvironmentId.ValueString(), ruleID, state.Mappings, true)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Reading Propagation Rule Mappings",
@@ -280,25 +573,76 @@ func (r *propagationRuleResource) Create(ctx context.Context, req resource.Creat
 			)
 			return
 		}
-		state.Mappings = resolvedMappings
+		resp.Diagnostics.Append(setPropagationRuleMappingsState(ctx, &state, matched, extra)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
+	state.MappingCount = propagationRuleMappingCount(ctx, service, environmentID, ruleID)
+	state.LifecycleStatus = propagationRuleLifecycleStatus(ctx, requestClient, &state.PropagationRuleModel)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(state.EnvironmentId, state.Id))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if _, revErr := createPropagationRevisionWithFallback(ctx, requestClient, environmentID); revErr != nil {
-		resp.Diagnostics.AddWarning(
-			"Propagation Revision Not Created",
-			fmt.Sprintf("Rule was created, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr),
-		)
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, requestClient, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Rule was created, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
 	}
 }
 
+// setPartialRuleState records a rule whose create failed after PingOne assigned its ID, so the
+// rule is tracked in state instead of being left behind. The values are read back from PingOne
+// and reflect the steps that completed, for example a rule that is still inactive or is missing
+// mappings, so the next plan shows the rest as in-place changes and Update finishes them.
+// mapping_count and lifecycle_status are left null until then. Terraform marks the rule tainted
+// because its create failed, so the warning says how to keep it.
+func (r *propagationRuleResource) setPartialRuleState(ctx context.Context, requestClient *management.APIClient, plan customtypes.PropagationRuleResourceModel, ruleID string, resp *resource.CreateResponse) {
+	environmentID := plan.EnvironmentId.ValueString()
+	service := propagationServiceFor(r.client, requestClient)
+
+	state := plan
+	state.Id = types.StringValue(ruleID)
+	state.ApiHostname = types.StringValue(currentPingOneHostname(requestClient))
+	state.PopulationExpression = types.StringNull()
+	state.Links = types.MapNull(types.StringType)
+
+	ruleObj, _, err := service.RuleRead(ctx, environmentID, ruleID)
+	if err == nil {
+		resp.Diagnostics.Append(applyRuleAPIToState(ctx, ruleObj, &state.PropagationRuleModel)...)
+		if expr, ok := utils.NestedString(ruleObj, "populationExpression"); ok && expr != "" {
+			state.PopulationExpression = types.StringValue(expr)
+		}
+	} else if !state.Active.IsNull() && !state.Active.IsUnknown() {
+		// Rules are created inactive and enabled last.
+		state.Active = types.BoolValue(false)
+	}
+
+	state.UnmanagedMappings = types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes})
+	if state.Mappings != nil {
+		matched, extra, err := resolvePropagationRuleMappings(ctx, service, environmentID, ruleID, state.Mappings, false)
+		if err != nil {
+			state.Mappings = nil
+		} else {
+			resp.Diagnostics.Append(setPropagationRuleMappingsState(ctx, &state, matched, extra)...)
+		}
+	}
+	state.MappingCount = types.Int64Null()
+	state.LifecycleStatus = types.StringNull()
+
+	resp.Diagnostics.AddWarning(
+		"Propagation Rule Partially Created",
+		fmt.Sprintf("Propagation rule '%s' (%s) was created, but a later step failed. It is recorded in state as PingOne reports it. "+
+			"Terraform marks it tainted, so the next apply replaces it; run `terraform untaint` on it to have the next apply finish configuring it in place instead.", plan.Name.ValueString(), ruleID),
+	)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(state.EnvironmentId, state.Id))...)
+}
+
 func (r *propagationRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state customtypes.PropagationRuleModel
+	var state customtypes.PropagationRuleResourceModel
 
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -307,10 +651,11 @@ func (r *propagationRuleResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	apiClient := r.client.API
+	service := propagationServiceFor(r.client, apiClient)
 	environmentID := state.EnvironmentId.ValueString()
 	ruleID := state.Id.ValueString()
 
-	ruleObj, httpResp, err := readPropagationRule(ctx, apiClient, environmentID, ruleID)
+	ruleObj, httpResp, err := service.RuleRead(ctx, environmentID, ruleID)
 	if err != nil {
 		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
 			resp.State.RemoveResource(ctx)
@@ -324,14 +669,42 @@ func (r *propagationRuleResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	apiDiags := applyRuleAPIToState(ctx, ruleObj, &state)
+	apiDiags := applyRuleAPIToState(ctx, ruleObj, &state.PropagationRuleModel)
 	resp.Diagnostics.Append(apiDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if state.Mappings != nil {
-		resolvedMappings, err := resolvePropagationRuleMappings(ctx, apiClient, environmentID, ruleID, state.Mappings)
+	state.PopulationExpression = types.StringNull()
+	if expr, ok := utils.NestedString(ruleObj, "populationExpression"); ok && expr != "" {
+		state.PopulationExpression = types.StringValue(expr)
+	}
+
+	// Imported rules were read through the configured hostname.
+	if state.ApiHostname.IsNull() || state.ApiHostname.IsUnknown() {
+		state.ApiHostname = types.StringValue(currentPingOneHostname(apiClient))
+	}
+
+	// State written before the flag existed behaves as authoritative.
+	if state.AuthoritativeMappings.IsNull() || state.AuthoritativeMappings.IsUnknown() {
+		state.AuthoritativeMappings = types.BoolValue(true)
+	}
+	if state.UpdateStrategy.IsNull() || state.UpdateStrategy.IsUnknown() {
+		state.UpdateStrategy = types.StringValue(ruleUpdateStrategyPatch)
+	}
+
+	switch {
+	case state.Mappings != nil && r.client.SkipMappingRefresh:
+		// Mappings, unmanaged mappings and the mapping count keep their values from state; the
+		// next create or update reconciles them.
+		tflog.Debug(ctx, "Skipping propagation rule mapping refresh", map[string]interface{}{
+			"rule_id":  ruleID,
+			"mappings": len(state.Mappings),
+		})
+	case state.Mappings != nil:
+		// In authoritative mode, configured mappings that were deleted outside Terraform are left
+		// out so that they show as drift.
+		matched, extra, err := resolvePropagationRuleMappings(ctx, service, environmentID, ruleID, state.Mappings, !state.AuthoritativeMappings.ValueBool())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Reading Propagation Rule Mappings",
@@ -339,16 +712,31 @@ func (r *propagationRuleResource) Read(ctx context.Context, req resource.ReadReq
 			)
 			return
 		}
-		state.Mappings = resolvedMappings
+		state.UnmanagedMappings = types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes})
+		resp.Diagnostics.Append(setPropagationRuleMappingsState(ctx, &state, matched, extra)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.MappingCount = propagationRuleMappingCount(ctx, service, environmentID, ruleID)
+	default:
+		state.UnmanagedMappings = types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes})
+		state.MappingCount = propagationRuleMappingCount(ctx, service, environmentID, ruleID)
 	}
+	state.LifecycleStatus = propagationRuleLifecycleStatus(ctx, apiClient, &state.PropagationRuleModel)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(state.EnvironmentId, state.Id))...)
 }
 
 func (r *propagationRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan customtypes.PropagationRuleModel
-	var state customtypes.PropagationRuleModel
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_propagation_rule") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.PropagationRuleResourceModel
+	var state customtypes.PropagationRuleResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -358,6 +746,17 @@ func (r *propagationRuleResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	resp.Diagnostics.Append(resolveRuleActivationWindow(&plan, time.Now())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values that were unknown at plan time are checked against the policy now.
+	resp.Diagnostics.Append(checkRulePolicy(policyOf(r.client), "pingoneprovisioning_propagation_rule", plan.Active, plan.Filter, plan.PopulationIds, plan.Deprovision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var mappingsAttr types.List
 	diags = req.Plan.GetAttribute(ctx, path.Root("mappings"), &mappingsAttr)
 	resp.Diagnostics.Append(diags...)
@@ -376,48 +775,28 @@ func (r *propagationRuleResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	apiClient := r.client.API
+	service := propagationServiceFor(r.client, apiClient)
 	environmentID := state.EnvironmentId.ValueString()
 	ruleID := state.Id.ValueString()
 
-	desiredActive := !plan.Active.IsNull() && !plan.Active.IsUnknown() && plan.Active.ValueBool()
-
-	if manageMappings {
-		if err := ensurePropagationRuleMappings(ctx, apiClient, environmentID, ruleID, state.Mappings, plan.Mappings); err != nil {
-			resp.Diagnostics.AddError(
-				"Error Updating Propagation Rule Mappings",
-				fmt.Sprintf("Could not reconcile mappings: %s", err),
-			)
-			return
-		}
-	}
-
-	payload, payloadDiags := propagationRulePayloadFromModel(ctx, &plan)
-	resp.Diagnostics.Append(payloadDiags...)
+	updateDiags := updatePropagationRuleWithMappings(ctx, service, ruleID, &state.PropagationRuleModel, &plan.PropagationRuleModel, manageMappings, plan.AuthoritativeMappings.ValueBool(), plan.UpdateStrategy.ValueString())
+	resp.Diagnostics.Append(updateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if desiredActive {
-		payload["populationExpression"] = populationExpressionForModel(ctx, &plan)
-	}
-
-	httpResp, err := apiClient.PropagationRulesApi.
-		EnvironmentsEnvironmentIDPropagationRulesStoreIDPut(ctx, environmentID, ruleID).
-		Body(payload).
-		Execute()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Propagation Rule",
-			fmt.Sprintf("Could not update propagation rule: %s", utils.HandleSDKError(err, httpResp)),
-		)
-		return
-	}
-
 	newState := plan
 	newState.Id = types.StringValue(ruleID)
+	if newState.ApiHostname.IsNull() || newState.ApiHostname.IsUnknown() {
+		newState.ApiHostname = types.StringValue(currentPingOneHostname(apiClient))
+	}
+	if newState.PopulationExpression.IsUnknown() {
+		newState.PopulationExpression = populationExpressionSent(ctx, &plan.PropagationRuleModel)
+	}
 
+	newState.UnmanagedMappings = types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes})
 	if manageMappings {
-		resolvedMappings, err := resolvePropagationRuleMappings(ctx, apiClient, environmentID, ruleID, newState.Mappings)
+		matched, extra, err := resolvePropagationRuleMappings(ctx, service, environmentID, ruleID, newState.Mappings, true)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Reading Propagation Rule Mappings",
@@ -425,27 +804,35 @@ func (r *propagationRuleResource) Update(ctx context.Context, req resource.Updat
 			)
 			return
 		}
-		newState.Mappings = resolvedMappings
+		resp.Diagnostics.Append(setPropagationRuleMappingsState(ctx, &newState, matched, extra)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	} else {
 		newState.Mappings = nil
 	}
+	newState.MappingCount = propagationRuleMappingCount(ctx, service, environmentID, ruleID)
+	newState.LifecycleStatus = propagationRuleLifecycleStatus(ctx, apiClient, &newState.PropagationRuleModel)
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(newState.EnvironmentId, newState.Id))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if _, revErr := createPropagationRevisionWithFallback(ctx, apiClient, environmentID); revErr != nil {
-		resp.Diagnostics.AddWarning(
-			"Propagation Revision Not Created",
-			fmt.Sprintf("Rule was updated, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr),
-		)
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, apiClient, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Rule was updated, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
 	}
 }
 
 func (r *propagationRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state customtypes.PropagationRuleModel
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_propagation_rule") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var state customtypes.PropagationRuleResourceModel
 
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -457,11 +844,7 @@ func (r *propagationRuleResource) Delete(ctx context.Context, req resource.Delet
 	environmentID := state.EnvironmentId.ValueString()
 	ruleID := state.Id.ValueString()
 
-	_ = deleteAllMappings(ctx, apiClient, environmentID, ruleID)
-
-	httpResp, err := apiClient.PropagationRulesApi.
-		EnvironmentsEnvironmentIDPropagationRulesRuleIDDelete(ctx, environmentID, ruleID).
-		Execute()
+	httpResp, err := deletePropagationRuleWithMappings(ctx, propagationServiceFor(r.client, apiClient), environmentID, ruleID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Propagation Rule",
@@ -470,20 +853,52 @@ func (r *propagationRuleResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	if _, revErr := createPropagationRevisionWithFallback(ctx, apiClient, environmentID); revErr != nil {
-		resp.Diagnostics.AddWarning(
-			"Propagation Revision Not Created",
-			fmt.Sprintf("Rule was deleted, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr),
-		)
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, apiClient, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Rule was deleted, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
 	}
 }
 
 func (r *propagationRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := utils.SplitImportID(req.ID, 2)
+	importID := environmentResourceImportID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rule names may contain slashes, so a name import is split on the first slash only.
+	if environmentID, name, ok := parsePropagationRuleNameImportID(importID); ok {
+		tflog.Info(ctx, "Resolving propagation rule by name for import", map[string]interface{}{
+			"environment_id": environmentID,
+			"name":           name,
+		})
+
+		rules, err := listPropagationRules(ctx, r.client.Endpoints, r.client.API, environmentID, "", r.client.PageSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Propagation Rule",
+				fmt.Sprintf("Could not list propagation rules: %s", err),
+			)
+			return
+		}
+
+		matches := propagationRuleIDsByName(rules, name)
+		if len(matches) != 1 {
+			resp.Diagnostics.AddError(
+				"Error Importing Propagation Rule",
+				fmt.Sprintf("Found %d propagation rules with name %q in environment %q; import by '<environment_id>/<rule_id>' instead.", len(matches), name, environmentID),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), matches[0])...)
+		return
+	}
+
+	idParts := utils.SplitImportID(importID, 2)
 	if idParts == nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Propagation Rule",
-			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>/<rule_id>'.", req.ID),
+			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>/<rule_id>' or '<environment_id>/name:<rule_name>'.", importID),
 		)
 		return
 	}
@@ -492,6 +907,262 @@ func (r *propagationRuleResource) ImportState(ctx context.Context, req resource.
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
 
+// parsePropagationRuleNameImportID parses an import ID of the form
+// `<environment_id>/name:<rule_name>`.
+func parsePropagationRuleNameImportID(id string) (string, string, bool) {
+	environmentID, rest, found := strings.Cut(id, "/")
+	if !found || environmentID == "" {
+		return "", "", false
+	}
+	name, found := strings.CutPrefix(rest, "name:")
+	if !found || name == "" {
+		return "", "", false
+	}
+	return environmentID, name, true
+}
+
+// createPropagationRuleWithMappings creates a rule inactive, applies its mappings, and then
+// enables or configures it as the model asks, since PingOne requires mappings to exist before a
+// rule is enabled. It returns the rule ID, which is set whenever the rule itself was created
+// even if a later step failed, and the client that served the request, which may use a
+// fallback hostname.
+func createPropagationRuleWithMappings(ctx context.Context, c *client.Client, apiClient *management.APIClient, model *customtypes.PropagationRuleModel, manageMappings bool) (string, *management.APIClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	requestClient := pingOneRequestClient(endpointsOf(c), apiClient)
+
+	payload, payloadDiags := propagationRulePayloadFromModel(ctx, model)
+	diags.Append(payloadDiags...)
+	if diags.HasError() {
+		return "", requestClient, diags
+	}
+
+	environmentID := model.EnvironmentId.ValueString()
+
+	payloadForCreate := cloneInterfaceMap(payload)
+	// The plan-scoped create endpoint requires mappings to exist before enabling a rule.
+	// Create inactive first, then apply mappings, then enable via update if desired. This holds
+	// even without mappings to apply: a rule whose mappings are managed elsewhere has none yet.
+	payloadForCreate["active"] = false
+	delete(payloadForCreate, "configuration")
+
+	service := propagationServiceFor(c, requestClient)
+	ruleID, httpResp, err := service.RuleCreate(ctx, environmentID, model.PlanId.ValueString(), payloadForCreate)
+	if err != nil && shouldTryAlternateHostname(err, httpResp) {
+		for _, hostname := range pingOneFallbackBaseHostnames(requestClient) {
+			altClient, altErr := cloneManagementClientWithBaseHostname(requestClient, hostname)
+			if altErr != nil || altClient == nil {
+				continue
+			}
+
+			altService := propagationServiceFor(c, altClient)
+			altRuleID, altResp, altReqErr := altService.RuleCreate(ctx, environmentID, model.PlanId.ValueString(), payloadForCreate)
+			httpResp = altResp
+			err = altReqErr
+			ruleID = altRuleID
+
+			if err == nil {
+				rememberHostnameFallback(endpointsOf(c), apiClient, altClient)
+				requestClient = altClient
+				service = altService
+				break
+			}
+			if !shouldTryAlternateHostname(err, httpResp) {
+				break
+			}
+		}
+	}
+	if err != nil {
+		addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths,
+			"Error Creating Propagation Rule",
+			fmt.Sprintf("Could not create propagation rule: %s", err),
+			httpResp,
+		)
+		return "", requestClient, diags
+	}
+
+	diags.Append(configureCreatedPropagationRule(ctx, service, ruleID, payload, model, manageMappings)...)
+	return ruleID, requestClient, diags
+}
+
+// configureCreatedPropagationRule applies the mappings of a rule that was just created inactive,
+// and then enables it or sets its configuration as model asks. payload is the rule built from
+// model.
+func configureCreatedPropagationRule(ctx context.Context, service propagationService, ruleID string, payload map[string]interface{}, model *customtypes.PropagationRuleModel, manageMappings bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	environmentID := model.EnvironmentId.ValueString()
+	desiredActive := !model.Active.IsNull() && !model.Active.IsUnknown() && model.Active.ValueBool()
+	manageConfiguration := !model.Configuration.IsNull() && !model.Configuration.IsUnknown()
+
+	if manageMappings && len(model.Mappings) > 0 {
+		if err := ensurePropagationRuleMappings(ctx, service, environmentID, ruleID, nil, model.Mappings, true); err != nil {
+			diags.AddError(
+				"Error Creating Propagation Rule Mappings",
+				fmt.Sprintf("Could not reconcile mappings: %s", err),
+			)
+			return diags
+		}
+	}
+
+	if !desiredActive && !manageConfiguration {
+		return diags
+	}
+
+	updatePayload := cloneInterfaceMap(payload)
+	if desiredActive {
+		updatePayload["active"] = true
+		updatePayload["populationExpression"] = populationExpressionForModel(ctx, model)
+
+		activateResp, activateErr := activatePropagationRule(ctx, service, environmentID, ruleID, updatePayload)
+		if activateErr != nil {
+			addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths,
+				"Error Activating Propagation Rule",
+				fmt.Sprintf("Activating propagation rule '%s' (%s) failed. Creating the rule did not fail: it was created inactive and its mappings were applied, so it is recorded in state as inactive. Error: %s",
+					model.Name.ValueString(), ruleID, activateErr),
+				activateResp,
+			)
+		}
+		return diags
+	}
+
+	updateResp, updateErr := service.RuleUpdate(ctx, environmentID, ruleID, updatePayload)
+	if updateErr != nil {
+		addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths,
+			"Error Updating Propagation Rule",
+			fmt.Sprintf("Could not update propagation rule: %s", utils.HandleSDKError(updateErr, updateResp)),
+			updateResp,
+		)
+	}
+	return diags
+}
+
+// ruleActivationAttempts is how many times a new rule is enabled before its create fails.
+const ruleActivationAttempts = 3
+
+// ruleActivationBackoff is the wait before enabling a new rule again; it doubles on each attempt.
+var ruleActivationBackoff = time.Second
+
+// activatePropagationRule enables a newly created rule by replacing it with payload. PingOne can
+// answer not found or conflict until it sees the rule and the mappings just added to it, so those
+// are retried with exponential backoff. Other failures, such as a payload PingOne rejects as
+// invalid or an authorization error, are returned without retrying.
+func activatePropagationRule(ctx context.Context, service propagationService, environmentID string, ruleID string, payload map[string]interface{}) (*http.Response, error) {
+	backoff := ruleActivationBackoff
+	for attempt := 1; ; attempt++ {
+		httpResp, err := service.RuleUpdate(ctx, environmentID, ruleID, payload)
+		if err == nil {
+			return httpResp, nil
+		}
+		err = fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
+		if attempt >= ruleActivationAttempts || !isRetryableRuleActivationStatus(httpResp) {
+			return httpResp, err
+		}
+
+		tflog.Debug(ctx, "Propagation rule could not be enabled yet; retrying", map[string]interface{}{
+			"rule_id": ruleID,
+			"attempt": attempt,
+			"backoff": backoff.String(),
+		})
+		if sleepErr := utils.SleepContext(ctx, backoff); sleepErr != nil {
+			return httpResp, err
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryableRuleActivationStatus reports whether PingOne failed an enable in a way that can
+// clear once the rule and its mappings are visible to it. A 400 means the payload itself is
+// invalid, so sending it again cannot help.
+func isRetryableRuleActivationStatus(httpResp *http.Response) bool {
+	if httpResp == nil {
+		return false
+	}
+	switch httpResp.StatusCode {
+	case http.StatusNotFound, http.StatusConflict:
+		return true
+	}
+	return false
+}
+
+// Values of `update_strategy`.
+const (
+	ruleUpdateStrategyPatch = "patch"
+	ruleUpdateStrategyPut   = "put"
+)
+
+// updatePropagationRuleWithMappings reconciles an existing rule's mappings and then updates the
+// rule with the values in model. See ensurePropagationRuleMappings for authoritativeMappings.
+// With ruleUpdateStrategyPatch only the attributes that differ between prior and model are
+// applied to the rule PingOne returns; otherwise the rule is replaced with model.
+func updatePropagationRuleWithMappings(ctx context.Context, service propagationService, ruleID string, prior *customtypes.PropagationRuleModel, model *customtypes.PropagationRuleModel, manageMappings bool, authoritativeMappings bool, updateStrategy string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	environmentID := model.EnvironmentId.ValueString()
+	desiredActive := !model.Active.IsNull() && !model.Active.IsUnknown() && model.Active.ValueBool()
+
+	if manageMappings {
+		if err := ensurePropagationRuleMappings(ctx, service, environmentID, ruleID, prior.Mappings, model.Mappings, authoritativeMappings); err != nil {
+			diags.AddError(
+				"Error Updating Propagation Rule Mappings",
+				fmt.Sprintf("Could not reconcile mappings: %s", err),
+			)
+			return diags
+		}
+	}
+
+	payload, payloadDiags := propagationRulePayloadFromModel(ctx, model)
+	diags.Append(payloadDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if desiredActive {
+		payload["populationExpression"] = populationExpressionForModel(ctx, model)
+	}
+
+	if updateStrategy == ruleUpdateStrategyPatch {
+		priorPayload, priorDiags := propagationRulePayloadFromModel(ctx, prior)
+		diags.Append(priorDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		if !prior.Active.IsNull() && !prior.Active.IsUnknown() && prior.Active.ValueBool() {
+			priorPayload["populationExpression"] = populationExpressionForModel(ctx, prior)
+		}
+
+		current, httpResp, err := service.RuleRead(ctx, environmentID, ruleID)
+		if err != nil {
+			addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths,
+				"Error Updating Propagation Rule",
+				fmt.Sprintf("Could not read propagation rule to apply changes to: %s", err),
+				httpResp,
+			)
+			return diags
+		}
+		payload = mergePropagationRuleChanges(current, priorPayload, payload)
+	}
+
+	httpResp, err := service.RuleUpdate(ctx, environmentID, ruleID, payload)
+	if err != nil {
+		addAPIErrorDiagnostics(&diags, propagationRuleAPIErrorPaths,
+			"Error Updating Propagation Rule",
+			fmt.Sprintf("Could not update propagation rule: %s", utils.HandleSDKError(err, httpResp)),
+			httpResp,
+		)
+		return diags
+	}
+
+	return diags
+}
+
+// deletePropagationRuleWithMappings deletes a rule's mappings on a best-effort basis and then
+// deletes the rule.
+func deletePropagationRuleWithMappings(ctx context.Context, service propagationService, environmentID string, ruleID string) (*http.Response, error) {
+	_ = deleteAllMappings(ctx, service, environmentID, ruleID)
+
+	return service.RuleDelete(ctx, environmentID, ruleID)
+}
+
 func propagationRulePayloadFromModel(ctx context.Context, model *customtypes.PropagationRuleModel) (map[string]interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -511,6 +1182,9 @@ func propagationRulePayloadFromModel(ctx context.Context, model *customtypes.Pro
 		"name": model.Name.ValueString(),
 	}
 
+	if !model.Description.IsNull() && !model.Description.IsUnknown() {
+		payload["description"] = model.Description.ValueString()
+	}
 	if !model.Active.IsNull() && !model.Active.IsUnknown() {
 		payload["active"] = model.Active.ValueBool()
 	}
@@ -536,10 +1210,56 @@ func propagationRulePayloadFromModel(ctx context.Context, model *customtypes.Pro
 			payload["configuration"] = cfg
 		}
 	}
+	if !model.CorrelationAttributes.IsNull() && !model.CorrelationAttributes.IsUnknown() {
+		var pairs []customtypes.PropagationRuleCorrelationAttributeModel
+		listDiags := model.CorrelationAttributes.ElementsAs(ctx, &pairs, false)
+		diags.Append(listDiags...)
+		if !listDiags.HasError() {
+			payload["correlationAttributes"] = correlationAttributesPayload(pairs)
+		}
+	}
 
 	return payload, diags
 }
 
+// correlationAttributesPayload returns the `correlationAttributes` of a rule payload.
+func correlationAttributesPayload(pairs []customtypes.PropagationRuleCorrelationAttributeModel) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(pairs))
+	for _, pair := range pairs {
+		out = append(out, map[string]interface{}{
+			"sourceAttribute": strings.TrimSpace(pair.SourceAttribute.ValueString()),
+			"targetAttribute": strings.TrimSpace(pair.TargetAttribute.ValueString()),
+		})
+	}
+	return out
+}
+
+// correlationAttributesFromAPI returns a rule's `correlationAttributes` as a
+// `correlation_attributes` list, or a null list when PingOne does not report them.
+func correlationAttributesFromAPI(ctx context.Context, apiObj map[string]interface{}) (types.List, diag.Diagnostics) {
+	elemType := types.ObjectType{AttrTypes: customtypes.PropagationRuleCorrelationAttributeAttrTypes}
+
+	rawList, ok := apiObj["correlationAttributes"].([]interface{})
+	if !ok {
+		return types.ListNull(elemType), nil
+	}
+
+	pairs := make([]customtypes.PropagationRuleCorrelationAttributeModel, 0, len(rawList))
+	for _, item := range rawList {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		source, _ := utils.NestedString(m, "sourceAttribute")
+		target, _ := utils.NestedString(m, "targetAttribute")
+		pairs = append(pairs, customtypes.PropagationRuleCorrelationAttributeModel{
+			SourceAttribute: types.StringValue(source),
+			TargetAttribute: types.StringValue(target),
+		})
+	}
+	return types.ListValueFrom(ctx, elemType, pairs)
+}
+
 func validatePropagationRuleMappings(mappings []customtypes.PropagationRuleMappingModel) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -552,9 +1272,22 @@ func validatePropagationRuleMappings(mappings []customtypes.PropagationRuleMappi
 		if !m.TargetAttribute.IsNull() && !m.TargetAttribute.IsUnknown() {
 			target = strings.TrimSpace(m.TargetAttribute.ValueString())
 		}
-		expression := ""
-		if !m.Expression.IsNull() && !m.Expression.IsUnknown() {
-			expression = strings.TrimSpace(m.Expression.ValueString())
+		expression := mappingExpression(m)
+
+		if _, err := compileMappingExpression(m.ExpressionBuilder); err != nil {
+			diags.AddAttributeError(
+				path.Root("mappings").AtListIndex(i).AtName("expression_builder"),
+				"Invalid Expression Builder",
+				err.Error(),
+			)
+		}
+
+		if !m.Expression.IsNull() && !m.SensitiveExpression.IsNull() {
+			diags.AddAttributeError(
+				path.Root("mappings").AtListIndex(i).AtName("sensitive_expression"),
+				"Conflicting Arguments",
+				"`sensitive_expression` cannot be used when `expression` is set; choose one.",
+			)
 		}
 
 		if target == "" {
@@ -569,20 +1302,27 @@ func validatePropagationRuleMappings(mappings []customtypes.PropagationRuleMappi
 			diags.AddAttributeError(
 				path.Root("mappings").AtListIndex(i).AtName("source_attribute"),
 				"Missing Required Argument",
-				"Either `source_attribute` or `expression` must be set for each mapping.",
+				"Either `source_attribute`, `expression`, `sensitive_expression` or `expression_builder` must be set for each mapping.",
 			)
 			diags.AddAttributeError(
 				path.Root("mappings").AtListIndex(i).AtName("expression"),
 				"Missing Required Argument",
-				"Either `source_attribute` or `expression` must be set for each mapping.",
+				"Either `source_attribute`, `expression`, `sensitive_expression` or `expression_builder` must be set for each mapping.",
 			)
 		}
 
 		if source != "" && expression != "" {
+			attribute := "expression"
+			switch {
+			case m.ExpressionBuilder != nil:
+				attribute = "expression_builder"
+			case m.Expression.IsNull():
+				attribute = "sensitive_expression"
+			}
 			diags.AddAttributeError(
-				path.Root("mappings").AtListIndex(i).AtName("expression"),
+				path.Root("mappings").AtListIndex(i).AtName(attribute),
 				"Conflicting Arguments",
-				"`expression` cannot be used when `source_attribute` is set; choose one.",
+				fmt.Sprintf("`%s` cannot be used when `source_attribute` is set; choose one.", attribute),
 			)
 		}
 	}
@@ -612,6 +1352,11 @@ func shouldTryAlternateHostname(err error, httpResp *http.Response) bool {
 		return false
 	}
 
+	// Every region answers the same way for an environment without provisioning.
+	if utils.IsProvisioningNotEnabled(httpResp) {
+		return false
+	}
+
 	if isBadAuthorizationHeaderError(err, httpResp) {
 		return true
 	}
@@ -620,31 +1365,45 @@ func shouldTryAlternateHostname(err error, httpResp *http.Response) bool {
 	return httpResp.StatusCode == http.StatusNotFound
 }
 
-func createPropagationRuleViaPlan(ctx context.Context, apiClient *management.APIClient, environmentID string, planID string, payload map[string]interface{}) (string, *http.Response, error) {
+func createPropagationRuleViaPlan(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, creator propagationRuleCreator, environmentID string, planID string, payload map[string]interface{}, pageSize int32) (string, *http.Response, error) {
 	if apiClient == nil {
 		return "", nil, fmt.Errorf("nil api client")
 	}
 
-	httpResp, err := createPropagationRuleForPlan(ctx, apiClient, environmentID, planID, payload)
-	if err != nil {
-		if httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusMethodNotAllowed) {
-			httpResp, err = apiClient.PropagationRulesApi.
-				EnvironmentsEnvironmentIDPropagationRulesPost(ctx, environmentID).
-				Body(payload).
-				Execute()
-			if err != nil {
-				return "", httpResp, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
-			}
-		} else {
+	name, _ := payload["name"].(string)
+	sourceStoreID, _ := utils.NestedString(payload, "sourceStore", "id")
+	targetStoreID, _ := utils.NestedString(payload, "targetStore", "id")
+
+	create := func(ctx context.Context) (string, *http.Response, error) {
+		httpResp, err := creator.createRule(ctx, apiClient, environmentID, planID, payload)
+		if err != nil {
+			return "", httpResp, err
+		}
+
+		ruleID, err := propagationRuleIDFromCreateResponse(ctx, endpoints, apiClient, environmentID, planID, name, sourceStoreID, targetStoreID, httpResp, pageSize)
+		if err != nil {
 			return "", httpResp, err
 		}
+		return ruleID, httpResp, nil
 	}
 
-	name, _ := payload["name"].(string)
-	sourceStoreID, _ := utils.NestedString(payload, "sourceStore", "id")
-	targetStoreID, _ := utils.NestedString(payload, "targetStore", "id")
+	find := func(ctx context.Context) (string, *http.Response, bool, error) {
+		rules, err := listPropagationRulesForPlan(ctx, endpoints, apiClient, environmentID, planID, pageSize)
+		if err != nil {
+			return "", nil, false, err
+		}
+		matches := matchingPropagationRuleIDs(rules, name, sourceStoreID, targetStoreID)
+		switch len(matches) {
+		case 0:
+			return "", nil, false, nil
+		case 1:
+			return matches[0], nil, true, nil
+		default:
+			return "", nil, false, fmt.Errorf("found %d rules matching name=%q source=%q target=%q", len(matches), name, sourceStoreID, targetStoreID)
+		}
+	}
 
-	ruleID, err := propagationRuleIDFromCreateResponse(ctx, apiClient, environmentID, planID, name, sourceStoreID, targetStoreID, httpResp)
+	ruleID, httpResp, err := createOrAdopt(ctx, "propagation rule", name, create, find)
 	if err != nil {
 		return "", httpResp, err
 	}
@@ -652,27 +1411,12 @@ func createPropagationRuleViaPlan(ctx context.Context, apiClient *management.API
 	return strings.TrimSpace(ruleID), httpResp, nil
 }
 
-func createPropagationRuleForPlan(ctx context.Context, apiClient *management.APIClient, environmentID string, planID string, payload map[string]interface{}) (*http.Response, error) {
-	cfg := apiClient.GetConfig()
-	if cfg == nil {
-		return nil, fmt.Errorf("api client has nil config")
-	}
-	if cfg.HTTPClient == nil {
-		return nil, fmt.Errorf("api client has nil http client")
-	}
-
-	basePath, err := cfg.ServerURLWithContext(ctx, "PropagationRulesApiService.EnvironmentsEnvironmentIDPropagationRulesPost")
+func createPropagationRuleForPlan(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, planID string, payload map[string]interface{}) (*http.Response, error) {
+	endpoint, httpClient, err := pingOneEndpoint(ctx, endpoints, apiClient, endpointFamilyPropagationRules,
+		"environments", environmentID, "propagation", "plans", planID, "rules")
 	if err != nil {
 		return nil, err
 	}
-	basePath = strings.TrimRight(strings.TrimSpace(basePath), "/")
-
-	endpoint := fmt.Sprintf(
-		"%s/environments/%s/propagation/plans/%s/rules",
-		basePath,
-		url.PathEscape(environmentID),
-		url.PathEscape(planID),
-	)
 
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -686,7 +1430,7 @@ func createPropagationRuleForPlan(ctx context.Context, apiClient *management.API
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := cfg.HTTPClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -721,21 +1465,24 @@ func createPropagationRevision(ctx context.Context, apiClient *management.APICli
 	return httpResp, nil
 }
 
-func createPropagationRevisionWithFallback(ctx context.Context, apiClient *management.APIClient, environmentID string) (*http.Response, error) {
-	httpResp, err := createPropagationRevision(ctx, apiClient, environmentID)
+func createPropagationRevisionWithFallback(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string) (*http.Response, error) {
+	requestClient := pingOneRequestClient(endpoints, apiClient)
+	httpResp, err := createPropagationRevision(ctx, requestClient, environmentID)
 	if err == nil {
 		return httpResp, nil
 	}
 
 	if shouldTryAlternateHostname(err, httpResp) {
-		for _, hostname := range pingOneFallbackBaseHostnames(apiClient) {
-			altClient, altErr := cloneManagementClientWithBaseHostname(apiClient, hostname)
+		for _, hostname := range pingOneFallbackBaseHostnames(requestClient) {
+			altClient, altErr := cloneManagementClientWithBaseHostname(requestClient, hostname)
 			if altErr != nil || altClient == nil {
 				continue
 			}
 
 			httpResp, err = createPropagationRevision(ctx, altClient, environmentID)
 			if err == nil {
+				logHostnameFallback(ctx, apiClient, hostname, "create propagation revision")
+				rememberHostnameFallback(endpoints, apiClient, altClient)
 				return httpResp, nil
 			}
 			if !shouldTryAlternateHostname(err, httpResp) {
@@ -747,34 +1494,136 @@ func createPropagationRevisionWithFallback(ctx context.Context, apiClient *manag
 	return httpResp, err
 }
 
-func deletePropagationMapping(ctx context.Context, apiClient *management.APIClient, environmentID string, mappingID string) (*http.Response, error) {
+// revisionRetryBackoff is the wait before the first retry of a failed propagation revision; it
+// doubles on each attempt, up to maxRevisionRetryBackoff.
+var revisionRetryBackoff = 2 * time.Second
+
+const maxRevisionRetryBackoff = 30 * time.Second
+
+// createPropagationRevisionUnlessDisabled creates a propagation revision after a change to a
+// propagation object, or does nothing when the provider is configured not to create revisions.
+// With revision_failure_behavior = "retry", a failed revision is retried until the provider's
+// revision retry timeout.
+func createPropagationRevisionUnlessDisabled(ctx context.Context, c *client.Client, apiClient *management.APIClient, environmentID string) (*http.Response, error) {
+	if c != nil && c.SkipPropagationRevisions {
+		tflog.Debug(ctx, "Skipping propagation revision", map[string]interface{}{"environment_id": environmentID})
+		return nil, nil
+	}
+	if c == nil || c.RevisionFailureBehavior != client.RevisionFailureRetry {
+		return createPropagationRevisionWithFallback(ctx, endpointsOf(c), apiClient, environmentID)
+	}
+
+	deadline := time.Now().Add(c.RevisionRetryTimeout)
+	backoff := revisionRetryBackoff
+	for attempt := 1; ; attempt++ {
+		httpResp, err := createPropagationRevisionWithFallback(ctx, endpointsOf(c), apiClient, environmentID)
+		if err == nil {
+			return httpResp, nil
+		}
+
+		wait := min(backoff, time.Until(deadline))
+		if wait <= 0 {
+			return httpResp, fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+		}
+		tflog.Warn(ctx, "Retrying propagation revision", map[string]interface{}{
+			"environment_id": environmentID,
+			"attempt":        attempt,
+			"wait":           wait.String(),
+			"error":          err.Error(),
+		})
+		if sleepErr := utils.SleepContext(ctx, wait); sleepErr != nil {
+			return httpResp, err
+		}
+		backoff = min(backoff*2, maxRevisionRetryBackoff)
+	}
+}
+
+// addPropagationRevisionFailure reports a propagation revision that could not be created, as a
+// warning or, when the provider's revision_failure_behavior is "error" or "retry", as an error.
+func addPropagationRevisionFailure(diags *diag.Diagnostics, c *client.Client, detail string) {
+	if c != nil && c.RevisionFailureBehavior != client.RevisionFailureWarn {
+		diags.AddError("Propagation Revision Not Created", detail)
+		return
+	}
+	diags.AddWarning("Propagation Revision Not Created", detail)
+}
+
+// deletePropagationMapping deletes a propagation mapping.
+//
+// The SDK sends DELETE to `/propagation/mapping/{id}` while the API serves
+// `/propagation/mappings/{id}`. The SDK path is tried first; when it fails in any way, the
+// hand-built path, which is known to work, is used instead. Whichever route succeeds is recorded
+// in endpoints for the client and used from then on, and a debug log records the outcome so the
+// manual workaround can be removed once the SDK is fixed.
+func deletePropagationMapping(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, mappingID string) (*http.Response, error) {
 	if apiClient == nil {
 		return nil, fmt.Errorf("nil api client")
 	}
 
-	cfg := apiClient.GetConfig()
-	if cfg == nil {
-		return nil, fmt.Errorf("api client has nil config")
+	switch endpoints.MappingDeleteRoute(apiClient) {
+	case client.MappingDeleteRouteManual:
+		return deletePropagationMappingManual(ctx, endpoints, apiClient, environmentID, mappingID)
+	case client.MappingDeleteRouteSDK:
+		return deletePropagationMappingSDK(ctx, apiClient, environmentID, mappingID)
+	}
+
+	httpResp, err := apiClient.PropagationMappingsApi.
+		EnvironmentsEnvironmentIDPropagationMappingMappingIDDelete(ctx, environmentID, mappingID).
+		Execute()
+	if err == nil && httpResp != nil && httpResp.StatusCode < 300 {
+		endpoints.SetMappingDeleteRoute(apiClient, client.MappingDeleteRouteSDK)
+		tflog.Debug(ctx, "SDK propagation mapping DELETE path succeeded; the manual URL workaround is no longer needed", map[string]interface{}{
+			"environment_id": environmentID,
+			"mapping_id":     mappingID,
+		})
+		return httpResp, nil
+	}
+
+	// Until a route has worked for this client, an SDK failure may come from its wrong path
+	// rather than from the mapping, so the manual path decides the outcome.
+	fields := map[string]interface{}{
+		"environment_id": environmentID,
+		"mapping_id":     mappingID,
+	}
+	if httpResp != nil {
+		fields["status_code"] = httpResp.StatusCode
 	}
-	if cfg.HTTPClient == nil {
-		return nil, fmt.Errorf("api client has nil http client")
+	if err != nil {
+		fields["error"] = err.Error()
 	}
+	tflog.Debug(ctx, "SDK propagation mapping DELETE path failed; falling back to the manual mappings URL", fields)
 
-	// Use a known-good server definition to derive the base path (the SDK's DELETE endpoint is currently incorrect).
-	basePath, err := cfg.ServerURLWithContext(ctx, "PropagationMappingsApiService.EnvironmentsEnvironmentIDPropagationMappingsMappingIDGet")
+	manualResp, manualErr := deletePropagationMappingManual(ctx, endpoints, apiClient, environmentID, mappingID)
+	// A 404 on the manual path means the mapping is already gone, which says nothing about
+	// which route is correct.
+	if manualErr == nil && manualResp != nil && manualResp.StatusCode < 300 {
+		endpoints.SetMappingDeleteRoute(apiClient, client.MappingDeleteRouteManual)
+		tflog.Debug(ctx, "Manual propagation mapping DELETE path succeeded; using it for this client", map[string]interface{}{
+			"environment_id": environmentID,
+			"mapping_id":     mappingID,
+		})
+	}
+
+	return manualResp, manualErr
+}
+
+func deletePropagationMappingSDK(ctx context.Context, apiClient *management.APIClient, environmentID string, mappingID string) (*http.Response, error) {
+	httpResp, err := apiClient.PropagationMappingsApi.
+		EnvironmentsEnvironmentIDPropagationMappingMappingIDDelete(ctx, environmentID, mappingID).
+		Execute()
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return httpResp, nil
+	}
 	if err != nil {
-		return nil, err
+		return httpResp, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
 	}
-	basePath = normalizePropagationMappingBasePath(basePath)
+	return httpResp, nil
+}
 
-	endpoint, err := url.JoinPath(
-		basePath,
-		"environments",
-		environmentID,
-		"propagation",
-		"mappings",
-		mappingID,
-	)
+func deletePropagationMappingManual(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, mappingID string) (*http.Response, error) {
+	// Use a known-good server definition to derive the base path (the SDK's DELETE endpoint is currently incorrect).
+	endpoint, httpClient, err := pingOneEndpoint(ctx, endpoints, apiClient, endpointFamilyPropagationMappings,
+		"environments", environmentID, "propagation", "mappings", mappingID)
 	if err != nil {
 		return nil, err
 	}
@@ -785,7 +1634,7 @@ func deletePropagationMapping(ctx context.Context, apiClient *management.APIClie
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := cfg.HTTPClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return resp, err
 	}
@@ -822,21 +1671,24 @@ func normalizePropagationMappingBasePath(basePath string) string {
 	return basePath
 }
 
-func deletePropagationMappingWithFallback(ctx context.Context, apiClient *management.APIClient, environmentID string, mappingID string) (*http.Response, error) {
-	httpResp, err := deletePropagationMapping(ctx, apiClient, environmentID, mappingID)
+func deletePropagationMappingWithFallback(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, mappingID string) (*http.Response, error) {
+	requestClient := pingOneRequestClient(endpoints, apiClient)
+	httpResp, err := deletePropagationMapping(ctx, endpoints, requestClient, environmentID, mappingID)
 	if err == nil {
 		return httpResp, nil
 	}
 
 	if shouldTryAlternateHostname(err, httpResp) {
-		for _, hostname := range pingOneFallbackBaseHostnames(apiClient) {
-			altClient, altErr := cloneManagementClientWithBaseHostname(apiClient, hostname)
+		for _, hostname := range pingOneFallbackBaseHostnames(requestClient) {
+			altClient, altErr := cloneManagementClientWithBaseHostname(requestClient, hostname)
 			if altErr != nil || altClient == nil {
 				continue
 			}
 
-			httpResp, err = deletePropagationMapping(ctx, altClient, environmentID, mappingID)
+			httpResp, err = deletePropagationMapping(ctx, endpoints, altClient, environmentID, mappingID)
 			if err == nil {
+				logHostnameFallback(ctx, apiClient, hostname, "delete propagation mapping")
+				rememberHostnameFallback(endpoints, apiClient, altClient)
 				return httpResp, nil
 			}
 			if !shouldTryAlternateHostname(err, httpResp) {
@@ -848,6 +1700,31 @@ func deletePropagationMappingWithFallback(ctx context.Context, apiClient *manage
 	return httpResp, err
 }
 
+// propagationRuleReadOnlyFields are the fields of a rule PingOne returns that are not sent back.
+var propagationRuleReadOnlyFields = []string{"id", "_links", "_embedded", "createdAt", "updatedAt"}
+
+// mergePropagationRuleChanges returns current, the rule as PingOne returns it, with the fields
+// that differ between the prior and desired payloads set to their desired values. Fields in prior
+// that desired no longer sets are removed.
+func mergePropagationRuleChanges(current map[string]interface{}, prior map[string]interface{}, desired map[string]interface{}) map[string]interface{} {
+	merged := cloneInterfaceMap(current)
+	for _, field := range propagationRuleReadOnlyFields {
+		delete(merged, field)
+	}
+
+	for field, value := range desired {
+		if priorValue, ok := prior[field]; !ok || !reflect.DeepEqual(priorValue, value) {
+			merged[field] = value
+		}
+	}
+	for field := range prior {
+		if _, ok := desired[field]; !ok {
+			delete(merged, field)
+		}
+	}
+	return merged
+}
+
 func cloneInterfaceMap(src map[string]interface{}) map[string]interface{} {
 	dst := make(map[string]interface{}, len(src))
 	for k, v := range src {
@@ -856,6 +1733,53 @@ func cloneInterfaceMap(src map[string]interface{}) map[string]interface{} {
 	return dst
 }
 
+// Values of `population_match`.
+const (
+	populationMatchAny = "any"
+	populationMatchAll = "all"
+)
+
+// populationMatchAllValidator rejects `population_match = "all"` next to more than one population
+// ID. A PingOne user belongs to one population, so joining population IDs with `and` selects no
+// users.
+type populationMatchAllValidator struct{}
+
+func (v populationMatchAllValidator) Description(_ context.Context) string {
+	return "all accepts at most one population ID."
+}
+
+func (v populationMatchAllValidator) MarkdownDescription(_ context.Context) string {
+	return "`all` accepts at most one population ID."
+}
+
+func (v populationMatchAllValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() != populationMatchAll {
+		return
+	}
+
+	var populationIDs types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("population_ids"), &populationIDs)...)
+	if resp.Diagnostics.HasError() || populationIDs.IsNull() || populationIDs.IsUnknown() {
+		return
+	}
+
+	distinct := map[string]bool{}
+	for _, element := range populationIDs.Elements() {
+		id, ok := element.(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() || strings.TrimSpace(id.ValueString()) == "" {
+			continue
+		}
+		distinct[strings.TrimSpace(id.ValueString())] = true
+	}
+	if len(distinct) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Population Match",
+			fmt.Sprintf("population_match = \"all\" joins population IDs with `and`, but a PingOne user belongs to exactly one population, so with %d population IDs the rule would select no users. Use \"any\" to select users in any of the populations, or list a single population ID.", len(distinct)),
+		)
+	}
+}
+
 func populationExpressionFromModel(ctx context.Context, model *customtypes.PropagationRuleModel) (string, bool) {
 	if model == nil {
 		return "", false
@@ -885,9 +1809,14 @@ func populationExpressionFromModel(ctx context.Context, model *customtypes.Propa
 	}
 	sort.Strings(popExprParts)
 
+	joiner := " or "
+	if !model.PopulationMatch.IsNull() && !model.PopulationMatch.IsUnknown() && model.PopulationMatch.ValueString() == populationMatchAll {
+		joiner = " and "
+	}
+
 	popExpr := ""
 	if len(popExprParts) > 0 {
-		popExpr = strings.Join(popExprParts, " or ")
+		popExpr = strings.Join(popExprParts, joiner)
 	}
 
 	switch {
@@ -902,6 +1831,67 @@ func populationExpressionFromModel(ctx context.Context, model *customtypes.Propa
 	}
 }
 
+// populationExpressionSent returns the populationExpression the provider sends for model, or
+// null when it sends none.
+func populationExpressionSent(ctx context.Context, model *customtypes.PropagationRuleModel) types.String {
+	if !model.Active.IsNull() && !model.Active.IsUnknown() && model.Active.ValueBool() {
+		return types.StringValue(populationExpressionForModel(ctx, model))
+	}
+	if expr, ok := populationExpressionFromModel(ctx, model); ok {
+		return types.StringValue(expr)
+	}
+	return types.StringNull()
+}
+
+// planPopulationExpression plans `population_expression` from the planned inputs. It keeps the
+// prior value, which may be PingOne's normalised form, while the inputs are unchanged, and
+// leaves it unknown while any input is unknown.
+func planPopulationExpression(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Only the inputs are read, since `mappings` can still be unknown.
+	plan, planDiags := populationExpressionInputs(ctx, resp.Plan.GetAttribute)
+	diags.Append(planDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if plan.Active.IsUnknown() || plan.Filter.IsUnknown() || plan.PopulationIds.IsUnknown() || plan.PopulationMatch.IsUnknown() {
+		return diags
+	}
+
+	expr := populationExpressionSent(ctx, &plan)
+
+	if !req.State.Raw.IsNull() {
+		state, stateDiags := populationExpressionInputs(ctx, req.State.GetAttribute)
+		diags.Append(stateDiags...)
+		var stateExpr types.String
+		diags.Append(req.State.GetAttribute(ctx, path.Root("population_expression"), &stateExpr)...)
+		if diags.HasError() {
+			return diags
+		}
+		if populationExpressionSent(ctx, &state).Equal(expr) {
+			expr = stateExpr
+		}
+	}
+
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("population_expression"), expr)...)
+	return diags
+}
+
+// populationExpressionInputs reads the attributes a rule's population expression is built from
+// with getAttribute, such as the GetAttribute of a plan or state.
+func populationExpressionInputs(ctx context.Context, getAttribute func(context.Context, path.Path, interface{}) diag.Diagnostics) (customtypes.PropagationRuleModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var model customtypes.PropagationRuleModel
+
+	diags.Append(getAttribute(ctx, path.Root("active"), &model.Active)...)
+	diags.Append(getAttribute(ctx, path.Root("filter"), &model.Filter)...)
+	diags.Append(getAttribute(ctx, path.Root("population_ids"), &model.PopulationIds)...)
+	diags.Append(getAttribute(ctx, path.Root("population_match"), &model.PopulationMatch)...)
+	return model, diags
+}
+
 func populationExpressionForModel(ctx context.Context, model *customtypes.PropagationRuleModel) string {
 	if expr, ok := populationExpressionFromModel(ctx, model); ok {
 		return expr
@@ -943,6 +1933,39 @@ func cloneManagementClientWithBaseHostname(apiClient *management.APIClient, base
 	return management.NewAPIClient(cfg), nil
 }
 
+// endpointsOf returns the endpoints recorded for c, or nil, which records nothing, when there is no
+// provider client.
+func endpointsOf(c *client.Client) *client.Endpoints {
+	if c == nil {
+		return nil
+	}
+	return c.Endpoints
+}
+
+// rememberHostnameFallback records in endpoints that altClient served a request that configured
+// could not, so that later requests through the same provider instance start on altClient's
+// hostname instead of probing every region again. A request served by configured's own hostname
+// clears the record.
+func rememberHostnameFallback(endpoints *client.Endpoints, configured *management.APIClient, altClient *management.APIClient) {
+	if configured == nil || altClient == nil {
+		return
+	}
+	if strings.EqualFold(currentPingOneHostname(configured), currentPingOneHostname(altClient)) {
+		endpoints.SetHostnameFallback(configured, nil)
+		return
+	}
+	endpoints.SetHostnameFallback(configured, altClient)
+}
+
+// pingOneRequestClient returns the client to start a request for configured with: the client
+// for the fallback hostname recorded in endpoints, or configured itself.
+func pingOneRequestClient(endpoints *client.Endpoints, configured *management.APIClient) *management.APIClient {
+	if altClient, ok := endpoints.HostnameFallback(configured); ok {
+		return altClient
+	}
+	return configured
+}
+
 func pingOneFallbackBaseHostnames(apiClient *management.APIClient) []string {
 	current := currentPingOneHostname(apiClient)
 
@@ -979,6 +2002,60 @@ func pingOneFallbackBaseHostnames(apiClient *management.APIClient) []string {
 	return out
 }
 
+// addHostnameFallbackWarning warns when a request only succeeded through a fallback hostname,
+// which usually means the provider's region does not match the environment's. The fallback
+// hostname is reused for the rest of the run; the next run starts on the configured hostname
+// again.
+func addHostnameFallbackWarning(diags *diag.Diagnostics, configured *management.APIClient, effective *management.APIClient, action string) {
+	configuredHost := currentPingOneHostname(configured)
+	effectiveHost := currentPingOneHostname(effective)
+	if effectiveHost == "" || strings.EqualFold(configuredHost, effectiveHost) {
+		return
+	}
+
+	configuredDesc := configuredHost
+	if region := pingOneRegionForHostname(configuredHost); region != "" {
+		configuredDesc = fmt.Sprintf("%s (%s)", configuredHost, region)
+	}
+
+	diags.AddWarning(
+		"PingOne Hostname Fallback Used",
+		fmt.Sprintf("%s via %s though the provider is configured for %s. Set the provider's `region` or `api_base_url` to match the environment; "+
+			"%s is reused for the rest of this run, but the next run starts on the configured hostname again.", action, effectiveHost, configuredDesc, effectiveHost),
+	)
+}
+
+// logHostnameFallback records a request that succeeded through a fallback hostname where no
+// diagnostics can be returned.
+func logHostnameFallback(ctx context.Context, configured *management.APIClient, hostname string, action string) {
+	tflog.Warn(ctx, "PingOne request succeeded via fallback hostname", map[string]interface{}{
+		"action":              action,
+		"hostname":            hostname,
+		"configured_hostname": currentPingOneHostname(configured),
+	})
+}
+
+// pingOneRegionForHostname returns the short region code for a PingOne API hostname, or "" if
+// the hostname is not a PingOne regional host.
+func pingOneRegionForHostname(hostname string) string {
+	switch {
+	case strings.HasSuffix(hostname, "pingone.com.au"):
+		return "AU"
+	case strings.HasSuffix(hostname, "pingone.asia"):
+		return "AP"
+	case strings.HasSuffix(hostname, "pingone.eu"):
+		return "EU"
+	case strings.HasSuffix(hostname, "pingone.ca"):
+		return "CA"
+	case strings.HasSuffix(hostname, "pingone.sg"):
+		return "SG"
+	case strings.HasSuffix(hostname, "pingone.com"):
+		return "NA"
+	default:
+		return ""
+	}
+}
+
 func currentPingOneHostname(apiClient *management.APIClient) string {
 	if apiClient == nil {
 		return ""
@@ -1011,7 +2088,7 @@ func currentPingOneHostname(apiClient *management.APIClient) string {
 	}
 }
 
-func propagationRuleIDFromCreateResponse(ctx context.Context, apiClient *management.APIClient, environmentID string, planID string, name string, sourceStoreID string, targetStoreID string, httpResp *http.Response) (string, error) {
+func propagationRuleIDFromCreateResponse(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, planID string, name string, sourceStoreID string, targetStoreID string, httpResp *http.Response, pageSize int32) (string, error) {
 	decoded, err := utils.DecodeResponseJSON(httpResp)
 	if err != nil {
 		return "", err
@@ -1025,36 +2102,11 @@ func propagationRuleIDFromCreateResponse(ctx context.Context, apiClient *managem
 
 	var lastErr error
 	for attempt := 0; attempt < 5; attempt++ {
-		rules, err := listPropagationRulesForPlan(ctx, apiClient, environmentID, planID)
+		rules, err := listPropagationRulesForPlan(ctx, endpoints, apiClient, environmentID, planID, pageSize)
 		if err != nil {
 			lastErr = err
 		} else {
-			var matches []string
-			for _, rule := range rules {
-				ruleName, _ := utils.NestedString(rule, "name")
-				if ruleName != name {
-					continue
-				}
-
-				if sourceStoreID != "" {
-					srcID, _ := utils.NestedString(rule, "sourceStore", "id")
-					if srcID != sourceStoreID {
-						continue
-					}
-				}
-				if targetStoreID != "" {
-					tgtID, _ := utils.NestedString(rule, "targetStore", "id")
-					if tgtID != targetStoreID {
-						continue
-					}
-				}
-
-				id, _ := utils.NestedString(rule, "id")
-				if id != "" {
-					matches = append(matches, id)
-				}
-			}
-
+			matches := matchingPropagationRuleIDs(rules, name, sourceStoreID, targetStoreID)
 			if len(matches) == 1 {
 				return matches[0], nil
 			}
@@ -1065,12 +2117,44 @@ func propagationRuleIDFromCreateResponse(ctx context.Context, apiClient *managem
 			lastErr = fmt.Errorf("rule not found yet")
 		}
 
-		time.Sleep(time.Duration(attempt+1) * 300 * time.Millisecond)
+		if err := utils.SleepContext(ctx, time.Duration(attempt+1)*300*time.Millisecond); err != nil {
+			return "", fmt.Errorf("could not locate created rule (name=%q source=%q target=%q): %w", name, sourceStoreID, targetStoreID, err)
+		}
 	}
 
 	return "", fmt.Errorf("could not locate created rule (name=%q source=%q target=%q): %v", name, sourceStoreID, targetStoreID, lastErr)
 }
 
+// matchingPropagationRuleIDs returns the IDs of the rules with the given name and, when set,
+// source and target store IDs.
+func matchingPropagationRuleIDs(rules []map[string]interface{}, name string, sourceStoreID string, targetStoreID string) []string {
+	var matches []string
+	for _, rule := range rules {
+		ruleName, _ := utils.NestedString(rule, "name")
+		if ruleName != name {
+			continue
+		}
+
+		if sourceStoreID != "" {
+			srcID, _ := utils.NestedString(rule, "sourceStore", "id")
+			if srcID != sourceStoreID {
+				continue
+			}
+		}
+		if targetStoreID != "" {
+			tgtID, _ := utils.NestedString(rule, "targetStore", "id")
+			if tgtID != targetStoreID {
+				continue
+			}
+		}
+
+		if id, _ := utils.NestedString(rule, "id"); id != "" {
+			matches = append(matches, id)
+		}
+	}
+	return matches
+}
+
 func readPropagationRule(ctx context.Context, apiClient *management.APIClient, environmentID string, ruleID string) (map[string]interface{}, *http.Response, error) {
 	httpResp, err := apiClient.PropagationRulesApi.
 		EnvironmentsEnvironmentIDPropagationRulesRuleIDGet(ctx, environmentID, ruleID).
@@ -1079,47 +2163,59 @@ func readPropagationRule(ctx context.Context, apiClient *management.APIClient, e
 		return nil, httpResp, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
 	}
 
-	decoded, err := utils.DecodeResponseJSON(httpResp)
+	ruleObj, err := utils.DecodeResponseJSONObject(httpResp, "rule")
 	if err != nil {
 		return nil, httpResp, err
 	}
 
-	ruleObj, ok := decoded.(map[string]interface{})
-	if !ok {
-		return nil, httpResp, fmt.Errorf("unexpected rule response shape")
-	}
-
 	return ruleObj, httpResp, nil
 }
 
-func listPropagationRulesForPlan(ctx context.Context, apiClient *management.APIClient, environmentID string, planID string) ([]map[string]interface{}, error) {
-	httpResp, err := apiClient.PropagationRulesApi.
-		EnvironmentsEnvironmentIDPropagationPlansPlanIDRulesGet(ctx, environmentID, planID).
-		Execute()
-	if err != nil {
-		return nil, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
-	}
+func listPropagationRulesForPlan(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, planID string, pageSize int32) ([]map[string]interface{}, error) {
+	return listPingOneCollection(
+		ctx,
+		endpoints,
+		apiClient,
+		endpointFamilyPropagationRules,
+		fmt.Sprintf("/environments/%s/propagation/plans/%s/rules", url.PathEscape(environmentID), url.PathEscape(planID)),
+		pageSize,
+		"rules", "items",
+	)
+}
 
-	decoded, err := utils.DecodeResponseJSON(httpResp)
+// propagationPlanRuleCount returns the number of rules in a plan, or null when they cannot be
+// counted, so that a failed count never fails a read.
+func propagationPlanRuleCount(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, planID string) types.Int64 {
+	count, err := countPingOneCollection(
+		ctx,
+		endpoints,
+		apiClient,
+		endpointFamilyPropagationRules,
+		fmt.Sprintf("/environments/%s/propagation/plans/%s/rules", url.PathEscape(environmentID), url.PathEscape(planID)),
+		"rules", "items",
+	)
 	if err != nil {
-		return nil, err
+		tflog.Debug(ctx, "Could not count propagation plan rules", map[string]interface{}{
+			"plan_id": planID,
+			"error":   err.Error(),
+		})
+		return types.Int64Null()
 	}
+	return types.Int64Value(count)
+}
 
-	list, err := utils.ExtractEmbeddedArray(decoded, "rules", "items")
+// readPropagationRuleLinks reads the HAL links of a rule that was just created, since the create
+// response is not kept. A failed read leaves links null until the next refresh.
+func readPropagationRuleLinks(ctx context.Context, service propagationService, environmentID string, ruleID string) types.Map {
+	ruleObj, _, err := service.RuleRead(ctx, environmentID, ruleID)
 	if err != nil {
-		return nil, err
-	}
-
-	var rules []map[string]interface{}
-	for _, v := range list {
-		m, ok := v.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		rules = append(rules, m)
+		tflog.Debug(ctx, "Could not read propagation rule links", map[string]interface{}{
+			"rule_id": ruleID,
+			"error":   err.Error(),
+		})
+		return types.MapNull(types.StringType)
 	}
-
-	return rules, nil
+	return linksFromJSON(ruleObj)
 }
 
 func applyRuleAPIToState(ctx context.Context, apiObj map[string]interface{}, state *customtypes.PropagationRuleModel) diag.Diagnostics {
@@ -1131,6 +2227,9 @@ func applyRuleAPIToState(ctx context.Context, apiObj map[string]interface{}, sta
 	if name, ok := utils.NestedString(apiObj, "name"); ok && name != "" {
 		state.Name = types.StringValue(name)
 	}
+	if description, ok := utils.NestedString(apiObj, "description"); ok && description != "" {
+		state.Description = types.StringValue(description)
+	}
 	if planID, ok := utils.NestedString(apiObj, "plan", "id"); ok && planID != "" {
 		state.PlanId = types.StringValue(planID)
 	}
@@ -1140,6 +2239,7 @@ func applyRuleAPIToState(ctx context.Context, apiObj map[string]interface{}, sta
 	if tgtID, ok := utils.NestedString(apiObj, "targetStore", "id"); ok && tgtID != "" {
 		state.TargetStoreId = types.StringValue(tgtID)
 	}
+	state.Links = linksFromJSON(apiObj)
 
 	if !state.Filter.IsNull() && !state.Filter.IsUnknown() {
 		if v, ok := apiObj["populationExpression"]; ok {
@@ -1192,9 +2292,9 @@ func applyRuleAPIToState(ctx context.Context, apiObj map[string]interface{}, sta
 					}
 				}
 
-				listVal, listDiags := types.ListValueFrom(ctx, types.StringType, ids)
-				diags.Append(listDiags...)
-				state.PopulationIds = listVal
+				setVal, setDiags := types.SetValueFrom(ctx, types.StringType, ids)
+				diags.Append(setDiags...)
+				state.PopulationIds = setVal
 			}
 		}
 	}
@@ -1211,12 +2311,19 @@ func applyRuleAPIToState(ctx context.Context, apiObj map[string]interface{}, sta
 				}
 				sort.Strings(ids)
 
-				listVal, listDiags := types.ListValueFrom(ctx, types.StringType, ids)
-				diags.Append(listDiags...)
-				state.GroupIds = listVal
+				setVal, setDiags := types.SetValueFrom(ctx, types.StringType, ids)
+				diags.Append(setDiags...)
+				state.GroupIds = setVal
 			}
 		}
 	}
+	if !state.CorrelationAttributes.IsNull() && !state.CorrelationAttributes.IsUnknown() {
+		if _, ok := apiObj["correlationAttributes"]; ok {
+			listVal, listDiags := correlationAttributesFromAPI(ctx, apiObj)
+			diags.Append(listDiags...)
+			state.CorrelationAttributes = listVal
+		}
+	}
 
 	return diags
 }
@@ -1246,8 +2353,16 @@ func groupRefsFromIDs(ids []string) []map[string]interface{} {
 	return out
 }
 
-func ensurePropagationRuleMappings(ctx context.Context, apiClient *management.APIClient, environmentID string, ruleID string, prior []customtypes.PropagationRuleMappingModel, desired []customtypes.PropagationRuleMappingModel) error {
-	existing, err := listPropagationRuleMappings(ctx, apiClient, environmentID, ruleID)
+// ensurePropagationRuleMappings reconciles the rule's mappings with the desired set.
+//
+// Mappings that already match are left alone. A mapping whose target attribute is unchanged but
+// whose source or expression differs is updated in place so its ID is preserved; mappings are
+// only deleted and recreated when the target attribute changes or the API rejects the update.
+//
+// When authoritative is false, only mappings that were in prior may be deleted or reused; any
+// other mapping on the rule was added outside Terraform and is left in place.
+func ensurePropagationRuleMappings(ctx context.Context, service propagationService, environmentID string, ruleID string, prior []customtypes.PropagationRuleMappingModel, desired []customtypes.PropagationRuleMappingModel, authoritative bool) error {
+	existing, err := service.MappingList(ctx, environmentID, ruleID)
 	if err != nil {
 		return err
 	}
@@ -1261,79 +2376,154 @@ func ensurePropagationRuleMappings(ctx context.Context, apiClient *management.AP
 		existingByKey[key] = m
 	}
 
-	desiredKeys := make(map[string]customtypes.PropagationRuleMappingModel)
+	type mappingUpdate struct {
+		id      string
+		desired customtypes.PropagationRuleMappingModel
+	}
+	var updates []mappingUpdate
+
+	desiredKeys := make(map[string]bool)
+	var missing []customtypes.PropagationRuleMappingModel
 	for _, m := range desired {
-		key := mappingKey(m.SourceAttribute.ValueString(), m.TargetAttribute.ValueString(), m.Expression.ValueString())
-		if key == "" {
+		key := mappingKey(m.SourceAttribute.ValueString(), m.TargetAttribute.ValueString(), mappingExpression(m))
+		if key == "" || desiredKeys[key] {
+			continue
+		}
+		desiredKeys[key] = true
+		current, ok := existingByKey[key]
+		if !ok {
+			missing = append(missing, m)
 			continue
 		}
-		desiredKeys[key] = m
+		// A disabled mapping is still present; only its flag needs updating.
+		if mappingEnabledDiffers(m, current) {
+			if id, _ := utils.NestedString(current, "id"); id != "" {
+				updates = append(updates, mappingUpdate{id: id, desired: m})
+			}
+		}
 	}
 
-	// Delete mappings not desired.
+	// Existing mappings that are no longer desired, indexed by target attribute so they can be
+	// reused for an in-place update.
+	priorKeys := make(map[string]bool, len(prior))
+	for _, m := range prior {
+		priorKeys[mappingKey(m.SourceAttribute.ValueString(), m.TargetAttribute.ValueString(), mappingExpression(m))] = true
+	}
+	staleKeys := make([]string, 0)
+	staleByTarget := make(map[string]string)
 	for key, m := range existingByKey {
-		if _, ok := desiredKeys[key]; ok {
+		if desiredKeys[key] {
 			continue
 		}
-		id, _ := utils.NestedString(m, "id")
+		if !authoritative && !priorKeys[key] {
+			continue
+		}
+		staleKeys = append(staleKeys, key)
+		target, _ := utils.NestedString(m, "targetAttribute")
+		target = strings.ToLower(strings.TrimSpace(target))
+		if _, ok := staleByTarget[target]; !ok {
+			staleByTarget[target] = key
+		}
+	}
+	sort.Strings(staleKeys)
+
+	var creates []customtypes.PropagationRuleMappingModel
+	reused := make(map[string]bool)
+
+	for _, m := range missing {
+		target := strings.ToLower(strings.TrimSpace(m.TargetAttribute.ValueString()))
+		if key, ok := staleByTarget[target]; ok && !reused[key] {
+			if id, _ := utils.NestedString(existingByKey[key], "id"); id != "" {
+				reused[key] = true
+				updates = append(updates, mappingUpdate{id: id, desired: m})
+				continue
+			}
+		}
+		creates = append(creates, m)
+	}
+
+	// Delete mappings not desired.
+	for _, key := range staleKeys {
+		if reused[key] {
+			continue
+		}
+		id, _ := utils.NestedString(existingByKey[key], "id")
 		if id == "" {
 			continue
 		}
-		delResp, delErr := deletePropagationMappingWithFallback(ctx, apiClient, environmentID, id)
+		delResp, delErr := service.MappingDelete(ctx, environmentID, id)
 		if delErr != nil {
 			return fmt.Errorf("delete mapping %s: %s", id, utils.HandleSDKError(delErr, delResp))
 		}
 	}
 
-	// Create mappings that are missing.
-	requestClient := apiClient
-	for key, m := range desiredKeys {
-		if _, ok := existingByKey[key]; ok {
+	// Update mappings whose target is unchanged.
+	for _, u := range updates {
+		payload := propagationMappingPayload(u.desired)
+
+		httpResp, updateErr := service.MappingUpdate(ctx, environmentID, u.id, payload)
+		if updateErr == nil {
+			tflog.Debug(ctx, "Updated propagation mapping in place", map[string]interface{}{
+				"rule_id":          ruleID,
+				"mapping_id":       u.id,
+				"target_attribute": payload["targetAttribute"],
+			})
 			continue
 		}
 
-		source := strings.TrimSpace(m.SourceAttribute.ValueString())
-		target := strings.TrimSpace(m.TargetAttribute.ValueString())
-		expression := strings.TrimSpace(m.Expression.ValueString())
-
-		payload := map[string]interface{}{
-			"targetAttribute": target,
+		if httpResp == nil || (httpResp.StatusCode != http.StatusMethodNotAllowed && httpResp.StatusCode != http.StatusNotFound) {
+			return fmt.Errorf("update mapping %s: %s", u.id, utils.HandleSDKError(updateErr, httpResp))
 		}
-		if expression != "" {
-			payload["expression"] = expression
-		} else {
-			payload["sourceAttribute"] = source
-		}
-
-		httpResp, createErr := requestClient.PropagationMappingsApi.
-			EnvironmentsEnvironmentIDPropagationRulesRuleIDMappingsPost(ctx, environmentID, ruleID).
-			Body(payload).
-			Execute()
-		if createErr != nil && shouldTryAlternateHostname(createErr, httpResp) {
-			for _, hostname := range pingOneFallbackBaseHostnames(requestClient) {
-				altClient, altErr := cloneManagementClientWithBaseHostname(requestClient, hostname)
-				if altErr != nil || altClient == nil {
-					continue
-				}
 
-				altResp, altReqErr := altClient.PropagationMappingsApi.
-					EnvironmentsEnvironmentIDPropagationRulesRuleIDMappingsPost(ctx, environmentID, ruleID).
-					Body(payload).
-					Execute()
+		// The API did not accept an in-place update; fall back to delete and create.
+		tflog.Debug(ctx, "In-place propagation mapping update not supported; recreating mapping", map[string]interface{}{
+			"rule_id":     ruleID,
+			"mapping_id":  u.id,
+			"status_code": httpResp.StatusCode,
+		})
+		delResp, delErr := service.MappingDelete(ctx, environmentID, u.id)
+		if delErr != nil {
+			return fmt.Errorf("delete mapping %s: %s", u.id, utils.HandleSDKError(delErr, delResp))
+		}
+		creates = append(creates, u.desired)
+	}
 
-				httpResp = altResp
-				createErr = altReqErr
+	// Mappings that existed before this call are never adopted as the result of a create.
+	knownIDs := make(map[string]bool, len(existing))
+	for _, m := range existing {
+		if id, _ := utils.NestedString(m, "id"); id != "" {
+			knownIDs[id] = true
+		}
+	}
 
-				if createErr == nil {
-					requestClient = altClient
-					break
+	// Create mappings that are missing.
+	for _, m := range creates {
+		payload := propagationMappingPayload(m)
+		target, _ := payload["targetAttribute"].(string)
+
+		_, httpResp, createErr := createOrAdopt(ctx, "propagation mapping", target,
+			func(ctx context.Context) (struct{}, *http.Response, error) {
+				httpResp, createErr := service.MappingCreate(ctx, environmentID, ruleID, payload)
+				return struct{}{}, httpResp, createErr
+			},
+			func(ctx context.Context) (struct{}, *http.Response, bool, error) {
+				mappings, err := service.MappingList(ctx, environmentID, ruleID)
+				if err != nil {
+					return struct{}{}, nil, false, err
 				}
-				if !shouldTryAlternateHostname(createErr, httpResp) {
-					break
+				for _, mapping := range mappings {
+					id, _ := utils.NestedString(mapping, "id")
+					mappingTarget, _ := utils.NestedString(mapping, "targetAttribute")
+					if id != "" && !knownIDs[id] && strings.EqualFold(strings.TrimSpace(mappingTarget), target) {
+						knownIDs[id] = true
+						return struct{}{}, nil, true, nil
+					}
 				}
-			}
-		}
+				return struct{}{}, nil, false, nil
+			},
+		)
 		if createErr != nil {
+			key := mappingKey(m.SourceAttribute.ValueString(), m.TargetAttribute.ValueString(), mappingExpression(m))
 			return fmt.Errorf("create mapping %s: %s", key, utils.HandleSDKError(createErr, httpResp))
 		}
 	}
@@ -1341,15 +2531,71 @@ func ensurePropagationRuleMappings(ctx context.Context, apiClient *management.AP
 	return nil
 }
 
-func resolvePropagationRuleMappings(ctx context.Context, apiClient *management.APIClient, environmentID string, ruleID string, preferredOrder []customtypes.PropagationRuleMappingModel) ([]customtypes.PropagationRuleMappingModel, error) {
-	existing, err := listPropagationRuleMappings(ctx, apiClient, environmentID, ruleID)
+// propagationMappingPayload builds the create/update request body for a mapping. An
+// expression takes precedence over a source attribute.
+func propagationMappingPayload(m customtypes.PropagationRuleMappingModel) map[string]interface{} {
+	source := strings.TrimSpace(m.SourceAttribute.ValueString())
+	target := strings.TrimSpace(m.TargetAttribute.ValueString())
+	expression := mappingExpression(m)
+
+	payload := map[string]interface{}{
+		"targetAttribute": target,
+	}
+	if expression != "" {
+		payload["expression"] = expression
+	} else {
+		payload["sourceAttribute"] = source
+	}
+	if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+		payload["enabled"] = m.Enabled.ValueBool()
+	}
+	return payload
+}
+
+// mappingExpression returns the mapping's expression, whether it is configured as `expression`,
+// `sensitive_expression` or `expression_builder`.
+func mappingExpression(m customtypes.PropagationRuleMappingModel) string {
+	if !m.Expression.IsNull() && !m.Expression.IsUnknown() {
+		return strings.TrimSpace(m.Expression.ValueString())
+	}
+	if !m.SensitiveExpression.IsNull() && !m.SensitiveExpression.IsUnknown() {
+		return strings.TrimSpace(m.SensitiveExpression.ValueString())
+	}
+	// An invalid builder is reported by validatePropagationRuleMappings.
+	expression, _ := compileMappingExpression(m.ExpressionBuilder)
+	return expression
+}
+
+// mappingEnabledDiffers reports whether the mapping's configured `enabled` flag differs from the
+// API object. A mapping without the flag in the API is enabled.
+func mappingEnabledDiffers(m customtypes.PropagationRuleMappingModel, apiObj map[string]interface{}) bool {
+	if m.Enabled.IsNull() || m.Enabled.IsUnknown() {
+		return false
+	}
+	return m.Enabled.ValueBool() != mappingEnabledFromAPI(apiObj)
+}
+
+func mappingEnabledFromAPI(apiObj map[string]interface{}) bool {
+	if v, ok := utils.NestedBool(apiObj, "enabled"); ok {
+		return v
+	}
+	return true
+}
+
+// resolvePropagationRuleMappings reads the rule's mappings and splits them into those matching
+// preferredOrder, in that order, and the extras that are not configured, sorted by key. When
+// keepMissing is true, configured mappings the API does not return are kept in matched; otherwise
+// they are dropped so that the deletion shows as drift.
+func resolvePropagationRuleMappings(ctx context.Context, service propagationService, environmentID string, ruleID string, preferredOrder []customtypes.PropagationRuleMappingModel, keepMissing bool) ([]customtypes.PropagationRuleMappingModel, []customtypes.PropagationRuleMappingModel, error) {
+	existing, err := service.MappingList(ctx, environmentID, ruleID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	existingByKey := make(map[string]customtypes.PropagationRuleMappingModel)
 	for _, m := range existing {
 		id, _ := utils.NestedString(m, "id")
+		enabled := mappingEnabledFromAPI(m)
 		source, _ := utils.NestedString(m, "sourceAttribute")
 		target, _ := utils.NestedString(m, "targetAttribute")
 		expression, _ := utils.NestedString(m, "expression")
@@ -1360,8 +2606,10 @@ func resolvePropagationRuleMappings(ctx context.Context, apiClient *management.A
 		}
 
 		mapping := customtypes.PropagationRuleMappingModel{
-			Id:              types.StringValue(id),
-			TargetAttribute: types.StringValue(target),
+			Id:                  types.StringValue(id),
+			TargetAttribute:     types.StringValue(target),
+			SensitiveExpression: types.StringNull(),
+			Enabled:             types.BoolValue(enabled),
 		}
 		if strings.TrimSpace(source) != "" {
 			mapping.SourceAttribute = types.StringValue(source)
@@ -1377,22 +2625,36 @@ func resolvePropagationRuleMappings(ctx context.Context, apiClient *management.A
 		existingByKey[key] = mapping
 	}
 
-	var resolved []customtypes.PropagationRuleMappingModel
+	var matched []customtypes.PropagationRuleMappingModel
 	seen := make(map[string]bool)
 
 	for _, preferred := range preferredOrder {
 		source := preferred.SourceAttribute.ValueString()
 		target := preferred.TargetAttribute.ValueString()
-		expression := preferred.Expression.ValueString()
+		expression := mappingExpression(preferred)
 		key := mappingKey(source, target, expression)
 		if key == "" {
 			continue
 		}
 		if v, ok := existingByKey[key]; ok {
-			resolved = append(resolved, v)
-		} else {
+			// Keep a sensitive expression in the sensitive attribute, and a built one in the
+			// builder.
+			if !preferred.SensitiveExpression.IsNull() {
+				v.SensitiveExpression = v.Expression
+				v.Expression = types.StringNull()
+			}
+			if preferred.ExpressionBuilder != nil {
+				v.ExpressionBuilder = preferred.ExpressionBuilder
+				v.Expression = types.StringNull()
+			}
+			// Leave the flag unset when the configuration does not manage it.
+			if preferred.Enabled.IsNull() {
+				v.Enabled = types.BoolNull()
+			}
+			matched = append(matched, v)
+		} else if keepMissing {
 			// Preserve configured mapping even if API doesn't return it yet.
-			resolved = append(resolved, preferred)
+			matched = append(matched, preferred)
 		}
 		seen[key] = true
 	}
@@ -1406,15 +2668,57 @@ func resolvePropagationRuleMappings(ctx context.Context, apiClient *management.A
 		remainingKeys = append(remainingKeys, key)
 	}
 	sort.Strings(remainingKeys)
+	var extra []customtypes.PropagationRuleMappingModel
 	for _, key := range remainingKeys {
-		resolved = append(resolved, existingByKey[key])
+		m := existingByKey[key]
+		// Only a disabled mapping needs the flag spelled out.
+		if m.Enabled.ValueBool() {
+			m.Enabled = types.BoolNull()
+		}
+		extra = append(extra, m)
+	}
+
+	return matched, extra, nil
+}
+
+// setPropagationRuleMappingsState records resolved mappings in state. In authoritative mode the
+// extras are part of `mappings`, so they show as drift against the configuration; otherwise they
+// are reported in `unmanaged_mappings`.
+func setPropagationRuleMappingsState(ctx context.Context, state *customtypes.PropagationRuleResourceModel, matched []customtypes.PropagationRuleMappingModel, extra []customtypes.PropagationRuleMappingModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	elemType := types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes}
+
+	if state.AuthoritativeMappings.IsNull() || state.AuthoritativeMappings.ValueBool() {
+		state.Mappings = append(matched, extra...)
+		state.UnmanagedMappings = types.ListNull(elemType)
+		return diags
 	}
 
-	return resolved, nil
+	state.Mappings = matched
+	unmanaged := make([]customtypes.PropagationRuleUnmanagedMappingModel, 0, len(extra))
+	for _, m := range extra {
+		expression := m.Expression
+		if expression.IsNull() {
+			expression = m.SensitiveExpression
+		}
+		enabled := m.Enabled
+		if enabled.IsNull() {
+			enabled = types.BoolValue(true)
+		}
+		unmanaged = append(unmanaged, customtypes.PropagationRuleUnmanagedMappingModel{
+			Id:              m.Id,
+			SourceAttribute: m.SourceAttribute,
+			TargetAttribute: m.TargetAttribute,
+			Expression:      expression,
+			Enabled:         enabled,
+		})
+	}
+	state.UnmanagedMappings, diags = types.ListValueFrom(ctx, elemType, unmanaged)
+	return diags
 }
 
-func deleteAllMappings(ctx context.Context, apiClient *management.APIClient, environmentID string, ruleID string) error {
-	mappings, err := listPropagationRuleMappings(ctx, apiClient, environmentID, ruleID)
+func deleteAllMappings(ctx context.Context, service propagationService, environmentID string, ruleID string) error {
+	mappings, err := service.MappingList(ctx, environmentID, ruleID)
 	if err != nil {
 		return err
 	}
@@ -1424,57 +2728,46 @@ func deleteAllMappings(ctx context.Context, apiClient *management.APIClient, env
 		if id == "" {
 			continue
 		}
-		_, _ = deletePropagationMappingWithFallback(ctx, apiClient, environmentID, id)
+		_, _ = service.MappingDelete(ctx, environmentID, id)
 	}
 	return nil
 }
 
-func listPropagationRuleMappings(ctx context.Context, apiClient *management.APIClient, environmentID string, ruleID string) ([]map[string]interface{}, error) {
-	httpResp, err := apiClient.PropagationMappingsApi.
-		EnvironmentsEnvironmentIDPropagationRulesRuleIDMappingsGet(ctx, environmentID, ruleID).
-		Execute()
-	if err != nil {
-		return nil, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
-	}
+func listPropagationRuleMappings(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, ruleID string, pageSize int32) ([]map[string]interface{}, error) {
+	return listPingOneCollection(
+		ctx,
+		endpoints,
+		apiClient,
+		endpointFamilyPropagationMappings,
+		fmt.Sprintf("/environments/%s/propagation/rules/%s/mappings", url.PathEscape(environmentID), url.PathEscape(ruleID)),
+		pageSize,
+		"mappings", "items",
+	)
+}
 
-	decoded, err := utils.DecodeResponseJSON(httpResp)
-	if err != nil {
-		return nil, err
-	}
+func countPropagationRuleMappings(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, ruleID string) (int64, error) {
+	return countPingOneCollection(
+		ctx,
+		endpoints,
+		apiClient,
+		endpointFamilyPropagationMappings,
+		fmt.Sprintf("/environments/%s/propagation/rules/%s/mappings", url.PathEscape(environmentID), url.PathEscape(ruleID)),
+		"mappings", "items",
+	)
+}
 
-	list, err := utils.ExtractEmbeddedArray(decoded, "mappings", "items")
+// propagationRuleMappingCount returns the number of mappings on a rule, or null when they
+// cannot be counted, so that a failed count never fails a read.
+func propagationRuleMappingCount(ctx context.Context, service propagationService, environmentID string, ruleID string) types.Int64 {
+	count, err := service.MappingCount(ctx, environmentID, ruleID)
 	if err != nil {
-		// Some endpoints return a raw array.
-		if arr, ok := decoded.([]interface{}); ok {
-			list = arr
-		} else {
-			return nil, err
-		}
-	}
-
-	var mappings []map[string]interface{}
-	for _, v := range list {
-		m, ok := v.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		// Ensure the map uses consistent key casing for downstream logic.
-		if _, has := m["sourceAttribute"]; !has {
-			if v, ok := m["source_attribute"]; ok {
-				m["sourceAttribute"] = v
-			}
-		}
-		if _, has := m["targetAttribute"]; !has {
-			if v, ok := m["target_attribute"]; ok {
-				m["targetAttribute"] = v
-			}
-		}
-
-		mappings = append(mappings, m)
+		tflog.Debug(ctx, "Could not count propagation rule mappings", map[string]interface{}{
+			"rule_id": ruleID,
+			"error":   err.Error(),
+		})
+		return types.Int64Null()
 	}
-
-	return mappings, nil
+	return types.Int64Value(count)
 }
 
 func mappingKey(source string, target string, expression string) string {
@@ -0,0 +1,801 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                 = &propagationRuleSetResource{}
+	_ resource.ResourceWithConfigure    = &propagationRuleSetResource{}
+	_ resource.ResourceWithImportState  = &propagationRuleSetResource{}
+	_ resource.ResourceWithModifyPlan   = &propagationRuleSetResource{}
+	_ resource.ResourceWithUpgradeState = &propagationRuleSetResource{}
+)
+
+type propagationRuleSetResource struct {
+	client *client.Client
+}
+
+func NewPropagationRuleSetResource() resource.Resource {
+	return &propagationRuleSetResource{}
+}
+
+func (r *propagationRuleSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_rule_set"
+}
+
+func (r *propagationRuleSetResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Version 1 changes population_ids and group_ids from lists to sets.
+		Version:     1,
+		Description: "Manages one PingOne provisioning propagation rule per target store, all sharing a source store, scoping, and mapping template.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier in the form `<plan_id>/<source_store_id>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"plan_id": schema.StringAttribute{
+				Description: "The ID of the propagation plan.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The base name of the rules. Each rule is named `<name> (<target_store_id>)`; changing the name renames the rules in place.",
+				Required:    true,
+			},
+			"source_store_id": schema.StringAttribute{
+				Description: "The source store ID shared by every rule in the set.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_store_ids": schema.SetAttribute{
+				Description: "The target store IDs. One rule is managed per target store; adding or removing an ID only creates or deletes that target's rule.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the propagation rules are active.",
+				Optional:    true,
+			},
+			"filter": schema.StringAttribute{
+				Description: "Expression used by PingOne to select users to synchronize (maps to the API field `populationExpression`).",
+				Optional:    true,
+			},
+			"deprovision": schema.BoolAttribute{
+				Description: "Whether to deprovision users in the target stores when they are removed from the source.",
+				Optional:    true,
+			},
+			"population_ids": schema.SetAttribute{
+				Description: "Optional set of population IDs in scope for every rule.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"population_match": schema.StringAttribute{
+				Description: "How `population_ids` are combined in the population expression: `any` (the default) joins them with `or`, `all` joins them with `and`. A PingOne user belongs to one population, so `all` is rejected with more than one population ID.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(populationMatchAny, populationMatchAll),
+					populationMatchAllValidator{},
+				},
+			},
+			"group_ids": schema.SetAttribute{
+				Description: "Optional set of group IDs to scope group provisioning for every rule.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"configuration": schema.MapAttribute{
+				Description: "Optional rule configuration map applied to every rule (for example, `MFA_USER_DEVICE_MANAGEMENT`).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"mappings": schema.ListNestedAttribute{
+				Description: "Attribute mappings applied to every rule in the set.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_attribute": schema.StringAttribute{
+							Description: "Source attribute expression.",
+							Optional:    true,
+						},
+						"target_attribute": schema.StringAttribute{
+							Description: "Target attribute expression.",
+							Optional:    true,
+						},
+						"expression": schema.StringAttribute{
+							Description: "Optional expression used to compute the target attribute value.",
+							Optional:    true,
+						},
+						"sensitive_expression": schema.StringAttribute{
+							Description: "Use instead of `expression` when the expression embeds a secret, such as a default password. The value is sent as the mapping's expression but is hidden in plan output.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"rules": schema.MapNestedAttribute{
+				Description: "The rule managed for each target store, keyed by target store ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the propagation rule.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the propagation rule.",
+							Computed:    true,
+						},
+						"active": schema.BoolAttribute{
+							Description: "Whether the propagation rule is active.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the propagation rule as reported by PingOne, or `ACTIVE`/`INACTIVE` when PingOne does not report one.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *propagationRuleSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData
+}
+
+func (r *propagationRuleSetResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	schemaV0 := ruleSchemaV0(current.Schema)
+
+	return map[int64]resource.StateUpgrader{
+		0: {PriorSchema: &schemaV0, StateUpgrader: upgradeRuleIDListsToSets},
+	}
+}
+
+func (r *propagationRuleSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_propagation_rule_set", req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to validate on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	if deferUnknownPlan(req, resp, "environment_id", "plan_id") {
+		return
+	}
+
+	var active types.Bool
+	var mappings types.List
+	var filter types.String
+	var populationIDs types.Set
+	var deprovision types.Bool
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("active"), &active)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("mappings"), &mappings)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("filter"), &filter)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("population_ids"), &populationIDs)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("deprovision"), &deprovision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkRulePolicy(policyOf(r.client), "pingoneprovisioning_propagation_rule_set", active, filter, populationIDs, deprovision)...)
+	resp.Diagnostics.Append(r.validateStoreDirections(ctx, req)...)
+
+	if mappings.IsUnknown() {
+		return
+	}
+	if mappings.IsNull() || len(mappings.Elements()) == 0 {
+		if !active.IsNull() && !active.IsUnknown() && active.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("active"),
+				"Propagation Rule Set Has No Mappings",
+				"`active` is `true` but the rule set has no `mappings`, and PingOne rejects enabling a rule without attribute mappings. "+
+					"Add at least one mapping or set `active = false`.",
+			)
+		}
+		return
+	}
+
+	if r.client == nil || !r.client.ValidateTargetAttributes {
+		return
+	}
+
+	var plannedMappings []customtypes.PropagationRuleSetMappingModel
+	var environmentID types.String
+	var targetStoreIDs types.Set
+	resp.Diagnostics.Append(mappings.ElementsAs(ctx, &plannedMappings, false)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &environmentID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("target_store_ids"), &targetStoreIDs)...)
+	if resp.Diagnostics.HasError() || targetStoreIDs.IsNull() || targetStoreIDs.IsUnknown() {
+		return
+	}
+
+	targetAttributes := make([]types.String, 0, len(plannedMappings))
+	for _, m := range plannedMappings {
+		targetAttributes = append(targetAttributes, m.TargetAttribute)
+	}
+
+	var targets []types.String
+	resp.Diagnostics.Append(targetStoreIDs.ElementsAs(ctx, &targets, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, target := range targets {
+		resp.Diagnostics.Append(validateMappingTargetAttributesAgainstStore(ctx, r.client, environmentID, target, targetAttributes)...)
+	}
+}
+
+// validateStoreDirections checks that the planned source store can be a rule's source and that
+// every target store can be a rule's target. Stores already in state were checked when they
+// were planned, so only new stores are read.
+func (r *propagationRuleSetResource) validateStoreDirections(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var environmentID types.String
+	var sourceStoreID types.String
+	var targetStoreIDs types.Set
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &environmentID)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("source_store_id"), &sourceStoreID)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("target_store_ids"), &targetStoreIDs)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	priorSourceStoreID := types.StringNull()
+	priorTargetStoreIDs := types.SetNull(types.StringType)
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("source_store_id"), &priorSourceStoreID)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root("target_store_ids"), &priorTargetStoreIDs)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if !sourceStoreID.Equal(priorSourceStoreID) {
+		diags.Append(validateRuleStoreDirections(planStoreType(ctx, r.client, environmentID, sourceStoreID), path.Root("target_store_ids"), "")...)
+	}
+	if targetStoreIDs.IsNull() || targetStoreIDs.IsUnknown() {
+		return diags
+	}
+
+	var targets []types.String
+	diags.Append(targetStoreIDs.ElementsAs(ctx, &targets, false)...)
+	priorTargets := priorTargetStoreIDs.Elements()
+	for _, target := range targets {
+		if slices.ContainsFunc(priorTargets, target.Equal) {
+			continue
+		}
+		diags.Append(validateRuleStoreDirections("", path.Root("target_store_ids"), planStoreType(ctx, r.client, environmentID, target))...)
+	}
+	return diags
+}
+
+func (r *propagationRuleSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_propagation_rule_set") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.PropagationRuleSetModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values that were unknown at plan time are checked against the policy now.
+	resp.Diagnostics.Append(checkRulePolicy(policyOf(r.client), "pingoneprovisioning_propagation_rule_set", plan.Active, plan.Filter, plan.PopulationIds, plan.Deprovision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manageMappings, targets, diags := preparePropagationRuleSetPlan(ctx, req.Plan, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := plan.EnvironmentId.ValueString()
+	requestClient := r.client.API
+	rules := make(map[string]customtypes.PropagationRuleSetRuleModel, len(targets))
+
+	for _, target := range targets {
+		model := propagationRuleSetRuleModel(&plan, target)
+
+		ruleID, ruleClient, createDiags := createPropagationRuleWithMappings(ctx, r.client, requestClient, &model, manageMappings)
+		if ruleID != "" {
+			rules[target] = appliedPropagationRuleSetRule(ruleID, &model)
+			requestClient = ruleClient
+		}
+		appendPropagationRuleSetTargetDiags(&resp.Diagnostics, target, createDiags)
+	}
+	addHostnameFallbackWarning(&resp.Diagnostics, r.client.API, requestClient, fmt.Sprintf("Propagation rules for '%s' were created", plan.Name.ValueString()))
+
+	// Rules that could not be created are left out of state; the rules that were created are
+	// kept so they are not orphaned.
+	if len(rules) == 0 {
+		return
+	}
+
+	state := plan
+	state.Id = types.StringValue(propagationRuleSetID(plan.PlanId.ValueString(), plan.SourceStoreId.ValueString()))
+	resp.Diagnostics.Append(setPropagationRuleSetRules(ctx, &state, rules)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, requestClient, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Rules were created, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
+	}
+}
+
+func (r *propagationRuleSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.PropagationRuleSetModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	environmentID := state.EnvironmentId.ValueString()
+
+	var known map[string]customtypes.PropagationRuleSetRuleModel
+	if state.Rules.IsNull() {
+		// Imported: find the rules by the naming convention the set uses.
+		planRules, err := listPropagationRulesForPlan(ctx, r.client.Endpoints, apiClient, environmentID, state.PlanId.ValueString(), r.client.PageSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Rule Set",
+				fmt.Sprintf("Could not list propagation rules: %s", err),
+			)
+			return
+		}
+		known = matchPropagationRuleSetRules(planRules, state.SourceStoreId.ValueString(), state.Name.ValueString())
+	} else {
+		known = make(map[string]customtypes.PropagationRuleSetRuleModel)
+		resp.Diagnostics.Append(state.Rules.ElementsAs(ctx, &known, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	refreshed := make(map[string]customtypes.PropagationRuleSetRuleModel, len(known))
+	for _, target := range sortedPropagationRuleSetTargets(known) {
+		ruleID := known[target].Id.ValueString()
+
+		ruleObj, httpResp, err := readPropagationRule(ctx, apiClient, environmentID, ruleID)
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				tflog.Debug(ctx, "Propagation rule in rule set no longer exists", map[string]interface{}{
+					"target_store_id": target,
+					"rule_id":         ruleID,
+				})
+				continue
+			}
+
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Rule Set",
+				fmt.Sprintf("Could not read propagation rule for target store '%s': %s", target, err),
+			)
+			return
+		}
+
+		rule := propagationRuleSetRuleFromAPI(ruleID, ruleObj)
+		refreshed[target] = rule
+
+		// Surface a rule toggled outside Terraform as drift on the shared setting.
+		if !state.Active.IsNull() && !state.Active.IsUnknown() && rule.Active.ValueBool() != state.Active.ValueBool() {
+			state.Active = rule.Active
+		}
+	}
+
+	if len(refreshed) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(setPropagationRuleSetRules(ctx, &state, refreshed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *propagationRuleSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_propagation_rule_set") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.PropagationRuleSetModel
+	var state customtypes.PropagationRuleSetModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values that were unknown at plan time are checked against the policy now.
+	resp.Diagnostics.Append(checkRulePolicy(policyOf(r.client), "pingoneprovisioning_propagation_rule_set", plan.Active, plan.Filter, plan.PopulationIds, plan.Deprovision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manageMappings, targets, diags := preparePropagationRuleSetPlan(ctx, req.Plan, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := make(map[string]customtypes.PropagationRuleSetRuleModel)
+	if !state.Rules.IsNull() && !state.Rules.IsUnknown() {
+		resp.Diagnostics.Append(state.Rules.ElementsAs(ctx, &prior, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	environmentID := state.EnvironmentId.ValueString()
+	requestClient := r.client.API
+	desired := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		desired[target] = true
+	}
+
+	rules := make(map[string]customtypes.PropagationRuleSetRuleModel, len(prior))
+	for target, rule := range prior {
+		rules[target] = rule
+	}
+	failed := false
+
+	// Delete the rules of targets that were removed from the set.
+	for _, target := range sortedPropagationRuleSetTargets(prior) {
+		if desired[target] {
+			continue
+		}
+
+		httpResp, err := deletePropagationRuleWithMappings(ctx, propagationServiceFor(r.client, requestClient), environmentID, prior[target].Id.ValueString())
+		if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Propagation Rule",
+				fmt.Sprintf("Target store '%s': could not delete propagation rule: %s", target, utils.HandleSDKError(err, httpResp)),
+			)
+			failed = true
+			continue
+		}
+		delete(rules, target)
+	}
+
+	// Update the rules of targets that stay in the set, and create rules for new targets.
+	for _, target := range targets {
+		model := propagationRuleSetRuleModel(&plan, target)
+
+		if existing, ok := prior[target]; ok {
+			priorModel := propagationRuleSetRuleModel(&state, target)
+			updateDiags := updatePropagationRuleWithMappings(ctx, propagationServiceFor(r.client, requestClient), existing.Id.ValueString(), &priorModel, &model, manageMappings, true, ruleUpdateStrategyPut)
+			appendPropagationRuleSetTargetDiags(&resp.Diagnostics, target, updateDiags)
+			if updateDiags.HasError() {
+				failed = true
+				continue
+			}
+			rules[target] = appliedPropagationRuleSetRule(existing.Id.ValueString(), &model)
+			continue
+		}
+
+		ruleID, ruleClient, createDiags := createPropagationRuleWithMappings(ctx, r.client, requestClient, &model, manageMappings)
+		if ruleID != "" {
+			rules[target] = appliedPropagationRuleSetRule(ruleID, &model)
+			requestClient = ruleClient
+		}
+		appendPropagationRuleSetTargetDiags(&resp.Diagnostics, target, createDiags)
+		if createDiags.HasError() {
+			failed = true
+		}
+	}
+	addHostnameFallbackWarning(&resp.Diagnostics, r.client.API, requestClient, fmt.Sprintf("Propagation rules for '%s' were created", plan.Name.ValueString()))
+
+	newState := plan
+	if failed {
+		// Keep the prior shared settings so the next plan retries the change on every rule;
+		// only the targets that were actually added or removed are reflected.
+		newState = state
+	}
+	newState.Id = state.Id
+	resp.Diagnostics.Append(setPropagationRuleSetRules(ctx, &newState, rules)...)
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, requestClient, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Rules were updated, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
+	}
+}
+
+func (r *propagationRuleSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_propagation_rule_set") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var state customtypes.PropagationRuleSetModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules := make(map[string]customtypes.PropagationRuleSetRuleModel)
+	resp.Diagnostics.Append(state.Rules.ElementsAs(ctx, &rules, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	environmentID := state.EnvironmentId.ValueString()
+
+	for _, target := range sortedPropagationRuleSetTargets(rules) {
+		// Rules already deleted by an earlier, partially failed destroy are skipped.
+		httpResp, err := deletePropagationRuleWithMappings(ctx, propagationServiceFor(r.client, apiClient), environmentID, rules[target].Id.ValueString())
+		if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Propagation Rule",
+				fmt.Sprintf("Target store '%s': could not delete propagation rule: %s", target, utils.HandleSDKError(err, httpResp)),
+			)
+		}
+	}
+
+	if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, apiClient, environmentID); revErr != nil {
+		addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Rules were deleted, but the provider could not create a propagation revision. The PingOne UI may not reflect the latest propagation configuration until a revision is created. Error: %s", revErr))
+	}
+}
+
+func (r *propagationRuleSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := utils.SplitImportID(req.ID, 4)
+	if idParts == nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Propagation Rule Set",
+			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>/<plan_id>/<source_store_id>/<name>'.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), propagationRuleSetID(idParts[1], idParts[2]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plan_id"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_store_id"), idParts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[3])...)
+}
+
+// preparePropagationRuleSetPlan reports whether the plan manages mappings, validates the mapping
+// template, and returns the target store IDs in a stable order.
+func preparePropagationRuleSetPlan(ctx context.Context, plan tfsdk.Plan, model *customtypes.PropagationRuleSetModel) (bool, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var mappingsAttr types.List
+	diags.Append(plan.GetAttribute(ctx, path.Root("mappings"), &mappingsAttr)...)
+	if diags.HasError() {
+		return false, nil, diags
+	}
+	manageMappings := !mappingsAttr.IsNull() && !mappingsAttr.IsUnknown()
+	if !manageMappings {
+		model.Mappings = nil
+	}
+
+	template := propagationRuleSetRuleModel(model, "")
+	diags.Append(validatePropagationRuleMappings(template.Mappings)...)
+	if diags.HasError() {
+		return false, nil, diags
+	}
+
+	var targets []string
+	diags.Append(model.TargetStoreIds.ElementsAs(ctx, &targets, false)...)
+	sort.Strings(targets)
+
+	return manageMappings, targets, diags
+}
+
+// propagationRuleSetRuleModel builds the rule the set manages for a target store from the
+// set's shared settings.
+func propagationRuleSetRuleModel(set *customtypes.PropagationRuleSetModel, target string) customtypes.PropagationRuleModel {
+	var mappings []customtypes.PropagationRuleMappingModel
+	if set.Mappings != nil {
+		mappings = make([]customtypes.PropagationRuleMappingModel, 0, len(set.Mappings))
+		for _, m := range set.Mappings {
+			mappings = append(mappings, customtypes.PropagationRuleMappingModel{
+				Id:                  types.StringNull(),
+				SourceAttribute:     m.SourceAttribute,
+				TargetAttribute:     m.TargetAttribute,
+				Expression:          m.Expression,
+				SensitiveExpression: m.SensitiveExpression,
+			})
+		}
+	}
+
+	return customtypes.PropagationRuleModel{
+		Id:                    types.StringNull(),
+		EnvironmentId:         set.EnvironmentId,
+		PlanId:                set.PlanId,
+		Name:                  types.StringValue(propagationRuleSetRuleName(set.Name.ValueString(), target)),
+		SourceStoreId:         set.SourceStoreId,
+		TargetStoreId:         types.StringValue(target),
+		Active:                set.Active,
+		Filter:                set.Filter,
+		Deprovision:           set.Deprovision,
+		PopulationIds:         set.PopulationIds,
+		PopulationMatch:       set.PopulationMatch,
+		GroupIds:              set.GroupIds,
+		Configuration:         set.Configuration,
+		Mappings:              mappings,
+		CorrelationAttributes: types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleCorrelationAttributeAttrTypes}),
+	}
+}
+
+func propagationRuleSetRuleName(name string, target string) string {
+	return fmt.Sprintf("%s (%s)", name, target)
+}
+
+func propagationRuleSetID(planID string, sourceStoreID string) string {
+	return planID + "/" + sourceStoreID
+}
+
+func appliedPropagationRuleSetRule(ruleID string, model *customtypes.PropagationRuleModel) customtypes.PropagationRuleSetRuleModel {
+	active := !model.Active.IsNull() && !model.Active.IsUnknown() && model.Active.ValueBool()
+
+	return customtypes.PropagationRuleSetRuleModel{
+		Id:     types.StringValue(ruleID),
+		Name:   model.Name,
+		Active: types.BoolValue(active),
+		Status: types.StringValue(propagationRuleSetStatus("", active)),
+	}
+}
+
+func propagationRuleSetRuleFromAPI(ruleID string, ruleObj map[string]interface{}) customtypes.PropagationRuleSetRuleModel {
+	name, _ := utils.NestedString(ruleObj, "name")
+	active, _ := utils.NestedBool(ruleObj, "active")
+	status, _ := utils.NestedString(ruleObj, "status")
+
+	return customtypes.PropagationRuleSetRuleModel{
+		Id:     types.StringValue(ruleID),
+		Name:   types.StringValue(name),
+		Active: types.BoolValue(active),
+		Status: types.StringValue(propagationRuleSetStatus(status, active)),
+	}
+}
+
+func propagationRuleSetStatus(status string, active bool) string {
+	if status = strings.TrimSpace(status); status != "" {
+		return status
+	}
+	if active {
+		return "ACTIVE"
+	}
+	return "INACTIVE"
+}
+
+// matchPropagationRuleSetRules picks the rules of a plan that belong to a rule set: rules from
+// the set's source store whose name follows the set's naming convention for their target.
+func matchPropagationRuleSetRules(planRules []map[string]interface{}, sourceStoreID string, name string) map[string]customtypes.PropagationRuleSetRuleModel {
+	matched := make(map[string]customtypes.PropagationRuleSetRuleModel)
+
+	for _, rule := range planRules {
+		if src, _ := utils.NestedString(rule, "sourceStore", "id"); src != sourceStoreID {
+			continue
+		}
+		target, _ := utils.NestedString(rule, "targetStore", "id")
+		ruleName, _ := utils.NestedString(rule, "name")
+		ruleID, _ := utils.NestedString(rule, "id")
+		if target == "" || ruleID == "" || ruleName != propagationRuleSetRuleName(name, target) {
+			continue
+		}
+
+		matched[target] = propagationRuleSetRuleFromAPI(ruleID, rule)
+	}
+
+	return matched
+}
+
+// setPropagationRuleSetRules records the rules in state. target_store_ids is derived from the
+// same map so that a target whose rule could not be created shows as a change on the next plan.
+func setPropagationRuleSetRules(ctx context.Context, state *customtypes.PropagationRuleSetModel, rules map[string]customtypes.PropagationRuleSetRuleModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	targets, targetDiags := types.SetValueFrom(ctx, types.StringType, sortedPropagationRuleSetTargets(rules))
+	diags.Append(targetDiags...)
+	rulesValue, rulesDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: customtypes.PropagationRuleSetRuleAttrTypes}, rules)
+	diags.Append(rulesDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	state.TargetStoreIds = targets
+	state.Rules = rulesValue
+	return diags
+}
+
+func sortedPropagationRuleSetTargets(rules map[string]customtypes.PropagationRuleSetRuleModel) []string {
+	targets := make([]string, 0, len(rules))
+	for target := range rules {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// appendPropagationRuleSetTargetDiags adds a rule's diagnostics, naming the target store so
+// failures for different targets can be told apart.
+func appendPropagationRuleSetTargetDiags(diags *diag.Diagnostics, target string, ruleDiags diag.Diagnostics) {
+	for _, d := range ruleDiags {
+		detail := fmt.Sprintf("Target store '%s': %s", target, d.Detail())
+		if d.Severity() == diag.SeverityError {
+			diags.AddError(d.Summary(), detail)
+		} else {
+			diags.AddWarning(d.Summary(), detail)
+		}
+	}
+}
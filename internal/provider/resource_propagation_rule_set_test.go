@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPropagationRuleSetRuleModel(t *testing.T) {
+	t.Parallel()
+
+	set := customtypes.PropagationRuleSetModel{
+		EnvironmentId: types.StringValue("env-id"),
+		PlanId:        types.StringValue("plan-id"),
+		Name:          types.StringValue("Users to SaaS"),
+		SourceStoreId: types.StringValue("source-id"),
+		Active:        types.BoolValue(true),
+		Mappings: []customtypes.PropagationRuleSetMappingModel{
+			{SourceAttribute: types.StringValue("userName"), TargetAttribute: types.StringValue("userName"), Expression: types.StringNull()},
+		},
+	}
+
+	rule := propagationRuleSetRuleModel(&set, "target-a")
+
+	if rule.Name.ValueString() != "Users to SaaS (target-a)" {
+		t.Fatalf("name = %q", rule.Name.ValueString())
+	}
+	if rule.TargetStoreId.ValueString() != "target-a" || rule.SourceStoreId.ValueString() != "source-id" {
+		t.Fatalf("stores = %s -> %s", rule.SourceStoreId, rule.TargetStoreId)
+	}
+	if len(rule.Mappings) != 1 || !rule.Mappings[0].Id.IsNull() || rule.Mappings[0].TargetAttribute.ValueString() != "userName" {
+		t.Fatalf("mappings = %+v", rule.Mappings)
+	}
+
+	set.Mappings = nil
+	if rule := propagationRuleSetRuleModel(&set, "target-a"); rule.Mappings != nil {
+		t.Fatalf("unmanaged mappings = %+v, want nil", rule.Mappings)
+	}
+}
+
+func TestMatchPropagationRuleSetRules(t *testing.T) {
+	t.Parallel()
+
+	planRules := []map[string]interface{}{
+		{"id": "rule-a", "name": "Fan-out (target-a)", "active": true, "sourceStore": map[string]interface{}{"id": "source-id"}, "targetStore": map[string]interface{}{"id": "target-a"}},
+		{"id": "rule-b", "name": "Fan-out (target-b)", "active": false, "sourceStore": map[string]interface{}{"id": "source-id"}, "targetStore": map[string]interface{}{"id": "target-b"}},
+		{"id": "rule-c", "name": "Manual rule", "sourceStore": map[string]interface{}{"id": "source-id"}, "targetStore": map[string]interface{}{"id": "target-c"}},
+		{"id": "rule-d", "name": "Fan-out (target-d)", "sourceStore": map[string]interface{}{"id": "other-source"}, "targetStore": map[string]interface{}{"id": "target-d"}},
+	}
+
+	matched := matchPropagationRuleSetRules(planRules, "source-id", "Fan-out")
+
+	if len(matched) != 2 {
+		t.Fatalf("matched = %+v, want target-a and target-b", matched)
+	}
+	if got := matched["target-a"]; got.Id.ValueString() != "rule-a" || got.Status.ValueString() != "ACTIVE" {
+		t.Fatalf("target-a = %+v", got)
+	}
+	if got := matched["target-b"]; got.Id.ValueString() != "rule-b" || got.Status.ValueString() != "INACTIVE" {
+		t.Fatalf("target-b = %+v", got)
+	}
+}
@@ -2,12 +2,28 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
 )
 
@@ -67,37 +83,23 @@ func TestIsBadAuthorizationHeaderError(t *testing.T) {
 func TestCreatePropagationRuleViaPlan_UsesPlanScopedEndpoint(t *testing.T) {
 	t.Parallel()
 
-	cfg := management.NewConfiguration()
-	cfg.SetDefaultServerIndex(1)
-	if err := cfg.SetDefaultServerVariableDefaultValue("baseHostname", "api.example"); err != nil {
-		t.Fatalf("SetDefaultServerVariableDefaultValue: %v", err)
-	}
-
-	cfg.HTTPClient = &http.Client{
-		Transport: ruleRoundTripperFunc(func(r *http.Request) (*http.Response, error) {
-			if r.Method != http.MethodPost {
-				t.Fatalf("method = %s, want %s", r.Method, http.MethodPost)
-			}
-
-			if got := r.URL.String(); got != "https://api.example/v1/environments/env-id/propagation/plans/plan-id/rules" {
-				t.Fatalf("url = %s, want %s", got, "https://api.example/v1/environments/env-id/propagation/plans/plan-id/rules")
-			}
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want %s", r.Method, http.MethodPost)
+		}
 
-			return &http.Response{
-				StatusCode: http.StatusCreated,
-				Status:     "201 Created",
-				Header:     http.Header{"Content-Type": []string{"application/json"}},
-				Body:       io.NopCloser(strings.NewReader(`{"id":"rule-123"}`)),
-				Request:    r,
-			}, nil
-		}),
-	}
+		if got := r.URL.String(); got != "https://api.example/v1/environments/env-id/propagation/plans/plan-id/rules" {
+			t.Fatalf("url = %s, want %s", got, "https://api.example/v1/environments/env-id/propagation/plans/plan-id/rules")
+		}
 
-	apiClient := management.NewAPIClient(cfg)
+		return testResponse(r, http.StatusCreated, `{"id":"rule-123"}`), nil
+	})
 
 	ruleID, _, err := createPropagationRuleViaPlan(
 		context.Background(),
+		nil,
 		apiClient,
+		planScopedRuleCreator{},
 		"env-id",
 		"plan-id",
 		map[string]interface{}{
@@ -109,6 +111,7 @@ func TestCreatePropagationRuleViaPlan_UsesPlanScopedEndpoint(t *testing.T) {
 				"id": "target-id",
 			},
 		},
+		0,
 	)
 	if err != nil {
 		t.Fatalf("createPropagationRuleViaPlan error: %v", err)
@@ -118,42 +121,302 @@ func TestCreatePropagationRuleViaPlan_UsesPlanScopedEndpoint(t *testing.T) {
 	}
 }
 
-func TestCreatePropagationRevisionWithFallback_UsesExpectedEndpoint(t *testing.T) {
+func TestCreatePropagationRuleViaPlan_SDKRuleCreate(t *testing.T) {
 	t.Parallel()
 
-	cfg := management.NewConfiguration()
-	cfg.SetDefaultServerIndex(1)
-	if err := cfg.SetDefaultServerVariableDefaultValue("baseHostname", "api.example"); err != nil {
-		t.Fatalf("SetDefaultServerVariableDefaultValue: %v", err)
+	if _, ok := propagationRuleCreatorFor(&client.Client{}).(planScopedRuleCreator); !ok {
+		t.Fatal("rules should be created with the plan-scoped request by default")
+	}
+	creator := propagationRuleCreatorFor(&client.Client{UseSDKRuleCreate: true})
+	if _, ok := creator.(sdkRuleCreator); !ok {
+		t.Fatalf("creator = %T, want sdkRuleCreator", creator)
 	}
 
-	cfg.HTTPClient = &http.Client{
-		Transport: ruleRoundTripperFunc(func(r *http.Request) (*http.Response, error) {
-			if r.Method != http.MethodPost {
-				t.Fatalf("method = %s, want %s", r.Method, http.MethodPost)
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		if got := r.Method + " " + r.URL.Path; got != "POST /v1/environments/env-id/propagation/rules" {
+			t.Fatalf("request = %s, want a POST to the environment's rules", got)
+		}
+
+		return testResponse(r, http.StatusCreated, `{"id":"rule-123"}`), nil
+	})
+
+	ruleID, _, err := createPropagationRuleViaPlan(
+		context.Background(),
+		nil,
+		apiClient,
+		creator,
+		"env-id",
+		"plan-id",
+		map[string]interface{}{
+			"name":        "test",
+			"plan":        map[string]interface{}{"id": "plan-id"},
+			"sourceStore": map[string]interface{}{"id": "source-id"},
+			"targetStore": map[string]interface{}{"id": "target-id"},
+		},
+		0,
+	)
+	if err != nil {
+		t.Fatalf("createPropagationRuleViaPlan error: %v", err)
+	}
+	if ruleID != "rule-123" {
+		t.Fatalf("ruleID = %q, want %q", ruleID, "rule-123")
+	}
+}
+
+func TestActivatePropagationRule(t *testing.T) {
+	prior := ruleActivationBackoff
+	ruleActivationBackoff = time.Millisecond
+	t.Cleanup(func() { ruleActivationBackoff = prior })
+
+	activate := func(t *testing.T, statuses ...int) (int, error) {
+		t.Helper()
+
+		puts := 0
+		apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+			if r.Method != http.MethodPut || r.URL.Path != "/v1/environments/env-id/propagation/rules/rule-123" {
+				t.Fatalf("request = %s %s, want PUT of the rule", r.Method, r.URL.Path)
 			}
+			status := statuses[puts]
+			puts++
 
-			if got := r.URL.String(); got != "https://api.example/v1/environments/env-id/propagation/revisions" {
-				t.Fatalf("url = %s, want %s", got, "https://api.example/v1/environments/env-id/propagation/revisions")
+			body := `{"id":"rule-123","active":true}`
+			if status >= 300 {
+				body = `{"code":"INVALID_DATA","message":"rule has no mappings"}`
 			}
+			return testResponse(r, status, body), nil
+		})
 
-			return &http.Response{
-				StatusCode: http.StatusCreated,
-				Status:     "201 Created",
-				Header:     http.Header{"Content-Type": []string{"application/json"}},
-				Body:       io.NopCloser(strings.NewReader(``)),
-				Request:    r,
-			}, nil
-		}),
+		_, err := activatePropagationRule(context.Background(), &sdkPropagationService{api: apiClient}, "env-id", "rule-123", map[string]interface{}{"active": true})
+		return puts, err
 	}
 
-	apiClient := management.NewAPIClient(cfg)
+	t.Run("retries_conflict", func(t *testing.T) {
+		puts, err := activate(t, http.StatusConflict, http.StatusOK)
+		if err != nil || puts != 2 {
+			t.Fatalf("puts = %d, err = %v; want 2, nil", puts, err)
+		}
+	})
+
+	t.Run("retries_not_found", func(t *testing.T) {
+		puts, err := activate(t, http.StatusNotFound, http.StatusOK)
+		if err != nil || puts != 2 {
+			t.Fatalf("puts = %d, err = %v; want 2, nil", puts, err)
+		}
+	})
+
+	t.Run("gives_up_after_attempts", func(t *testing.T) {
+		puts, err := activate(t, http.StatusNotFound, http.StatusConflict, http.StatusConflict)
+		if err == nil || puts != ruleActivationAttempts {
+			t.Fatalf("puts = %d, err = %v; want %d and an error", puts, err, ruleActivationAttempts)
+		}
+	})
+
+	t.Run("does_not_retry_bad_request", func(t *testing.T) {
+		puts, err := activate(t, http.StatusBadRequest)
+		if err == nil || puts != 1 {
+			t.Fatalf("puts = %d, err = %v; want 1 and an error", puts, err)
+		}
+		if !strings.Contains(err.Error(), "rule has no mappings") {
+			t.Fatalf("err = %v, want the PingOne message", err)
+		}
+	})
+
+	t.Run("does_not_retry_forbidden", func(t *testing.T) {
+		puts, err := activate(t, http.StatusForbidden)
+		if err == nil || puts != 1 {
+			t.Fatalf("puts = %d, err = %v; want 1 and an error", puts, err)
+		}
+	})
+}
 
-	if _, err := createPropagationRevisionWithFallback(context.Background(), apiClient, "env-id"); err != nil {
+func TestCorrelationAttributesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	elemType := types.ObjectType{AttrTypes: customtypes.PropagationRuleCorrelationAttributeAttrTypes}
+	configured, diags := types.ListValueFrom(ctx, elemType, []customtypes.PropagationRuleCorrelationAttributeModel{
+		{SourceAttribute: types.StringValue(" email "), TargetAttribute: types.StringValue("userName")},
+		{SourceAttribute: types.StringValue("externalId"), TargetAttribute: types.StringValue("externalId")},
+	})
+	if diags.HasError() {
+		t.Fatalf("ListValueFrom: %v", diags)
+	}
+
+	payload, diags := propagationRulePayloadFromModel(ctx, &customtypes.PropagationRuleModel{
+		GroupIds:              types.SetNull(types.StringType),
+		Configuration:         types.MapNull(types.StringType),
+		CorrelationAttributes: configured,
+	})
+	if diags.HasError() {
+		t.Fatalf("propagationRulePayloadFromModel: %v", diags)
+	}
+
+	raw, err := json.Marshal(payload["correlationAttributes"])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `[{"sourceAttribute":"email","targetAttribute":"userName"},{"sourceAttribute":"externalId","targetAttribute":"externalId"}]`
+	if string(raw) != want {
+		t.Fatalf("correlationAttributes = %s, want %s", raw, want)
+	}
+
+	var apiObj map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"correlationAttributes":`+want+`}`), &apiObj); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	got, diags := correlationAttributesFromAPI(ctx, apiObj)
+	if diags.HasError() {
+		t.Fatalf("correlationAttributesFromAPI: %v", diags)
+	}
+	var pairs []customtypes.PropagationRuleCorrelationAttributeModel
+	if diags := got.ElementsAs(ctx, &pairs, false); diags.HasError() {
+		t.Fatalf("ElementsAs: %v", diags)
+	}
+	if len(pairs) != 2 || pairs[0].SourceAttribute.ValueString() != "email" || pairs[1].TargetAttribute.ValueString() != "externalId" {
+		t.Fatalf("pairs = %+v", pairs)
+	}
+
+	if got, _ := correlationAttributesFromAPI(ctx, map[string]interface{}{}); !got.IsNull() {
+		t.Fatalf("correlation attributes without the API field = %v, want null", got)
+	}
+}
+
+func TestCreatePropagationRevisionWithFallback_UsesExpectedEndpoint(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method = %s, want %s", r.Method, http.MethodPost)
+		}
+
+		if got := r.URL.String(); got != "https://api.example/v1/environments/env-id/propagation/revisions" {
+			t.Fatalf("url = %s, want %s", got, "https://api.example/v1/environments/env-id/propagation/revisions")
+		}
+
+		return testResponse(r, http.StatusCreated, ``), nil
+	})
+
+	if _, err := createPropagationRevisionWithFallback(context.Background(), nil, apiClient, "env-id"); err != nil {
 		t.Fatalf("createPropagationRevisionWithFallback error: %v", err)
 	}
 }
 
+func TestCreatePropagationRevisionUnlessDisabled_RevisionFailureBehavior(t *testing.T) {
+	previous := revisionRetryBackoff
+	revisionRetryBackoff = time.Millisecond
+	t.Cleanup(func() { revisionRetryBackoff = previous })
+
+	// newClient returns a client whose revision requests fail with a 409 until the
+	// failures-th request, and a pointer to the number of requests sent.
+	newClient := func(t *testing.T, failures int) (*management.APIClient, *int) {
+		t.Helper()
+
+		requests := 0
+		apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+			requests++
+			status, body := http.StatusCreated, ``
+			if requests <= failures {
+				status, body = http.StatusConflict, `{"code":"CONFLICT","message":"revision in progress"}`
+			}
+			return testResponse(r, status, body), nil
+		})
+		return apiClient, &requests
+	}
+
+	apiClient, requests := newClient(t, 2)
+	c := &client.Client{RevisionFailureBehavior: client.RevisionFailureRetry, RevisionRetryTimeout: time.Minute}
+	if _, err := createPropagationRevisionUnlessDisabled(context.Background(), c, apiClient, "env-id"); err != nil || *requests != 3 {
+		t.Fatalf("retry: err %v after %d requests, want success after 3", err, *requests)
+	}
+
+	apiClient, requests = newClient(t, 2)
+	c = &client.Client{RevisionFailureBehavior: client.RevisionFailureError}
+	_, err := createPropagationRevisionUnlessDisabled(context.Background(), c, apiClient, "env-id")
+	if err == nil || *requests != 1 {
+		t.Fatalf("error: err %v after %d requests, want a failure after 1", err, *requests)
+	}
+
+	var diags diag.Diagnostics
+	addPropagationRevisionFailure(&diags, c, err.Error())
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("error behavior diagnostics = %v, want an error", diags)
+	}
+	diags = nil
+	addPropagationRevisionFailure(&diags, &client.Client{}, err.Error())
+	if diags.ErrorsCount() != 0 || diags.WarningsCount() != 1 {
+		t.Fatalf("warn behavior diagnostics = %v, want a warning", diags)
+	}
+}
+
+func TestCreatePropagationRevisionWithFallback_SkipsFallbackWithoutProvisioning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		body         string
+		wantFallback bool
+	}{
+		{name: "provisioning_not_enabled", body: `{"code":"CAPABILITY_NOT_ENABLED","message":"Provisioning is not enabled."}`, wantFallback: false},
+		{name: "not_found", body: `{"code":"NOT_FOUND","message":"The requested resource was not found."}`, wantFallback: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var hosts []string
+			apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+				hosts = append(hosts, r.URL.Host)
+				return testResponse(r, http.StatusNotFound, tt.body), nil
+			})
+
+			_, err := createPropagationRevisionWithFallback(context.Background(), nil, apiClient, "env-id")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if fellBack := len(hosts) > 1; fellBack != tt.wantFallback {
+				t.Fatalf("requested hosts %v, want fallback = %v", hosts, tt.wantFallback)
+			}
+		})
+	}
+}
+
+func TestCreatePropagationRevisionWithFallback_RemembersWorkingHostname(t *testing.T) {
+	t.Parallel()
+
+	var hosts []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		hosts = append(hosts, r.URL.Host)
+		if r.URL.Host != "api.pingone.eu" {
+			return testResponse(r, http.StatusNotFound, `{"code":"NOT_FOUND"}`), nil
+		}
+		return testResponse(r, http.StatusCreated, `{}`), nil
+	})
+	endpoints := client.NewEndpoints()
+
+	if _, err := createPropagationRevisionWithFallback(context.Background(), endpoints, apiClient, "env-id"); err != nil {
+		t.Fatalf("first createPropagationRevisionWithFallback error: %v", err)
+	}
+	if len(hosts) < 2 || hosts[len(hosts)-1] != "api.pingone.eu" {
+		t.Fatalf("first call hosts = %v, want a fallback ending at api.pingone.eu", hosts)
+	}
+
+	hosts = nil
+	if _, err := createPropagationRevisionWithFallback(context.Background(), endpoints, apiClient, "env-id"); err != nil {
+		t.Fatalf("second createPropagationRevisionWithFallback error: %v", err)
+	}
+	if len(hosts) != 1 || hosts[0] != "api.pingone.eu" {
+		t.Fatalf("second call hosts = %v, want [api.pingone.eu]", hosts)
+	}
+	if got := currentPingOneHostname(pingOneRequestClient(endpoints, apiClient)); got != "api.pingone.eu" {
+		t.Fatalf("request client hostname = %q, want api.pingone.eu", got)
+	}
+	if got := pingOneRequestClient(client.NewEndpoints(), apiClient); got != apiClient {
+		t.Fatalf("another provider instance starts on %q, want the configured hostname", currentPingOneHostname(got))
+	}
+}
+
 func TestDeletePropagationMapping_UsesMappingsEndpoint(t *testing.T) {
 	t.Parallel()
 
@@ -164,7 +427,7 @@ func TestDeletePropagationMapping_UsesMappingsEndpoint(t *testing.T) {
 	cfg.SetDefaultServerIndex(0)
 
 	cfg.HTTPClient = &http.Client{
-		Transport: ruleRoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
 			if r.Method != http.MethodDelete {
 				t.Fatalf("method = %s, want %s", r.Method, http.MethodDelete)
 			}
@@ -173,25 +436,1059 @@ func TestDeletePropagationMapping_UsesMappingsEndpoint(t *testing.T) {
 				t.Fatalf("url = %s, want %s", got, "https://api.example/v1/environments/env-id/propagation/mappings/map-id")
 			}
 
-			return &http.Response{
-				StatusCode: http.StatusNoContent,
-				Status:     "204 No Content",
-				Header:     http.Header{"Content-Type": []string{"application/json"}},
-				Body:       io.NopCloser(strings.NewReader(``)),
-				Request:    r,
-			}, nil
+			return testResponse(r, http.StatusNoContent, ``), nil
 		}),
 	}
 
 	apiClient := management.NewAPIClient(cfg)
 
-	if _, err := deletePropagationMapping(context.Background(), apiClient, "env-id", "map-id"); err != nil {
-		t.Fatalf("deletePropagationMapping error: %v", err)
+	if _, err := deletePropagationMappingManual(context.Background(), nil, apiClient, "env-id", "map-id"); err != nil {
+		t.Fatalf("deletePropagationMappingManual error: %v", err)
+	}
+}
+
+func TestDeletePropagationMapping_RemembersWorkingRoute(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+
+		status := http.StatusNoContent
+		if strings.Contains(r.URL.Path, "/propagation/mapping/") {
+			status = http.StatusNotFound
+		}
+
+		return testResponse(r, status, ``), nil
+	})
+
+	endpoints := client.NewEndpoints()
+
+	for i := 0; i < 2; i++ {
+		if _, err := deletePropagationMapping(context.Background(), endpoints, apiClient, "env-id", "map-id"); err != nil {
+			t.Fatalf("deletePropagationMapping error: %v", err)
+		}
+	}
+
+	want := []string{
+		"/v1/environments/env-id/propagation/mapping/map-id",
+		"/v1/environments/env-id/propagation/mappings/map-id",
+		"/v1/environments/env-id/propagation/mappings/map-id",
+	}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+}
+
+func TestDeletePropagationMapping_FallsBackOnAnySDKFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		sdkStatus    int
+		manualStatus int
+		wantError    string
+		wantRoute    client.MappingDeleteRoute
+	}{
+		{name: "bad_request", sdkStatus: http.StatusBadRequest, manualStatus: http.StatusNoContent, wantRoute: client.MappingDeleteRouteManual},
+		{name: "forbidden", sdkStatus: http.StatusForbidden, manualStatus: http.StatusNoContent, wantRoute: client.MappingDeleteRouteManual},
+		{name: "conflict", sdkStatus: http.StatusConflict, manualStatus: http.StatusNoContent, wantRoute: client.MappingDeleteRouteManual},
+		{name: "both_fail", sdkStatus: http.StatusForbidden, manualStatus: http.StatusForbidden, wantError: "missing permission", wantRoute: client.MappingDeleteRouteUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var paths []string
+			apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+				paths = append(paths, r.URL.Path)
+				status := tt.manualStatus
+				if strings.Contains(r.URL.Path, "/propagation/mapping/") {
+					status = tt.sdkStatus
+				}
+				body := ``
+				if status >= 300 {
+					body = `{"code":"ACCESS_FAILED","message":"missing permission"}`
+				}
+				return testResponse(r, status, body), nil
+			})
+
+			endpoints := client.NewEndpoints()
+
+			_, err := deletePropagationMapping(context.Background(), endpoints, apiClient, "env-id", "map-id")
+			if tt.wantError == "" && err != nil {
+				t.Fatalf("deletePropagationMapping error: %v", err)
+			}
+			if tt.wantError != "" && (err == nil || !strings.Contains(err.Error(), tt.wantError)) {
+				t.Fatalf("error = %v, want it to describe the response body", err)
+			}
+			want := []string{
+				"/v1/environments/env-id/propagation/mapping/map-id",
+				"/v1/environments/env-id/propagation/mappings/map-id",
+			}
+			if !slices.Equal(paths, want) {
+				t.Fatalf("paths = %v, want %v", paths, want)
+			}
+			if route := endpoints.MappingDeleteRoute(apiClient); route != tt.wantRoute {
+				t.Fatalf("route = %v, want %v", route, tt.wantRoute)
+			}
+		})
+	}
+}
+
+func TestEnsurePropagationRuleMappings_UpdatesInPlaceWhenTargetUnchanged(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		body := ``
+		status := http.StatusOK
+		if r.Method == http.MethodGet {
+			body = `{"_embedded":{"mappings":[` +
+				`{"id":"map-mail","sourceAttribute":"email","targetAttribute":"mail"},` +
+				`{"id":"map-name","sourceAttribute":"username","targetAttribute":"userName"}]}}`
+		}
+
+		return testResponse(r, status, body), nil
+	})
+
+	desired := []customtypes.PropagationRuleMappingModel{
+		{
+			TargetAttribute: types.StringValue("mail"),
+			SourceAttribute: types.StringNull(),
+			Expression:      types.StringValue("${user.email}"),
+		},
+		{
+			TargetAttribute: types.StringValue("userName"),
+			SourceAttribute: types.StringValue("username"),
+			Expression:      types.StringNull(),
+		},
+	}
+
+	if err := ensurePropagationRuleMappings(context.Background(), &sdkPropagationService{api: apiClient}, "env-id", "rule-id", nil, desired, true); err != nil {
+		t.Fatalf("ensurePropagationRuleMappings error: %v", err)
+	}
+
+	want := []string{
+		"GET /v1/environments/env-id/propagation/rules/rule-id/mappings",
+		"PUT /v1/environments/env-id/propagation/mappings/map-mail",
+	}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("calls = %v, want %v", calls, want)
 	}
 }
 
-type ruleRoundTripperFunc func(*http.Request) (*http.Response, error)
+func TestEnsurePropagationRuleMappings_NonAuthoritativeKeepsUnmanaged(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		body := ``
+		if r.Method == http.MethodGet {
+			body = `{"_embedded":{"mappings":[` +
+				`{"id":"map-name","sourceAttribute":"username","targetAttribute":"userName"},` +
+				`{"id":"map-title","sourceAttribute":"title","targetAttribute":"title"},` +
+				`{"id":"map-extra","sourceAttribute":"mobilePhone","targetAttribute":"phoneNumbers"}]}}`
+		}
+
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	userName := customtypes.PropagationRuleMappingModel{
+		TargetAttribute: types.StringValue("userName"),
+		SourceAttribute: types.StringValue("username"),
+		Expression:      types.StringNull(),
+	}
+	title := customtypes.PropagationRuleMappingModel{
+		TargetAttribute: types.StringValue("title"),
+		SourceAttribute: types.StringValue("title"),
+		Expression:      types.StringNull(),
+	}
+	prior := []customtypes.PropagationRuleMappingModel{userName, title}
+	desired := []customtypes.PropagationRuleMappingModel{userName}
 
-func (f ruleRoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
-	return f(r)
+	if err := ensurePropagationRuleMappings(context.Background(), &sdkPropagationService{api: apiClient}, "env-id", "rule-id", prior, desired, false); err != nil {
+		t.Fatalf("ensurePropagationRuleMappings error: %v", err)
+	}
+
+	want := []string{
+		"GET /v1/environments/env-id/propagation/rules/rule-id/mappings",
+		"DELETE /v1/environments/env-id/propagation/mapping/map-title",
+	}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestResolvePropagationRuleMappings_KeepMissing(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		body := `{"_embedded":{"mappings":[{"id":"map-extra","sourceAttribute":"mobilePhone","targetAttribute":"phoneNumbers","enabled":false}]}}`
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	configured := []customtypes.PropagationRuleMappingModel{{
+		TargetAttribute:     types.StringValue("userName"),
+		SourceAttribute:     types.StringValue("username"),
+		Expression:          types.StringNull(),
+		SensitiveExpression: types.StringNull(),
+		Enabled:             types.BoolNull(),
+	}}
+
+	// A configured mapping deleted outside Terraform is dropped so that it shows as drift.
+	matched, extra, err := resolvePropagationRuleMappings(context.Background(), &sdkPropagationService{api: apiClient}, "env-id", "rule-id", configured, false)
+	if err != nil {
+		t.Fatalf("resolvePropagationRuleMappings error: %v", err)
+	}
+	if len(matched) != 0 {
+		t.Fatalf("matched = %+v, want none", matched)
+	}
+	if len(extra) != 1 || extra[0].Id.ValueString() != "map-extra" {
+		t.Fatalf("extra = %+v, want map-extra", extra)
+	}
+
+	matched, _, err = resolvePropagationRuleMappings(context.Background(), &sdkPropagationService{api: apiClient}, "env-id", "rule-id", configured, true)
+	if err != nil {
+		t.Fatalf("resolvePropagationRuleMappings error: %v", err)
+	}
+	if len(matched) != 1 || matched[0].TargetAttribute.ValueString() != "userName" {
+		t.Fatalf("matched = %+v, want the configured mapping", matched)
+	}
+
+	state := customtypes.PropagationRuleResourceModel{AuthoritativeMappings: types.BoolValue(false)}
+	if diags := setPropagationRuleMappingsState(context.Background(), &state, matched, extra); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(state.Mappings) != 1 || len(state.UnmanagedMappings.Elements()) != 1 {
+		t.Fatalf("mappings = %+v, unmanaged = %s; want one of each", state.Mappings, state.UnmanagedMappings)
+	}
+
+	state.AuthoritativeMappings = types.BoolValue(true)
+	if diags := setPropagationRuleMappingsState(context.Background(), &state, matched, extra); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(state.Mappings) != 2 || !state.UnmanagedMappings.IsNull() {
+		t.Fatalf("mappings = %+v, unmanaged = %s; want both in mappings", state.Mappings, state.UnmanagedMappings)
+	}
+}
+
+func TestValidatePropagationRuleActivation(t *testing.T) {
+	t.Parallel()
+
+	mappingType := types.ObjectType{AttrTypes: map[string]attr.Type{"target_attribute": types.StringType}}
+	oneMapping := types.ListValueMust(mappingType, []attr.Value{
+		types.ObjectValueMust(mappingType.AttrTypes, map[string]attr.Value{"target_attribute": types.StringValue("userName")}),
+	})
+	noMappings := types.ListValueMust(mappingType, []attr.Value{})
+
+	tests := []struct {
+		name             string
+		active           types.Bool
+		externalMappings types.Bool
+		mappings         types.List
+		wantError        bool
+	}{
+		{name: "active_with_mappings", active: types.BoolValue(true), externalMappings: types.BoolNull(), mappings: oneMapping},
+		{name: "active_without_mappings", active: types.BoolValue(true), externalMappings: types.BoolNull(), mappings: types.ListNull(mappingType), wantError: true},
+		{name: "active_with_empty_mappings", active: types.BoolValue(true), externalMappings: types.BoolNull(), mappings: noMappings, wantError: true},
+		{name: "active_with_external_mappings", active: types.BoolValue(true), externalMappings: types.BoolValue(true), mappings: types.ListNull(mappingType)},
+		{name: "inactive_without_mappings", active: types.BoolValue(false), externalMappings: types.BoolNull(), mappings: types.ListNull(mappingType)},
+		{name: "unknown_mappings", active: types.BoolValue(true), externalMappings: types.BoolNull(), mappings: types.ListUnknown(mappingType)},
+		{name: "external_conflicts_with_mappings", active: types.BoolNull(), externalMappings: types.BoolValue(true), mappings: oneMapping, wantError: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validatePropagationRuleActivation(tt.active, tt.externalMappings, tt.mappings)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("HasError() = %v, want %v (diags: %v)", diags.HasError(), tt.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestAddHostnameFallbackWarning(t *testing.T) {
+	t.Parallel()
+
+	configured, err := newManagementClient(context.Background(), "", "client-id", "client-secret", "env-id", "NorthAmerica", "", "", defaultRequestTimeout, defaultRequestTimeout, nil)
+	if err != nil {
+		t.Fatalf("newManagementClient: %v", err)
+	}
+	fallback, err := cloneManagementClientWithBaseHostname(configured, "api.pingone.eu")
+	if err != nil {
+		t.Fatalf("cloneManagementClientWithBaseHostname: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	addHostnameFallbackWarning(&diags, configured, configured, "Propagation rule 'x' was created")
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics for the configured hostname, got %v", diags)
+	}
+
+	addHostnameFallbackWarning(&diags, configured, fallback, "Propagation rule 'x' was created")
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("WarningsCount() = %d, want 1 (diags: %v)", diags.WarningsCount(), diags)
+	}
+	detail := diags.Warnings()[0].Detail()
+	if !strings.Contains(detail, "created via api.pingone.eu though the provider is configured for api.pingone.com (NA)") {
+		t.Fatalf("unexpected warning detail: %s", detail)
+	}
+	if !strings.Contains(detail, "api.pingone.eu is reused for the rest of this run") {
+		t.Fatalf("warning detail does not say the fallback hostname is reused: %s", detail)
+	}
+}
+
+func TestPopulationExpressionFromModel(t *testing.T) {
+	t.Parallel()
+
+	populations := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("pop-b"),
+		types.StringValue("pop-a"),
+	})
+
+	tests := []struct {
+		name   string
+		match  types.String
+		filter types.String
+		want   string
+	}{
+		{name: "default", match: types.StringNull(), filter: types.StringNull(), want: `population.id eq "pop-a" or population.id eq "pop-b"`},
+		{name: "any with filter", match: types.StringValue("any"), filter: types.StringValue(`department eq "IT"`), want: `(population.id eq "pop-a" or population.id eq "pop-b") and (department eq "IT")`},
+		{name: "all", match: types.StringValue("all"), filter: types.StringNull(), want: `population.id eq "pop-a" and population.id eq "pop-b"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := &customtypes.PropagationRuleModel{
+				Filter:          tt.filter,
+				PopulationIds:   populations,
+				PopulationMatch: tt.match,
+			}
+
+			got, ok := populationExpressionFromModel(context.Background(), model)
+			if !ok || got != tt.want {
+				t.Fatalf("populationExpressionFromModel = %q, %v, want %q", got, ok, tt.want)
+			}
+			if want := tt.match.ValueString(); want != "" && populationMatchFromExpression(got) != want {
+				t.Fatalf("populationMatchFromExpression(%q) = %q, want %q", got, populationMatchFromExpression(got), want)
+			}
+		})
+	}
+}
+
+func TestPopulationMatchAllValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&propagationRuleResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name        string
+		populations tftypes.Value
+		wantError   bool
+	}{
+		{name: "two_populations", populations: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "pop-a"),
+			tftypes.NewValue(tftypes.String, "pop-b"),
+		}), wantError: true},
+		{name: "one_population", populations: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "pop-a"),
+		})},
+		{name: "one_known_population", populations: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "pop-a"),
+			tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		})},
+		{name: "unknown_populations", populations: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue)},
+		{name: "no_populations", populations: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			values := map[string]tftypes.Value{}
+			for name, attrType := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(attrType, nil)
+			}
+			values["population_ids"] = tt.populations
+			values["population_match"] = tftypes.NewValue(tftypes.String, populationMatchAll)
+
+			req := validator.StringRequest{
+				Path:        path.Root("population_match"),
+				ConfigValue: types.StringValue(populationMatchAll),
+				Config:      tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := &validator.StringResponse{}
+			populationMatchAllValidator{}.ValidateString(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("HasError() = %v, want %v (diags: %v)", resp.Diagnostics.HasError(), tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPopulationExpressionSent(t *testing.T) {
+	t.Parallel()
+
+	inactive := &customtypes.PropagationRuleModel{
+		Active:        types.BoolValue(false),
+		Filter:        types.StringNull(),
+		PopulationIds: types.SetNull(types.StringType),
+	}
+	if got := populationExpressionSent(context.Background(), inactive); !got.IsNull() {
+		t.Fatalf("inactive rule without filter = %s, want null", got)
+	}
+
+	active := *inactive
+	active.Active = types.BoolValue(true)
+	if got := populationExpressionSent(context.Background(), &active); got.ValueString() != "population.id pr" {
+		t.Fatalf("active rule without filter = %s, want %q", got, "population.id pr")
+	}
+
+	active.Filter = types.StringValue(`department eq "IT"`)
+	if got := populationExpressionSent(context.Background(), &active); got.ValueString() != `department eq "IT"` {
+		t.Fatalf("active rule with filter = %s", got)
+	}
+}
+
+func TestEnsurePropagationRuleMappings_TogglesEnabledInPlace(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	var sent map[string]interface{}
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		body := ``
+		if r.Method == http.MethodGet {
+			body = `{"_embedded":{"mappings":[` +
+				`{"id":"map-mail","sourceAttribute":"email","targetAttribute":"mail","enabled":false},` +
+				`{"id":"map-name","sourceAttribute":"username","targetAttribute":"userName"}]}}`
+		}
+		if r.Method == http.MethodPut {
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decode mapping update: %v", err)
+			}
+		}
+
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	desired := []customtypes.PropagationRuleMappingModel{
+		{
+			TargetAttribute: types.StringValue("mail"),
+			SourceAttribute: types.StringValue("email"),
+			Expression:      types.StringNull(),
+			Enabled:         types.BoolValue(true),
+		},
+		{
+			TargetAttribute: types.StringValue("userName"),
+			SourceAttribute: types.StringValue("username"),
+			Expression:      types.StringNull(),
+			Enabled:         types.BoolNull(),
+		},
+	}
+
+	if err := ensurePropagationRuleMappings(context.Background(), &sdkPropagationService{api: apiClient}, "env-id", "rule-id", nil, desired, true); err != nil {
+		t.Fatalf("ensurePropagationRuleMappings error: %v", err)
+	}
+
+	want := []string{
+		"GET /v1/environments/env-id/propagation/rules/rule-id/mappings",
+		"PUT /v1/environments/env-id/propagation/mappings/map-mail",
+	}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	if sent["enabled"] != true || sent["sourceAttribute"] != "email" {
+		t.Fatalf("update payload = %v", sent)
+	}
+}
+
+func TestValidatePropagationRuleMappings_SensitiveExpression(t *testing.T) {
+	t.Parallel()
+
+	sensitive := customtypes.PropagationRuleMappingModel{
+		SourceAttribute:     types.StringNull(),
+		TargetAttribute:     types.StringValue("password"),
+		Expression:          types.StringNull(),
+		SensitiveExpression: types.StringValue(" \"s3cret\" "),
+	}
+	if got := mappingExpression(sensitive); got != "\"s3cret\"" {
+		t.Fatalf("mappingExpression() = %q, want %q", got, "\"s3cret\"")
+	}
+	if diags := validatePropagationRuleMappings([]customtypes.PropagationRuleMappingModel{sensitive}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	both := sensitive
+	both.Expression = types.StringValue("\"plain\"")
+	if diags := validatePropagationRuleMappings([]customtypes.PropagationRuleMappingModel{both}); !diags.HasError() {
+		t.Fatal("expected an error when both expression and sensitive_expression are set")
+	}
+}
+
+func TestParsePropagationRuleNameImportID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id        string
+		wantEnv   string
+		wantName  string
+		wantMatch bool
+	}{
+		{id: "env-id/name:Sync to SCIM", wantEnv: "env-id", wantName: "Sync to SCIM", wantMatch: true},
+		{id: "env-id/name:HR/Workday", wantEnv: "env-id", wantName: "HR/Workday", wantMatch: true},
+		{id: "env-id/rule-id"},
+		{id: "env-id/name:"},
+		{id: "/name:Sync"},
+		{id: "name:Sync"},
+	}
+
+	for _, tt := range tests {
+		env, name, ok := parsePropagationRuleNameImportID(tt.id)
+		if ok != tt.wantMatch || env != tt.wantEnv || name != tt.wantName {
+			t.Fatalf("parsePropagationRuleNameImportID(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.id, env, name, ok, tt.wantEnv, tt.wantName, tt.wantMatch)
+		}
+	}
+}
+
+func TestPropagationRuleSetPartialRuleState_RecordsRuleAsRead(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		body := `{"message":"not found"}`
+		status := http.StatusNotFound
+		switch r.URL.Path {
+		case "/v1/environments/env-id/propagation/rules/rule-123":
+			body = `{"id":"rule-123","name":"users","active":false,"plan":{"id":"plan-id"},"sourceStore":{"id":"source-id"},"targetStore":{"id":"target-id"}}`
+			status = http.StatusOK
+		case "/v1/environments/env-id/propagation/rules/rule-123/mappings":
+			body = `{"_embedded":{"mappings":[{"id":"map-1","sourceAttribute":"email","targetAttribute":"emails[type eq \"work\"].value"}]}}`
+			status = http.StatusOK
+		}
+		return testResponse(r, status, body), nil
+	})
+
+	r := &propagationRuleResource{client: &client.Client{API: apiClient}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	plan := customtypes.PropagationRuleResourceModel{
+		PropagationRuleModel: customtypes.PropagationRuleModel{
+			Id:                    types.StringUnknown(),
+			EnvironmentId:         types.StringValue("env-id"),
+			PlanId:                types.StringValue("plan-id"),
+			Name:                  types.StringValue("users"),
+			SourceStoreId:         types.StringValue("source-id"),
+			TargetStoreId:         types.StringValue("target-id"),
+			Active:                types.BoolValue(true),
+			Filter:                types.StringNull(),
+			Deprovision:           types.BoolValue(true),
+			PopulationIds:         types.SetNull(types.StringType),
+			PopulationMatch:       types.StringNull(),
+			GroupIds:              types.SetNull(types.StringType),
+			Configuration:         types.MapNull(types.StringType),
+			CorrelationAttributes: types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleCorrelationAttributeAttrTypes}),
+			Mappings: []customtypes.PropagationRuleMappingModel{
+				{
+					Id:                  types.StringUnknown(),
+					SourceAttribute:     types.StringValue("email"),
+					TargetAttribute:     types.StringValue(`emails[type eq "work"].value`),
+					Expression:          types.StringNull(),
+					SensitiveExpression: types.StringNull(),
+					Enabled:             types.BoolValue(true),
+				},
+			},
+		},
+		ExternalMappings:      types.BoolValue(false),
+		AuthoritativeMappings: types.BoolValue(true),
+		UnmanagedMappings:     types.ListUnknown(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes}),
+		ApiHostname:           types.StringUnknown(),
+		PopulationExpression:  types.StringUnknown(),
+	}
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+		},
+		Identity: emptyResourceIdentity(t, r),
+	}
+	r.setPartialRuleState(context.Background(), apiClient, plan, "rule-123", resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("warnings = %d, want 1", resp.Diagnostics.WarningsCount())
+	}
+
+	var state customtypes.PropagationRuleResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if state.Id.ValueString() != "rule-123" {
+		t.Fatalf("id = %q, want rule-123", state.Id.ValueString())
+	}
+	if state.Active.ValueBool() {
+		t.Fatalf("active = true, want the inactive value read from PingOne")
+	}
+	if len(state.Mappings) != 1 || state.Mappings[0].Id.ValueString() != "map-1" {
+		t.Fatalf("mappings = %+v, want the created mapping", state.Mappings)
+	}
+}
+
+// fakePropagationRuleAPI serves a single rule and its mappings. While failMappings is set,
+// creating a mapping fails.
+type fakePropagationRuleAPI struct {
+	rule         map[string]interface{}
+	mappings     []map[string]interface{}
+	failMappings bool
+}
+
+func (f *fakePropagationRuleAPI) roundTrip(r *http.Request) (*http.Response, error) {
+	var payload map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}
+	jsonResponse := func(status int, v interface{}) (*http.Response, error) {
+		encoded, _ := json.Marshal(v)
+		return testResponse(r, status, string(encoded)), nil
+	}
+
+	switch r.Method + " " + r.URL.Path {
+	case "POST /v1/environments/env-id/propagation/plans/plan-id/rules":
+		f.rule = payload
+		f.rule["id"] = "rule-123"
+		return jsonResponse(http.StatusCreated, f.rule)
+	case "GET /v1/environments/env-id/propagation/rules/rule-123":
+		return jsonResponse(http.StatusOK, f.rule)
+	case "PUT /v1/environments/env-id/propagation/rules/rule-123", "PATCH /v1/environments/env-id/propagation/rules/rule-123":
+		for k, v := range payload {
+			f.rule[k] = v
+		}
+		return jsonResponse(http.StatusOK, f.rule)
+	case "GET /v1/environments/env-id/propagation/rules/rule-123/mappings":
+		return jsonResponse(http.StatusOK, map[string]interface{}{"_embedded": map[string]interface{}{"mappings": f.mappings}})
+	case "POST /v1/environments/env-id/propagation/rules/rule-123/mappings":
+		if f.failMappings {
+			return testResponse(r, http.StatusBadRequest, `{"code":"INVALID_DATA","message":"unknown target attribute"}`), nil
+		}
+		payload["id"] = fmt.Sprintf("map-%d", len(f.mappings)+1)
+		f.mappings = append(f.mappings, payload)
+		return jsonResponse(http.StatusCreated, payload)
+	}
+	return testResponse(r, http.StatusNotFound, `{"code":"NOT_FOUND","message":"not found"}`), nil
+}
+
+func TestPropagationRuleCreate_PartialFailureIsReconciledByUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := &fakePropagationRuleAPI{failMappings: true}
+	apiClient := newManagementTestClient(t, "api.example", api.roundTrip)
+
+	r := &propagationRuleResource{client: &client.Client{API: apiClient, SkipPropagationRevisions: true}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	planModel := customtypes.PropagationRuleResourceModel{
+		PropagationRuleModel: customtypes.PropagationRuleModel{
+			Id:                    types.StringUnknown(),
+			EnvironmentId:         types.StringValue("env-id"),
+			PlanId:                types.StringValue("plan-id"),
+			Name:                  types.StringValue("users"),
+			Description:           types.StringNull(),
+			SourceStoreId:         types.StringValue("source-id"),
+			TargetStoreId:         types.StringValue("target-id"),
+			Active:                types.BoolValue(true),
+			Filter:                types.StringNull(),
+			Deprovision:           types.BoolValue(true),
+			PopulationIds:         types.SetNull(types.StringType),
+			PopulationMatch:       types.StringNull(),
+			GroupIds:              types.SetNull(types.StringType),
+			Configuration:         types.MapNull(types.StringType),
+			Links:                 types.MapUnknown(types.StringType),
+			MappingCount:          types.Int64Unknown(),
+			CorrelationAttributes: types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleCorrelationAttributeAttrTypes}),
+			Mappings: []customtypes.PropagationRuleMappingModel{
+				{
+					Id:                  types.StringUnknown(),
+					SourceAttribute:     types.StringValue("email"),
+					TargetAttribute:     types.StringValue("mail"),
+					Expression:          types.StringNull(),
+					SensitiveExpression: types.StringNull(),
+					Enabled:             types.BoolValue(true),
+				},
+			},
+		},
+		ExternalMappings:      types.BoolValue(false),
+		AuthoritativeMappings: types.BoolValue(true),
+		UnmanagedMappings:     types.ListUnknown(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes}),
+		ApiHostname:           types.StringUnknown(),
+		PopulationExpression:  types.StringUnknown(),
+		MappingsCsv:           types.StringNull(),
+		UpdateStrategy:        types.StringValue(ruleUpdateStrategyPatch),
+		ActivateAfter:         types.StringNull(),
+		DeactivateAfter:       types.StringNull(),
+		LifecycleStatus:       types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := plan.Set(ctx, &planModel); diags.HasError() {
+		t.Fatalf("Plan.Set: %v", diags)
+	}
+
+	createResp := &resource.CreateResponse{
+		State:    tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		Identity: emptyResourceIdentity(t, r),
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+
+	// The failed step is reported as an error, so Terraform skips comparing the state with the
+	// plan and shows that error instead of an inconsistent result.
+	if errs := createResp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Summary() != "Error Creating Propagation Rule Mappings" {
+		t.Fatalf("Create diagnostics = %v, want the mapping error", createResp.Diagnostics)
+	}
+	// The state differs from the plan only in the steps that did not run, which the next plan
+	// shows as in-place changes. The planned mappings are not wholly known, since their IDs are
+	// computed, so they are checked below.
+	var planned, recorded map[string]tftypes.Value
+	if err := plan.Raw.As(&planned); err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if err := createResp.State.Raw.As(&recorded); err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	var differing []string
+	for name, value := range planned {
+		if value.IsFullyKnown() && !value.Equal(recorded[name]) {
+			differing = append(differing, name)
+		}
+	}
+	slices.Sort(differing)
+	if want := []string{"active"}; !slices.Equal(differing, want) {
+		t.Fatalf("attributes differing from the plan = %v, want %v", differing, want)
+	}
+	for _, name := range []string{"active", "mappings"} {
+		if requiresReplace(ctx, t, schemaResp.Schema.Attributes[name]) {
+			t.Fatalf("%s requires replacement, want an in-place change", name)
+		}
+	}
+
+	var created customtypes.PropagationRuleResourceModel
+	if diags := createResp.State.Get(ctx, &created); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if created.Id.ValueString() != "rule-123" || created.Active.ValueBool() || len(created.Mappings) != 0 {
+		t.Fatalf("state = id %s, active %v, mappings %v; want the inactive rule without mappings", created.Id, created.Active, created.Mappings)
+	}
+	if !created.MappingCount.IsNull() || !created.LifecycleStatus.IsNull() {
+		t.Fatalf("mapping_count = %v, lifecycle_status = %v; want null until the rule is reconciled", created.MappingCount, created.LifecycleStatus)
+	}
+
+	api.failMappings = false
+	planModel.Id = types.StringValue("rule-123")
+	if diags := plan.Set(ctx, &planModel); diags.HasError() {
+		t.Fatalf("Plan.Set: %v", diags)
+	}
+	updateResp := &resource.UpdateResponse{
+		State:    tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		Identity: emptyResourceIdentity(t, r),
+	}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update diagnostics = %v", updateResp.Diagnostics)
+	}
+
+	if len(api.mappings) != 1 || api.mappings[0]["targetAttribute"] != "mail" {
+		t.Fatalf("mappings = %v, want the planned mapping", api.mappings)
+	}
+	if active, _ := api.rule["active"].(bool); !active {
+		t.Fatalf("rule = %v, want it active", api.rule)
+	}
+}
+
+// requiresReplace reports whether a plan modifier of attribute replaces the resource when the
+// attribute changes in an update.
+func requiresReplace(ctx context.Context, t *testing.T, attribute schema.Attribute) bool {
+	t.Helper()
+
+	// Replacement is only considered when there is a prior state and a planned state.
+	existing := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+	state := tfsdk.State{Raw: existing}
+	plan := tfsdk.Plan{Raw: existing}
+
+	switch a := attribute.(type) {
+	case schema.BoolAttribute:
+		for _, modifier := range a.PlanModifiers {
+			resp := &planmodifier.BoolResponse{PlanValue: types.BoolValue(true)}
+			modifier.PlanModifyBool(ctx, planmodifier.BoolRequest{State: state, Plan: plan, StateValue: types.BoolValue(false), PlanValue: types.BoolValue(true), ConfigValue: types.BoolValue(true)}, resp)
+			if resp.RequiresReplace {
+				return true
+			}
+		}
+	case schema.ListNestedAttribute:
+		elemType := a.NestedObject.Type()
+		planValue := types.ListUnknown(elemType)
+		for _, modifier := range a.PlanModifiers {
+			resp := &planmodifier.ListResponse{PlanValue: planValue}
+			modifier.PlanModifyList(ctx, planmodifier.ListRequest{State: state, Plan: plan, StateValue: types.ListNull(elemType), PlanValue: planValue, ConfigValue: planValue}, resp)
+			if resp.RequiresReplace {
+				return true
+			}
+		}
+	default:
+		t.Fatalf("requiresReplace: unsupported attribute %T", attribute)
+	}
+	return false
+}
+
+func TestPropagationRuleDescription(t *testing.T) {
+	t.Parallel()
+
+	model := &customtypes.PropagationRuleModel{
+		Name:        types.StringValue("Sales to Slack"),
+		Description: types.StringValue("Provisions the sales team into Slack."),
+	}
+	payload, diags := propagationRulePayloadFromModel(context.Background(), model)
+	if diags.HasError() {
+		t.Fatalf("propagationRulePayloadFromModel: %v", diags)
+	}
+	if payload["description"] != "Provisions the sales team into Slack." {
+		t.Fatalf("payload description = %v", payload["description"])
+	}
+
+	model.Description = types.StringNull()
+	payload, _ = propagationRulePayloadFromModel(context.Background(), model)
+	if _, ok := payload["description"]; ok {
+		t.Fatalf("payload has a description for a null description: %v", payload["description"])
+	}
+
+	var state customtypes.PropagationRuleModel
+	if diags := applyRuleAPIToState(context.Background(), map[string]interface{}{"description": "Updated in PingOne"}, &state); diags.HasError() {
+		t.Fatalf("applyRuleAPIToState: %v", diags)
+	}
+	if state.Description.ValueString() != "Updated in PingOne" {
+		t.Fatalf("state description = %s", state.Description)
+	}
+
+	var dataSourceState customtypes.PropagationRuleModel
+	applyRuleAPIToStateDataSource(map[string]interface{}{"description": "Updated in PingOne"}, &dataSourceState)
+	if dataSourceState.Description.ValueString() != "Updated in PingOne" {
+		t.Fatalf("data source description = %s", dataSourceState.Description)
+	}
+}
+
+func TestPropagationRuleRead_SkipMappingRefresh(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		body := `{"message":"not found"}`
+		status := http.StatusNotFound
+		switch r.URL.Path {
+		case "/v1/environments/env-id/propagation/rules/rule-123":
+			body = `{"id":"rule-123","name":"users","active":true,"plan":{"id":"plan-id"},"sourceStore":{"id":"source-id"},"targetStore":{"id":"target-id"}}`
+			status = http.StatusOK
+		case "/v1/environments/env-id/propagation/stores/target-id":
+			body = `{"id":"target-id","name":"SCIM","type":"scim","status":"ACTIVE","syncStatus":{"syncState":"FAILED","details":"invalid credentials","lastSyncAt":"2026-10-01T00:00:00Z"}}`
+			status = http.StatusOK
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		return testResponse(r, status, body), nil
+	})
+
+	r := &propagationRuleResource{client: &client.Client{API: apiClient, SkipMappingRefresh: true}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	prior := customtypes.PropagationRuleResourceModel{
+		PropagationRuleModel: customtypes.PropagationRuleModel{
+			Id:                    types.StringValue("rule-123"),
+			EnvironmentId:         types.StringValue("env-id"),
+			PlanId:                types.StringValue("plan-id"),
+			Name:                  types.StringValue("users"),
+			SourceStoreId:         types.StringValue("source-id"),
+			TargetStoreId:         types.StringValue("target-id"),
+			Active:                types.BoolValue(true),
+			PopulationIds:         types.SetNull(types.StringType),
+			GroupIds:              types.SetNull(types.StringType),
+			Configuration:         types.MapNull(types.StringType),
+			CorrelationAttributes: types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleCorrelationAttributeAttrTypes}),
+			Links:                 types.MapNull(types.StringType),
+			PopulationMatch:       types.StringNull(),
+			Mappings: []customtypes.PropagationRuleMappingModel{
+				{
+					Id:              types.StringValue("map-1"),
+					SourceAttribute: types.StringValue("email"),
+					TargetAttribute: types.StringValue("userName"),
+				},
+			},
+		},
+		AuthoritativeMappings: types.BoolValue(true),
+		UnmanagedMappings:     types.ListNull(types.ObjectType{AttrTypes: customtypes.PropagationRuleUnmanagedMappingAttrTypes}),
+		ApiHostname:           types.StringValue("api.example"),
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+	}
+	if diags := state.Set(context.Background(), &prior); diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+
+	resp := &resource.ReadResponse{State: state, Identity: emptyResourceIdentity(t, r)}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got customtypes.PropagationRuleResourceModel
+	if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if len(got.Mappings) != 1 || got.Mappings[0].Id.ValueString() != "map-1" {
+		t.Fatalf("mappings = %+v, want the mappings from state", got.Mappings)
+	}
+	if got.LifecycleStatus.ValueString() != "ERROR: invalid credentials" {
+		t.Fatalf("lifecycle_status = %s, want the target store's sync error", got.LifecycleStatus)
+	}
+}
+
+func TestPropagationRuleResourceUpgradeState_ListsToSets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &propagationRuleResource{}
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok || upgrader.PriorSchema == nil || upgrader.PriorSchema.Version != 0 {
+		t.Fatal("expected a version 0 upgrader with a prior schema")
+	}
+
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+
+	priorState := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range map[string]attr.Value{
+		"id":   types.StringValue("rule-123"),
+		"name": types.StringValue("Sync users"),
+		"population_ids": types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("pop-b"),
+			types.StringValue("pop-a"),
+			types.StringValue("pop-b"),
+		}),
+		"group_ids": types.ListNull(types.StringType),
+	} {
+		if diags := priorState.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("SetAttribute(%s): %v", name, diags)
+		}
+	}
+
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: current.Schema,
+			Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &priorState}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade: %v", resp.Diagnostics)
+	}
+
+	var got customtypes.PropagationRuleResourceModel
+	if diags := resp.State.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+
+	wantPopulations := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("pop-a"), types.StringValue("pop-b")})
+	if !got.PopulationIds.Equal(wantPopulations) {
+		t.Fatalf("population_ids = %s, want %s", got.PopulationIds, wantPopulations)
+	}
+	if !got.GroupIds.IsNull() {
+		t.Fatalf("group_ids = %s, want null", got.GroupIds)
+	}
+	if got.Id.ValueString() != "rule-123" || got.Name.ValueString() != "Sync users" {
+		t.Fatalf("id, name = %s, %s", got.Id, got.Name)
+	}
+}
+
+func TestUpdatePropagationRuleWithMappings_PatchKeepsServerFields(t *testing.T) {
+	t.Parallel()
+
+	var sent map[string]interface{}
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		body := `{}`
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/environments/env-id/propagation/rules/rule-id":
+			body = `{"id":"rule-id","_links":{"self":{"href":"x"}},"name":"Old","description":"Remove me","deprovision":true,"sourceStore":{"id":"source-id"},"targetStore":{"id":"target-id"},"plan":{"id":"plan-id"},"environment":{"id":"env-id"},"active":false}`
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/environments/env-id/propagation/rules/rule-id":
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	rule := func(name string, description types.String) customtypes.PropagationRuleModel {
+		return customtypes.PropagationRuleModel{
+			EnvironmentId: types.StringValue("env-id"),
+			PlanId:        types.StringValue("plan-id"),
+			Name:          types.StringValue(name),
+			Description:   description,
+			SourceStoreId: types.StringValue("source-id"),
+			TargetStoreId: types.StringValue("target-id"),
+			Active:        types.BoolValue(false),
+			Deprovision:   types.BoolNull(),
+			PopulationIds: types.SetNull(types.StringType),
+			GroupIds:      types.SetNull(types.StringType),
+			Configuration: types.MapNull(types.StringType),
+		}
+	}
+	prior := rule("Old", types.StringValue("Remove me"))
+	desired := rule("New", types.StringNull())
+
+	diags := updatePropagationRuleWithMappings(context.Background(), &sdkPropagationService{api: apiClient}, "rule-id", &prior, &desired, false, true, ruleUpdateStrategyPatch)
+	if diags.HasError() {
+		t.Fatalf("updatePropagationRuleWithMappings: %v", diags)
+	}
+
+	if sent["name"] != "New" {
+		t.Errorf("name = %v, want New", sent["name"])
+	}
+	if _, ok := sent["description"]; ok {
+		t.Errorf("description = %v, want it removed", sent["description"])
+	}
+	// Not managed by the configuration, so PingOne's value is sent back.
+	if sent["deprovision"] != true {
+		t.Errorf("deprovision = %v, want true", sent["deprovision"])
+	}
+	if _, ok := sent["_links"]; ok {
+		t.Error("read-only _links sent back")
+	}
+	if _, ok := sent["id"]; ok {
+		t.Error("read-only id sent back")
+	}
 }
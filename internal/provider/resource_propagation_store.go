@@ -4,8 +4,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
-	"time"
 
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/mappers"
@@ -14,10 +14,13 @@ import (
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -27,9 +30,12 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &propagationStoreResource{}
-	_ resource.ResourceWithConfigure   = &propagationStoreResource{}
-	_ resource.ResourceWithImportState = &propagationStoreResource{}
+	_ resource.Resource                 = &propagationStoreResource{}
+	_ resource.ResourceWithConfigure    = &propagationStoreResource{}
+	_ resource.ResourceWithImportState  = &propagationStoreResource{}
+	_ resource.ResourceWithModifyPlan   = &propagationStoreResource{}
+	_ resource.ResourceWithUpgradeState = &propagationStoreResource{}
+	_ resource.ResourceWithIdentity     = &propagationStoreResource{}
 )
 
 // propagationStoreResource is the resource implementation.
@@ -47,7 +53,30 @@ func (r *propagationStoreResource) Metadata(_ context.Context, req resource.Meta
 }
 
 func (r *propagationStoreResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	blocks := map[string]schema.Block{
+		"configuration_aquera":              schemas.AqueraConfigSchema(false),
+		"configuration_azure_ad_saml_v2":    schemas.AzureAdSamlV2ConfigSchema(false),
+		"configuration_github_emu":          schemas.GithubEmuConfigSchema(false),
+		"configuration_google_apps":         schemas.GoogleAppsConfigSchema(false),
+		"configuration_ldap_gateway":        schemas.LdapGatewayConfigSchema(false),
+		"configuration_ping_one":            schemas.PingOneConfigSchema(false),
+		"configuration_salesforce":          schemas.SalesforceConfigSchema(false),
+		"configuration_salesforce_contacts": schemas.SalesforceContactsConfigSchema(false),
+		"configuration_scim":                schemas.ScimConfigSchema(false),
+		"scim_configuration":                schemas.ScimConfigDeprecatedAliasSchema(false),
+		"configuration_service_now":         schemas.ServiceNowConfigSchema(false),
+		"configuration_slack":               schemas.SlackConfigSchema(false),
+		"configuration_workday":             schemas.WorkdayConfigSchema(false),
+		"configuration_zoom":                schemas.ZoomConfigSchema(false),
+	}
+	blocks["credentials"] = schemas.CredentialsSchema(blocks)
+
 	resp.Schema = schema.Schema{
+		// Version 1 migrates the deprecated scim_configuration block to configuration_scim.
+		// Version 2 folds store type aliases into their canonical spelling.
+		// Version 3 stops recording managed = false when the configuration leaves managed unset.
+		// Version 4 replaces sync_status.next_sync_time with last_error.
+		Version:     4,
 		Description: "Manages a PingOne provisioning propagation store.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -65,21 +94,18 @@ func (r *propagationStoreResource) Schema(_ context.Context, req resource.Schema
 				},
 			},
 			"name": schema.StringAttribute{
-				Description: "A name for the identity store.",
+				Description: "A name for the identity store. Changing the name renames the store in place.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "A description of the identity store.",
 				Optional:    true,
 			},
 			"type": schema.StringAttribute{
-				Description: "The type of the identity store. Options are `Aquera`, `AzureADSAMLV2`, `GithubEMU`, `GitHubEMU`, `GoogleApps`, `LDAPGateway`, `PingOne`, `Salesforce`, `SalesforceContacts`, `SCIM` (alias: `scim`), `ServiceNow`, `Slack`, `Workday`, `Zoom`.",
+				Description: "The type of the identity store. Options are `Aquera`, `AzureADSAMLV2`, `GithubEMU`, `GitHubEMU`, `GoogleApps`, `LDAPGateway`, `PingOne`, `Salesforce`, `SalesforceContacts`, `SCIM` (alias: `scim`), `ServiceNow`, `Slack`, `Workday`, `Zoom`. Changing the type recreates the store; switching between aliases of the same type updates it in place.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceIfStoreTypeChanged(),
 				},
 				Validators: []validator.String{
 					stringvalidator.OneOf(
@@ -89,6 +115,10 @@ func (r *propagationStoreResource) Schema(_ context.Context, req resource.Schema
 					),
 				},
 			},
+			"provisioning_direction": schema.StringAttribute{
+				Description: "The direction the store provisions in, which follows from its type: `inbound` for stores that provision identities into PingOne (Workday), `bidirectional` for stores that can be a rule's source or target (LDAP Gateway), and `outbound` for every other store.",
+				Computed:    true,
+			},
 			"image_id": schema.StringAttribute{
 				Description: "The image ID for the identity store resource.",
 				Optional:    true,
@@ -98,46 +128,61 @@ func (r *propagationStoreResource) Schema(_ context.Context, req resource.Schema
 				Computed:    true,
 			},
 			"managed": schema.BoolAttribute{
-				Description: "Indicates whether or not to enable deprovisioning of users from the target store.",
+				Description: "Indicates whether or not to enable deprovisioning of users from the target store. If unset, PingOne's default is used and recorded in state.",
 				Optional:    true,
 				Computed:    true,
-				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Description: "The status of the propagation store.",
 				Optional:    true,
 				Computed:    true,
 			},
-			"sync_status": schema.ObjectAttribute{
-				Description: "Sync status for the propagation store.",
+			"secret_refs_sha256": schema.StringAttribute{
+				Description: "The SHA-256 of the provider secrets the configuration references with `bearer_token_ref`, so that rotating a secret plans an update without showing its value. Null when no secret is referenced.",
+				Computed:    true,
+			},
+			"preset": schema.StringAttribute{
+				Description: "A set of vendor-recommended provisioning settings for the store's configuration block. Options are `github-emu-standard`, `google-apps-standard`, `salesforce-standard`, `servicenow-standard` and `slack-standard`, each of which sets `create_users` and `update_users` to `true`, `remove_action` to `Disable` and, where the store supports it, `group_name_source` to `Common Name`. Settings configured in the block override the preset's. The preset must match the store's `type`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(propagationStorePresetNames()...),
+				},
+			},
+			"disable_instead_of_delete": schema.BoolAttribute{
+				Description: "When `true`, destroying the resource sets the store's status to `INACTIVE` and removes it from state instead of deleting it. To delete the store, set this to `false` and apply before destroying. Default: `false`.",
+				Optional:    true,
 				Computed:    true,
-				AttributeTypes: map[string]attr.Type{
-					"last_sync_time": types.StringType,
-					"next_sync_time": types.StringType,
-					"status":         types.StringType,
-					"details":        types.StringType,
+				Default:     booldefault.StaticBool(false),
+			},
+			"links": schema.MapAttribute{
+				Description: linksAttributeDescription,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"sync_status": schema.ObjectAttribute{
+				Description:    "Sync status for the propagation store.",
+				Computed:       true,
+				AttributeTypes: customtypes.SyncStatusAttrTypes,
+			},
+			"lifecycle_status": schema.StringAttribute{
+				Description: "A summary of the store's readiness from `status` and `sync_status`: `INACTIVE`, `CONFIGURED_NOT_SYNCED` before the first sync, `SYNCING`, `ACTIVE_HEALTHY` after a successful sync, or `ERROR: ` followed by the reason the last sync failed, for example `ERROR: invalid credentials`.",
+				Computed:    true,
+			},
 		},
-		Blocks: map[string]schema.Block{
-			"configuration_aquera":              schemas.AqueraConfigSchema(false),
-			"configuration_azure_ad_saml_v2":    schemas.AzureAdSamlV2ConfigSchema(false),
-			"configuration_github_emu":          schemas.GithubEmuConfigSchema(false),
-			"configuration_google_apps":         schemas.GoogleAppsConfigSchema(false),
-			"configuration_ldap_gateway":        schemas.LdapGatewayConfigSchema(false),
-			"configuration_ping_one":            schemas.PingOneConfigSchema(false),
-			"configuration_salesforce":          schemas.SalesforceConfigSchema(false),
-			"configuration_salesforce_contacts": schemas.SalesforceContactsConfigSchema(false),
-			"configuration_scim":                schemas.ScimConfigSchema(false),
-			"scim_configuration":                schemas.ScimConfigSchema(false),
-			"configuration_service_now":         schemas.ServiceNowConfigSchema(false),
-			"configuration_slack":               schemas.SlackConfigSchema(false),
-			"configuration_workday":             schemas.WorkdayConfigSchema(false),
-			"configuration_zoom":                schemas.ZoomConfigSchema(false),
-		},
+		Blocks: blocks,
 	}
 }
 
+func (r *propagationStoreResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = environmentResourceIdentitySchema("propagation store")
+}
+
 func (r *propagationStoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -155,15 +200,164 @@ func (r *propagationStoreResource) Configure(_ context.Context, req resource.Con
 	r.client = client
 }
 
+func (r *propagationStoreResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_propagation_store", req, resp)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if deferUnknownPlan(req, resp, "environment_id") {
+		return
+	}
+
+	var storeType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &storeType)...)
+	if resp.Diagnostics.HasError() || storeType.IsNull() || storeType.IsUnknown() {
+		return
+	}
+	direction := types.StringValue(utils.PropagationStoreProvisioningDirection(storeType.ValueString()))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("provisioning_direction"), direction)...)
+	resp.Diagnostics.Append(planPropagationStorePreset(ctx, req, resp)...)
+
+	var credentials types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("credentials"), &credentials)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validatePropagationStoreCredentials(ctx, req.Plan, credentials)...)
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	var plan customtypes.PropagationStoreResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, _, ref := propagationStoreBearerToken(&plan.PropagationStoreModel)
+	if ref != nil && ref.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_refs_sha256"), types.StringUnknown())...)
+		return
+	}
+	secretRefs, secretDiags := propagationStoreSecretRefsSHA256(&plan.PropagationStoreModel, r.client.Secrets)
+	resp.Diagnostics.Append(secretDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_refs_sha256"), secretRefs)...)
+}
+
+func (r *propagationStoreResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	// Earlier schema versions decode with the current schema, except that sync_status had a
+	// next_sync_time that PingOne never reported instead of last_error. Since version 0 the schema
+	// has otherwise only gained attributes and blocks: the scim_configuration block of versions 0
+	// and 1 has kept its attributes and only became deprecated, and an attribute missing from
+	// older state decodes as null. Removing or retyping an attribute needs a prior schema of its own.
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	priorSchema := func(version int64) *schema.Schema {
+		prior := current.Schema
+		prior.Version = version
+		prior.Attributes = maps.Clone(current.Schema.Attributes)
+		prior.Attributes["sync_status"] = schema.ObjectAttribute{
+			Computed: true,
+			AttributeTypes: map[string]attr.Type{
+				"last_sync_time": types.StringType,
+				"next_sync_time": types.StringType,
+				"status":         types.StringType,
+				"details":        types.StringType,
+			},
+		}
+		return &prior
+	}
+
+	upgradeFrom := func(version int64) func(context.Context, resource.UpgradeStateRequest, *resource.UpgradeStateResponse) {
+		return func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			var state customtypes.PropagationStoreResourceModel
+
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			upgradeScimConfigurationAlias(&state.PropagationStoreModel)
+			upgradeStoreTypeAlias(&state.PropagationStoreModel)
+			// From version 3 on, managed = false in state was configured or read from PingOne, not defaulted.
+			if version < 3 {
+				upgradeManagedDefault(&state.PropagationStoreModel)
+			}
+			state.DisableInsteadOfDelete = disableInsteadOfDeleteOrDefault(state.DisableInsteadOfDelete)
+			// The next refresh reads the sync status again.
+			state.SyncStatus = types.ObjectNull(customtypes.SyncStatusAttrTypes)
+
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {PriorSchema: priorSchema(0), StateUpgrader: upgradeFrom(0)},
+		1: {PriorSchema: priorSchema(1), StateUpgrader: upgradeFrom(1)},
+		2: {PriorSchema: priorSchema(2), StateUpgrader: upgradeFrom(2)},
+		3: {PriorSchema: priorSchema(3), StateUpgrader: upgradeFrom(3)},
+	}
+}
+
+// upgradeScimConfigurationAlias moves the deprecated scim_configuration block to
+// configuration_scim.
+func upgradeScimConfigurationAlias(state *customtypes.PropagationStoreModel) {
+	if state.ScimConfiguration == nil {
+		return
+	}
+	if state.ConfigurationScim == nil {
+		state.ConfigurationScim = state.ScimConfiguration
+	}
+	state.ScimConfiguration = nil
+}
+
+// upgradeStoreTypeAlias stores the canonical spelling of the store type, so that `scim` and
+// `SCIM`, or `GitHubEMU` and `GithubEMU`, are recorded the same way.
+func upgradeStoreTypeAlias(state *customtypes.PropagationStoreModel) {
+	if state.Type.IsNull() || state.Type.IsUnknown() || state.Type.ValueString() == "" {
+		return
+	}
+	state.Type = types.StringValue(utils.CanonicalPropagationStoreType(state.Type.ValueString()))
+}
+
+// upgradeManagedDefault clears a managed value of false, which schema versions before 3 stored
+// whenever the configuration left managed unset. The next refresh records PingOne's value; a
+// configuration that sets managed = false still plans that value.
+func upgradeManagedDefault(state *customtypes.PropagationStoreModel) {
+	if !state.Managed.IsNull() && !state.Managed.IsUnknown() && !state.Managed.ValueBool() {
+		state.Managed = types.BoolNull()
+	}
+}
+
+// requiresReplaceIfStoreTypeChanged requires replacement when the store type changes, but not
+// when the configuration switches between aliases of the same type.
+func requiresReplaceIfStoreTypeChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = utils.CanonicalPropagationStoreType(req.StateValue.ValueString()) != utils.CanonicalPropagationStoreType(req.PlanValue.ValueString())
+		},
+		"Changing the type (other than to an alias of the same type) requires replacement.",
+		"Changing the type (other than to an alias of the same type) requires replacement.",
+	)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *propagationStoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan customtypes.PropagationStoreModel
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_propagation_store") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var resourcePlan customtypes.PropagationStoreResourceModel
 
-	diags := req.Plan.Get(ctx, &plan)
+	diags := req.Plan.Get(ctx, &resourcePlan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan := resourcePlan.PropagationStoreModel
 
 	configMap, err := mappers.ModelToConfigurationMap(&plan)
 	if err != nil {
@@ -174,13 +368,28 @@ func (r *propagationStoreResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	resp.Diagnostics.Append(applyPropagationStoreSecretRefs(configMap, &plan, r.client.Secrets)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	applyPropagationStoreCredentials(configMap, resourcePlan.Credentials)
+
 	apiClient := r.client.API
 	payload := buildPropagationStorePayload(&plan, configMap)
 
-	result, httpResp, err := apiClient.PropagationStoresApi.
-		CreatePropagationStore(ctx, plan.EnvironmentId.ValueString()).
-		PropagationStore(*payload).
-		Execute()
+	environmentID := plan.EnvironmentId.ValueString()
+	_, httpResp, err := createOrAdopt(ctx, "propagation store", plan.Name.ValueString(),
+		func(ctx context.Context) (*management.PropagationStore, *http.Response, error) {
+			result, httpResp, err := apiClient.PropagationStoresApi.
+				CreatePropagationStore(ctx, environmentID).
+				PropagationStore(*payload).
+				Execute()
+			return result, httpResp, ignoreStoreDecodeError(httpResp, err)
+		},
+		func(ctx context.Context) (*management.PropagationStore, *http.Response, bool, error) {
+			return findPropagationStoreByName(ctx, r.client.Endpoints, apiClient, environmentID, plan.Name.ValueString(), r.client.PageSize)
+		},
+	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Propagation Store",
@@ -189,7 +398,7 @@ func (r *propagationStoreResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	state, mapErr := r.apiToModel(result, httpResp, plan.EnvironmentId.ValueString(), &plan)
+	model, mapErr := r.apiToModel(httpResp, plan.EnvironmentId.ValueString(), &plan)
 	if mapErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Propagation Store",
@@ -198,25 +407,36 @@ func (r *propagationStoreResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	state := customtypes.PropagationStoreResourceModel{
+		PropagationStoreModel:  model,
+		DisableInsteadOfDelete: disableInsteadOfDeleteOrDefault(resourcePlan.DisableInsteadOfDelete),
+		SecretRefsSha256:       resourcePlan.SecretRefsSha256,
+		Preset:                 resourcePlan.Preset,
+		Credentials:            resourcePlan.Credentials,
+	}
+	state.LifecycleStatus = propagationStoreLifecycleStatus(&state.PropagationStoreModel)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(clearPropagationStoreCredentials(ctx, &resp.State, state.Credentials)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(state.EnvironmentId, state.Id))...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *propagationStoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state customtypes.PropagationStoreModel
+	var resourceState customtypes.PropagationStoreResourceModel
 
-	diags := req.State.Get(ctx, &state)
+	diags := req.State.Get(ctx, &resourceState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state := resourceState.PropagationStoreModel
 
 	apiClient := r.client.API
-	result, httpResp, err := apiClient.PropagationStoresApi.
+	_, httpResp, err := apiClient.PropagationStoresApi.
 		ReadOnePropagationStore(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString()).
 		Execute()
-	if err != nil {
+	if err = ignoreStoreDecodeError(httpResp, err); err != nil {
 		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
 			resp.State.RemoveResource(ctx)
 			return
@@ -228,7 +448,7 @@ func (r *propagationStoreResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	newState, mapErr := r.apiToModel(result, httpResp, state.EnvironmentId.ValueString(), &state)
+	model, mapErr := r.apiToModel(httpResp, state.EnvironmentId.ValueString(), &state)
 	if mapErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Propagation Store",
@@ -237,19 +457,37 @@ func (r *propagationStoreResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	newState := customtypes.PropagationStoreResourceModel{
+		PropagationStoreModel:  model,
+		DisableInsteadOfDelete: disableInsteadOfDeleteOrDefault(resourceState.DisableInsteadOfDelete),
+		SecretRefsSha256:       resourceState.SecretRefsSha256,
+		Preset:                 resourceState.Preset,
+		Credentials:            resourceState.Credentials,
+	}
+	newState.LifecycleStatus = propagationStoreLifecycleStatus(&newState.PropagationStoreModel)
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(clearPropagationStoreCredentials(ctx, &resp.State, newState.Credentials)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(newState.EnvironmentId, newState.Id))...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *propagationStoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan customtypes.PropagationStoreModel
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_propagation_store") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var resourcePlan, resourcePrior customtypes.PropagationStoreResourceModel
 
-	diags := req.Plan.Get(ctx, &plan)
+	diags := req.Plan.Get(ctx, &resourcePlan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &resourcePrior)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan, prior := resourcePlan.PropagationStoreModel, resourcePrior.PropagationStoreModel
 
 	configMap, err := mappers.ModelToConfigurationMap(&plan)
 	if err != nil {
@@ -259,15 +497,20 @@ func (r *propagationStoreResource) Update(ctx context.Context, req resource.Upda
 		)
 		return
 	}
+	resp.Diagnostics.Append(applyPropagationStoreSecretRefs(configMap, &plan, r.client.Secrets)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	applyPropagationStoreCredentials(configMap, resourcePlan.Credentials)
 
 	apiClient := r.client.API
 	payload := buildPropagationStorePayload(&plan, configMap)
 
-	result, httpResp, err := apiClient.PropagationStoresApi.
+	_, httpResp, err := apiClient.PropagationStoresApi.
 		UpdatePropagationStore(ctx, plan.EnvironmentId.ValueString(), plan.Id.ValueString()).
 		PropagationStore(*payload).
 		Execute()
-	if err != nil {
+	if err = ignoreStoreDecodeError(httpResp, err); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Propagation Store",
 			fmt.Sprintf("Could not update propagation store: %s", utils.HandleSDKError(err, httpResp)),
@@ -275,7 +518,7 @@ func (r *propagationStoreResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	state, mapErr := r.apiToModel(result, httpResp, plan.EnvironmentId.ValueString(), &plan)
+	model, mapErr := r.apiToModel(httpResp, plan.EnvironmentId.ValueString(), &plan)
 	if mapErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Propagation Store",
@@ -284,19 +527,51 @@ func (r *propagationStoreResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	state := customtypes.PropagationStoreResourceModel{
+		PropagationStoreModel:  model,
+		DisableInsteadOfDelete: disableInsteadOfDeleteOrDefault(resourcePlan.DisableInsteadOfDelete),
+		SecretRefsSha256:       resourcePlan.SecretRefsSha256,
+		Preset:                 resourcePlan.Preset,
+		Credentials:            resourcePlan.Credentials,
+	}
+	state.LifecycleStatus = propagationStoreLifecycleStatus(&state.PropagationStoreModel)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(clearPropagationStoreCredentials(ctx, &resp.State, state.Credentials)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, environmentResourceIdentity(state.EnvironmentId, state.Id))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rules display the store name, so a rename needs a new propagation revision before
+	// the PingOne UI reflects it.
+	if plan.Name.ValueString() != prior.Name.ValueString() {
+		if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, apiClient, plan.EnvironmentId.ValueString()); revErr != nil {
+			addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Store was renamed, but the provider could not create a propagation revision. The PingOne UI may not reflect the new name until a revision is created. Error: %s", revErr))
+		}
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *propagationStoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state customtypes.PropagationStoreModel
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_propagation_store") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var resourceState customtypes.PropagationStoreResourceModel
 
-	diags := req.State.Get(ctx, &state)
+	diags := req.State.Get(ctx, &resourceState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state := resourceState.PropagationStoreModel
+
+	if resourceState.DisableInsteadOfDelete.ValueBool() {
+		r.disable(ctx, &state, resourceState.Credentials, &resp.Diagnostics)
+		return
+	}
 
 	apiClient := r.client.API
 	httpResp, err := apiClient.PropagationStoresApi.
@@ -311,12 +586,66 @@ func (r *propagationStoreResource) Delete(ctx context.Context, req resource.Dele
 	}
 }
 
+// disable sets the store's status to INACTIVE in place of deleting it. The rest of the payload
+// is the configuration and credentials recorded in state, so nothing but the status changes.
+func (r *propagationStoreResource) disable(ctx context.Context, state *customtypes.PropagationStoreModel, credentials types.Object, diags *diag.Diagnostics) {
+	configMap, err := mappers.ModelToConfigurationMap(state)
+	if err != nil {
+		diags.AddError(
+			"Error Disabling Propagation Store",
+			fmt.Sprintf("Could not build configuration map: %s", err),
+		)
+		return
+	}
+	diags.Append(applyPropagationStoreSecretRefs(configMap, state, r.client.Secrets)...)
+	if diags.HasError() {
+		return
+	}
+	applyPropagationStoreCredentials(configMap, credentials)
+
+	payload := buildPropagationStorePayload(state, configMap)
+	payload.SetStatus(management.ENUMPROPAGATIONSTORESTATUS_INACTIVE)
+
+	_, httpResp, err := r.client.API.PropagationStoresApi.
+		UpdatePropagationStore(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString()).
+		PropagationStore(*payload).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		diags.AddError(
+			"Error Disabling Propagation Store",
+			fmt.Sprintf("Could not disable propagation store: %s", utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	diags.AddWarning(
+		"Propagation Store Disabled, Not Deleted",
+		fmt.Sprintf("disable_instead_of_delete is set, so propagation store %q (%s) was set to INACTIVE and removed from state but still exists in PingOne. To delete it, set disable_instead_of_delete = false and apply before destroying, or import it again to manage it.", state.Name.ValueString(), state.Id.ValueString()),
+	)
+}
+
+// disableInsteadOfDeleteOrDefault returns false for state written before
+// disable_instead_of_delete existed.
+func disableInsteadOfDeleteOrDefault(value types.Bool) types.Bool {
+	if value.IsNull() || value.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return value
+}
+
 func (r *propagationStoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := utils.SplitImportID(req.ID, 2)
+	importID := environmentResourceImportID(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	idParts := utils.SplitImportID(importID, 2)
 	if idParts == nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Propagation Store",
-			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>/<store_id>'.", req.ID),
+			fmt.Sprintf("Unexpected import identifier format: %s. Expected '<environment_id>/<store_id>'.", importID),
 		)
 		return
 	}
@@ -325,114 +654,57 @@ func (r *propagationStoreResource) ImportState(ctx context.Context, req resource
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
 
-func (r *propagationStoreResource) apiToModel(apiObj *management.PropagationStore, httpResp *http.Response, environmentId string, plan *customtypes.PropagationStoreModel) (customtypes.PropagationStoreModel, error) {
+func (r *propagationStoreResource) apiToModel(httpResp *http.Response, environmentId string, plan *customtypes.PropagationStoreModel) (customtypes.PropagationStoreModel, error) {
 	preferredType := ""
 	if plan != nil && !plan.Type.IsNull() && !plan.Type.IsUnknown() {
 		preferredType = plan.Type.ValueString()
 	}
 
-	rawType, rawStatus, err := utils.ExtractPropagationStoreTypeStatus(httpResp)
+	storeObj, err := utils.DecodeResponseJSONObject(httpResp, "store")
 	if err != nil {
 		return customtypes.PropagationStoreModel{}, err
 	}
 
-	apiType := rawType
-	if apiType == "" || apiType == "UNKNOWN" {
-		apiType = string(apiObj.GetType())
-	}
-	if apiType == "" || apiType == "UNKNOWN" {
-		apiType = preferredType
+	model := propagationStoreFromJSON(storeObj, environmentId, preferredType, plan)
+	if model.Status.IsNull() && plan != nil && !plan.Status.IsNull() && !plan.Status.IsUnknown() && plan.Status.ValueString() != "" {
+		model.Status = plan.Status
 	}
-	tfType := utils.NormalizePropagationStoreTypeForTerraform(apiType, preferredType)
+	keepPropagationStoreSecretRefs(&model, plan)
 
-	model := customtypes.PropagationStoreModel{
-		Id:            types.StringValue(apiObj.GetId()),
-		EnvironmentId: types.StringValue(environmentId),
-		Name:          types.StringValue(apiObj.GetName()),
-		Type:          types.StringValue(tfType),
-	}
-
-	if v, ok := apiObj.GetDescriptionOk(); ok {
-		model.Description = types.StringValue(*v)
-	} else {
-		model.Description = types.StringNull()
-	}
-
-	if image, ok := apiObj.GetImageOk(); ok && image != nil {
-		if v, ok := image.GetIdOk(); ok && v != nil {
-			model.ImageId = types.StringValue(*v)
-		} else {
-			model.ImageId = types.StringNull()
-		}
-
-		if v, ok := image.GetHrefOk(); ok && v != nil {
-			model.ImageHref = types.StringValue(*v)
-		} else {
-			model.ImageHref = types.StringNull()
-		}
-	} else {
-		model.ImageId = types.StringNull()
-		model.ImageHref = types.StringNull()
-	}
+	return model, nil
+}
 
-	if v, ok := apiObj.GetManagedOk(); ok {
-		model.Managed = types.BoolValue(*v)
-	} else {
-		model.Managed = types.BoolNull()
+// findPropagationStoreByName reads the store named name, reporting found = false when there is
+// none. More than one store with the name is an error, since none of them can be chosen safely.
+func findPropagationStoreByName(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, name string, pageSize int32) (*management.PropagationStore, *http.Response, bool, error) {
+	stores, err := listPropagationStores(ctx, endpoints, apiClient, environmentID, pageSize)
+	if err != nil {
+		return nil, nil, false, err
 	}
 
-	status := rawStatus
-	if status == "" {
-		if v, ok := apiObj.GetStatusOk(); ok && v != nil && string(*v) != "" && string(*v) != "UNKNOWN" {
-			status = string(*v)
+	var ids []string
+	for _, store := range stores {
+		if storeName, _ := utils.NestedString(store, "name"); storeName == name {
+			if id, _ := utils.NestedString(store, "id"); id != "" {
+				ids = append(ids, id)
+			}
 		}
 	}
-	if status == "" && plan != nil && !plan.Status.IsNull() && !plan.Status.IsUnknown() && plan.Status.ValueString() != "" {
-		status = plan.Status.ValueString()
-	}
-	if status != "" {
-		model.Status = types.StringValue(status)
-	} else {
-		model.Status = types.StringNull()
+	switch len(ids) {
+	case 0:
+		return nil, nil, false, nil
+	case 1:
+	default:
+		return nil, nil, false, fmt.Errorf("found %d propagation stores named %q", len(ids), name)
 	}
 
-	if v, ok := apiObj.GetSyncStatusOk(); ok && v != nil {
-		attrs := map[string]attr.Value{
-			"last_sync_time": types.StringNull(),
-			"next_sync_time": types.StringNull(),
-			"status":         types.StringNull(),
-			"details":        types.StringNull(),
-		}
-
-		if vv, ok := v.GetLastSyncAtOk(); ok && vv != nil {
-			attrs["last_sync_time"] = types.StringValue(vv.Format(time.RFC3339))
-		}
-		if vv, ok := v.GetSyncStateOk(); ok && vv != nil {
-			attrs["status"] = types.StringValue(string(*vv))
-		}
-		if vv, ok := v.GetDetailsOk(); ok && vv != nil {
-			attrs["details"] = types.StringValue(*vv)
-		}
-
-		model.SyncStatus = types.ObjectValueMust(map[string]attr.Type{
-			"last_sync_time": types.StringType,
-			"next_sync_time": types.StringType,
-			"status":         types.StringType,
-			"details":        types.StringType,
-		}, attrs)
-	} else {
-		model.SyncStatus = types.ObjectNull(map[string]attr.Type{
-			"last_sync_time": types.StringType,
-			"next_sync_time": types.StringType,
-			"status":         types.StringType,
-			"details":        types.StringType,
-		})
+	result, httpResp, err := apiClient.PropagationStoresApi.
+		ReadOnePropagationStore(ctx, environmentID, ids[0]).
+		Execute()
+	if err != nil {
+		return nil, httpResp, false, fmt.Errorf("%s", utils.HandleSDKError(err, httpResp))
 	}
-
-	config := apiObj.GetConfiguration()
-	mappers.ApplyPropagationStoreConfigurationFromMap(&model, tfType, config, plan)
-
-	return model, nil
+	return result, httpResp, true, nil
 }
 
 func buildPropagationStorePayload(plan *customtypes.PropagationStoreModel, config map[string]interface{}) *management.PropagationStore {
@@ -451,7 +723,7 @@ func buildPropagationStorePayload(plan *customtypes.PropagationStoreModel, confi
 		payload.SetDescription(plan.Description.ValueString())
 	}
 
-	if !plan.Managed.IsNull() {
+	if !plan.Managed.IsNull() && !plan.Managed.IsUnknown() {
 		payload.SetManaged(plan.Managed.ValueBool())
 	}
 
@@ -0,0 +1,483 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &propagationStoreMirrorResource{}
+	_ resource.ResourceWithConfigure  = &propagationStoreMirrorResource{}
+	_ resource.ResourceWithModifyPlan = &propagationStoreMirrorResource{}
+)
+
+// propagationStoreMirrorResource manages a propagation store created from the portable
+// configuration of a store in another environment.
+type propagationStoreMirrorResource struct {
+	client *client.Client
+}
+
+// NewPropagationStoreMirrorResource is a helper function to simplify the provider implementation.
+func NewPropagationStoreMirrorResource() resource.Resource {
+	return &propagationStoreMirrorResource{}
+}
+
+func (r *propagationStoreMirrorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_store_mirror"
+}
+
+func (r *propagationStoreMirrorResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a PingOne provisioning propagation store that mirrors the configuration of a store in another environment, such as a disaster recovery environment in another region.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique ID of the propagation store.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment to create the store in.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"portable_configuration": schema.StringAttribute{
+				Description: "The `portable_configuration` of the `pingoneprovisioning_propagation_store` data source for the store to mirror. Changing the store type recreates the store; other changes update it in place.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfPortableStoreTypeChanged(),
+				},
+				Validators: []validator.String{
+					portablePropagationStoreValidator{},
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "A name for the store. Defaults to the name in `portable_configuration`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"secrets": schema.MapAttribute{
+				Description: "Configuration values to write over `portable_configuration`, keyed by PingOne configuration key, for example `OAUTH_CLIENT_SECRET`. Every key in the configuration's `redacted_keys` must be set. Secrets PingOne does not return, and so are missing from the configuration, can be added here as well.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the store.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the store.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *propagationStoreMirrorResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *propagationStoreMirrorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_propagation_store_mirror", req, resp)
+	if req.Plan.Raw.IsNull() || deferUnknownPlan(req, resp, "environment_id") {
+		return
+	}
+
+	var plan customtypes.PropagationStoreMirrorModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.PortableConfiguration.IsUnknown() || plan.Secrets.IsUnknown() {
+		return
+	}
+
+	portable, err := parsePortablePropagationStore(plan.PortableConfiguration.ValueString())
+	if err != nil {
+		return
+	}
+	secrets, diags := mirrorSecrets(ctx, plan.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if missing := portable.missingSecrets(secrets); len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secrets"),
+			"Missing Propagation Store Secrets",
+			fmt.Sprintf("The portable configuration was exported with redacted values for %s. Set them in secrets.", strings.Join(missing, ", ")),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *propagationStoreMirrorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_propagation_store_mirror") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.PropagationStoreMirrorModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	portable, payload, diags := mirrorPayload(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	environmentID := plan.EnvironmentId.ValueString()
+	result, httpResp, err := createOrAdopt(ctx, "propagation store", payload.GetName(),
+		func(ctx context.Context) (*management.PropagationStore, *http.Response, error) {
+			return apiClient.PropagationStoresApi.
+				CreatePropagationStore(ctx, environmentID).
+				PropagationStore(*payload).
+				Execute()
+		},
+		func(ctx context.Context) (*management.PropagationStore, *http.Response, bool, error) {
+			return findPropagationStoreByName(ctx, r.client.Endpoints, apiClient, environmentID, payload.GetName(), r.client.PageSize)
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Propagation Store Mirror",
+			fmt.Sprintf("Could not create propagation store: %s", utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	applyMirrorAPIToState(result, httpResp, portable, &plan)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *propagationStoreMirrorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.PropagationStoreMirrorModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	result, httpResp, err := apiClient.PropagationStoresApi.
+		ReadOnePropagationStore(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString()).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Store Mirror",
+			fmt.Sprintf("Could not read propagation store: %s", utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	portable, err := parsePortablePropagationStore(state.PortableConfiguration.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Propagation Store Mirror",
+			fmt.Sprintf("Could not parse the portable configuration in state: %s", err),
+		)
+		return
+	}
+	secrets, diags := mirrorSecrets(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A store changed outside Terraform shows as a difference in portable_configuration. The
+	// stored JSON is only rewritten when something differs, so an unchanged store keeps the
+	// formatting of the configuration.
+	if portable.refreshFrom(result, secrets, state.Name.IsNull()) {
+		raw, err := json.Marshal(portable)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Propagation Store Mirror",
+				fmt.Sprintf("Could not encode the portable configuration: %s", err),
+			)
+			return
+		}
+		state.PortableConfiguration = types.StringValue(string(raw))
+	}
+	if !state.Name.IsNull() {
+		state.Name = types.StringValue(result.GetName())
+	}
+
+	applyMirrorAPIToState(result, httpResp, portable, &state)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *propagationStoreMirrorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_propagation_store_mirror") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan, prior customtypes.PropagationStoreMirrorModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	portable, payload, diags := mirrorPayload(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	if !prior.Status.IsNull() && prior.Status.ValueString() != "" {
+		payload.SetStatus(management.EnumPropagationStoreStatus(prior.Status.ValueString()))
+	}
+
+	result, httpResp, err := apiClient.PropagationStoresApi.
+		UpdatePropagationStore(ctx, plan.EnvironmentId.ValueString(), prior.Id.ValueString()).
+		PropagationStore(*payload).
+		Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Propagation Store Mirror",
+			fmt.Sprintf("Could not update propagation store: %s", utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	plan.Id = prior.Id
+	applyMirrorAPIToState(result, httpResp, portable, &plan)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rules display the store name, so a rename needs a new propagation revision before
+	// the PingOne UI reflects it.
+	if payload.GetName() != mirrorStoreName(&prior) {
+		if _, revErr := createPropagationRevisionUnlessDisabled(ctx, r.client, apiClient, plan.EnvironmentId.ValueString()); revErr != nil {
+			addPropagationRevisionFailure(&resp.Diagnostics, r.client, fmt.Sprintf("Store was renamed, but the provider could not create a propagation revision. The PingOne UI may not reflect the new name until a revision is created. Error: %s", revErr))
+		}
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *propagationStoreMirrorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_propagation_store_mirror") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var state customtypes.PropagationStoreMirrorModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiClient := r.client.API
+	httpResp, err := apiClient.PropagationStoresApi.
+		DeletePropagationStore(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString()).
+		Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Propagation Store Mirror",
+			fmt.Sprintf("Could not delete propagation store: %s", utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+}
+
+// mirrorPayload parses the portable configuration of model and builds the store to send to
+// PingOne.
+func mirrorPayload(ctx context.Context, model *customtypes.PropagationStoreMirrorModel) (*portablePropagationStore, *management.PropagationStore, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	portable, err := parsePortablePropagationStore(model.PortableConfiguration.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("portable_configuration"),
+			"Invalid Portable Configuration",
+			fmt.Sprintf("Could not read the portable store configuration: %s", err),
+		)
+		return nil, nil, diags
+	}
+
+	secrets, secretDiags := mirrorSecrets(ctx, model.Secrets)
+	diags.Append(secretDiags...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	name := portable.Name
+	if !model.Name.IsNull() {
+		name = model.Name.ValueString()
+	}
+
+	payload, err := portable.payload(name, secrets)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("secrets"),
+			"Missing Propagation Store Secrets",
+			err.Error(),
+		)
+		return nil, nil, diags
+	}
+
+	return portable, payload, diags
+}
+
+func mirrorSecrets(ctx context.Context, value types.Map) (map[string]string, diag.Diagnostics) {
+	secrets := make(map[string]string)
+	if value.IsNull() || value.IsUnknown() {
+		return secrets, nil
+	}
+	diags := value.ElementsAs(ctx, &secrets, false)
+	return secrets, diags
+}
+
+// mirrorStoreName returns the name the store of model is given.
+func mirrorStoreName(model *customtypes.PropagationStoreMirrorModel) string {
+	if !model.Name.IsNull() {
+		return model.Name.ValueString()
+	}
+	if portable, err := parsePortablePropagationStore(model.PortableConfiguration.ValueString()); err == nil {
+		return portable.Name
+	}
+	return ""
+}
+
+// applyMirrorAPIToState sets the computed attributes of state from the store PingOne returned.
+func applyMirrorAPIToState(store *management.PropagationStore, httpResp *http.Response, portable *portablePropagationStore, state *customtypes.PropagationStoreMirrorModel) {
+	state.Id = types.StringValue(store.GetId())
+
+	rawType, rawStatus, _ := utils.ExtractPropagationStoreTypeStatus(httpResp)
+	if rawType == "" || rawType == "UNKNOWN" {
+		rawType = string(store.GetType())
+	}
+	if rawType == "" || rawType == "UNKNOWN" {
+		rawType = portable.Type
+	}
+	state.Type = types.StringValue(utils.NormalizePropagationStoreTypeForTerraform(rawType, portable.Type))
+
+	if rawStatus == "" {
+		if v, ok := store.GetStatusOk(); ok && v != nil && string(*v) != "UNKNOWN" {
+			rawStatus = string(*v)
+		}
+	}
+	if rawStatus != "" {
+		state.Status = types.StringValue(rawStatus)
+	} else {
+		state.Status = types.StringNull()
+	}
+}
+
+// requiresReplaceIfPortableStoreTypeChanged replaces the store when the portable configuration
+// names a different store type, since PingOne cannot change the type of a store.
+func requiresReplaceIfPortableStoreTypeChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			prior, err := parsePortablePropagationStore(req.StateValue.ValueString())
+			if err != nil {
+				return
+			}
+			planned, err := parsePortablePropagationStore(req.PlanValue.ValueString())
+			if err != nil {
+				return
+			}
+			resp.RequiresReplace = utils.CanonicalPropagationStoreType(prior.Type) != utils.CanonicalPropagationStoreType(planned.Type)
+		},
+		"Changing the store type requires replacement.",
+		"Changing the store type requires replacement.",
+	)
+}
+
+// portablePropagationStoreValidator checks that a string is a portable store configuration this
+// provider can apply.
+type portablePropagationStoreValidator struct{}
+
+func (v portablePropagationStoreValidator) Description(_ context.Context) string {
+	return "Must be the portable_configuration of a propagation store."
+}
+
+func (v portablePropagationStoreValidator) MarkdownDescription(_ context.Context) string {
+	return "Must be the `portable_configuration` of a propagation store."
+}
+
+func (v portablePropagationStoreValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parsePortablePropagationStore(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Portable Configuration",
+			fmt.Sprintf("Could not read the portable store configuration: %s", err),
+		)
+	}
+}
@@ -0,0 +1,453 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+var (
+	_ resource.Resource                = &propagationStorePingOneCredentialsResource{}
+	_ resource.ResourceWithConfigure   = &propagationStorePingOneCredentialsResource{}
+	_ resource.ResourceWithImportState = &propagationStorePingOneCredentialsResource{}
+	_ resource.ResourceWithModifyPlan  = &propagationStorePingOneCredentialsResource{}
+)
+
+const (
+	// pingOneStoreCredentialsDefaultName is the name of the worker application when `name` is
+	// not set.
+	pingOneStoreCredentialsDefaultName = "PingOne Provisioning Propagation"
+
+	// pingOneStoreCredentialsRole is the role the worker application is given in its
+	// environment, which lets the PingOne store create, update and remove users and groups.
+	pingOneStoreCredentialsRole = "Identity Data Admin"
+
+	// pingOneStoreAuthenticationMethod is the PingOne store `AUTHENTICATION_METHOD` for a
+	// worker application's client credentials.
+	pingOneStoreAuthenticationMethod = "OAuth 2 Client Credentials"
+)
+
+// propagationStorePingOneCredentialsResource manages the worker application a PingOne
+// propagation store authenticates with in its target environment.
+type propagationStorePingOneCredentialsResource struct {
+	client *client.Client
+}
+
+// NewPropagationStorePingOneCredentialsResource is a helper function to simplify the provider
+// implementation.
+func NewPropagationStorePingOneCredentialsResource() resource.Resource {
+	return &propagationStorePingOneCredentialsResource{}
+}
+
+func (r *propagationStorePingOneCredentialsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_propagation_store_pingone_credentials"
+}
+
+func (r *propagationStorePingOneCredentialsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computedString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: description,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	oauthClientSecret := computedString("The worker application's client secret, for `configuration_ping_one.oauth_client_secret`.")
+	oauthClientSecret.Sensitive = true
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the worker application a `PingOne` propagation store authenticates with in the environment it provisions into, and exposes the values `configuration_ping_one` needs.",
+		Attributes: map[string]schema.Attribute{
+			"id": computedString("The ID of the worker application."),
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment the store provisions into. The worker application is created, and given the Identity Data Admin role, in this environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: fmt.Sprintf("The name of the worker application. Defaults to `%s`. Changing it creates a new application with new credentials.", pingOneStoreCredentialsDefaultName),
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(pingOneStoreCredentialsDefaultName),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role_assignment_id":    computedString("The ID of the worker application's Identity Data Admin role assignment. Null after import."),
+			"authentication_method": computedString(fmt.Sprintf("`%s`, for `configuration_ping_one.authentication_method`.", pingOneStoreAuthenticationMethod)),
+			"base_url":              computedString("The Management API base URL of the environment's region, for `configuration_ping_one.base_url`."),
+			"scim_url":              computedString("The SCIM endpoint of the environment, for `configuration_ping_one.scim_url`."),
+			"oauth_client_id":       computedString("The worker application's client ID, for `configuration_ping_one.oauth_client_id`."),
+			"oauth_client_secret":   oauthClientSecret,
+			"oauth_token_url":       computedString("The token endpoint of the environment, for `configuration_ping_one.oauth_token_url`."),
+		},
+	}
+}
+
+func (r *propagationStorePingOneCredentialsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientData, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clientData
+}
+
+func (r *propagationStorePingOneCredentialsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_propagation_store_pingone_credentials", req, resp)
+	deferUnknownPlan(req, resp, "environment_id")
+}
+
+func (r *propagationStorePingOneCredentialsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_propagation_store_pingone_credentials") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var plan customtypes.PropagationStorePingOneCredentialsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := plan.EnvironmentId.ValueString()
+	endpoints, err := pingOneStoreEndpointsFor(ctx, r.client, environmentID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating PingOne Store Credentials",
+			fmt.Sprintf("Could not resolve the PingOne endpoints for environment '%s': %s", environmentID, err),
+		)
+		return
+	}
+
+	roleID, err := pingOneRoleID(ctx, r.client.Endpoints, r.client.API, pingOneStoreCredentialsRole, r.client.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating PingOne Store Credentials",
+			fmt.Sprintf("Could not look up the %s role: %s", pingOneStoreCredentialsRole, err),
+		)
+		return
+	}
+
+	app := management.NewApplicationOIDC(
+		true,
+		plan.Name.ValueString(),
+		management.ENUMAPPLICATIONPROTOCOL_OPENID_CONNECT,
+		management.ENUMAPPLICATIONTYPE_WORKER,
+		management.ENUMAPPLICATIONOIDCTOKENAUTHMETHOD_CLIENT_SECRET_BASIC,
+	)
+	app.SetDescription("Credentials for a PingOne provisioning propagation store. Managed by Terraform.")
+	app.SetGrantTypes([]management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_CLIENT_CREDENTIALS})
+
+	created, httpResp, err := r.client.API.ApplicationsApi.
+		CreateApplication(ctx, environmentID).
+		CreateApplicationRequest(management.CreateApplicationRequest{ApplicationOIDC: app}).
+		Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating PingOne Store Credentials",
+			fmt.Sprintf("Could not create the worker application in environment '%s': %s", environmentID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+	if created == nil || created.ApplicationOIDC == nil || created.ApplicationOIDC.GetId() == "" {
+		resp.Diagnostics.AddError(
+			"Error Creating PingOne Store Credentials",
+			fmt.Sprintf("PingOne did not return the ID of the worker application created in environment '%s'.", environmentID),
+		)
+		return
+	}
+	applicationID := created.ApplicationOIDC.GetId()
+
+	// An application without its role cannot provision anything, so it is deleted again rather
+	// than left behind in the environment when a later step fails.
+	cleanUp := func() {
+		if httpResp, err := deletePingOneApplication(ctx, r.client.API, environmentID, applicationID); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Worker Application Left Behind",
+				fmt.Sprintf("Could not delete worker application '%s' after a failed create; delete it in PingOne: %s", applicationID, utils.HandleSDKError(err, httpResp)),
+			)
+		}
+	}
+
+	assignment, httpResp, err := r.client.API.ApplicationRoleAssignmentsApi.
+		CreateApplicationRoleAssignment(ctx, environmentID, applicationID).
+		RoleAssignment(*management.NewRoleAssignment(
+			*management.NewRoleAssignmentRole(roleID),
+			*management.NewRoleAssignmentScope(environmentID, management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT),
+		)).
+		Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating PingOne Store Credentials",
+			fmt.Sprintf("Could not give worker application '%s' the %s role: %s", applicationID, pingOneStoreCredentialsRole, utils.HandleSDKError(err, httpResp)),
+		)
+		cleanUp()
+		return
+	}
+
+	secret, httpResp, err := r.client.API.ApplicationSecretApi.ReadApplicationSecret(ctx, environmentID, applicationID).Execute()
+	if err != nil || secret == nil || secret.GetSecret() == "" {
+		detail := "PingOne returned no secret"
+		if err != nil {
+			detail = utils.HandleSDKError(err, httpResp)
+		}
+		resp.Diagnostics.AddError(
+			"Error Creating PingOne Store Credentials",
+			fmt.Sprintf("Could not read the secret of worker application '%s': %s", applicationID, detail),
+		)
+		cleanUp()
+		return
+	}
+
+	plan.Id = types.StringValue(applicationID)
+	plan.RoleAssignmentId = types.StringNull()
+	if assignment != nil && assignment.GetId() != "" {
+		plan.RoleAssignmentId = types.StringValue(assignment.GetId())
+	}
+	plan.OauthClientId = types.StringValue(applicationID)
+	plan.OauthClientSecret = types.StringValue(secret.GetSecret())
+	endpoints.apply(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *propagationStorePingOneCredentialsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customtypes.PropagationStorePingOneCredentialsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := state.EnvironmentId.ValueString()
+	applicationID := state.Id.ValueString()
+
+	app, httpResp, err := r.client.API.ApplicationsApi.ReadOneApplication(ctx, environmentID, applicationID).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading PingOne Store Credentials",
+			fmt.Sprintf("Could not read worker application '%s': %s", applicationID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+	if app == nil || app.ApplicationOIDC == nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PingOne Store Credentials",
+			fmt.Sprintf("Application '%s' is not an OIDC worker application.", applicationID),
+		)
+		return
+	}
+
+	// The secret is read on every refresh so that a secret rotated in PingOne reaches the store
+	// configuration on the next apply.
+	secret, httpResp, err := r.client.API.ApplicationSecretApi.ReadApplicationSecret(ctx, environmentID, applicationID).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PingOne Store Credentials",
+			fmt.Sprintf("Could not read the secret of worker application '%s': %s", applicationID, utils.HandleSDKError(err, httpResp)),
+		)
+		return
+	}
+
+	endpoints, err := pingOneStoreEndpointsFor(ctx, r.client, environmentID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PingOne Store Credentials",
+			fmt.Sprintf("Could not resolve the PingOne endpoints for environment '%s': %s", environmentID, err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(app.ApplicationOIDC.GetName())
+	state.OauthClientId = types.StringValue(applicationID)
+	if secret != nil && secret.GetSecret() != "" {
+		state.OauthClientSecret = types.StringValue(secret.GetSecret())
+	}
+	if state.RoleAssignmentId.IsUnknown() {
+		state.RoleAssignmentId = types.StringNull()
+	}
+	endpoints.apply(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *propagationStorePingOneCredentialsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_propagation_store_pingone_credentials") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	// Every argument requires replacement, so there is nothing to update in place.
+	var plan customtypes.PropagationStorePingOneCredentialsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *propagationStorePingOneCredentialsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_propagation_store_pingone_credentials") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
+	var state customtypes.PropagationStorePingOneCredentialsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the application also removes its role assignments.
+	applicationID := state.Id.ValueString()
+	httpResp, err := deletePingOneApplication(ctx, r.client.API, state.EnvironmentId.ValueString(), applicationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting PingOne Store Credentials",
+			fmt.Sprintf("Could not delete worker application '%s': %s", applicationID, utils.HandleSDKError(err, httpResp)),
+		)
+	}
+}
+
+func (r *propagationStorePingOneCredentialsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := utils.SplitImportID(req.ID, 2)
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			"Expected import identifier format: <environment_id>/<application_id>.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// pingOneStoreEndpoints are the URLs a PingOne store needs to reach its target environment.
+type pingOneStoreEndpoints struct {
+	BaseURL  string
+	ScimURL  string
+	TokenURL string
+}
+
+func (e pingOneStoreEndpoints) apply(m *customtypes.PropagationStorePingOneCredentialsModel) {
+	m.AuthenticationMethod = types.StringValue(pingOneStoreAuthenticationMethod)
+	m.BaseUrl = types.StringValue(e.BaseURL)
+	m.ScimUrl = types.StringValue(e.ScimURL)
+	m.OauthTokenUrl = types.StringValue(e.TokenURL)
+}
+
+// pingOneStoreEndpointsFor returns the endpoints of environmentID, which is assumed to be in the
+// region the provider is configured for.
+func pingOneStoreEndpointsFor(ctx context.Context, c *client.Client, environmentID string) (pingOneStoreEndpoints, error) {
+	if c == nil {
+		return pingOneStoreEndpoints{}, fmt.Errorf("nil client")
+	}
+
+	basePath, _, err := pingOneBasePath(ctx, endpointsOf(c), c.API, endpointFamilyUsers)
+	if err != nil {
+		return pingOneStoreEndpoints{}, err
+	}
+	return pingOneStoreEndpointsFromURLs(basePath, c.TokenURL, environmentID)
+}
+
+// pingOneStoreEndpointsFromURLs derives the endpoints of environmentID from the Management API
+// base path and the token URL of the provider's own environment. The SCIM host is the API host
+// with a `scim-` prefix, for example `scim-api.pingone.eu` for `api.pingone.eu`.
+func pingOneStoreEndpointsFromURLs(apiBasePath string, providerTokenURL string, environmentID string) (pingOneStoreEndpoints, error) {
+	api, err := url.Parse(strings.TrimRight(apiBasePath, "/"))
+	if err != nil || api.Host == "" {
+		return pingOneStoreEndpoints{}, fmt.Errorf("invalid Management API base URL %q", apiBasePath)
+	}
+	token, err := url.Parse(providerTokenURL)
+	if err != nil || token.Host == "" {
+		return pingOneStoreEndpoints{}, fmt.Errorf("invalid OAuth token URL %q", providerTokenURL)
+	}
+
+	scimHost := api.Host
+	if strings.HasPrefix(scimHost, "api.") {
+		scimHost = "scim-" + scimHost
+	}
+	scim := url.URL{Scheme: api.Scheme, Host: scimHost, Path: "/" + url.PathEscape(environmentID) + "/v1"}
+	targetToken := url.URL{Scheme: token.Scheme, Host: token.Host, Path: "/" + url.PathEscape(environmentID) + "/as/token"}
+
+	return pingOneStoreEndpoints{
+		BaseURL:  api.String(),
+		ScimURL:  scim.String(),
+		TokenURL: targetToken.String(),
+	}, nil
+}
+
+// pingOneRoleID returns the ID of the built-in role called name.
+func pingOneRoleID(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, name string, pageSize int32) (string, error) {
+	roles, err := listPingOneCollection(ctx, endpoints, apiClient, endpointFamilyRoles, "/roles", pageSize, "roles")
+	if err != nil {
+		return "", err
+	}
+	for _, role := range roles {
+		if roleName, _ := utils.NestedString(role, "name"); strings.EqualFold(roleName, name) {
+			if id, _ := utils.NestedString(role, "id"); id != "" {
+				return id, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no role named %q", name)
+}
+
+// deletePingOneApplication deletes an application. An application that no longer exists is not
+// an error.
+func deletePingOneApplication(ctx context.Context, apiClient *management.APIClient, environmentID string, applicationID string) (*http.Response, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("nil api client")
+	}
+
+	httpResp, err := apiClient.ApplicationsApi.DeleteApplication(ctx, environmentID, applicationID).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return httpResp, nil
+		}
+		return httpResp, err
+	}
+
+	return httpResp, nil
+}
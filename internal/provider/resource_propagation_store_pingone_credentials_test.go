@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPingOneStoreEndpointsFromURLs(t *testing.T) {
+	t.Parallel()
+
+	got, err := pingOneStoreEndpointsFromURLs("https://api.pingone.eu/v1/", "https://auth.pingone.eu/provider-env/as/token", "target-env")
+	if err != nil {
+		t.Fatalf("pingOneStoreEndpointsFromURLs error: %v", err)
+	}
+
+	want := pingOneStoreEndpoints{
+		BaseURL:  "https://api.pingone.eu/v1",
+		ScimURL:  "https://scim-api.pingone.eu/target-env/v1",
+		TokenURL: "https://auth.pingone.eu/target-env/as/token",
+	}
+	if got != want {
+		t.Fatalf("endpoints = %+v, want %+v", got, want)
+	}
+
+	if _, err := pingOneStoreEndpointsFromURLs("https://api.pingone.eu/v1", "", "target-env"); err == nil {
+		t.Fatal("expected an error for a missing token URL")
+	}
+}
+
+func TestPingOneRoleID(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{"_embedded":{"roles":[{"id":"role-env","name":"Environment Admin"},{"id":"role-data","name":"Identity Data Admin"}]}}`
+		if r.URL.Path != "/v1/roles" {
+			status, body = http.StatusNotFound, `{"code":"NOT_FOUND"}`
+		}
+
+		return testResponse(r, status, body), nil
+	})
+
+	id, err := pingOneRoleID(context.Background(), nil, apiClient, "identity data admin", 0)
+	if err != nil || id != "role-data" {
+		t.Fatalf("role ID = %q, err = %v, want role-data", id, err)
+	}
+
+	if _, err := pingOneRoleID(context.Background(), nil, apiClient, "Client Application Developer", 0); err == nil {
+		t.Fatal("expected an error for an unknown role")
+	}
+}
@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUpgradeScimConfigurationAlias(t *testing.T) {
+	t.Parallel()
+
+	scim := &customtypes.ConfigurationScim{ScimUrl: types.StringValue("https://scim.example/v2")}
+	state := customtypes.PropagationStoreModel{ScimConfiguration: scim}
+
+	upgradeScimConfigurationAlias(&state)
+
+	if state.ScimConfiguration != nil {
+		t.Fatal("scim_configuration should be cleared")
+	}
+	if state.ConfigurationScim != scim {
+		t.Fatal("configuration_scim should hold the migrated block")
+	}
+}
+
+func TestPropagationStoreResourceUpgradeState_PriorSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	r := &propagationStoreResource{}
+	upgraders := r.UpgradeState(context.Background())
+
+	for _, version := range []int64{0, 1, 2, 3} {
+		upgrader, ok := upgraders[version]
+		if !ok || upgrader.PriorSchema == nil {
+			t.Fatalf("expected a version %d upgrader with a prior schema", version)
+		}
+		if upgrader.PriorSchema.Version != version {
+			t.Fatalf("prior schema version = %d, want %d", upgrader.PriorSchema.Version, version)
+		}
+		syncStatus := upgrader.PriorSchema.Attributes["sync_status"].(schema.ObjectAttribute)
+		if _, ok := syncStatus.AttributeTypes["next_sync_time"]; !ok {
+			t.Fatalf("version %d sync_status should have next_sync_time", version)
+		}
+	}
+}
+
+func TestPropagationStoreResourceUpgradeState_Version0JSON(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &propagationStoreResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	// Version 0 state: the scim_configuration block, sync_status.next_sync_time and none of the
+	// attributes added since.
+	raw, err := tftypes.ValueFromJSON([]byte(`{
+		"id": "store-123",
+		"environment_id": "env-123",
+		"name": "SCIM",
+		"type": "scim",
+		"managed": false,
+		"scim_configuration": {
+			"authentication_method": "OAuth 2 Bearer Token",
+			"authorization_type": "Bearer",
+			"scim_url": "https://scim.example/v2",
+			"scim_version": "2.0",
+			"unique_user_identifier": "userName",
+			"user_filter": "userName eq \"%s\"",
+			"users_resource": "/Users"
+		},
+		"sync_status": {
+			"last_sync_time": "2024-01-01T00:00:00Z",
+			"next_sync_time": null,
+			"status": "SUCCESS",
+			"details": null
+		}
+	}`), upgrader.PriorSchema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("decoding version 0 state with the prior schema: %v", err)
+	}
+
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: current.Schema,
+			Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade: %v", resp.Diagnostics)
+	}
+
+	var got customtypes.PropagationStoreResourceModel
+	if diags := resp.State.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if got.ScimConfiguration != nil || got.ConfigurationScim == nil || got.ConfigurationScim.ScimUrl.ValueString() != "https://scim.example/v2" {
+		t.Fatalf("scim_configuration was not migrated to configuration_scim: %+v, %+v", got.ScimConfiguration, got.ConfigurationScim)
+	}
+	if got.Type.ValueString() != "SCIM" {
+		t.Fatalf("type = %s, want SCIM", got.Type)
+	}
+	if !got.SyncStatus.IsNull() {
+		t.Fatalf("sync_status = %s, want null", got.SyncStatus)
+	}
+}
+
+func TestPropagationStoreResourceUpgradeState_ManagedFalse(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &propagationStoreResource{}
+	upgraders := r.UpgradeState(ctx)
+
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+
+	tests := []struct {
+		version int64
+		want    types.Bool
+	}{
+		{version: 0, want: types.BoolNull()},
+		{version: 2, want: types.BoolNull()},
+		{version: 3, want: types.BoolValue(false)},
+	}
+
+	for _, tt := range tests {
+		upgrader := upgraders[tt.version]
+		priorState := tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+		}
+		if diags := priorState.SetAttribute(ctx, path.Root("managed"), types.BoolValue(false)); diags.HasError() {
+			t.Fatalf("version %d: SetAttribute: %v", tt.version, diags)
+		}
+
+		resp := &resource.UpgradeStateResponse{
+			State: tfsdk.State{
+				Schema: current.Schema,
+				Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &priorState}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("version %d: upgrade: %v", tt.version, resp.Diagnostics)
+		}
+
+		var got types.Bool
+		if diags := resp.State.GetAttribute(ctx, path.Root("managed"), &got); diags.HasError() {
+			t.Fatalf("version %d: GetAttribute: %v", tt.version, diags)
+		}
+		if !got.Equal(tt.want) {
+			t.Fatalf("version %d: managed = %s, want %s", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestPropagationStoreSyncStatus_LastError(t *testing.T) {
+	t.Parallel()
+
+	if got := propagationStoreSyncStatus(map[string]interface{}{}); !got.IsNull() {
+		t.Fatalf("sync status without one = %v, want null", got)
+	}
+
+	store := map[string]interface{}{
+		"syncStatus": map[string]interface{}{
+			"syncState": "FAILED",
+			"details":   "401 Unauthorized from target",
+		},
+	}
+	attrs := propagationStoreSyncStatus(store).Attributes()
+	if got := attrs["last_error"].(types.String).ValueString(); got != "401 Unauthorized from target" {
+		t.Fatalf("last_error = %q", got)
+	}
+	if got := attrs["status"].(types.String).ValueString(); got != "FAILED" {
+		t.Fatalf("status = %q", got)
+	}
+
+	store["syncStatus"].(map[string]interface{})["syncState"] = "SYNCING"
+	if got := propagationStoreSyncStatus(store).Attributes()["last_error"]; !got.IsNull() {
+		t.Fatalf("last_error while syncing = %v, want null", got)
+	}
+}
+
+func TestPropagationStoreAPIToModel_UnknownEnumValues(t *testing.T) {
+	t.Parallel()
+
+	// A connector, status and sync state newer than the SDK, which would decode them as
+	// UNKNOWN.
+	body := `{"id":"store-id","name":"New Connector","type":"NewConnector","status":"SUSPENDED",` +
+		`"syncStatus":{"syncState":"THROTTLED","lastSyncAt":"2026-10-01T12:00:00.123Z"},"configuration":{"BASE_URL":"https://new.example"}}`
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	prior := customtypes.PropagationStoreModel{Type: types.StringValue("NewConnector"), Status: types.StringValue("ACTIVE")}
+	model, err := (&propagationStoreResource{}).apiToModel(httpResp, "env-id", &prior)
+	if err != nil {
+		t.Fatalf("apiToModel: %v", err)
+	}
+
+	if model.Type.ValueString() != "NewConnector" || model.Status.ValueString() != "SUSPENDED" {
+		t.Fatalf("type = %s, status = %s; want NewConnector, SUSPENDED", model.Type, model.Status)
+	}
+	attrs := model.SyncStatus.Attributes()
+	if got := attrs["status"].(types.String).ValueString(); got != "THROTTLED" {
+		t.Fatalf("sync status = %q, want THROTTLED", got)
+	}
+	if got := attrs["last_sync_time"].(types.String).ValueString(); got != "2026-10-01T12:00:00Z" {
+		t.Fatalf("last_sync_time = %q", got)
+	}
+}
+
+func TestUpgradeStoreTypeAlias(t *testing.T) {
+	t.Parallel()
+
+	state := customtypes.PropagationStoreModel{Type: types.StringValue("scim")}
+	upgradeStoreTypeAlias(&state)
+	if state.Type.ValueString() != "SCIM" {
+		t.Fatalf("type = %q, want SCIM", state.Type.ValueString())
+	}
+
+	state.Type = types.StringNull()
+	upgradeStoreTypeAlias(&state)
+	if !state.Type.IsNull() {
+		t.Fatalf("type = %s, want null", state.Type)
+	}
+}
+
+func TestUpgradeManagedDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value types.Bool
+		want  types.Bool
+	}{
+		{name: "false_from_default", value: types.BoolValue(false), want: types.BoolNull()},
+		{name: "true", value: types.BoolValue(true), want: types.BoolValue(true)},
+		{name: "null", value: types.BoolNull(), want: types.BoolNull()},
+	}
+
+	for _, tt := range tests {
+		state := customtypes.PropagationStoreModel{Managed: tt.value}
+		upgradeManagedDefault(&state)
+		if !state.Managed.Equal(tt.want) {
+			t.Fatalf("%s: managed = %s, want %s", tt.name, state.Managed, tt.want)
+		}
+	}
+}
+
+func TestBuildPropagationStorePayload_ManagedUnset(t *testing.T) {
+	t.Parallel()
+
+	plan := customtypes.PropagationStoreModel{
+		Name:    types.StringValue("Example"),
+		Type:    types.StringValue("SCIM"),
+		Managed: types.BoolUnknown(),
+	}
+	if payload := buildPropagationStorePayload(&plan, map[string]interface{}{}); payload.HasManaged() {
+		t.Fatalf("managed = %v, want it omitted so PingOne applies its default", payload.GetManaged())
+	}
+
+	plan.Managed = types.BoolValue(false)
+	if payload := buildPropagationStorePayload(&plan, map[string]interface{}{}); !payload.HasManaged() || payload.GetManaged() {
+		t.Fatal("managed = false should be sent")
+	}
+}
+
+func TestRequiresReplaceIfStoreTypeChanged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		state, plan string
+		want        bool
+	}{
+		{state: "SCIM", plan: "scim", want: false},
+		{state: "GithubEMU", plan: "GitHubEMU", want: false},
+		{state: "SCIM", plan: "SCIM", want: false},
+		{state: "SCIM", plan: "Slack", want: true},
+	}
+
+	for _, tt := range tests {
+		req := planmodifier.StringRequest{
+			StateValue: types.StringValue(tt.state),
+			PlanValue:  types.StringValue(tt.plan),
+		}
+		req.State.Raw = tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+		req.Plan.Raw = tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		requiresReplaceIfStoreTypeChanged().PlanModifyString(context.Background(), req, resp)
+		if resp.RequiresReplace != tt.want {
+			t.Fatalf("%s -> %s: RequiresReplace = %v, want %v", tt.state, tt.plan, resp.RequiresReplace, tt.want)
+		}
+	}
+}
+
+func TestPropagationStoreResourceSchema_NameUpdatesInPlace(t *testing.T) {
+	t.Parallel()
+
+	r := &propagationStoreResource{}
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	name, ok := resp.Schema.Attributes["name"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected name to be a string attribute")
+	}
+	if len(name.PlanModifiers) != 0 {
+		t.Fatalf("name plan modifiers = %d, want 0 so renames update in place", len(name.PlanModifiers))
+	}
+}
+
+func TestPropagationStoreResourceDisable_SetsStatusInactive(t *testing.T) {
+	t.Parallel()
+
+	var sent map[string]interface{}
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		if r.Method == http.MethodPut && r.URL.Path == "/v1/environments/env-id/propagation/stores/store-id" {
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+		} else {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			status = http.StatusNotFound
+		}
+
+		return testResponse(r, status, `{"id":"store-id","name":"SCIM","type":"scim","status":"INACTIVE"}`), nil
+	})
+	r := &propagationStoreResource{client: &client.Client{API: apiClient}}
+
+	state := customtypes.PropagationStoreModel{
+		Id:            types.StringValue("store-id"),
+		EnvironmentId: types.StringValue("env-id"),
+		Name:          types.StringValue("SCIM"),
+		Type:          types.StringValue("SCIM"),
+		Status:        types.StringValue("ACTIVE"),
+		ConfigurationScim: &customtypes.ConfigurationScim{
+			ScimUrl: types.StringValue("https://scim.example"),
+		},
+	}
+	var diags diag.Diagnostics
+	r.disable(context.Background(), &state, types.ObjectNull(nil), &diags)
+
+	if diags.HasError() {
+		t.Fatalf("disable errors: %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("warnings = %d, want 1", diags.WarningsCount())
+	}
+	if sent["status"] != "INACTIVE" || sent["name"] != "SCIM" {
+		t.Fatalf("payload = %v", sent)
+	}
+}
+
+func TestDisableInsteadOfDeleteOrDefault(t *testing.T) {
+	t.Parallel()
+
+	if got := disableInsteadOfDeleteOrDefault(types.BoolNull()); !got.Equal(types.BoolValue(false)) {
+		t.Fatalf("null = %v, want false", got)
+	}
+	if got := disableInsteadOfDeleteOrDefault(types.BoolValue(true)); !got.Equal(types.BoolValue(true)) {
+		t.Fatalf("true = %v, want true", got)
+	}
+}
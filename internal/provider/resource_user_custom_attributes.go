@@ -8,12 +8,15 @@ import (
 	"math"
 	"math/big"
 	"net/http"
-	"net/url"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
 	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -21,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
@@ -30,6 +34,7 @@ var (
 	_ resource.Resource                = &userCustomAttributesResource{}
 	_ resource.ResourceWithConfigure   = &userCustomAttributesResource{}
 	_ resource.ResourceWithImportState = &userCustomAttributesResource{}
+	_ resource.ResourceWithModifyPlan  = &userCustomAttributesResource{}
 )
 
 type userCustomAttributesResource struct {
@@ -70,8 +75,26 @@ func (r *userCustomAttributesResource) Schema(_ context.Context, _ resource.Sche
 				},
 			},
 			"attributes": schema.DynamicAttribute{
-				Description: "Map of custom user attribute values keyed by schema attribute name.",
+				Description: "Map of custom user attribute values keyed by schema attribute name. Read-only user properties such as `id` and `createdAt` are rejected, and core profile attributes such as `email` and `username` produce a warning.",
 				Required:    true,
+				Validators: []validator.Dynamic{
+					reservedUserAttributeNamesValidator{},
+				},
+			},
+			"user_updated_at": schema.StringAttribute{
+				Description: "The user's `updatedAt` timestamp returned by the last write of the custom attributes. Null after import until the next write.",
+				Computed:    true,
+			},
+			"merge_strategies": schema.MapAttribute{
+				Description: "How each multi-valued attribute in `attributes` is written, keyed by attribute name. " +
+					"`replace` (the default) sets the attribute to the listed values. " +
+					"`append` adds the listed values that the user does not already have and keeps the user's other values. " +
+					"`remove_listed` removes the listed values and keeps the user's other values.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(customAttributeMergeReplace, customAttributeMergeAppend, customAttributeMergeRemoveListed)),
+				},
 			},
 		},
 	}
@@ -94,7 +117,17 @@ func (r *userCustomAttributesResource) Configure(_ context.Context, req resource
 	r.client = clientData
 }
 
+func (r *userCustomAttributesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkReadOnlyPlan(ctx, readOnlyModeOf(r.client), "pingoneprovisioning_user_custom_attributes", req, resp)
+	deferUnknownPlan(req, resp, "environment_id")
+}
+
 func (r *userCustomAttributesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "create", "pingoneprovisioning_user_custom_attributes") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
 	var plan customtypes.UserCustomAttributesModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -103,13 +136,13 @@ func (r *userCustomAttributesResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	payload, diags := expandCustomAttributes(ctx, plan.Attributes)
+	payload, diags := r.customAttributesPayload(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpResp, err := patchUserCustomAttributes(ctx, r.client.API, plan.EnvironmentId.ValueString(), plan.UserId.ValueString(), payload)
+	httpResp, err := patchUserCustomAttributes(ctx, r.client.Endpoints, r.client.API, plan.EnvironmentId.ValueString(), plan.UserId.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating User Custom Attributes",
@@ -119,6 +152,7 @@ func (r *userCustomAttributesResource) Create(ctx context.Context, req resource.
 	}
 
 	plan.Id = types.StringValue(buildUserCustomAttributesID(plan.EnvironmentId.ValueString(), plan.UserId.ValueString()))
+	plan.UserUpdatedAt = userUpdatedAtFromResponse(httpResp)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -132,7 +166,7 @@ func (r *userCustomAttributesResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	userMap, httpResp, err := readUserCustomAttributes(ctx, r.client.API, state.EnvironmentId.ValueString(), state.UserId.ValueString())
+	userMap, httpResp, err := readUserCustomAttributes(ctx, r.client.Endpoints, r.client.API, state.EnvironmentId.ValueString(), state.UserId.ValueString())
 	if err != nil {
 		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
 			resp.State.RemoveResource(ctx)
@@ -146,7 +180,13 @@ func (r *userCustomAttributesResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	updated, mapDiags := mergeCustomAttributesFromAPI(ctx, state.Attributes, userMap)
+	strategies, diags := customAttributeMergeStrategies(ctx, state.MergeStrategies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, mapDiags := mergeCustomAttributesFromAPI(ctx, state.Attributes, userMap, strategies)
 	resp.Diagnostics.Append(mapDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -158,6 +198,11 @@ func (r *userCustomAttributesResource) Read(ctx context.Context, req resource.Re
 }
 
 func (r *userCustomAttributesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "update", "pingoneprovisioning_user_custom_attributes") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
 	var plan customtypes.UserCustomAttributesModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -166,13 +211,13 @@ func (r *userCustomAttributesResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	payload, diags := expandCustomAttributes(ctx, plan.Attributes)
+	payload, diags := r.customAttributesPayload(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpResp, err := patchUserCustomAttributes(ctx, r.client.API, plan.EnvironmentId.ValueString(), plan.UserId.ValueString(), payload)
+	httpResp, err := patchUserCustomAttributes(ctx, r.client.Endpoints, r.client.API, plan.EnvironmentId.ValueString(), plan.UserId.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating User Custom Attributes",
@@ -182,11 +227,17 @@ func (r *userCustomAttributesResource) Update(ctx context.Context, req resource.
 	}
 
 	plan.Id = types.StringValue(buildUserCustomAttributesID(plan.EnvironmentId.ValueString(), plan.UserId.ValueString()))
+	plan.UserUpdatedAt = userUpdatedAtFromResponse(httpResp)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *userCustomAttributesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkReadOnlyApply(&resp.Diagnostics, readOnlyModeOf(r.client), "delete", "pingoneprovisioning_user_custom_attributes") {
+		return
+	}
+	defer reportAPIMetrics(ctx, metricsOf(r.client), &resp.Diagnostics)
+
 	// Intentionally leave custom attributes in place; removing this resource only clears Terraform state.
 }
 
@@ -204,37 +255,202 @@ func (r *userCustomAttributesResource) ImportState(ctx context.Context, req reso
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), parts[1])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), buildUserCustomAttributesID(parts[0], parts[1]))...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attributes"), types.DynamicUnknown())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_updated_at"), types.StringNull())...)
+}
+
+// reservedUserAttributeNamesValidator flags names in `attributes` that are not custom
+// attributes: read-only user properties are errors, and core profile attributes are warnings,
+// since PingOne accepts them but they belong to whatever manages the user itself.
+type reservedUserAttributeNamesValidator struct{}
+
+func (v reservedUserAttributeNamesValidator) Description(_ context.Context) string {
+	return "Rejects read-only user properties and warns on core profile attributes."
+}
+
+func (v reservedUserAttributeNamesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v reservedUserAttributeNamesValidator) ValidateDynamic(ctx context.Context, req validator.DynamicRequest, resp *validator.DynamicResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.IsUnderlyingValueNull() || req.ConfigValue.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	var names []string
+	switch v := req.ConfigValue.UnderlyingValue().(type) {
+	case types.Map:
+		for name := range v.Elements() {
+			names = append(names, name)
+		}
+	case types.Object:
+		for name := range v.Attributes() {
+			names = append(names, name)
+		}
+	default:
+		return
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if slices.ContainsFunc(userSourceAttributesOutsideSchema, func(s string) bool { return strings.EqualFold(s, name) }) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Read-Only User Property",
+				fmt.Sprintf("`%s` is a user property that PingOne sets itself and cannot be written as a custom attribute. Remove it from attributes.", name),
+			)
+			continue
+		}
+		if slices.ContainsFunc(userProfileAttributes, func(s string) bool { return strings.EqualFold(s, name) }) {
+			resp.Diagnostics.AddAttributeWarning(
+				req.Path.AtMapKey(name),
+				"Core User Attribute",
+				fmt.Sprintf("`%s` is a core user profile attribute, not a custom attribute. Manage it with the resource or process that manages the user, such as the PingOne provider's `pingone_user` resource; writing it here would fight over the value.", name),
+			)
+		}
+	}
 }
 
 func buildUserCustomAttributesID(environmentID string, userID string) string {
 	return fmt.Sprintf("%s/%s", strings.TrimSpace(environmentID), strings.TrimSpace(userID))
 }
 
-func patchUserCustomAttributes(ctx context.Context, apiClient *management.APIClient, environmentID string, userID string, payload map[string]interface{}) (*http.Response, error) {
-	if apiClient == nil {
-		return nil, fmt.Errorf("nil api client")
+const (
+	customAttributeMergeReplace      = "replace"
+	customAttributeMergeAppend       = "append"
+	customAttributeMergeRemoveListed = "remove_listed"
+)
+
+// customAttributesPayload builds the PATCH body for plan. Attributes with an `append` or
+// `remove_listed` merge strategy are combined with the user's current values, which are read
+// first.
+func (r *userCustomAttributesResource) customAttributesPayload(ctx context.Context, plan *customtypes.UserCustomAttributesModel) (map[string]interface{}, diag.Diagnostics) {
+	payload, diags := expandCustomAttributes(ctx, plan.Attributes)
+	if diags.HasError() {
+		return nil, diags
 	}
 
-	cfg := apiClient.GetConfig()
-	if cfg == nil {
-		return nil, fmt.Errorf("api client has nil config")
+	strategies, strategyDiags := customAttributeMergeStrategies(ctx, plan.MergeStrategies)
+	diags.Append(strategyDiags...)
+	if diags.HasError() {
+		return nil, diags
 	}
-	if cfg.HTTPClient == nil {
-		return nil, fmt.Errorf("api client has nil http client")
+
+	var userMap map[string]interface{}
+	for name, strategy := range strategies {
+		if strategy == customAttributeMergeReplace {
+			continue
+		}
+
+		configured, ok := payload[name]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("merge_strategies").AtMapKey(name),
+				"Invalid Merge Strategy",
+				fmt.Sprintf("A merge strategy is set for %q, which is not in attributes.", name),
+			)
+			continue
+		}
+		listed, ok := configured.([]interface{})
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("merge_strategies").AtMapKey(name),
+				"Invalid Merge Strategy",
+				fmt.Sprintf("The %q merge strategy only applies to multi-valued attributes, but %q is not a list.", strategy, name),
+			)
+			continue
+		}
+
+		if userMap == nil {
+			current, httpResp, err := readUserCustomAttributes(ctx, r.client.Endpoints, r.client.API, plan.EnvironmentId.ValueString(), plan.UserId.ValueString())
+			if err != nil {
+				diags.AddError(
+					"Error Reading User",
+					fmt.Sprintf("Could not read the user's current custom attribute values: %s", utils.HandleSDKError(err, httpResp)),
+				)
+				return nil, diags
+			}
+			userMap = current
+		}
+
+		payload[name] = mergeCustomAttributeValues(strategy, userMap[name], listed)
 	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return payload, diags
+}
+
+// customAttributeMergeStrategies returns the merge strategy for each attribute that has one.
+func customAttributeMergeStrategies(ctx context.Context, value types.Map) (map[string]string, diag.Diagnostics) {
+	strategies := map[string]string{}
+	if value.IsNull() || value.IsUnknown() {
+		return strategies, nil
+	}
+	diags := value.ElementsAs(ctx, &strategies, false)
+	return strategies, diags
+}
 
-	basePath, err := cfg.ServerURLWithContext(ctx, "UsersApiService.UpdateUserPatch")
+// mergeCustomAttributeValues combines the listed values of a multi-valued attribute with the
+// user's current value for it.
+func mergeCustomAttributeValues(strategy string, current interface{}, listed []interface{}) []interface{} {
+	existing := customAttributeValueList(current)
+
+	merged := make([]interface{}, 0, len(existing)+len(listed))
+	switch strategy {
+	case customAttributeMergeAppend:
+		merged = append(merged, existing...)
+		for _, v := range listed {
+			if !customAttributeValuesContain(merged, v) {
+				merged = append(merged, v)
+			}
+		}
+	case customAttributeMergeRemoveListed:
+		for _, v := range existing {
+			if !customAttributeValuesContain(listed, v) {
+				merged = append(merged, v)
+			}
+		}
+	default:
+		merged = append(merged, listed...)
+	}
+	return merged
+}
+
+// customAttributeValueList returns an API attribute value as a list; a single value is a list
+// of one.
+func customAttributeValueList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	default:
+		return []interface{}{v}
+	}
+}
+
+// customAttributeValuesContain reports whether values holds v. Values are compared by their
+// JSON encoding, so a configured 1 matches the 1.0 decoded from an API response.
+func customAttributeValuesContain(values []interface{}, v interface{}) bool {
+	want, err := json.Marshal(v)
 	if err != nil {
-		return nil, err
+		return false
+	}
+	for _, candidate := range values {
+		got, err := json.Marshal(candidate)
+		if err == nil && bytes.Equal(got, want) {
+			return true
+		}
 	}
-	basePath = strings.TrimRight(strings.TrimSpace(basePath), "/")
+	return false
+}
 
-	endpoint := fmt.Sprintf(
-		"%s/environments/%s/users/%s",
-		basePath,
-		url.PathEscape(environmentID),
-		url.PathEscape(userID),
-	)
+func patchUserCustomAttributes(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, userID string, payload map[string]interface{}) (*http.Response, error) {
+	endpoint, httpClient, err := pingOneEndpoint(ctx, endpoints, apiClient, endpointFamilyUsers, "environments", environmentID, "users", userID)
+	if err != nil {
+		return nil, err
+	}
 
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -248,7 +464,7 @@ func patchUserCustomAttributes(ctx context.Context, apiClient *management.APICli
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := cfg.HTTPClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return resp, err
 	}
@@ -260,31 +476,28 @@ func patchUserCustomAttributes(ctx context.Context, apiClient *management.APICli
 	return resp, nil
 }
 
-func readUserCustomAttributes(ctx context.Context, apiClient *management.APIClient, environmentID string, userID string) (map[string]interface{}, *http.Response, error) {
-	if apiClient == nil {
-		return nil, nil, fmt.Errorf("nil api client")
+// userUpdatedAtFromResponse returns the `updatedAt` of the user in a PATCH response, or null
+// when the response does not include it.
+func userUpdatedAtFromResponse(resp *http.Response) types.String {
+	decoded, err := utils.DecodeResponseJSON(resp)
+	if err != nil {
+		return types.StringNull()
 	}
-
-	cfg := apiClient.GetConfig()
-	if cfg == nil {
-		return nil, nil, fmt.Errorf("api client has nil config")
+	userMap, ok := decoded.(map[string]interface{})
+	if !ok {
+		return types.StringNull()
 	}
-	if cfg.HTTPClient == nil {
-		return nil, nil, fmt.Errorf("api client has nil http client")
+	if updatedAt, ok := utils.NestedString(userMap, "updatedAt"); ok && updatedAt != "" {
+		return types.StringValue(updatedAt)
 	}
+	return types.StringNull()
+}
 
-	basePath, err := cfg.ServerURLWithContext(ctx, "UsersApiService.ReadUser")
+func readUserCustomAttributes(ctx context.Context, endpoints *client.Endpoints, apiClient *management.APIClient, environmentID string, userID string) (map[string]interface{}, *http.Response, error) {
+	endpoint, httpClient, err := pingOneEndpoint(ctx, endpoints, apiClient, endpointFamilyUsers, "environments", environmentID, "users", userID)
 	if err != nil {
 		return nil, nil, err
 	}
-	basePath = strings.TrimRight(strings.TrimSpace(basePath), "/")
-
-	endpoint := fmt.Sprintf(
-		"%s/environments/%s/users/%s",
-		basePath,
-		url.PathEscape(environmentID),
-		url.PathEscape(userID),
-	)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -292,7 +505,7 @@ func readUserCustomAttributes(ctx context.Context, apiClient *management.APIClie
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := cfg.HTTPClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -301,20 +514,19 @@ func readUserCustomAttributes(ctx context.Context, apiClient *management.APIClie
 		return nil, resp, fmt.Errorf("%s", utils.HandleSDKError(fmt.Errorf("%s", resp.Status), resp))
 	}
 
-	decoded, err := utils.DecodeResponseJSON(resp)
+	userMap, err := utils.DecodeResponseJSONObject(resp, "user")
 	if err != nil {
 		return nil, resp, err
 	}
 
-	userMap, ok := decoded.(map[string]interface{})
-	if !ok {
-		return nil, resp, fmt.Errorf("unexpected user response shape")
-	}
-
 	return userMap, resp, nil
 }
 
-func mergeCustomAttributesFromAPI(ctx context.Context, current types.Dynamic, userMap map[string]interface{}) (types.Dynamic, diag.Diagnostics) {
+// mergeCustomAttributesFromAPI refreshes the configured attributes from the user. An attribute
+// with an `append` strategy keeps the listed values the user still has, and one with a
+// `remove_listed` strategy keeps the listed values the user no longer has, so either shows a
+// difference when another system has changed the user's values.
+func mergeCustomAttributesFromAPI(ctx context.Context, current types.Dynamic, userMap map[string]interface{}, strategies map[string]string) (types.Dynamic, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if current.IsNull() || current.IsUnknown() || current.IsUnderlyingValueUnknown() {
@@ -351,6 +563,23 @@ func mergeCustomAttributesFromAPI(ctx context.Context, current types.Dynamic, us
 	merged := make(map[string]attr.Value, len(elements))
 	for key := range elements {
 		raw, ok := userMap[key]
+
+		if strategy := strategies[key]; strategy == customAttributeMergeAppend || strategy == customAttributeMergeRemoveListed {
+			existing := customAttributeValueList(raw)
+			filtered, filterDiags := filterCustomAttributeElements(ctx, elements[key], func(v interface{}) bool {
+				return customAttributeValuesContain(existing, v) == (strategy == customAttributeMergeAppend)
+			})
+			diags.Append(filterDiags...)
+			if diags.HasError() {
+				return types.DynamicUnknown(), diags
+			}
+			merged[key] = filtered
+			if attrTypes != nil {
+				attrTypes[key] = filtered.Type(ctx)
+			}
+			continue
+		}
+
 		if !ok {
 			if attrTypes != nil {
 				merged[key] = nullValueForType(attrTypes[key])
@@ -394,6 +623,62 @@ func mergeCustomAttributesFromAPI(ctx context.Context, current types.Dynamic, us
 	return types.DynamicValue(mapVal), diags
 }
 
+// filterCustomAttributeElements returns the list, set or tuple value with only the elements
+// keep accepts. Other values are returned unchanged.
+func filterCustomAttributeElements(ctx context.Context, value attr.Value, keep func(interface{}) bool) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value == nil || value.IsNull() || value.IsUnknown() {
+		return value, diags
+	}
+
+	var elements []attr.Value
+	switch v := value.(type) {
+	case types.Dynamic:
+		if v.IsUnderlyingValueNull() || v.IsUnderlyingValueUnknown() {
+			return value, diags
+		}
+		filtered, filterDiags := filterCustomAttributeElements(ctx, v.UnderlyingValue(), keep)
+		diags.Append(filterDiags...)
+		return types.DynamicValue(filtered), diags
+	case types.List:
+		elements = v.Elements()
+	case types.Set:
+		elements = v.Elements()
+	case types.Tuple:
+		elements = v.Elements()
+	default:
+		return value, diags
+	}
+
+	kept := make([]attr.Value, 0, len(elements))
+	keptTypes := make([]attr.Type, 0, len(elements))
+	for _, elem := range elements {
+		raw, elemDiags := attributeValueToInterface(ctx, elem)
+		diags.Append(elemDiags...)
+		if diags.HasError() {
+			return value, diags
+		}
+		if keep(raw) {
+			kept = append(kept, elem)
+			keptTypes = append(keptTypes, elem.Type(ctx))
+		}
+	}
+
+	var result attr.Value
+	var resultDiags diag.Diagnostics
+	switch v := value.(type) {
+	case types.List:
+		result, resultDiags = types.ListValue(v.ElementType(ctx), kept)
+	case types.Set:
+		result, resultDiags = types.SetValue(v.ElementType(ctx), kept)
+	default:
+		result, resultDiags = types.TupleValue(keptTypes, kept)
+	}
+	diags.Append(resultDiags...)
+	return result, diags
+}
+
 func expandCustomAttributes(ctx context.Context, attrs types.Dynamic) (map[string]interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
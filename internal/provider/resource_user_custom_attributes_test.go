@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMergeCustomAttributeValues(t *testing.T) {
+	t.Parallel()
+
+	current := []interface{}{"a", "b", float64(1)}
+
+	tests := []struct {
+		strategy string
+		listed   []interface{}
+		want     []interface{}
+	}{
+		{strategy: customAttributeMergeReplace, listed: []interface{}{"c"}, want: []interface{}{"c"}},
+		{strategy: customAttributeMergeAppend, listed: []interface{}{"b", "c"}, want: []interface{}{"a", "b", float64(1), "c"}},
+		{strategy: customAttributeMergeAppend, listed: []interface{}{int64(1)}, want: []interface{}{"a", "b", float64(1)}},
+		{strategy: customAttributeMergeRemoveListed, listed: []interface{}{"a", int64(1), "z"}, want: []interface{}{"b"}},
+	}
+
+	for _, tt := range tests {
+		got := mergeCustomAttributeValues(tt.strategy, current, tt.listed)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("%s %v: got %v, want %v", tt.strategy, tt.listed, got, tt.want)
+		}
+	}
+
+	if got := mergeCustomAttributeValues(customAttributeMergeAppend, "a", []interface{}{"b"}); !reflect.DeepEqual(got, []interface{}{"a", "b"}) {
+		t.Fatalf("append to a single value: got %v", got)
+	}
+}
+
+func TestMergeCustomAttributesFromAPI_MergeStrategies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tuple := func(values ...string) types.Tuple {
+		elems := make([]attr.Value, 0, len(values))
+		elemTypes := make([]attr.Type, 0, len(values))
+		for _, v := range values {
+			elems = append(elems, types.StringValue(v))
+			elemTypes = append(elemTypes, types.StringType)
+		}
+		return types.TupleValueMust(elemTypes, elems)
+	}
+	object := func(appended types.Tuple, removed types.Tuple) types.Dynamic {
+		return types.DynamicValue(types.ObjectValueMust(
+			map[string]attr.Type{"roles": appended.Type(ctx), "blocked": removed.Type(ctx)},
+			map[string]attr.Value{"roles": appended, "blocked": removed},
+		))
+	}
+
+	state := object(tuple("admin", "auditor"), tuple("guest", "legacy"))
+	strategies := map[string]string{"roles": customAttributeMergeAppend, "blocked": customAttributeMergeRemoveListed}
+	userMap := map[string]interface{}{
+		"roles":   []interface{}{"admin", "owner"},
+		"blocked": []interface{}{"legacy", "other"},
+	}
+
+	got, diags := mergeCustomAttributesFromAPI(ctx, state, userMap, strategies)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	want := object(tuple("admin"), tuple("guest"))
+	if !got.Equal(want) {
+		t.Fatalf("attributes = %s, want %s", got, want)
+	}
+
+	userMap = map[string]interface{}{
+		"roles":   []interface{}{"admin", "auditor", "owner"},
+		"blocked": []interface{}{"other"},
+	}
+	got, diags = mergeCustomAttributesFromAPI(ctx, state, userMap, strategies)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !got.Equal(state) {
+		t.Fatalf("attributes = %s, want the configured values %s", got, state)
+	}
+}
+
+func TestPatchUserCustomAttributes_UserUpdatedAt(t *testing.T) {
+	t.Parallel()
+
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v1/environments/env-id/users/user-id" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		return testResponse(r, http.StatusOK, `{"id":"user-id","updatedAt":"2026-10-17T09:30:00.000Z"}`), nil
+	})
+
+	httpResp, err := patchUserCustomAttributes(context.Background(), nil, apiClient, "env-id", "user-id", map[string]interface{}{"customRoles": []interface{}{"a"}})
+	if err != nil {
+		t.Fatalf("patchUserCustomAttributes error: %v", err)
+	}
+	if got := userUpdatedAtFromResponse(httpResp); got.ValueString() != "2026-10-17T09:30:00.000Z" {
+		t.Fatalf("user_updated_at = %s", got)
+	}
+
+	empty := &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}
+	if got := userUpdatedAtFromResponse(empty); !got.IsNull() {
+		t.Fatalf("user_updated_at = %s, want null", got)
+	}
+}
+
+func TestReservedUserAttributeNamesValidator(t *testing.T) {
+	t.Parallel()
+
+	value, diags := types.MapValue(types.StringType, map[string]attr.Value{
+		"Email":      types.StringValue("user@example.com"),
+		"createdAt":  types.StringValue("2024-01-01T00:00:00Z"),
+		"department": types.StringValue("Sales"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics building map: %v", diags)
+	}
+
+	req := validator.DynamicRequest{
+		Path:        path.Root("attributes"),
+		ConfigValue: types.DynamicValue(value),
+	}
+	resp := &validator.DynamicResponse{}
+	reservedUserAttributeNamesValidator{}.ValidateDynamic(context.Background(), req, resp)
+
+	if got := resp.Diagnostics.ErrorsCount(); got != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", got, resp.Diagnostics)
+	}
+	if got := resp.Diagnostics.WarningsCount(); got != 1 {
+		t.Fatalf("expected 1 warning, got %d: %v", got, resp.Diagnostics)
+	}
+	errs := resp.Diagnostics.Errors()
+	if wantPath := path.Root("attributes").AtMapKey("createdAt"); !errs[0].(diag.DiagnosticWithPath).Path().Equal(wantPath) {
+		t.Fatalf("expected error at %s, got %s", wantPath, errs[0].(diag.DiagnosticWithPath).Path())
+	}
+	warnings := resp.Diagnostics.Warnings()
+	if wantPath := path.Root("attributes").AtMapKey("Email"); !warnings[0].(diag.DiagnosticWithPath).Path().Equal(wantPath) {
+		t.Fatalf("expected warning at %s, got %s", wantPath, warnings[0].(diag.DiagnosticWithPath).Path())
+	}
+}
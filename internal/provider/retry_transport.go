@@ -8,6 +8,9 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
 )
 
 // retryTransport wraps an http.RoundTripper and implements exponential backoff
@@ -18,6 +21,7 @@ type retryTransport struct {
 	initialBackoff  time.Duration // Initial backoff duration (default 1 second)
 	maxBackoff      time.Duration // Maximum backoff duration (default 60 seconds)
 	backoffFactor   float64       // Multiplier for exponential backoff (default 2.0)
+	metrics         *client.APIMetrics
 }
 
 // retryTransportOption is a functional option for configuring retryTransport.
@@ -51,6 +55,13 @@ func WithBackoffFactor(f float64) retryTransportOption {
 	}
 }
 
+// WithMetrics records retries and the time spent waiting for them in m.
+func WithMetrics(m *client.APIMetrics) retryTransportOption {
+	return func(rt *retryTransport) {
+		rt.metrics = m
+	}
+}
+
 // newRetryTransport creates a new retryTransport wrapping the given RoundTripper.
 // Default configuration:
 //   - maxRetryTimeout: 5 minutes
@@ -108,6 +119,12 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return resp, nil
 		}
 
+		// A create answered by a gateway may still have been applied; only a 429 is certain to
+		// have been rejected. createOrAdopt checks for the object before sending it again.
+		if isCreateRequest(req.Context()) && resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
 		// Check if we've exceeded the deadline
 		if time.Now().After(deadline) {
 			log.Printf("pingoneprovisioning: retry timeout exceeded after %d attempts for %s %s",
@@ -132,12 +149,11 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 		log.Printf("pingoneprovisioning: received %d for %s %s, retrying in %s (attempt %d)",
 			resp.StatusCode, req.Method, req.URL.String(), sleepDuration.Round(time.Millisecond), attempt+1)
+		t.metrics.RecordRetry(client.PingOneAPIFamily(req), sleepDuration)
 
 		// Sleep before retry
-		select {
-		case <-req.Context().Done():
-			return nil, req.Context().Err()
-		case <-time.After(sleepDuration):
+		if err := utils.SleepContext(req.Context(), sleepDuration); err != nil {
+			return nil, err
 		}
 
 		// Increase backoff for next iteration
@@ -264,10 +280,8 @@ func (r *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 		log.Printf("pingoneprovisioning: received %d for %s %s, retrying in %s (attempt %d)",
 			resp.StatusCode, req.Method, req.URL.String(), sleepDuration.Round(time.Millisecond), attempt+1)
 
-		select {
-		case <-req.Context().Done():
-			return nil, req.Context().Err()
-		case <-time.After(sleepDuration):
+		if err := utils.SleepContext(req.Context(), sleepDuration); err != nil {
+			return nil, err
 		}
 
 		backoff = r.nextBackoff(backoff)
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// schemaJSONFormatVersion is the format version of `terraform providers schema -json` that
+// WriteSchemaJSON follows.
+const schemaJSONFormatVersion = "1.0"
+
+type schemaJSON struct {
+	FormatVersion   string                        `json:"format_version"`
+	ProviderSchemas map[string]providerSchemaJSON `json:"provider_schemas"`
+}
+
+type providerSchemaJSON struct {
+	Provider          schemaEntryJSON            `json:"provider"`
+	ResourceSchemas   map[string]schemaEntryJSON `json:"resource_schemas,omitempty"`
+	DataSourceSchemas map[string]schemaEntryJSON `json:"data_source_schemas,omitempty"`
+}
+
+type schemaEntryJSON struct {
+	Version int64     `json:"version"`
+	Block   blockJSON `json:"block"`
+}
+
+type blockJSON struct {
+	Attributes      map[string]attributeJSON `json:"attributes,omitempty"`
+	BlockTypes      map[string]blockTypeJSON `json:"block_types,omitempty"`
+	Description     string                   `json:"description,omitempty"`
+	DescriptionKind string                   `json:"description_kind"`
+	Deprecated      bool                     `json:"deprecated,omitempty"`
+}
+
+type attributeJSON struct {
+	Type            json.RawMessage `json:"type,omitempty"`
+	NestedType      *nestedTypeJSON `json:"nested_type,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	DescriptionKind string          `json:"description_kind"`
+	Deprecated      bool            `json:"deprecated,omitempty"`
+	Required        bool            `json:"required,omitempty"`
+	Optional        bool            `json:"optional,omitempty"`
+	Computed        bool            `json:"computed,omitempty"`
+	Sensitive       bool            `json:"sensitive,omitempty"`
+	WriteOnly       bool            `json:"write_only,omitempty"`
+}
+
+type nestedTypeJSON struct {
+	Attributes  map[string]attributeJSON `json:"attributes"`
+	NestingMode string                   `json:"nesting_mode"`
+}
+
+type blockTypeJSON struct {
+	NestingMode string    `json:"nesting_mode"`
+	Block       blockJSON `json:"block"`
+	MinItems    int64     `json:"min_items,omitempty"`
+	MaxItems    int64     `json:"max_items,omitempty"`
+}
+
+// WriteSchemaJSON writes the schemas of the provider, its resources and its data sources to w,
+// in the format of `terraform providers schema -json` under the provider address address. Policy
+// tooling written against that format can read it without running Terraform or configuring
+// credentials.
+func WriteSchemaJSON(ctx context.Context, w io.Writer, address string, version string) error {
+	server, err := providerserver.NewProtocol6WithError(New(version)())()
+	if err != nil {
+		return err
+	}
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return err
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	provider := providerSchemaJSON{
+		Provider:          schemaEntryFromProto(resp.Provider),
+		ResourceSchemas:   make(map[string]schemaEntryJSON, len(resp.ResourceSchemas)),
+		DataSourceSchemas: make(map[string]schemaEntryJSON, len(resp.DataSourceSchemas)),
+	}
+	for name, s := range resp.ResourceSchemas {
+		provider.ResourceSchemas[name] = schemaEntryFromProto(s)
+	}
+	for name, s := range resp.DataSourceSchemas {
+		provider.DataSourceSchemas[name] = schemaEntryFromProto(s)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schemaJSON{
+		FormatVersion:   schemaJSONFormatVersion,
+		ProviderSchemas: map[string]providerSchemaJSON{address: provider},
+	})
+}
+
+func schemaEntryFromProto(s *tfprotov6.Schema) schemaEntryJSON {
+	if s == nil {
+		return schemaEntryJSON{Block: blockJSON{DescriptionKind: descriptionKind(tfprotov6.StringKindPlain)}}
+	}
+	return schemaEntryJSON{Version: s.Version, Block: blockFromProto(s.Block)}
+}
+
+func blockFromProto(b *tfprotov6.SchemaBlock) blockJSON {
+	if b == nil {
+		return blockJSON{DescriptionKind: descriptionKind(tfprotov6.StringKindPlain)}
+	}
+
+	block := blockJSON{
+		Description:     b.Description,
+		DescriptionKind: descriptionKind(b.DescriptionKind),
+		Deprecated:      b.Deprecated,
+	}
+	if len(b.Attributes) > 0 {
+		block.Attributes = attributesFromProto(b.Attributes)
+	}
+	if len(b.BlockTypes) > 0 {
+		block.BlockTypes = make(map[string]blockTypeJSON, len(b.BlockTypes))
+		for _, nested := range b.BlockTypes {
+			block.BlockTypes[nested.TypeName] = blockTypeJSON{
+				NestingMode: strings.ToLower(nested.Nesting.String()),
+				Block:       blockFromProto(nested.Block),
+				MinItems:    nested.MinItems,
+				MaxItems:    nested.MaxItems,
+			}
+		}
+	}
+	return block
+}
+
+func attributesFromProto(attributes []*tfprotov6.SchemaAttribute) map[string]attributeJSON {
+	result := make(map[string]attributeJSON, len(attributes))
+	for _, a := range attributes {
+		attribute := attributeJSON{
+			Description:     a.Description,
+			DescriptionKind: descriptionKind(a.DescriptionKind),
+			Deprecated:      a.Deprecated,
+			Required:        a.Required,
+			Optional:        a.Optional,
+			Computed:        a.Computed,
+			Sensitive:       a.Sensitive,
+			WriteOnly:       a.WriteOnly,
+		}
+		if a.NestedType != nil {
+			attribute.NestedType = &nestedTypeJSON{
+				Attributes:  attributesFromProto(a.NestedType.Attributes),
+				NestingMode: strings.ToLower(a.NestedType.Nesting.String()),
+			}
+		} else if a.Type != nil {
+			if raw, err := a.Type.MarshalJSON(); err == nil {
+				attribute.Type = raw
+			}
+		}
+		result[a.Name] = attribute
+	}
+	return result
+}
+
+func descriptionKind(kind tfprotov6.StringKind) string {
+	if kind == tfprotov6.StringKindMarkdown {
+		return "markdown"
+	}
+	return "plain"
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteSchemaJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteSchemaJSON(context.Background(), &buf, "example.com/test/pingoneprovisioning", "test"); err != nil {
+		t.Fatalf("WriteSchemaJSON: %v", err)
+	}
+
+	var got schemaJSON
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if got.FormatVersion != schemaJSONFormatVersion {
+		t.Fatalf("format_version = %q", got.FormatVersion)
+	}
+
+	p, ok := got.ProviderSchemas["example.com/test/pingoneprovisioning"]
+	if !ok {
+		t.Fatalf("provider address missing: %v", got.ProviderSchemas)
+	}
+	if !p.Provider.Block.Attributes["client_secret"].Sensitive {
+		t.Fatal("provider client_secret should be sensitive")
+	}
+
+	store, ok := p.ResourceSchemas["pingoneprovisioning_propagation_store"]
+	if !ok {
+		t.Fatal("propagation store resource missing")
+	}
+	if store.Version != 4 {
+		t.Fatalf("propagation store schema version = %d, want 4", store.Version)
+	}
+	if string(store.Block.Attributes["name"].Type) != `"string"` || !store.Block.Attributes["name"].Required {
+		t.Fatalf("name attribute = %+v", store.Block.Attributes["name"])
+	}
+	scim, ok := store.Block.BlockTypes["configuration_scim"]
+	if !ok || scim.NestingMode != "single" || !scim.Block.Attributes["oauth_client_secret"].Sensitive {
+		t.Fatalf("configuration_scim = %+v", scim)
+	}
+
+	rule := p.ResourceSchemas["pingoneprovisioning_propagation_rule"]
+	mappings := rule.Block.Attributes["mappings"]
+	if mappings.NestedType == nil || mappings.NestedType.NestingMode != "list" || !mappings.NestedType.Attributes["sensitive_expression"].Sensitive {
+		t.Fatalf("mappings = %+v", mappings)
+	}
+
+	if _, ok := p.DataSourceSchemas["pingoneprovisioning_user"]; !ok {
+		t.Fatal("user data source missing")
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// targetStoreMetadataRequests returns the PingOne store metadata request for the store types
+// whose attribute catalog the API exposes. Other store types cannot be validated.
+var targetStoreMetadataRequests = map[string]func(ctx context.Context, apiClient *management.APIClient, environmentID string, body map[string]interface{}) (*http.Response, error){
+	"aquera": func(ctx context.Context, apiClient *management.APIClient, environmentID string, body map[string]interface{}) (*http.Response, error) {
+		return apiClient.PropagationStoreMetadataApi.EnvironmentsEnvironmentIDPropagationStoreMetadataAqueraPost(ctx, environmentID).Body(body).Execute()
+	},
+	"salesforce": func(ctx context.Context, apiClient *management.APIClient, environmentID string, body map[string]interface{}) (*http.Response, error) {
+		return apiClient.PropagationStoreMetadataApi.EnvironmentsEnvironmentIDPropagationStoreMetadataSalesforcePost(ctx, environmentID).Body(body).Execute()
+	},
+	"salesforcecontacts": func(ctx context.Context, apiClient *management.APIClient, environmentID string, body map[string]interface{}) (*http.Response, error) {
+		return apiClient.PropagationStoreMetadataApi.EnvironmentsEnvironmentIDPropagationStoreMetadataSalesforceContactsPost(ctx, environmentID).Body(body).Execute()
+	},
+	"scim": func(ctx context.Context, apiClient *management.APIClient, environmentID string, body map[string]interface{}) (*http.Response, error) {
+		return apiClient.PropagationStoreMetadataApi.EnvironmentsEnvironmentIDPropagationStoreMetadataScimPost(ctx, environmentID).Body(body).Execute()
+	},
+}
+
+// targetStoreAttributeNames returns the attribute catalog of a target store. The second return
+// value is false when PingOne has no metadata endpoint for the store's type. The catalog is
+// requested with the store's configuration as read back from PingOne, and results are cached
+// on the client per store.
+func targetStoreAttributeNames(ctx context.Context, clientData *client.Client, environmentID string, storeID string) ([]string, bool, error) {
+	if clientData == nil || clientData.API == nil {
+		return nil, false, fmt.Errorf("nil api client")
+	}
+
+	cacheKey := environmentID + "/" + storeID
+	if names, ok := clientData.TargetStoreAttributes.Get(cacheKey); ok {
+		return names, true, nil
+	}
+
+	apiClient := clientData.API
+
+	_, storeResp, err := apiClient.PropagationStoresApi.ReadOnePropagationStore(ctx, environmentID, storeID).Execute()
+	if err != nil && (storeResp == nil || storeResp.StatusCode >= 300) {
+		return nil, false, fmt.Errorf("could not read target store: %s", utils.HandleSDKError(err, storeResp))
+	}
+
+	storeObj, err := utils.DecodeResponseJSONObject(storeResp, "store")
+	if err != nil {
+		return nil, false, err
+	}
+
+	storeType, _ := utils.NestedString(storeObj, "type")
+	request, ok := targetStoreMetadataRequests[strings.ToLower(storeType)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	configuration, _ := storeObj["configuration"].(map[string]interface{})
+	if configuration == nil {
+		configuration = map[string]interface{}{}
+	}
+
+	metadataResp, err := request(ctx, apiClient, environmentID, configuration)
+	if err != nil && (metadataResp == nil || metadataResp.StatusCode >= 300) {
+		return nil, true, fmt.Errorf("could not read %s store metadata: %s", storeType, utils.HandleSDKError(err, metadataResp))
+	}
+
+	metadata, err := utils.DecodeResponseJSON(metadataResp)
+	if err != nil {
+		return nil, true, err
+	}
+
+	names := storeMetadataAttributeNames(metadata)
+	if len(names) == 0 {
+		return nil, true, fmt.Errorf("%s store metadata did not list any attributes", storeType)
+	}
+
+	clientData.TargetStoreAttributes.Set(cacheKey, names)
+
+	return names, true, nil
+}
+
+// storeMetadataAttributeNames collects attribute names from a store metadata response. The
+// attributes are listed either under `attributes` or `_embedded.attributes`, or as a root
+// array.
+func storeMetadataAttributeNames(decoded any) []string {
+	var list []interface{}
+	if root, ok := decoded.(map[string]interface{}); ok {
+		list, _ = root["attributes"].([]interface{})
+	}
+	if list == nil {
+		list, _ = utils.ExtractEmbeddedArray(decoded, "attributes")
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := utils.NestedString(m, "name")
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// validateMappingTargetAttributesAgainstStore checks mapping target attributes against the
+// target store's attribute catalog when the provider's `validate_target_attributes` is set.
+// Stores whose type has no metadata endpoint are skipped; a failed lookup is reported as a
+// warning so that the plan still completes.
+func validateMappingTargetAttributesAgainstStore(ctx context.Context, clientData *client.Client, environmentID types.String, targetStoreID types.String, targetAttributes []types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if clientData == nil || !clientData.ValidateTargetAttributes || len(targetAttributes) == 0 {
+		return diags
+	}
+	if environmentID.IsNull() || environmentID.IsUnknown() || targetStoreID.IsNull() || targetStoreID.IsUnknown() {
+		return diags
+	}
+
+	storeID := targetStoreID.ValueString()
+
+	names, supported, err := targetStoreAttributeNames(ctx, clientData, environmentID.ValueString(), storeID)
+	if err != nil {
+		diags.AddWarning(
+			"Target Attribute Validation Skipped",
+			fmt.Sprintf("Mapping target attributes were not checked against target store '%s': %s", storeID, err),
+		)
+		return diags
+	}
+	if !supported {
+		tflog.Debug(ctx, "Skipping mapping target attribute validation: store type has no attribute catalog", map[string]interface{}{
+			"target_store_id": storeID,
+		})
+		return diags
+	}
+
+	diags.Append(validateMappingTargetAttributes(targetAttributes, names, storeID)...)
+	return diags
+}
+
+// validateMappingTargetAttributes reports mappings whose `target_attribute` is not in the
+// target store's attribute catalog. As with source attributes, only the top-level attribute
+// (before any `.` or `[`) must exist unless the catalog lists the full name.
+func validateMappingTargetAttributes(targetAttributes []types.String, catalog []string, storeID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	known := make(map[string]bool, len(catalog))
+	for _, name := range catalog {
+		known[strings.ToLower(name)] = true
+	}
+
+	for i, attribute := range targetAttributes {
+		if attribute.IsNull() || attribute.IsUnknown() {
+			continue
+		}
+
+		target := strings.TrimSpace(attribute.ValueString())
+		if target == "" || known[strings.ToLower(target)] {
+			continue
+		}
+
+		root := target
+		if idx := strings.IndexAny(root, ".["); idx >= 0 {
+			root = root[:idx]
+		}
+		if known[strings.ToLower(root)] {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root("mappings").AtListIndex(i).AtName("target_attribute"),
+			"Unknown Target Attribute",
+			fmt.Sprintf("`%s` is not an attribute of target store '%s'. Check the attribute name against the store's attribute catalog in PingOne.", target, storeID),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateMappingTargetAttributes(t *testing.T) {
+	t.Parallel()
+
+	catalog := []string{"Email", "FirstName", "LastName", "Username", "name"}
+
+	targetAttributes := []types.String{
+		types.StringValue("Username"),
+		types.StringValue("email"),
+		types.StringValue("name.givenName"),
+		types.StringUnknown(),
+		types.StringValue("CostCenter__c"),
+	}
+
+	diags := validateMappingTargetAttributes(targetAttributes, catalog, "store-id")
+	if got := diags.ErrorsCount(); got != 1 {
+		t.Fatalf("ErrorsCount() = %d, want 1 (diags: %v)", got, diags)
+	}
+
+	wantPath := path.Root("mappings").AtListIndex(4).AtName("target_attribute")
+	errDiag, ok := diags.Errors()[0].(interface{ Path() path.Path })
+	if !ok {
+		t.Fatalf("expected an attribute diagnostic, got %T", diags.Errors()[0])
+	}
+	if !errDiag.Path().Equal(wantPath) {
+		t.Fatalf("Path() = %s, want %s", errDiag.Path(), wantPath)
+	}
+}
+
+func TestTargetStoreAttributeNames(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	apiClient := newManagementTestClient(t, "api.example", func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		body := ``
+		switch r.URL.Path {
+		case "/v1/environments/env-id/propagation/stores/sf-id":
+			body = `{"id":"sf-id","type":"Salesforce","configuration":{"domain":"example.my.salesforce.com","clientId":"client"}}`
+		case "/v1/environments/env-id/propagation/stores/slack-id":
+			body = `{"id":"slack-id","type":"Slack","configuration":{}}`
+		case "/v1/environments/env-id/propagation/storeMetadata/Salesforce":
+			var sent map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decode metadata request: %v", err)
+			}
+			if sent["domain"] != "example.my.salesforce.com" {
+				t.Errorf("metadata request body = %v", sent)
+			}
+			body = `{"attributes":[{"name":"Username"},{"name":"Email"},{"name":"Username"}]}`
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		return testResponse(r, http.StatusOK, body), nil
+	})
+
+	clientData := &client.Client{
+		API:                   apiClient,
+		TargetStoreAttributes: client.NewAttributeCache(),
+	}
+
+	names, supported, err := targetStoreAttributeNames(context.Background(), clientData, "env-id", "sf-id")
+	if err != nil || !supported {
+		t.Fatalf("targetStoreAttributeNames = %v, %v, %v", names, supported, err)
+	}
+	if want := []string{"Email", "Username"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+
+	// The second lookup is served from the cache.
+	if _, _, err := targetStoreAttributeNames(context.Background(), clientData, "env-id", "sf-id"); err != nil {
+		t.Fatalf("cached lookup: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("calls = %v, want 2 requests", calls)
+	}
+
+	names, supported, err = targetStoreAttributeNames(context.Background(), clientData, "env-id", "slack-id")
+	if err != nil || supported || names != nil {
+		t.Fatalf("Slack store: names = %v, supported = %v, err = %v", names, supported, err)
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// roundTripperFunc answers HTTP requests in tests without a network.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// newManagementTestClient returns a management API client for https://<baseHostname>/v1 whose
+// requests are answered by roundTrip. With a nil roundTrip the client's requests use the default
+// HTTP client.
+func newManagementTestClient(t *testing.T, baseHostname string, roundTrip roundTripperFunc) *management.APIClient {
+	t.Helper()
+
+	cfg := management.NewConfiguration()
+	cfg.SetDefaultServerIndex(1)
+	if err := cfg.SetDefaultServerVariableDefaultValue("baseHostname", baseHostname); err != nil {
+		t.Fatalf("SetDefaultServerVariableDefaultValue: %v", err)
+	}
+	if roundTrip != nil {
+		cfg.HTTPClient = &http.Client{Transport: roundTrip}
+	}
+	return management.NewAPIClient(cfg)
+}
+
+// testResponse returns a JSON response to r.
+func testResponse(r *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}
+}
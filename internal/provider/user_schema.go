@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/client"
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// userSourceAttributesOutsideSchema lists user properties that PingOne exposes to mappings but
+// does not return from the schema attributes endpoint.
+var userSourceAttributesOutsideSchema = []string{
+	"account",
+	"createdAt",
+	"enabled",
+	"id",
+	"identityProvider",
+	"lifecycle",
+	"memberOfGroupIDs",
+	"memberOfGroupNames",
+	"mfaEnabled",
+	"population",
+	"updatedAt",
+	"verifyStatus",
+}
+
+// userProfileAttributes lists the core and standard attributes of the PingOne user profile.
+// They are managed by user resources rather than as custom attributes.
+var userProfileAttributes = []string{
+	"accountId",
+	"address",
+	"email",
+	"externalId",
+	"locale",
+	"mobilePhone",
+	"name",
+	"nickname",
+	"password",
+	"photo",
+	"preferredLanguage",
+	"primaryPhone",
+	"timezone",
+	"title",
+	"type",
+	"username",
+}
+
+// userSchemaAttribute is an attribute of the PingOne user schema. SchemaType is `CORE`,
+// `STANDARD` or `CUSTOM`.
+type userSchemaAttribute struct {
+	Name          string
+	SchemaType    string
+	SubAttributes []string
+}
+
+// userSchemaAttributeNames returns the names of the core and custom attributes in the
+// environment's user schema. Complex attributes also contribute dotted sub-attribute names
+// (for example `name.given`). Results are cached on the client per environment.
+func userSchemaAttributeNames(ctx context.Context, clientData *client.Client, environmentID string) ([]string, error) {
+	if clientData == nil || clientData.API == nil {
+		return nil, fmt.Errorf("nil api client")
+	}
+
+	if names, ok := clientData.UserSchemaAttributes.Get(environmentID); ok {
+		return names, nil
+	}
+
+	attributes, err := readUserSchemaAttributes(ctx, clientData.API, environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	for _, attribute := range attributes {
+		add(attribute.Name)
+		for _, sub := range attribute.SubAttributes {
+			add(attribute.Name + "." + sub)
+		}
+	}
+
+	sort.Strings(names)
+	clientData.UserSchemaAttributes.Set(environmentID, names)
+
+	return names, nil
+}
+
+// readUserSchemaAttributes reads the attributes of the environment's user schema.
+func readUserSchemaAttributes(ctx context.Context, apiClient *management.APIClient, environmentID string) ([]userSchemaAttribute, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("nil api client")
+	}
+
+	schemaID := ""
+	for cursor, iterErr := range apiClient.SchemasApi.ReadAllSchemas(ctx, environmentID).Execute() {
+		if iterErr != nil {
+			return nil, iterErr
+		}
+		if cursor.HTTPResponse == nil {
+			continue
+		}
+
+		decoded, err := utils.DecodeResponseJSON(cursor.HTTPResponse)
+		if err != nil {
+			return nil, err
+		}
+		list, err := utils.ExtractEmbeddedArray(decoded, "schemas")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := utils.NestedString(m, "name")
+			id, _ := utils.NestedString(m, "id")
+			if id != "" && (schemaID == "" || strings.EqualFold(name, "User")) {
+				schemaID = id
+			}
+		}
+	}
+
+	if schemaID == "" {
+		return nil, fmt.Errorf("no user schema found in environment %s", environmentID)
+	}
+
+	var attributes []userSchemaAttribute
+	for cursor, iterErr := range apiClient.SchemasApi.ReadAllSchemaAttributes(ctx, environmentID, schemaID).Execute() {
+		if iterErr != nil {
+			return nil, iterErr
+		}
+		if cursor.HTTPResponse == nil {
+			continue
+		}
+
+		decoded, err := utils.DecodeResponseJSON(cursor.HTTPResponse)
+		if err != nil {
+			return nil, err
+		}
+		list, err := utils.ExtractEmbeddedArray(decoded, "attributes")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := utils.NestedString(m, "name")
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+
+			attribute := userSchemaAttribute{Name: name}
+			attribute.SchemaType, _ = utils.NestedString(m, "schemaType")
+
+			subAttributes, _ := m["subAttributes"].([]interface{})
+			for _, sub := range subAttributes {
+				subMap, ok := sub.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if subName, ok := utils.NestedString(subMap, "name"); ok && strings.TrimSpace(subName) != "" {
+					attribute.SubAttributes = append(attribute.SubAttributes, strings.TrimSpace(subName))
+				}
+			}
+
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	return attributes, nil
+}
+
+// validateMappingSourceAttributes reports mappings whose `source_attribute` does not name an
+// attribute in the PingOne user schema. Only the top-level attribute (before any `.` or `[`)
+// must exist unless the schema lists the dotted sub-attribute.
+func validateMappingSourceAttributes(mappings []customtypes.PropagationRuleMappingModel, schemaAttributes []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	known := make(map[string]bool, len(schemaAttributes)+len(userSourceAttributesOutsideSchema))
+	for _, name := range schemaAttributes {
+		known[strings.ToLower(name)] = true
+	}
+	for _, name := range userSourceAttributesOutsideSchema {
+		known[strings.ToLower(name)] = true
+	}
+
+	for i, m := range mappings {
+		if m.SourceAttribute.IsNull() || m.SourceAttribute.IsUnknown() {
+			continue
+		}
+
+		source := strings.TrimSpace(m.SourceAttribute.ValueString())
+		if source == "" || known[strings.ToLower(source)] {
+			continue
+		}
+
+		root := source
+		if idx := strings.IndexAny(root, ".["); idx >= 0 {
+			root = root[:idx]
+		}
+		if known[strings.ToLower(root)] {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root("mappings").AtListIndex(i).AtName("source_attribute"),
+			"Unknown Source Attribute",
+			fmt.Sprintf("`%s` is not an attribute of the PingOne user schema. Check the attribute name, or create the custom attribute before referencing it in a mapping.", source),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	customtypes "github.com/easytofu/terraform-provider-pingoneprovisioning/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateMappingSourceAttributes(t *testing.T) {
+	t.Parallel()
+
+	schemaAttributes := []string{"email", "name", "name.given", "username", "employeeNumber"}
+
+	mapping := func(source string) customtypes.PropagationRuleMappingModel {
+		return customtypes.PropagationRuleMappingModel{
+			Id:              types.StringUnknown(),
+			SourceAttribute: types.StringValue(source),
+			TargetAttribute: types.StringValue("target"),
+			Expression:      types.StringNull(),
+		}
+	}
+
+	mappings := []customtypes.PropagationRuleMappingModel{
+		mapping("username"),
+		mapping("name.family"),
+		mapping("EmployeeNumber"),
+		mapping("population.id"),
+		mapping("costCenter"),
+		{
+			Id:              types.StringUnknown(),
+			SourceAttribute: types.StringNull(),
+			TargetAttribute: types.StringValue("displayName"),
+			Expression:      types.StringValue("${user.name.given}"),
+		},
+	}
+
+	diags := validateMappingSourceAttributes(mappings, schemaAttributes)
+	if got := diags.ErrorsCount(); got != 1 {
+		t.Fatalf("ErrorsCount() = %d, want 1 (diags: %v)", got, diags)
+	}
+
+	wantPath := path.Root("mappings").AtListIndex(4).AtName("source_attribute")
+	errDiag, ok := diags.Errors()[0].(interface{ Path() path.Path })
+	if !ok {
+		t.Fatalf("expected an attribute diagnostic, got %T", diags.Errors()[0])
+	}
+	if !errDiag.Path().Equal(wantPath) {
+		t.Fatalf("Path() = %s, want %s", errDiag.Path(), wantPath)
+	}
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"golang.org/x/oauth2"
+)
+
+// validateCredentials requests an access token and reads the worker application's environment,
+// so that bad credentials, a region mismatch or missing roles are reported at Configure time
+// instead of by the first resource that makes a request.
+func validateCredentials(ctx context.Context, apiClient *management.APIClient, environmentID string, tokenURL string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if transport, ok := apiClient.GetConfig().HTTPClient.Transport.(*oauth2.Transport); ok && transport.Source != nil {
+		if _, err := transport.Source.Token(); err != nil {
+			diags.AddError(
+				"Invalid PingOne Credentials",
+				fmt.Sprintf("The provider could not authenticate the worker application: %s", err),
+			)
+			return diags
+		}
+	}
+
+	hostname := currentPingOneHostname(apiClient)
+
+	tflog.Debug(ctx, "Validating PingOne credentials", map[string]interface{}{
+		"environment_id": environmentID,
+		"api_hostname":   hostname,
+	})
+
+	_, httpResp, err := apiClient.EnvironmentsApi.ReadOneEnvironment(ctx, environmentID).Execute()
+	if err == nil || (httpResp != nil && httpResp.StatusCode < 300) {
+		return diags
+	}
+
+	statusCode := 0
+	if httpResp != nil {
+		statusCode = httpResp.StatusCode
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		diags.AddError(
+			"PingOne Region Mismatch",
+			fmt.Sprintf("An access token was issued by %s, but %s rejected it. Tokens are only valid in the region that issued them; check that `region`, `oauth_token_url` and `api_base_url` all point at the environment's region.", tokenURL, hostname),
+		)
+	case http.StatusForbidden:
+		diags.AddError(
+			"Insufficient PingOne Permissions",
+			fmt.Sprintf("The worker application authenticated but may not read environment '%s'. Assign it a role scoped to the environment, such as Environment Admin or Identity Data Admin, that grants read access.", environmentID),
+		)
+	case http.StatusNotFound:
+		diags.AddError(
+			"PingOne Environment Not Found",
+			fmt.Sprintf("Environment '%s' was not found at %s. Check `environment_id`, and check that `region` matches the environment's region.", environmentID, hostname),
+		)
+	default:
+		diags.AddError(
+			"Unable to Validate PingOne Credentials",
+			fmt.Sprintf("Could not read environment '%s': %s", environmentID, utils.HandleSDKError(err, httpResp)),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenStatus int
+		apiStatus   int
+		wantSummary string
+	}{
+		{name: "valid", tokenStatus: http.StatusOK, apiStatus: http.StatusOK},
+		{name: "bad secret", tokenStatus: http.StatusUnauthorized, wantSummary: "Invalid PingOne Credentials"},
+		{name: "token from another region", tokenStatus: http.StatusOK, apiStatus: http.StatusUnauthorized, wantSummary: "PingOne Region Mismatch"},
+		{name: "missing role", tokenStatus: http.StatusOK, apiStatus: http.StatusForbidden, wantSummary: "Insufficient PingOne Permissions"},
+		{name: "unknown environment", tokenStatus: http.StatusOK, apiStatus: http.StatusNotFound, wantSummary: "PingOne Environment Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalDefaultTransport := http.DefaultTransport
+			http.DefaultTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				status, body := tt.apiStatus, `{"id":"env-id","name":"Example","region":"NA"}`
+				if r.URL.Path == "/as/token" {
+					status, body = tt.tokenStatus, `{"access_token":"test-token","token_type":"Bearer","expires_in":3600}`
+					if status != http.StatusOK {
+						body = `{"error":"invalid_client"}`
+					}
+				} else if r.URL.Path != "/v1/environments/env-id" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if status != http.StatusOK && r.URL.Path != "/as/token" {
+					body = `{"code":"REQUEST_FAILED"}`
+				}
+
+				return testResponse(r, status, body), nil
+			})
+			t.Cleanup(func() { http.DefaultTransport = originalDefaultTransport })
+
+			apiClient, err := newManagementClient(context.Background(), "", "client-id", "client-secret", "env-id", "NorthAmerica", "https://auth.example/as/token", "https://api.example/v1", defaultRequestTimeout, defaultRequestTimeout, nil)
+			if err != nil {
+				t.Fatalf("newManagementClient: %v", err)
+			}
+
+			diags := validateCredentials(context.Background(), apiClient, "env-id", "https://auth.example/as/token")
+			if tt.wantSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != tt.wantSummary {
+				t.Fatalf("diagnostics = %v, want one error %q", diags, tt.wantSummary)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package schemas
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 // Helper function to define an attribute that is Optional in Resource and Computed in DataSource
@@ -21,6 +26,30 @@ func optionalOrComputedBool(isDataSource bool, defaultValue bool) schema.Attribu
 	return schema.BoolAttribute{Optional: true, Default: booldefault.StaticBool(defaultValue), Computed: true}
 }
 
+// presetString defines a setting a propagation store `preset` can fill in. Resources plan it as
+// computed so that the preset's value can be planned when the setting is not configured.
+func presetString(isDataSource bool) schema.Attribute {
+	if isDataSource {
+		return schema.StringAttribute{Computed: true}
+	}
+	return schema.StringAttribute{Optional: true, Computed: true}
+}
+
+// secretRefString defines the name of a provider secret used instead of the sensitive attribute
+// named attribute. Data sources cannot tell which secret a store uses, so they report null.
+func secretRefString(isDataSource bool, attribute string) schema.Attribute {
+	if isDataSource {
+		return schema.StringAttribute{Computed: true}
+	}
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: fmt.Sprintf("The name of a provider `secrets` entry to send as `%s`. The secret's value is not stored in state; changing it in the provider plans an update of the store. Conflicts with `%s`.", attribute, attribute),
+		Validators: []validator.String{
+			stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName(attribute)),
+		},
+	}
+}
+
 // CHANGED: For Resources, we strictly use Optional: true to avoid validation errors
 // when the parent block is missing. Logic validation should handle missing required fields if the block is present.
 func requiredOrComputedString(isDataSource bool, sensitive bool) schema.Attribute {
@@ -46,13 +75,14 @@ func AqueraConfigSchema(isDataSource bool) schema.Block {
 			"api_key":               optionalOrComputedString(isDataSource, true),
 			"api_secret":            optionalOrComputedString(isDataSource, true),
 			"bearer_token":          optionalOrComputedString(isDataSource, true),
+			"bearer_token_ref":      secretRefString(isDataSource, "bearer_token"),
 			"domain":                optionalOrComputedString(isDataSource, false),
 			"username":              optionalOrComputedString(isDataSource, false),
 			"password":              optionalOrComputedString(isDataSource, true),
 			"create_users":          optionalOrComputedBool(isDataSource, true),
 			"disable_users":         optionalOrComputedBool(isDataSource, true),
-			"group_name_source":     optionalOrComputedString(isDataSource, false),
-			"remove_action":         optionalOrComputedString(isDataSource, false),
+			"group_name_source":     presetString(isDataSource),
+			"remove_action":         presetString(isDataSource),
 			"update_users":          optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -65,11 +95,12 @@ func AzureAdSamlV2ConfigSchema(isDataSource bool) schema.Block {
 			"base_url":          requiredOrComputedString(isDataSource, false),
 			"scim_url":          requiredOrComputedString(isDataSource, false),
 			"bearer_token":      optionalOrComputedString(isDataSource, true),
-			"group_name_source": optionalOrComputedString(isDataSource, false),
+			"bearer_token_ref":  secretRefString(isDataSource, "bearer_token"),
+			"group_name_source": presetString(isDataSource),
 			"create_users":      optionalOrComputedBool(isDataSource, true),
 			"deprovision_users": optionalOrComputedBool(isDataSource, true),
 			"disable_users":     optionalOrComputedBool(isDataSource, true),
-			"remove_action":     optionalOrComputedString(isDataSource, false),
+			"remove_action":     presetString(isDataSource),
 			"update_users":      optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -83,7 +114,7 @@ func GithubEmuConfigSchema(isDataSource bool) schema.Block {
 			"oauth_access_token": optionalOrComputedString(isDataSource, true),
 			"create_users":       optionalOrComputedBool(isDataSource, true),
 			"deprovision_users":  optionalOrComputedBool(isDataSource, true),
-			"remove_action":      optionalOrComputedString(isDataSource, false),
+			"remove_action":      presetString(isDataSource),
 			"update_users":       optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -103,8 +134,8 @@ func GoogleAppsConfigSchema(isDataSource bool) schema.Block {
 			"create_users":          optionalOrComputedBool(isDataSource, true),
 			"deprovision_users":     optionalOrComputedBool(isDataSource, true),
 			"disable_users":         optionalOrComputedBool(isDataSource, true),
-			"group_name_source":     optionalOrComputedString(isDataSource, false),
-			"remove_action":         optionalOrComputedString(isDataSource, false),
+			"group_name_source":     presetString(isDataSource),
+			"remove_action":         presetString(isDataSource),
 			"update_users":          optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -121,13 +152,14 @@ func LdapGatewayConfigSchema(isDataSource bool) schema.Block {
 			"api_key":               optionalOrComputedString(isDataSource, true),
 			"api_secret":            optionalOrComputedString(isDataSource, true),
 			"bearer_token":          optionalOrComputedString(isDataSource, true),
+			"bearer_token_ref":      secretRefString(isDataSource, "bearer_token"),
 			"username":              optionalOrComputedString(isDataSource, false),
 			"password":              optionalOrComputedString(isDataSource, true),
 			"create_users":          optionalOrComputedBool(isDataSource, true),
 			"deprovision_users":     optionalOrComputedBool(isDataSource, true),
 			"disable_users":         optionalOrComputedBool(isDataSource, true),
-			"group_name_source":     optionalOrComputedString(isDataSource, false),
-			"remove_action":         optionalOrComputedString(isDataSource, false),
+			"group_name_source":     presetString(isDataSource),
+			"remove_action":         presetString(isDataSource),
 			"update_users":          optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -141,6 +173,7 @@ func PingOneConfigSchema(isDataSource bool) schema.Block {
 			"base_url":              requiredOrComputedString(isDataSource, false),
 			"scim_url":              requiredOrComputedString(isDataSource, false),
 			"bearer_token":          optionalOrComputedString(isDataSource, true),
+			"bearer_token_ref":      secretRefString(isDataSource, "bearer_token"),
 			"oauth_client_id":       optionalOrComputedString(isDataSource, false),
 			"oauth_client_secret":   optionalOrComputedString(isDataSource, true),
 			"oauth_token_url":       optionalOrComputedString(isDataSource, false),
@@ -151,8 +184,8 @@ func PingOneConfigSchema(isDataSource bool) schema.Block {
 			"create_users":          optionalOrComputedBool(isDataSource, true),
 			"deprovision_users":     optionalOrComputedBool(isDataSource, true),
 			"disable_users":         optionalOrComputedBool(isDataSource, true),
-			"group_name_source":     optionalOrComputedString(isDataSource, false),
-			"remove_action":         optionalOrComputedString(isDataSource, false),
+			"group_name_source":     presetString(isDataSource),
+			"remove_action":         presetString(isDataSource),
 			"update_users":          optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -171,11 +204,12 @@ func SalesforceConfigSchema(isDataSource bool) schema.Block {
 			"password":              optionalOrComputedString(isDataSource, true),
 			"security_token":        optionalOrComputedString(isDataSource, true),
 			"bearer_token":          optionalOrComputedString(isDataSource, true),
+			"bearer_token_ref":      secretRefString(isDataSource, "bearer_token"),
 			"create_users":          optionalOrComputedBool(isDataSource, true),
 			"deprovision_users":     optionalOrComputedBool(isDataSource, true),
 			"disable_users":         optionalOrComputedBool(isDataSource, true),
-			"group_name_source":     optionalOrComputedString(isDataSource, false),
-			"remove_action":         optionalOrComputedString(isDataSource, false),
+			"group_name_source":     presetString(isDataSource),
+			"remove_action":         presetString(isDataSource),
 			"update_users":          optionalOrComputedBool(isDataSource, true),
 			"record_type":           optionalOrComputedString(isDataSource, false),
 		},
@@ -194,6 +228,20 @@ func ScimConfigSchema(isDataSource bool) schema.Block {
 	}
 }
 
+// ScimConfigDeprecatedAliasSchema defines the deprecated `scim_configuration` block, an alias of
+// `configuration_scim`.
+func ScimConfigDeprecatedAliasSchema(isDataSource bool) schema.Block {
+	message := "Use `configuration_scim` instead. `scim_configuration` will be removed in a future major version; existing state is migrated to `configuration_scim` automatically."
+	if isDataSource {
+		message = "Use `configuration_scim` instead. `scim_configuration` will be removed in a future major version."
+	}
+
+	return schema.SingleNestedBlock{
+		DeprecationMessage: message,
+		Attributes:         scimConfigAttributes(isDataSource),
+	}
+}
+
 // ScimConfigAttributeSchema defines the SCIM configuration as an attribute (HCL assignment syntax),
 // for compatibility with configurations that use `scim_configuration = { ... }`.
 func ScimConfigAttributeSchema(isDataSource bool) schema.Attribute {
@@ -218,13 +266,13 @@ func scimConfigAttributes(isDataSource bool) map[string]schema.Attribute {
 		"basic_auth_password":    optionalOrComputedString(isDataSource, true),
 		"create_users":           optionalOrComputedBool(isDataSource, true),
 		"disable_users":          optionalOrComputedBool(isDataSource, true),
-		"group_name_source":      optionalOrComputedString(isDataSource, false),
+		"group_name_source":      presetString(isDataSource),
 		"groups_resource":        optionalOrComputedString(isDataSource, false),
 		"oauth_access_token":     optionalOrComputedString(isDataSource, true),
 		"oauth_client_id":        optionalOrComputedString(isDataSource, true),
 		"oauth_client_secret":    optionalOrComputedString(isDataSource, true),
 		"oauth_token_request":    optionalOrComputedString(isDataSource, false),
-		"remove_action":          optionalOrComputedString(isDataSource, false),
+		"remove_action":          presetString(isDataSource),
 		"scim_url":               requiredOrComputedString(isDataSource, false),
 		"scim_version":           requiredOrComputedString(isDataSource, false),
 		"unique_user_identifier": requiredOrComputedString(isDataSource, false),
@@ -245,8 +293,8 @@ func ServiceNowConfigSchema(isDataSource bool) schema.Block {
 			"create_users":      optionalOrComputedBool(isDataSource, true),
 			"deprovision_users": optionalOrComputedBool(isDataSource, true),
 			"disable_users":     optionalOrComputedBool(isDataSource, true),
-			"group_name_source": optionalOrComputedString(isDataSource, false),
-			"remove_action":     optionalOrComputedString(isDataSource, false),
+			"group_name_source": presetString(isDataSource),
+			"remove_action":     presetString(isDataSource),
 			"update_users":      optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -259,11 +307,12 @@ func SlackConfigSchema(isDataSource bool) schema.Block {
 			"base_url":          requiredOrComputedString(isDataSource, false),
 			"scim_url":          requiredOrComputedString(isDataSource, false),
 			"bearer_token":      optionalOrComputedString(isDataSource, true),
+			"bearer_token_ref":  secretRefString(isDataSource, "bearer_token"),
 			"create_users":      optionalOrComputedBool(isDataSource, true),
 			"deprovision_users": optionalOrComputedBool(isDataSource, true),
 			"disable_users":     optionalOrComputedBool(isDataSource, true),
-			"group_name_source": optionalOrComputedString(isDataSource, false),
-			"remove_action":     optionalOrComputedString(isDataSource, false),
+			"group_name_source": presetString(isDataSource),
+			"remove_action":     presetString(isDataSource),
 			"update_users":      optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -284,8 +333,8 @@ func WorkdayConfigSchema(isDataSource bool) schema.Block {
 			"create_users":          optionalOrComputedBool(isDataSource, true),
 			"deprovision_users":     optionalOrComputedBool(isDataSource, true),
 			"disable_users":         optionalOrComputedBool(isDataSource, true),
-			"group_name_source":     optionalOrComputedString(isDataSource, false),
-			"remove_action":         optionalOrComputedString(isDataSource, false),
+			"group_name_source":     presetString(isDataSource),
+			"remove_action":         presetString(isDataSource),
 			"update_users":          optionalOrComputedBool(isDataSource, true),
 		},
 	}
@@ -303,7 +352,7 @@ func ZoomConfigSchema(isDataSource bool) schema.Block {
 		"oauth_client_id":     optionalOrComputedString(isDataSource, false),
 		"oauth_client_secret": optionalOrComputedString(isDataSource, true),
 		"oauth_token_url":     optionalOrComputedString(isDataSource, false),
-		"remove_action":       optionalOrComputedString(isDataSource, false),
+		"remove_action":       presetString(isDataSource),
 		"scim_url":            requiredOrComputedString(isDataSource, false),
 		"update_users":        optionalOrComputedBool(isDataSource, true),
 	}
@@ -322,3 +371,27 @@ func ZoomConfigSchema(isDataSource bool) schema.Block {
 		Attributes: attrs,
 	}
 }
+
+// CredentialsSchema defines the resource's `credentials` block, which has an attribute for each
+// sensitive attribute of configurationBlocks. It lets credentials be managed, or left to
+// `ignore_changes`, apart from the rest of a store's configuration.
+func CredentialsSchema(configurationBlocks map[string]schema.Block) schema.Block {
+	attrs := map[string]schema.Attribute{}
+	for _, block := range configurationBlocks {
+		for name, attribute := range block.GetNestedObject().GetAttributes() {
+			if !attribute.IsSensitive() {
+				continue
+			}
+			attrs[name] = schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Sent as `%s` of the store's configuration block, which must have that attribute. Conflicts with the block's `%s`.", name, name),
+			}
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description: "Credentials for the store's configuration block, kept apart from its other settings so that `lifecycle { ignore_changes = [credentials] }` can leave credentials rotated outside Terraform alone. Values set here are not recorded in the configuration block's state.",
+		Attributes:  attrs,
+	}
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = CaseInsensitiveStringType{}
+	_ basetypes.StringValuableWithSemanticEquals = CaseInsensitiveStringValue{}
+)
+
+// CaseInsensitiveStringType is a string type whose values compare case-insensitively. It is
+// used for identifiers such as GitHub enterprise and organization slugs, which the API treats
+// as case-insensitive and may return with different casing than configured.
+type CaseInsensitiveStringType struct {
+	basetypes.StringType
+}
+
+func (t CaseInsensitiveStringType) Equal(o attr.Type) bool {
+	other, ok := o.(CaseInsensitiveStringType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t CaseInsensitiveStringType) String() string {
+	return "CaseInsensitiveStringType"
+}
+
+func (t CaseInsensitiveStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return CaseInsensitiveStringValue{StringValue: in}, nil
+}
+
+func (t CaseInsensitiveStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return CaseInsensitiveStringValue{StringValue: stringValue}, nil
+}
+
+func (t CaseInsensitiveStringType) ValueType(_ context.Context) attr.Value {
+	return CaseInsensitiveStringValue{}
+}
+
+// CaseInsensitiveStringValue is the value of a CaseInsensitiveStringType. A refreshed value
+// that differs from the prior value only by case keeps the prior value, so it never shows as
+// a change.
+type CaseInsensitiveStringValue struct {
+	basetypes.StringValue
+}
+
+func (v CaseInsensitiveStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(CaseInsensitiveStringValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v CaseInsensitiveStringValue) Type(_ context.Context) attr.Type {
+	return CaseInsensitiveStringType{}
+}
+
+func (v CaseInsensitiveStringValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(CaseInsensitiveStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return strings.EqualFold(v.ValueString(), newValue.ValueString()), diags
+}
+
+// NewCaseInsensitiveStringValue returns a known CaseInsensitiveStringValue.
+func NewCaseInsensitiveStringValue(value string) CaseInsensitiveStringValue {
+	return CaseInsensitiveStringValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewCaseInsensitiveStringNull returns a null CaseInsensitiveStringValue.
+func NewCaseInsensitiveStringNull() CaseInsensitiveStringValue {
+	return CaseInsensitiveStringValue{StringValue: basetypes.NewStringNull()}
+}
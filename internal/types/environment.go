@@ -0,0 +1,44 @@
+package types
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// EnvironmentModel describes a PingOne environment for data sources.
+type EnvironmentModel struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	Type         types.String `tfsdk:"type"`
+	Region       types.String `tfsdk:"region"`
+	LicenseId    types.String `tfsdk:"license_id"`
+	SolutionType types.String `tfsdk:"solution_type"`
+}
+
+var EnvironmentModelAttrTypes = map[string]attr.Type{
+	"id":            types.StringType,
+	"name":          types.StringType,
+	"description":   types.StringType,
+	"type":          types.StringType,
+	"region":        types.StringType,
+	"license_id":    types.StringType,
+	"solution_type": types.StringType,
+}
+
+// EnvironmentModelType returns the object type for environment list elements.
+func EnvironmentModelType() attr.Type {
+	return types.ObjectType{AttrTypes: EnvironmentModelAttrTypes}
+}
+
+// EnvironmentResourceIdentityModel is the resource identity of a resource addressed by its
+// environment and ID.
+type EnvironmentResourceIdentityModel struct {
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	Id            types.String `tfsdk:"id"`
+}
+
+// EnvironmentIdentityModel is the resource identity of a resource an environment has only one of.
+type EnvironmentIdentityModel struct {
+	EnvironmentId types.String `tfsdk:"environment_id"`
+}
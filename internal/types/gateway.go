@@ -0,0 +1,14 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// GatewayModel describes the Terraform model for a PingOne gateway lookup.
+type GatewayModel struct {
+	Id            types.String `tfsdk:"id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	Description   types.String `tfsdk:"description"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Region        types.String `tfsdk:"region"`
+}
@@ -0,0 +1,29 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// EnterpriseTeamOrganizationsModel describes the organizations assigned to a GitHub enterprise team.
+type EnterpriseTeamOrganizationsModel struct {
+	Id            types.String               `tfsdk:"id"`
+	Enterprise    CaseInsensitiveStringValue `tfsdk:"enterprise"`
+	TeamSlug      CaseInsensitiveStringValue `tfsdk:"team_slug"`
+	TeamId        types.Int64                `tfsdk:"team_id"`
+	Organizations types.Set                  `tfsdk:"organizations"`
+	Authoritative types.Bool                 `tfsdk:"authoritative"`
+}
+
+// EnterpriseTeamOrganizationsIdentityModel is the resource identity of an enterprise team's
+// organization assignments.
+type EnterpriseTeamOrganizationsIdentityModel struct {
+	Enterprise types.String `tfsdk:"enterprise"`
+	TeamSlug   types.String `tfsdk:"team_slug"`
+}
+
+// EnterpriseTeamExternalGroupModel describes the IdP group a GitHub enterprise team is linked to.
+type EnterpriseTeamExternalGroupModel struct {
+	Id         types.String               `tfsdk:"id"`
+	Enterprise CaseInsensitiveStringValue `tfsdk:"enterprise"`
+	TeamSlug   CaseInsensitiveStringValue `tfsdk:"team_slug"`
+	TeamId     types.Int64                `tfsdk:"team_id"`
+	GroupId    types.String               `tfsdk:"group_id"`
+}
@@ -0,0 +1,20 @@
+package types
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// GroupMembershipModel describes the Terraform model for a single PingOne group membership.
+type GroupMembershipModel struct {
+	Id            types.String `tfsdk:"id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	UserId        types.String `tfsdk:"user_id"`
+	GroupId       types.String `tfsdk:"group_id"`
+}
+
+// GroupMembershipsModel describes the Terraform model for a set of users managed as members of
+// one PingOne group.
+type GroupMembershipsModel struct {
+	Id            types.String `tfsdk:"id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	GroupId       types.String `tfsdk:"group_id"`
+	UserIds       types.Set    `tfsdk:"user_ids"`
+}
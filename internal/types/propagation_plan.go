@@ -4,8 +4,17 @@ import "github.com/hashicorp/terraform-plugin-framework/types"
 
 // PropagationPlanModel describes the Terraform model for a PingOne propagation plan.
 type PropagationPlanModel struct {
-	Id            types.String `tfsdk:"id"`
-	EnvironmentId types.String `tfsdk:"environment_id"`
-	Name          types.String `tfsdk:"name"`
-	Status        types.String `tfsdk:"status"`
+	Id              types.String `tfsdk:"id"`
+	EnvironmentId   types.String `tfsdk:"environment_id"`
+	Name            types.String `tfsdk:"name"`
+	Status          types.String `tfsdk:"status"`
+	RuleCount       types.Int64  `tfsdk:"rule_count"`
+	LifecycleStatus types.String `tfsdk:"lifecycle_status"`
+}
+
+// PropagationDefaultPlanModel describes the Terraform model for an environment's propagation
+// plan managed as a singleton.
+type PropagationDefaultPlanModel struct {
+	PropagationPlanModel
+	DeleteOnDestroy types.Bool `tfsdk:"delete_on_destroy"`
 }
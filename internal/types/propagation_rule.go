@@ -1,28 +1,165 @@
 package types
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 // PropagationRuleMappingModel describes a single propagation mapping for a rule.
 type PropagationRuleMappingModel struct {
+	Id                  types.String                                  `tfsdk:"id"`
+	SourceAttribute     types.String                                  `tfsdk:"source_attribute"`
+	TargetAttribute     types.String                                  `tfsdk:"target_attribute"`
+	Expression          types.String                                  `tfsdk:"expression"`
+	SensitiveExpression types.String                                  `tfsdk:"sensitive_expression"`
+	ExpressionBuilder   *PropagationRuleMappingExpressionBuilderModel `tfsdk:"expression_builder"`
+	Enabled             types.Bool                                    `tfsdk:"enabled"`
+}
+
+// PropagationRuleMappingExpressionBuilderModel describes a mapping expression as structured
+// arguments that the provider compiles into the PingOne expression syntax. Exactly one field is
+// set.
+type PropagationRuleMappingExpressionBuilderModel struct {
+	Concat    []PropagationRuleMappingExpressionOperandModel  `tfsdk:"concat"`
+	Lower     *PropagationRuleMappingExpressionOperandModel   `tfsdk:"lower"`
+	Upper     *PropagationRuleMappingExpressionOperandModel   `tfsdk:"upper"`
+	Substring *PropagationRuleMappingExpressionSubstringModel `tfsdk:"substring"`
+	Default   *PropagationRuleMappingExpressionDefaultModel   `tfsdk:"default"`
+}
+
+// PropagationRuleMappingExpressionOperandModel is a source attribute or a string literal.
+type PropagationRuleMappingExpressionOperandModel struct {
+	Attribute types.String `tfsdk:"attribute"`
+	Literal   types.String `tfsdk:"literal"`
+}
+
+// PropagationRuleMappingExpressionSubstringModel takes part of a source attribute.
+type PropagationRuleMappingExpressionSubstringModel struct {
+	Attribute types.String `tfsdk:"attribute"`
+	Start     types.Int64  `tfsdk:"start"`
+	End       types.Int64  `tfsdk:"end"`
+}
+
+// PropagationRuleMappingExpressionDefaultModel uses a source attribute, or a fixed value when
+// the attribute is not set.
+type PropagationRuleMappingExpressionDefaultModel struct {
+	Attribute types.String `tfsdk:"attribute"`
+	Value     types.String `tfsdk:"value"`
+}
+
+// PropagationRuleModel describes the Terraform model for a PingOne propagation rule.
+type PropagationRuleModel struct {
+	Id                    types.String                  `tfsdk:"id"`
+	EnvironmentId         types.String                  `tfsdk:"environment_id"`
+	PlanId                types.String                  `tfsdk:"plan_id"`
+	Name                  types.String                  `tfsdk:"name"`
+	Description           types.String                  `tfsdk:"description"`
+	SourceStoreId         types.String                  `tfsdk:"source_store_id"`
+	TargetStoreId         types.String                  `tfsdk:"target_store_id"`
+	Active                types.Bool                    `tfsdk:"active"`
+	Filter                types.String                  `tfsdk:"filter"`
+	Deprovision           types.Bool                    `tfsdk:"deprovision"`
+	PopulationIds         types.Set                     `tfsdk:"population_ids"`
+	PopulationMatch       types.String                  `tfsdk:"population_match"`
+	GroupIds              types.Set                     `tfsdk:"group_ids"`
+	Configuration         types.Map                     `tfsdk:"configuration"`
+	Links                 types.Map                     `tfsdk:"links"`
+	Mappings              []PropagationRuleMappingModel `tfsdk:"mappings"`
+	MappingCount          types.Int64                   `tfsdk:"mapping_count"`
+	CorrelationAttributes types.List                    `tfsdk:"correlation_attributes"`
+}
+
+// PropagationRuleCorrelationAttributeModel pairs a source attribute with the target attribute
+// PingOne compares it to when matching a user to an existing user in the target store.
+type PropagationRuleCorrelationAttributeModel struct {
+	SourceAttribute types.String `tfsdk:"source_attribute"`
+	TargetAttribute types.String `tfsdk:"target_attribute"`
+}
+
+// PropagationRuleCorrelationAttributeAttrTypes describes PropagationRuleCorrelationAttributeModel.
+var PropagationRuleCorrelationAttributeAttrTypes = map[string]attr.Type{
+	"source_attribute": types.StringType,
+	"target_attribute": types.StringType,
+}
+
+// PropagationRuleResourceModel extends PropagationRuleModel with arguments that only apply
+// to the propagation rule resource.
+type PropagationRuleResourceModel struct {
+	PropagationRuleModel
+	ExternalMappings      types.Bool   `tfsdk:"external_mappings"`
+	AuthoritativeMappings types.Bool   `tfsdk:"authoritative_mappings"`
+	UnmanagedMappings     types.List   `tfsdk:"unmanaged_mappings"`
+	ApiHostname           types.String `tfsdk:"api_hostname"`
+	PopulationExpression  types.String `tfsdk:"population_expression"`
+	MappingsCsv           types.String `tfsdk:"mappings_csv"`
+	UpdateStrategy        types.String `tfsdk:"update_strategy"`
+	ActivateAfter         types.String `tfsdk:"activate_after"`
+	DeactivateAfter       types.String `tfsdk:"deactivate_after"`
+	LifecycleStatus       types.String `tfsdk:"lifecycle_status"`
+}
+
+// PropagationRuleUnmanagedMappingModel describes a mapping on a rule that is not in the rule's
+// configured mappings.
+type PropagationRuleUnmanagedMappingModel struct {
 	Id              types.String `tfsdk:"id"`
 	SourceAttribute types.String `tfsdk:"source_attribute"`
 	TargetAttribute types.String `tfsdk:"target_attribute"`
 	Expression      types.String `tfsdk:"expression"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
 }
 
-// PropagationRuleModel describes the Terraform model for a PingOne propagation rule.
-type PropagationRuleModel struct {
-	Id            types.String                  `tfsdk:"id"`
-	EnvironmentId types.String                  `tfsdk:"environment_id"`
-	PlanId        types.String                  `tfsdk:"plan_id"`
-	Name          types.String                  `tfsdk:"name"`
-	SourceStoreId types.String                  `tfsdk:"source_store_id"`
-	TargetStoreId types.String                  `tfsdk:"target_store_id"`
-	Active        types.Bool                    `tfsdk:"active"`
-	Filter        types.String                  `tfsdk:"filter"`
-	Deprovision   types.Bool                    `tfsdk:"deprovision"`
-	PopulationIds types.List                    `tfsdk:"population_ids"`
-	GroupIds      types.List                    `tfsdk:"group_ids"`
-	Configuration types.Map                     `tfsdk:"configuration"`
-	Mappings      []PropagationRuleMappingModel `tfsdk:"mappings"`
+// PropagationRuleUnmanagedMappingAttrTypes describes PropagationRuleUnmanagedMappingModel.
+var PropagationRuleUnmanagedMappingAttrTypes = map[string]attr.Type{
+	"id":               types.StringType,
+	"source_attribute": types.StringType,
+	"target_attribute": types.StringType,
+	"expression":       types.StringType,
+	"enabled":          types.BoolType,
+}
+
+// PropagationRuleSetMappingModel describes a mapping in a propagation rule set's shared
+// mapping template.
+type PropagationRuleSetMappingModel struct {
+	SourceAttribute     types.String `tfsdk:"source_attribute"`
+	TargetAttribute     types.String `tfsdk:"target_attribute"`
+	Expression          types.String `tfsdk:"expression"`
+	SensitiveExpression types.String `tfsdk:"sensitive_expression"`
+}
+
+// PropagationRuleSetModel describes the Terraform model for a set of propagation rules that
+// share one source store and template, with one rule per target store.
+type PropagationRuleSetModel struct {
+	Id              types.String                     `tfsdk:"id"`
+	EnvironmentId   types.String                     `tfsdk:"environment_id"`
+	PlanId          types.String                     `tfsdk:"plan_id"`
+	Name            types.String                     `tfsdk:"name"`
+	SourceStoreId   types.String                     `tfsdk:"source_store_id"`
+	TargetStoreIds  types.Set                        `tfsdk:"target_store_ids"`
+	Active          types.Bool                       `tfsdk:"active"`
+	Filter          types.String                     `tfsdk:"filter"`
+	Deprovision     types.Bool                       `tfsdk:"deprovision"`
+	PopulationIds   types.Set                        `tfsdk:"population_ids"`
+	PopulationMatch types.String                     `tfsdk:"population_match"`
+	GroupIds        types.Set                        `tfsdk:"group_ids"`
+	Configuration   types.Map                        `tfsdk:"configuration"`
+	Mappings        []PropagationRuleSetMappingModel `tfsdk:"mappings"`
+	Rules           types.Map                        `tfsdk:"rules"`
+}
+
+// PropagationRuleSetRuleModel describes the rule a propagation rule set manages for one target
+// store.
+type PropagationRuleSetRuleModel struct {
+	Id     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Active types.Bool   `tfsdk:"active"`
+	Status types.String `tfsdk:"status"`
+}
+
+// PropagationRuleSetRuleAttrTypes describes the per-target rule status reported by a
+// propagation rule set.
+var PropagationRuleSetRuleAttrTypes = map[string]attr.Type{
+	"id":     types.StringType,
+	"name":   types.StringType,
+	"active": types.BoolType,
+	"status": types.StringType,
 }
@@ -12,11 +12,13 @@ type PropagationStoreModel struct {
 	Name                            types.String                     `tfsdk:"name"`
 	Description                     types.String                     `tfsdk:"description"`
 	Type                            types.String                     `tfsdk:"type"`
+	ProvisioningDirection           types.String                     `tfsdk:"provisioning_direction"`
 	ImageId                         types.String                     `tfsdk:"image_id"`
 	ImageHref                       types.String                     `tfsdk:"image_href"`
 	Managed                         types.Bool                       `tfsdk:"managed"`
 	Status                          types.String                     `tfsdk:"status"`
 	SyncStatus                      types.Object                     `tfsdk:"sync_status"`
+	Links                           types.Map                        `tfsdk:"links"`
 	ConfigurationAquera             *ConfigurationAquera             `tfsdk:"configuration_aquera"`
 	ConfigurationAzureAdSamlV2      *ConfigurationAzureAdSamlV2      `tfsdk:"configuration_azure_ad_saml_v2"`
 	ConfigurationGithubEmu          *ConfigurationGithubEmu          `tfsdk:"configuration_github_emu"`
@@ -33,11 +35,60 @@ type PropagationStoreModel struct {
 	ConfigurationZoom               *ConfigurationZoom               `tfsdk:"configuration_zoom"`
 }
 
+// PropagationStoreResourceModel extends PropagationStoreModel with arguments that only apply to
+// the propagation store resource.
+type PropagationStoreResourceModel struct {
+	PropagationStoreModel
+	DisableInsteadOfDelete types.Bool   `tfsdk:"disable_instead_of_delete"`
+	SecretRefsSha256       types.String `tfsdk:"secret_refs_sha256"`
+	Preset                 types.String `tfsdk:"preset"`
+	LifecycleStatus        types.String `tfsdk:"lifecycle_status"`
+	Credentials            types.Object `tfsdk:"credentials"`
+}
+
+// PropagationStoreDataSourceModel extends PropagationStoreModel with arguments that only apply
+// to the propagation store data source.
+type PropagationStoreDataSourceModel struct {
+	PropagationStoreModel
+	RedactSecrets         types.Bool   `tfsdk:"redact_secrets"`
+	SecretsPlaceholder    types.String `tfsdk:"secrets_placeholder"`
+	PortableConfiguration types.String `tfsdk:"portable_configuration"`
+	ConfigurationRaw      types.Map    `tfsdk:"configuration_raw"`
+	EndpointUrl           types.String `tfsdk:"endpoint_url"`
+}
+
+// PropagationStoreMirrorModel describes the resource data model of a propagation store created
+// from the portable configuration of a store in another environment.
+type PropagationStoreMirrorModel struct {
+	Id                    types.String `tfsdk:"id"`
+	EnvironmentId         types.String `tfsdk:"environment_id"`
+	PortableConfiguration types.String `tfsdk:"portable_configuration"`
+	Name                  types.String `tfsdk:"name"`
+	Secrets               types.Map    `tfsdk:"secrets"`
+	Type                  types.String `tfsdk:"type"`
+	Status                types.String `tfsdk:"status"`
+}
+
+// PropagationStorePingOneCredentialsModel describes the resource data model of the worker
+// application a PingOne store uses to provision into its target environment.
+type PropagationStorePingOneCredentialsModel struct {
+	Id                   types.String `tfsdk:"id"`
+	EnvironmentId        types.String `tfsdk:"environment_id"`
+	Name                 types.String `tfsdk:"name"`
+	RoleAssignmentId     types.String `tfsdk:"role_assignment_id"`
+	AuthenticationMethod types.String `tfsdk:"authentication_method"`
+	BaseUrl              types.String `tfsdk:"base_url"`
+	ScimUrl              types.String `tfsdk:"scim_url"`
+	OauthClientId        types.String `tfsdk:"oauth_client_id"`
+	OauthClientSecret    types.String `tfsdk:"oauth_client_secret"`
+	OauthTokenUrl        types.String `tfsdk:"oauth_token_url"`
+}
+
 var SyncStatusAttrTypes = map[string]attr.Type{
 	"last_sync_time": types.StringType,
-	"next_sync_time": types.StringType,
 	"status":         types.StringType,
 	"details":        types.StringType,
+	"last_error":     types.StringType,
 }
 
 // --- Configuration Structs ---
@@ -48,6 +99,7 @@ type ConfigurationAquera struct {
 	ApiKey               types.String `tfsdk:"api_key"`
 	ApiSecret            types.String `tfsdk:"api_secret"`
 	BearerToken          types.String `tfsdk:"bearer_token"`
+	BearerTokenRef       types.String `tfsdk:"bearer_token_ref"`
 	Domain               types.String `tfsdk:"domain"`
 	Username             types.String `tfsdk:"username"`
 	Password             types.String `tfsdk:"password"`
@@ -62,6 +114,7 @@ type ConfigurationAzureAdSamlV2 struct {
 	BaseUrl          types.String `tfsdk:"base_url"`
 	ScimUrl          types.String `tfsdk:"scim_url"`
 	BearerToken      types.String `tfsdk:"bearer_token"`
+	BearerTokenRef   types.String `tfsdk:"bearer_token_ref"`
 	GroupNameSource  types.String `tfsdk:"group_name_source"`
 	CreateUsers      types.Bool   `tfsdk:"create_users"`
 	DeprovisionUsers types.Bool   `tfsdk:"deprovision_users"`
@@ -103,6 +156,7 @@ type ConfigurationLdapGateway struct {
 	ApiKey               types.String `tfsdk:"api_key"`
 	ApiSecret            types.String `tfsdk:"api_secret"`
 	BearerToken          types.String `tfsdk:"bearer_token"`
+	BearerTokenRef       types.String `tfsdk:"bearer_token_ref"`
 	Username             types.String `tfsdk:"username"`
 	Password             types.String `tfsdk:"password"`
 	CreateUsers          types.Bool   `tfsdk:"create_users"`
@@ -118,6 +172,7 @@ type ConfigurationPingOne struct {
 	BaseUrl              types.String `tfsdk:"base_url"`
 	ScimUrl              types.String `tfsdk:"scim_url"`
 	BearerToken          types.String `tfsdk:"bearer_token"`
+	BearerTokenRef       types.String `tfsdk:"bearer_token_ref"`
 	OauthClientId        types.String `tfsdk:"oauth_client_id"`
 	OauthClientSecret    types.String `tfsdk:"oauth_client_secret"`
 	OauthTokenUrl        types.String `tfsdk:"oauth_token_url"`
@@ -143,6 +198,7 @@ type ConfigurationSalesforce struct {
 	Password             types.String `tfsdk:"password"`
 	SecurityToken        types.String `tfsdk:"security_token"`
 	BearerToken          types.String `tfsdk:"bearer_token"`
+	BearerTokenRef       types.String `tfsdk:"bearer_token_ref"`
 	CreateUsers          types.Bool   `tfsdk:"create_users"`
 	DeprovisionUsers     types.Bool   `tfsdk:"deprovision_users"`
 	DisableUsers         types.Bool   `tfsdk:"disable_users"`
@@ -162,6 +218,7 @@ type ConfigurationSalesforceContacts struct {
 	Password             types.String `tfsdk:"password"`
 	SecurityToken        types.String `tfsdk:"security_token"`
 	BearerToken          types.String `tfsdk:"bearer_token"`
+	BearerTokenRef       types.String `tfsdk:"bearer_token_ref"`
 	CreateUsers          types.Bool   `tfsdk:"create_users"`
 	DeprovisionUsers     types.Bool   `tfsdk:"deprovision_users"`
 	DisableUsers         types.Bool   `tfsdk:"disable_users"`
@@ -210,6 +267,7 @@ type ConfigurationSlack struct {
 	BaseUrl          types.String `tfsdk:"base_url"`
 	ScimUrl          types.String `tfsdk:"scim_url"`
 	BearerToken      types.String `tfsdk:"bearer_token"`
+	BearerTokenRef   types.String `tfsdk:"bearer_token_ref"`
 	CreateUsers      types.Bool   `tfsdk:"create_users"`
 	DeprovisionUsers types.Bool   `tfsdk:"deprovision_users"`
 	DisableUsers     types.Bool   `tfsdk:"disable_users"`
@@ -260,11 +318,13 @@ func PropagationStoreModelType() types.ObjectType {
 			"name":                              types.StringType,
 			"description":                       types.StringType,
 			"type":                              types.StringType,
+			"provisioning_direction":            types.StringType,
 			"image_id":                          types.StringType,
 			"image_href":                        types.StringType,
 			"managed":                           types.BoolType,
 			"status":                            types.StringType,
 			"sync_status":                       types.ObjectType{AttrTypes: SyncStatusAttrTypes},
+			"links":                             types.MapType{ElemType: types.StringType},
 			"configuration_aquera":              configurationAqueraAttrType(),
 			"configuration_azure_ad_saml_v2":    configurationAzureADSAMLAttrType(),
 			"configuration_github_emu":          configurationGithubEMUAttrType(),
@@ -291,6 +351,7 @@ func configurationAqueraAttrType() types.ObjectType {
 			"api_key":               types.StringType,
 			"api_secret":            types.StringType,
 			"bearer_token":          types.StringType,
+			"bearer_token_ref":      types.StringType,
 			"domain":                types.StringType,
 			"username":              types.StringType,
 			"password":              types.StringType,
@@ -309,6 +370,7 @@ func configurationAzureADSAMLAttrType() types.ObjectType {
 			"base_url":          types.StringType,
 			"scim_url":          types.StringType,
 			"bearer_token":      types.StringType,
+			"bearer_token_ref":  types.StringType,
 			"group_name_source": types.StringType,
 			"create_users":      types.BoolType,
 			"deprovision_users": types.BoolType,
@@ -362,6 +424,7 @@ func configurationLdapGatewayAttrType() types.ObjectType {
 			"api_key":               types.StringType,
 			"api_secret":            types.StringType,
 			"bearer_token":          types.StringType,
+			"bearer_token_ref":      types.StringType,
 			"username":              types.StringType,
 			"password":              types.StringType,
 			"create_users":          types.BoolType,
@@ -381,6 +444,7 @@ func configurationPingOneAttrType() types.ObjectType {
 			"base_url":              types.StringType,
 			"scim_url":              types.StringType,
 			"bearer_token":          types.StringType,
+			"bearer_token_ref":      types.StringType,
 			"oauth_client_id":       types.StringType,
 			"oauth_client_secret":   types.StringType,
 			"oauth_token_url":       types.StringType,
@@ -410,6 +474,7 @@ func configurationSalesforceAttrType() types.ObjectType {
 			"password":              types.StringType,
 			"security_token":        types.StringType,
 			"bearer_token":          types.StringType,
+			"bearer_token_ref":      types.StringType,
 			"create_users":          types.BoolType,
 			"deprovision_users":     types.BoolType,
 			"disable_users":         types.BoolType,
@@ -470,6 +535,7 @@ func configurationSlackAttrType() types.ObjectType {
 			"base_url":          types.StringType,
 			"scim_url":          types.StringType,
 			"bearer_token":      types.StringType,
+			"bearer_token_ref":  types.StringType,
 			"create_users":      types.BoolType,
 			"deprovision_users": types.BoolType,
 			"disable_users":     types.BoolType,
@@ -1,9 +1,7 @@
 package utils
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -14,15 +12,15 @@ import (
 // ReadError processes the HTTP response to extract error details
 func ReadError(resp *http.Response, err error) string {
 	if resp == nil {
-		return err.Error()
+		return DescribeRequestError(err)
 	}
 
-	bodyBytes, readErr := io.ReadAll(resp.Body)
+	bodyBytes, readErr := ReadAndRestoreResponseBody(resp)
 	if readErr != nil {
 		return fmt.Sprintf("%s (failed to read response body: %s)", err, readErr)
 	}
 
-	return fmt.Sprintf("%s: %s", err, string(bodyBytes))
+	return fmt.Sprintf("%s: %s", err, ResponseBodyForError(bodyBytes))
 }
 
 // SplitImportID is a helper to split import IDs
@@ -95,14 +93,17 @@ func FromMapBool(m map[string]interface{}, key string) types.Bool {
 // HandleSDKError provides a consistent error message from SDK failures.
 func HandleSDKError(err error, resp *http.Response) string {
 	if resp == nil {
-		return err.Error()
+		return DescribeRequestError(err)
 	}
 
-	bodyBytes, readErr := io.ReadAll(resp.Body)
+	bodyBytes, readErr := ReadAndRestoreResponseBody(resp)
 	if readErr != nil {
 		return fmt.Sprintf("%s (failed to read response body: %s)", err, readErr)
 	}
-	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 
-	return fmt.Sprintf("%s: %s", err, string(bodyBytes))
+	body := ResponseBodyForError(bodyBytes)
+	if IsProvisioningNotEnabled(resp) {
+		return fmt.Sprintf("%s: %s (%s)", err, body, ProvisioningNotEnabledHint)
+	}
+	return fmt.Sprintf("%s: %s", err, body)
 }
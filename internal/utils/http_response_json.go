@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"unicode/utf8"
 )
 
 // ReadAndRestoreResponseBody reads the response body and restores it so it can be read again.
@@ -23,9 +25,79 @@ func ReadAndRestoreResponseBody(resp *http.Response) ([]byte, error) {
 	return bodyBytes, nil
 }
 
+// responseBodySnippetLimit caps how much of a response body that is not JSON is quoted in an
+// error, so that an HTML error page does not flood the diagnostics.
+const responseBodySnippetLimit = 512
+
+// UnexpectedResponseError reports a response body that is not the JSON the provider expected,
+// for example an HTML error page from a proxy or web application firewall in front of the API.
+type UnexpectedResponseError struct {
+	// Reason describes what was wrong with the body.
+	Reason string
+	// StatusCode is the HTTP status code of the response, or 0 when it is not known.
+	StatusCode int
+	// ContentType is the response's Content-Type header, or the type detected from the body
+	// when the header is missing.
+	ContentType string
+	// Snippet is the start of the body, truncated to a few hundred bytes.
+	Snippet string
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	snippet := e.Snippet
+	if snippet == "" {
+		snippet = "empty body"
+	}
+	return fmt.Sprintf("%s (HTTP %d, %s): %s", e.Reason, e.StatusCode, e.ContentType, snippet)
+}
+
+func newUnexpectedResponseError(resp *http.Response, bodyBytes []byte, reason string) *UnexpectedResponseError {
+	e := &UnexpectedResponseError{
+		Reason:  reason,
+		Snippet: ResponseBodySnippet(bodyBytes),
+	}
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+		e.ContentType = resp.Header.Get("Content-Type")
+	}
+	if e.ContentType == "" {
+		e.ContentType = "no content type"
+		if len(bodyBytes) > 0 {
+			e.ContentType = http.DetectContentType(bodyBytes)
+		}
+	}
+	return e
+}
+
+// ResponseBodySnippet returns the body for use in an error message, trimmed and truncated to
+// a few hundred bytes.
+func ResponseBodySnippet(bodyBytes []byte) string {
+	body := bytes.TrimSpace(bodyBytes)
+	if len(body) <= responseBodySnippetLimit {
+		return string(body)
+	}
+
+	// Cut on a rune boundary so that the snippet stays valid UTF-8.
+	cut := responseBodySnippetLimit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", body[:cut], len(body)-cut)
+}
+
+// ResponseBodyForError returns the body to quote in an error: JSON bodies in full, since they
+// carry PingOne's error details, and anything else as a snippet.
+func ResponseBodyForError(bodyBytes []byte) string {
+	if json.Valid(bodyBytes) {
+		return string(bodyBytes)
+	}
+	return ResponseBodySnippet(bodyBytes)
+}
+
 // DecodeResponseJSON reads, restores, and JSON-decodes the response body.
 //
-// If the response has an empty body, this returns (nil, nil).
+// If the response has an empty body, this returns (nil, nil). A body that is not JSON returns
+// an *UnexpectedResponseError.
 func DecodeResponseJSON(resp *http.Response) (any, error) {
 	bodyBytes, err := ReadAndRestoreResponseBody(resp)
 	if err != nil {
@@ -38,12 +110,48 @@ func DecodeResponseJSON(resp *http.Response) (any, error) {
 
 	var decoded any
 	if err := json.Unmarshal(bodyBytes, &decoded); err != nil {
-		return nil, err
+		return nil, newUnexpectedResponseError(resp, bodyBytes, "response is not valid JSON")
 	}
 
 	return decoded, nil
 }
 
+// DecodeResponseJSONObject decodes a response body that must be a JSON object, such as a single
+// PingOne resource. what names the resource in the error, for example "rule".
+func DecodeResponseJSONObject(resp *http.Response, what string) (map[string]interface{}, error) {
+	decoded, err := DecodeResponseJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := decoded.(map[string]interface{})
+	if !ok {
+		bodyBytes, _ := ReadAndRestoreResponseBody(resp)
+		return nil, newUnexpectedResponseError(resp, bodyBytes, fmt.Sprintf("unexpected %s response shape", what))
+	}
+
+	return obj, nil
+}
+
+// DecodeResponseJSONInto reads, restores, and JSON-decodes the response body into v. A body
+// that is not JSON, or does not match v, returns an *UnexpectedResponseError.
+func DecodeResponseJSONInto(resp *http.Response, v any) error {
+	bodyBytes, err := ReadAndRestoreResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(bodyBytes, v); err != nil {
+		reason := "response is not valid JSON"
+		if json.Valid(bodyBytes) {
+			reason = fmt.Sprintf("unexpected response shape: %s", err)
+		}
+		return newUnexpectedResponseError(resp, bodyBytes, reason)
+	}
+
+	return nil
+}
+
 // ExtractEmbeddedArray attempts to extract a list payload from common PingOne list response shapes.
 //
 // Supports:
@@ -83,26 +191,3 @@ func ExtractEmbeddedArray(decoded any, embeddedKeys ...string) ([]interface{}, e
 
 	return nil, errors.New("embedded list not found in response")
 }
-
-func NestedString(m map[string]interface{}, keys ...string) (string, bool) {
-	if len(keys) == 0 {
-		return "", false
-	}
-
-	var current any = m
-	for _, key := range keys {
-		obj, ok := current.(map[string]interface{})
-		if !ok {
-			return "", false
-		}
-
-		val, ok := obj[key]
-		if !ok || val == nil {
-			return "", false
-		}
-		current = val
-	}
-
-	s, ok := current.(string)
-	return s, ok
-}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newTestResponse(statusCode int, contentType string, body string) *http.Response {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestDecodeResponseJSON_NonJSONBody(t *testing.T) {
+	t.Parallel()
+
+	page := "<html><head><title>Request blocked</title></head><body>" + strings.Repeat("x", 2000) + "</body></html>"
+	resp := newTestResponse(http.StatusOK, "text/html; charset=utf-8", page)
+
+	_, err := DecodeResponseJSON(resp)
+
+	var unexpected *UnexpectedResponseError
+	if !errors.As(err, &unexpected) {
+		t.Fatalf("error = %v, want *UnexpectedResponseError", err)
+	}
+	if unexpected.StatusCode != http.StatusOK || unexpected.ContentType != "text/html; charset=utf-8" {
+		t.Fatalf("status and content type = %d %q", unexpected.StatusCode, unexpected.ContentType)
+	}
+	if !strings.HasPrefix(unexpected.Snippet, "<html><head><title>Request blocked</title>") {
+		t.Fatalf("snippet = %q", unexpected.Snippet)
+	}
+	if len(unexpected.Snippet) > responseBodySnippetLimit+64 {
+		t.Fatalf("snippet is %d bytes, want it truncated", len(unexpected.Snippet))
+	}
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if string(bodyBytes) != page {
+		t.Fatal("expected response body to be preserved")
+	}
+}
+
+func TestDecodeResponseJSON_DetectsMissingContentType(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeResponseJSON(newTestResponse(http.StatusBadGateway, "", "<!DOCTYPE html><html>Bad gateway</html>"))
+
+	var unexpected *UnexpectedResponseError
+	if !errors.As(err, &unexpected) {
+		t.Fatalf("error = %v, want *UnexpectedResponseError", err)
+	}
+	if !strings.HasPrefix(unexpected.ContentType, "text/html") {
+		t.Fatalf("content type = %q, want text/html", unexpected.ContentType)
+	}
+}
+
+func TestDecodeResponseJSONObject(t *testing.T) {
+	t.Parallel()
+
+	obj, err := DecodeResponseJSONObject(newTestResponse(http.StatusOK, "application/json", `{"id":"rule-1"}`), "rule")
+	if err != nil || obj["id"] != "rule-1" {
+		t.Fatalf("obj = %v, err = %v", obj, err)
+	}
+
+	for name, body := range map[string]string{
+		"array": `[{"id":"rule-1"}]`,
+		"empty": "",
+	} {
+		_, err := DecodeResponseJSONObject(newTestResponse(http.StatusOK, "application/json", body), "rule")
+		if err == nil || !strings.Contains(err.Error(), "unexpected rule response shape") {
+			t.Errorf("%s: error = %v, want unexpected rule response shape", name, err)
+		}
+	}
+}
+
+func TestDecodeResponseJSONInto(t *testing.T) {
+	t.Parallel()
+
+	var page []struct {
+		Login string `json:"login"`
+	}
+	if err := DecodeResponseJSONInto(newTestResponse(http.StatusOK, "application/json", `[{"login":"octo"}]`), &page); err != nil {
+		t.Fatalf("DecodeResponseJSONInto error: %v", err)
+	}
+	if len(page) != 1 || page[0].Login != "octo" {
+		t.Fatalf("page = %v", page)
+	}
+
+	err := DecodeResponseJSONInto(newTestResponse(http.StatusOK, "application/json", `{"message":"not a list"}`), &page)
+	if err == nil || !strings.Contains(err.Error(), "unexpected response shape") {
+		t.Fatalf("error = %v, want unexpected response shape", err)
+	}
+}
+
+func TestResponseBodySnippet(t *testing.T) {
+	t.Parallel()
+
+	if got := ResponseBodySnippet([]byte("  short body \n")); got != "short body" {
+		t.Fatalf("snippet = %q", got)
+	}
+
+	long := strings.Repeat("é", responseBodySnippetLimit)
+	got := ResponseBodySnippet([]byte(long))
+	if !strings.HasSuffix(got, fmt.Sprintf("... (%d bytes truncated)", len(long)-responseBodySnippetLimit)) {
+		t.Fatalf("snippet = %q", got)
+	}
+	if !strings.HasPrefix(long, strings.TrimSuffix(got, fmt.Sprintf("... (%d bytes truncated)", len(long)-responseBodySnippetLimit))) {
+		t.Fatal("snippet is not a prefix of the body")
+	}
+}
+
+func TestHandleSDKError_TruncatesNonJSONBody(t *testing.T) {
+	t.Parallel()
+
+	page := "<html>" + strings.Repeat("blocked ", 500) + "</html>"
+	got := HandleSDKError(fmt.Errorf("403 Forbidden"), newTestResponse(http.StatusForbidden, "text/html", page))
+	if strings.Contains(got, "</html>") || !strings.Contains(got, "bytes truncated") {
+		t.Fatalf("expected a truncated body, got %q", got)
+	}
+
+	got = HandleSDKError(fmt.Errorf("403 Forbidden"), &http.Response{StatusCode: http.StatusForbidden})
+	if got != "403 Forbidden: " {
+		t.Fatalf("nil body: got %q", got)
+	}
+}
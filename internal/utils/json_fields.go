@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Raw PingOne responses are read through the getters in this file rather than by indexing
+// maps directly. Some endpoints have returned snake_case keys where the documented field is
+// camelCase, so each lookup falls back to the other spelling when the exact key is missing.
+
+// NestedValue walks keys through nested objects and returns the value found, or false when a
+// key is missing or null. Each key matches its camelCase or snake_case spelling.
+func NestedValue(m map[string]interface{}, keys ...string) (any, bool) {
+	if len(keys) == 0 {
+		return nil, false
+	}
+
+	var current any = m
+	for _, key := range keys {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		val, ok := lookupField(obj, key)
+		if !ok || val == nil {
+			return nil, false
+		}
+		current = val
+	}
+
+	return current, true
+}
+
+// NestedString returns the string at keys, as NestedValue.
+func NestedString(m map[string]interface{}, keys ...string) (string, bool) {
+	v, ok := NestedValue(m, keys...)
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// NestedBool returns the bool at keys, as NestedValue.
+func NestedBool(m map[string]interface{}, keys ...string) (bool, bool) {
+	v, ok := NestedValue(m, keys...)
+	if !ok {
+		return false, false
+	}
+	b, ok := v.(bool)
+	return b, ok
+}
+
+// SortByString stable-sorts items by the string at keys. Items without the field sort first.
+func SortByString(items []map[string]interface{}, keys ...string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, _ := NestedString(items[i], keys...)
+		b, _ := NestedString(items[j], keys...)
+		return a < b
+	})
+}
+
+func lookupField(obj map[string]interface{}, key string) (any, bool) {
+	if v, ok := obj[key]; ok {
+		return v, true
+	}
+	alt := alternateFieldName(key)
+	if alt == key {
+		return nil, false
+	}
+	v, ok := obj[alt]
+	return v, ok
+}
+
+// alternateFieldName converts a camelCase key to snake_case and a snake_case key to
+// camelCase. Keys that are neither are returned unchanged.
+func alternateFieldName(key string) string {
+	if key == "" || !unicode.IsLower(rune(key[0])) {
+		return key
+	}
+
+	if strings.Contains(key, "_") {
+		parts := strings.Split(key, "_")
+		var b strings.Builder
+		b.WriteString(parts[0])
+		for _, part := range parts[1:] {
+			if part == "" {
+				continue
+			}
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+		return b.String()
+	}
+
+	var b strings.Builder
+	for _, r := range key {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNestedValue_ToleratesKeyCasing(t *testing.T) {
+	t.Parallel()
+
+	obj := map[string]interface{}{
+		"source_attribute": "email",
+		"targetAttribute":  "mail",
+		"sync_status":      map[string]interface{}{"syncState": "SYNCING"},
+		"enabled":          false,
+		"description":      nil,
+	}
+
+	tests := []struct {
+		keys []string
+		want string
+		ok   bool
+	}{
+		{keys: []string{"sourceAttribute"}, want: "email", ok: true},
+		{keys: []string{"target_attribute"}, want: "mail", ok: true},
+		{keys: []string{"syncStatus", "sync_state"}, want: "SYNCING", ok: true},
+		{keys: []string{"description"}},
+		{keys: []string{"missing"}},
+		{keys: []string{"enabled"}},
+	}
+
+	for _, tt := range tests {
+		got, ok := NestedString(obj, tt.keys...)
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("NestedString(%v) = (%q, %v), want (%q, %v)", tt.keys, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if v, ok := NestedBool(obj, "enabled"); v || !ok {
+		t.Fatalf("NestedBool(enabled) = (%v, %v), want (false, true)", v, ok)
+	}
+}
+
+func TestAlternateFieldName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"sourceAttribute":  "source_attribute",
+		"source_attribute": "sourceAttribute",
+		"id":               "id",
+		"SCIM_URL":         "SCIM_URL",
+		"_embedded":        "_embedded",
+	}
+
+	for in, want := range tests {
+		if got := alternateFieldName(in); got != want {
+			t.Fatalf("alternateFieldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSortByString(t *testing.T) {
+	t.Parallel()
+
+	items := []map[string]interface{}{
+		{"id": "c"},
+		{"id": "a"},
+		{"name": "no-id"},
+		{"id": "b"},
+	}
+	SortByString(items, "id")
+
+	got := make([]string, 0, len(items))
+	for _, item := range items {
+		id, _ := NestedString(item, "id")
+		got = append(got, id)
+	}
+	if want := []string{"", "a", "b", "c"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+}
@@ -1,9 +1,6 @@
 package utils
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
 	"net/http"
 )
 
@@ -22,14 +19,8 @@ func ExtractPropagationStoreTypeStatus(resp *http.Response) (string, string, err
 		return "", "", nil
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", "", err
-	}
-	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
 	var envelope propagationStoreResponseEnvelope
-	if err := json.Unmarshal(bodyBytes, &envelope); err != nil {
+	if err := DecodeResponseJSONInto(resp, &envelope); err != nil {
 		return "", "", err
 	}
 
@@ -91,3 +91,65 @@ func NormalizePropagationStoreTypeForTerraform(apiType string, preferredTFType s
 
 	return apiT
 }
+
+// CanonicalPropagationStoreType returns the canonical Terraform spelling of a propagation store
+// type, folding aliases such as `scim` and `GitHubEMU` and API spellings such as
+// `AzureActiveDirectorySAML2` into the names listed in PropagationStoreTerraformTypes.
+func CanonicalPropagationStoreType(storeType string) string {
+	return NormalizePropagationStoreTypeForTerraform(NormalizePropagationStoreTypeForAPI(storeType), "")
+}
+
+// PropagationStoreTerraformTypes lists the canonical Terraform spelling of every propagation
+// store type supported by the provider.
+var PropagationStoreTerraformTypes = []string{
+	"Aquera",
+	"AzureADSAMLV2",
+	"GithubEMU",
+	"GoogleApps",
+	"LDAPGateway",
+	"PingOne",
+	"Salesforce",
+	"SalesforceContacts",
+	"SCIM",
+	"ServiceNow",
+	"Slack",
+	"Workday",
+	"Zoom",
+}
+
+const (
+	// ProvisioningDirectionInbound marks a store that only provisions identities into PingOne,
+	// so it can only be the source of a rule.
+	ProvisioningDirectionInbound = "inbound"
+	// ProvisioningDirectionOutbound marks a store that PingOne provisions identities to, so it
+	// can only be the target of a rule.
+	ProvisioningDirectionOutbound = "outbound"
+	// ProvisioningDirectionBidirectional marks a store that can be the source or the target of
+	// a rule.
+	ProvisioningDirectionBidirectional = "bidirectional"
+)
+
+// PropagationStoreProvisioningDirection returns the direction a store of the given type
+// provisions in, accepting Terraform and API spellings. PingOne does not report a direction;
+// it follows from the connector: Workday is a source for inbound provisioning, the LDAP
+// Gateway and the PingOne directory work both ways, and every other connector is a target.
+func PropagationStoreProvisioningDirection(storeType string) string {
+	switch strings.ToLower(NormalizePropagationStoreTypeForAPI(storeType)) {
+	case "workday":
+		return ProvisioningDirectionInbound
+	case "ldapgateway", "directory":
+		return ProvisioningDirectionBidirectional
+	default:
+		return ProvisioningDirectionOutbound
+	}
+}
+
+// PropagationStoreCanBeSource reports whether a store of the given type can be a rule's source.
+func PropagationStoreCanBeSource(storeType string) bool {
+	return PropagationStoreProvisioningDirection(storeType) != ProvisioningDirectionOutbound
+}
+
+// PropagationStoreCanBeTarget reports whether a store of the given type can be a rule's target.
+func PropagationStoreCanBeTarget(storeType string) bool {
+	return PropagationStoreProvisioningDirection(storeType) != ProvisioningDirectionInbound
+}
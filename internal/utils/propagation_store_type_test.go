@@ -67,3 +67,51 @@ func TestNormalizePropagationStoreTypeForTerraform(t *testing.T) {
 		})
 	}
 }
+
+func TestCanonicalPropagationStoreType(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                          "",
+		"SCIM":                      "SCIM",
+		"scim":                      "SCIM",
+		"GithubEMU":                 "GithubEMU",
+		"GitHubEMU":                 "GithubEMU",
+		"LdapGateway":               "LDAPGateway",
+		"AzureActiveDirectorySAML2": "AzureADSAMLV2",
+		"PingOne":                   "PingOne",
+	}
+
+	for in, want := range tests {
+		if got := CanonicalPropagationStoreType(in); got != want {
+			t.Fatalf("CanonicalPropagationStoreType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPropagationStoreProvisioningDirection(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Workday":     ProvisioningDirectionInbound,
+		"LDAPGateway": ProvisioningDirectionBidirectional,
+		"LdapGateway": ProvisioningDirectionBidirectional,
+		"directory":   ProvisioningDirectionBidirectional,
+		"SCIM":        ProvisioningDirectionOutbound,
+		"scim":        ProvisioningDirectionOutbound,
+		"PingOne":     ProvisioningDirectionOutbound,
+	}
+
+	for in, want := range tests {
+		if got := PropagationStoreProvisioningDirection(in); got != want {
+			t.Fatalf("PropagationStoreProvisioningDirection(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if PropagationStoreCanBeTarget("Workday") || !PropagationStoreCanBeSource("Workday") {
+		t.Fatal("Workday should only be a source")
+	}
+	if PropagationStoreCanBeSource("Slack") || !PropagationStoreCanBeTarget("Slack") {
+		t.Fatal("Slack should only be a target")
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ProvisioningNotEnabledHint explains a response from an environment without the provisioning
+// capability.
+const ProvisioningNotEnabledHint = "PingOne provisioning is not enabled in this environment; check that the environment's license includes provisioning and that the provisioning service has been added to the environment"
+
+// provisioningNotEnabledCodes are the error codes PingOne uses when a capability is not part
+// of the environment.
+var provisioningNotEnabledCodes = map[string]bool{
+	"CAPABILITY_NOT_ENABLED": true,
+	"FEATURE_NOT_ENABLED":    true,
+	"NOT_LICENSED":           true,
+}
+
+// IsProvisioningNotEnabled reports whether resp is PingOne's answer for an environment whose
+// license or configuration does not include provisioning. The same request fails the same way
+// in every region, so it is not a sign of a region mismatch.
+func IsProvisioningNotEnabled(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	if resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	decoded, err := DecodeResponseJSON(resp)
+	if err != nil {
+		return false
+	}
+	root, ok := decoded.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	if isProvisioningNotEnabledError(root) {
+		return true
+	}
+
+	details, _ := root["details"].([]interface{})
+	for _, detailRaw := range details {
+		if detail, ok := detailRaw.(map[string]interface{}); ok && isProvisioningNotEnabledError(detail) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isProvisioningNotEnabledError(obj map[string]interface{}) bool {
+	if code, ok := obj["code"].(string); ok && provisioningNotEnabledCodes[strings.ToUpper(code)] {
+		return true
+	}
+
+	msg, _ := obj["message"].(string)
+	msg = strings.ToLower(msg)
+	if strings.Contains(msg, "not licensed") {
+		return true
+	}
+	return strings.Contains(msg, "not enabled") &&
+		(strings.Contains(msg, "capability") || strings.Contains(msg, "feature") || strings.Contains(msg, "provisioning"))
+}
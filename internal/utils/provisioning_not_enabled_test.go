@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIsProvisioningNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{name: "root_code", status: http.StatusNotFound, body: `{"code":"CAPABILITY_NOT_ENABLED","message":"x"}`, want: true},
+		{name: "detail_code", status: http.StatusForbidden, body: `{"code":"ACCESS_FAILED","details":[{"code":"FEATURE_NOT_ENABLED"}]}`, want: true},
+		{name: "message", status: http.StatusNotFound, body: `{"code":"NOT_FOUND","message":"The provisioning capability is not enabled for this environment."}`, want: true},
+		{name: "plain_not_found", status: http.StatusNotFound, body: `{"code":"NOT_FOUND","message":"The requested resource was not found."}`, want: false},
+		{name: "other_status", status: http.StatusBadRequest, body: `{"code":"CAPABILITY_NOT_ENABLED"}`, want: false},
+		{name: "not_json", status: http.StatusNotFound, body: `not found`, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{StatusCode: tt.status, Body: io.NopCloser(strings.NewReader(tt.body))}
+			if got := IsProvisioningNotEnabled(resp); got != tt.want {
+				t.Fatalf("IsProvisioningNotEnabled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleSDKError_ProvisioningNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"code":"CAPABILITY_NOT_ENABLED"}`)),
+	}
+	got := HandleSDKError(fmt.Errorf("404 Not Found"), resp)
+	if !strings.Contains(got, ProvisioningNotEnabledHint) {
+		t.Fatalf("expected the provisioning hint, got %q", got)
+	}
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// SleepContext waits for d, or until ctx is done. It returns ctx.Err() when ctx ends the wait
+// first, so retry and poll loops stop as soon as Terraform cancels the operation or its timeout
+// expires.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSleepContext(t *testing.T) {
+	t.Parallel()
+
+	if err := SleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("SleepContext = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := SleepContext(ctx, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("SleepContext after cancel = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("SleepContext after cancel returned after %s", elapsed)
+	}
+}
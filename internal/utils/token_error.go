@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenError marks a failure to obtain an OAuth access token, as opposed to a failure of the
+// API request the token was for.
+type TokenError struct {
+	TokenURL string
+	Err      error
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("could not obtain a PingOne access token from %s: %s (%s)", e.TokenURL, e.Err, tokenErrorHint(e.Err))
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
+// tokenErrorHint names the provider settings most likely to cause a token failure.
+func tokenErrorHint(err error) string {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		switch {
+		case retrieveErr.ErrorCode == "invalid_client" || (retrieveErr.Response != nil && retrieveErr.Response.StatusCode == http.StatusUnauthorized):
+			return "the client credentials were rejected; check client_id and client_secret"
+		case retrieveErr.ErrorCode == "unauthorized_client" || retrieveErr.ErrorCode == "unsupported_grant_type":
+			return "the worker application is not allowed the client_credentials grant"
+		case retrieveErr.Response != nil && retrieveErr.Response.StatusCode == http.StatusNotFound:
+			return "the token endpoint was not found; check environment_id and that region matches the environment's region"
+		}
+		return "check client_id, client_secret, environment_id and region"
+	}
+
+	if isTimeout(err) {
+		return "the token request exceeded token_request_timeout"
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return "the token host could not be resolved; check region and oauth_token_url"
+	}
+
+	return "check region and oauth_token_url"
+}
+
+// DescribeRequestError returns a message for an error returned by an API call made without a
+// response, stating whether the token request or the API request itself failed.
+func DescribeRequestError(err error) string {
+	var tokenErr *TokenError
+	if errors.As(err, &tokenErr) {
+		return err.Error()
+	}
+	if isTimeout(err) {
+		return fmt.Sprintf("%s (the API request exceeded api_request_timeout)", err)
+	}
+	return err.Error()
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return strings.Contains(err.Error(), "Client.Timeout exceeded")
+}
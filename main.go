@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"log"
 
+	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/easytofu/terraform-provider-pingoneprovisioning/internal/provider"
 )
@@ -10,7 +12,15 @@ import (
 var version = "dev"
 
 func main() {
-	providerserver.Serve(context.Background(), provider.New(version), providerserver.ServeOpts{
+	p := provider.New(version)()
+	err := providerserver.Serve(context.Background(), func() tfprovider.Provider { return p }, providerserver.ServeOpts{
 		Address: "registry.opentofu.org/easytofu/pingoneprovisioning",
 	})
+	// Log the API call totals for the whole run once Terraform stops the provider.
+	if p, ok := p.(*provider.PingOneProvisioningProvider); ok {
+		p.LogAPIMetricsSummary()
+	}
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }